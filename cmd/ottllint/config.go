@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// statementsFields maps the transform processor statements fields to their signal.
+var statementsFields = map[string]string{
+	"trace_statements":   signalTraces,
+	"metric_statements":  signalMetrics,
+	"log_statements":     signalLogs,
+	"profile_statements": signalProfiles,
+}
+
+// lintTarget is a group of statements and conditions sharing the same signal and context.
+type lintTarget struct {
+	// source describes where the statements come from, e.g. "processors::transform/foo::log_statements[0]".
+	source        string
+	signal        string
+	context       string
+	statements    []string
+	conditions    []string
+	userFunctions *ottl.UserFunctions
+}
+
+// transformConfig holds the transform processor configuration fields relevant to linting.
+type transformConfig struct {
+	Functions []userFunctionConfig `yaml:"functions"`
+	// Statements holds the remaining fields, indexed by field name. Each element of the
+	// statements fields is either a flat statement string, or a contextStatements object.
+	Statements map[string]yaml.Node `yaml:",inline"`
+}
+
+type userFunctionConfig struct {
+	Name       string   `yaml:"name"`
+	Params     []string `yaml:"params"`
+	Statements []string `yaml:"statements"`
+	Expression string   `yaml:"expression"`
+}
+
+type contextStatements struct {
+	Context    string   `yaml:"context"`
+	Conditions []string `yaml:"conditions"`
+	Statements []string `yaml:"statements"`
+}
+
+// loadConfigFile reads the lint targets from a collector configuration file, linting all its
+// transform processors, or from a file holding a single transform processor configuration.
+func loadConfigFile(path string) ([]lintTarget, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var document yaml.Node
+	if err = yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+	root := document.Content[0]
+
+	processors := mappingValue(root, "processors")
+	if processors == nil {
+		return parseTransformConfig(path, root)
+	}
+
+	var processorsConfigs map[string]yaml.Node
+	if err = processors.Decode(&processorsConfigs); err != nil {
+		return nil, fmt.Errorf("%s: processors: %w", path, err)
+	}
+	ids := make([]string, 0, len(processorsConfigs))
+	for id := range processorsConfigs {
+		if componentType, _, _ := strings.Cut(id, "/"); componentType == "transform" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var targets []lintTarget
+	var errs []error
+	for _, id := range ids {
+		config := processorsConfigs[id]
+		processorTargets, err := parseTransformConfig(fmt.Sprintf("%s: processors::%s", path, id), &config)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		targets = append(targets, processorTargets...)
+	}
+	return targets, errors.Join(errs...)
+}
+
+func parseTransformConfig(source string, node *yaml.Node) ([]lintTarget, error) {
+	var config transformConfig
+	if err := node.Decode(&config); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+
+	userFunctions, err := config.userFunctions()
+	if err != nil {
+		return nil, fmt.Errorf("%s::functions: %w", source, err)
+	}
+
+	fields := make([]string, 0, len(config.Statements))
+	for field := range config.Statements {
+		if _, ok := statementsFields[field]; ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	var targets []lintTarget
+	for _, field := range fields {
+		var values []yaml.Node
+		node := config.Statements[field]
+		if err = node.Decode(&values); err != nil {
+			return nil, fmt.Errorf("%s::%s: %w", source, field, err)
+		}
+		for i, value := range values {
+			target := lintTarget{
+				source:        fmt.Sprintf("%s::%s[%d]", source, field, i),
+				signal:        statementsFields[field],
+				userFunctions: userFunctions,
+			}
+			// flat statements are parsed as a group holding a single statement
+			if value.Kind == yaml.ScalarNode {
+				target.statements = []string{value.Value}
+				targets = append(targets, target)
+				continue
+			}
+			var cs contextStatements
+			if err := value.Decode(&cs); err != nil {
+				return nil, fmt.Errorf("%s: %w", target.source, err)
+			}
+			target.context, target.statements, target.conditions = cs.Context, cs.Statements, cs.Conditions
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+func (c transformConfig) userFunctions() (*ottl.UserFunctions, error) {
+	if len(c.Functions) == 0 {
+		return nil, nil
+	}
+	configs := make([]ottl.UserFunctionConfig, 0, len(c.Functions))
+	for _, f := range c.Functions {
+		configs = append(configs, ottl.UserFunctionConfig(f))
+	}
+	return ottl.NewUserFunctions(configs)
+}
+
+// mappingValue returns the value of the given key in a mapping node, or nil if not found.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+const (
+	signalTraces   = "traces"
+	signalMetrics  = "metrics"
+	signalLogs     = "logs"
+	signalProfiles = "profiles"
+)
+
+type newParserFunc[K any] func(map[string]ottl.Factory[K], component.TelemetrySettings, ...ottl.Option[K]) (ottl.Parser[K], error)
+
+// contextLinter lints the conditions of a single context. Statements are linted by the
+// signal ottl.ParserCollection instead, so their context can be inferred.
+type contextLinter func(conditions []string, pathContextNames bool) ([]ottl.LintIssue, error)
+
+// signalLinter lints the statements and conditions of all contexts supported by a signal.
+type signalLinter struct {
+	collection *ottl.ParserCollection[any]
+	contexts   map[string]contextLinter
+}
+
+type contextOption struct {
+	name           string
+	collection     ottl.ParserCollectionOption[any]
+	lintConditions contextLinter
+}
+
+func newContextOption[K any](name string, newParser newParserFunc[K], enablePathContextNames func() ottl.Option[K], userFunctions *ottl.UserFunctions) contextOption {
+	settings := componenttest.NewNopTelemetrySettings()
+	return contextOption{
+		name: name,
+		collection: func(pc *ottl.ParserCollection[any]) error {
+			parser, err := newParser(ottlfuncs.StandardFuncs[K](), pc.Settings, parserOptions(userFunctions, enablePathContextNames())...)
+			if err != nil {
+				return err
+			}
+			return ottl.WithParserCollectionContext(name, &parser, nopStatementsConverter[K])(pc)
+		},
+		lintConditions: func(conditions []string, pathContextNames bool) ([]ottl.LintIssue, error) {
+			var options []ottl.Option[K]
+			if pathContextNames {
+				options = append(options, enablePathContextNames())
+			}
+			parser, err := newParser(ottlfuncs.StandardConverters[K](), settings, parserOptions(userFunctions, options...)...)
+			if err != nil {
+				return nil, err
+			}
+			return parser.LintConditions(conditions), nil
+		},
+	}
+}
+
+func parserOptions[K any](userFunctions *ottl.UserFunctions, options ...ottl.Option[K]) []ottl.Option[K] {
+	if userFunctions == nil {
+		return options
+	}
+	return append(options, ottl.WithUserFunctions[K](userFunctions))
+}
+
+// nopStatementsConverter is used as the statements are only linted, never executed.
+func nopStatementsConverter[K any](_ *ottl.ParserCollection[any], _ *ottl.Parser[K], _ string, _ ottl.StatementsGetter, _ []*ottl.Statement[K]) (any, error) {
+	return nil, nil
+}
+
+func newSignalLinter(signal string, userFunctions *ottl.UserFunctions) (*signalLinter, error) {
+	resource := newContextOption(ottlresource.ContextName, ottlresource.NewParser, ottlresource.EnablePathContextNames, userFunctions)
+	scope := newContextOption(ottlscope.ContextName, ottlscope.NewParser, ottlscope.EnablePathContextNames, userFunctions)
+
+	var options []contextOption
+	switch signal {
+	case signalTraces:
+		options = []contextOption{
+			resource,
+			scope,
+			newContextOption(ottlspan.ContextName, ottlspan.NewParser, ottlspan.EnablePathContextNames, userFunctions),
+			newContextOption(ottlspanevent.ContextName, ottlspanevent.NewParser, ottlspanevent.EnablePathContextNames, userFunctions),
+		}
+	case signalMetrics:
+		options = []contextOption{
+			resource,
+			scope,
+			newContextOption(ottlmetric.ContextName, ottlmetric.NewParser, ottlmetric.EnablePathContextNames, userFunctions),
+			newContextOption(ottldatapoint.ContextName, ottldatapoint.NewParser, ottldatapoint.EnablePathContextNames, userFunctions),
+		}
+	case signalLogs:
+		options = []contextOption{
+			resource,
+			scope,
+			newContextOption(ottllog.ContextName, ottllog.NewParser, ottllog.EnablePathContextNames, userFunctions),
+		}
+	case signalProfiles:
+		options = []contextOption{
+			resource,
+			scope,
+			newContextOption(ottlprofile.ContextName, ottlprofile.NewParser, ottlprofile.EnablePathContextNames, userFunctions),
+			newContextOption(ottlsample.ContextName, ottlsample.NewParser, ottlsample.EnablePathContextNames, userFunctions),
+		}
+	default:
+		return nil, fmt.Errorf("unknown signal %q, valid options are: %q", signal, signals())
+	}
+
+	collectionOptions := make([]ottl.ParserCollectionOption[any], 0, len(options))
+	contexts := make(map[string]contextLinter, len(options))
+	for _, option := range options {
+		collectionOptions = append(collectionOptions, option.collection)
+		contexts[option.name] = option.lintConditions
+	}
+	collection, err := ottl.NewParserCollection(componenttest.NewNopTelemetrySettings(), collectionOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return &signalLinter{collection: collection, contexts: contexts}, nil
+}
+
+func signals() []string {
+	return []string{signalTraces, signalMetrics, signalLogs, signalProfiles}
+}
+
+// lint lints the given statements and conditions, returning the statements result and the
+// conditions issues. If the context is empty, it is inferred from the statements, and the
+// conditions paths must be prefixed with their context name.
+func (l *signalLinter) lint(context string, statements []string, conditions []string) (ottl.LintResult, []ottl.LintIssue, error) {
+	result := l.collection.LintStatements(context, statements)
+	if len(conditions) == 0 {
+		return result, nil, nil
+	}
+	lintConditions, ok := l.contexts[result.Context]
+	if !ok {
+		// the context could not be determined, which is already reported by the statements issues
+		return result, nil, nil
+	}
+	conditionIssues, err := lintConditions(conditions, context == "")
+	return result, conditionIssues, err
+}
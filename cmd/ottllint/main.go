@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func main() {
+	var statements, conditions stringsFlag
+	signal := flag.String("signal", "", fmt.Sprintf("signal of the statements passed with -statement, one of %q", signals()))
+	context := flag.String("context", "", "context of the statements passed with -statement, inferred from the statements if empty")
+	flag.Var(&statements, "statement", "statement to lint, can be repeated")
+	flag.Var(&conditions, "condition", "condition of the statements passed with -statement, can be repeated")
+	strict := flag.Bool("strict", false, "exit with a non-zero status if warnings are found")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [config.yaml ...]\n\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Lints the OTTL statements of the transform processors declared in the given collector configuration")
+		fmt.Fprintln(flag.CommandLine.Output(), "files, or of the statements passed with the -statement flag.")
+		fmt.Fprintln(flag.CommandLine.Output())
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var targets []lintTarget
+	if len(statements) > 0 {
+		if *signal == "" {
+			log.Fatal("the -signal flag is required when linting statements passed with -statement")
+		}
+		targets = append(targets, lintTarget{source: "flags", signal: *signal, context: *context, statements: statements, conditions: conditions})
+	}
+	for _, path := range flag.Args() {
+		fileTargets, err := loadConfigFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		targets = append(targets, fileTargets...)
+	}
+	if len(targets) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	errorsCount, warningsCount, err := run(os.Stdout, targets)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%d error(s), %d warning(s)\n", errorsCount, warningsCount)
+	if errorsCount > 0 || (*strict && warningsCount > 0) {
+		os.Exit(1)
+	}
+}
+
+// run lints the given targets, writing the issues found to w, and returns the number of
+// errors and warnings found.
+func run(w io.Writer, targets []lintTarget) (int, int, error) {
+	var errorsCount, warningsCount int
+	var errs []error
+	for _, target := range targets {
+		linter, err := newSignalLinter(target.signal, target.userFunctions)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", target.source, err))
+			continue
+		}
+		result, conditionIssues, err := linter.lint(target.context, target.statements, target.conditions)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", target.source, err))
+			continue
+		}
+		if target.context == "" && result.Context != "" {
+			fmt.Fprintf(w, "%s: info: inferred context %q\n", target.source, result.Context)
+		}
+		for _, issue := range result.Issues {
+			fmt.Fprintf(w, "%s: %s\n", target.source, issue)
+		}
+		for _, issue := range conditionIssues {
+			fmt.Fprintf(w, "%s: %s: condition %d %q: %s (%s)\n", target.source, issue.Severity, issue.Index, issue.Statement, issue.Message, issue.Rule)
+		}
+		for _, issue := range append(result.Issues, conditionIssues...) {
+			switch issue.Severity {
+			case ottl.LintSeverityError:
+				errorsCount++
+			case ottl.LintSeverityWarning:
+				warningsCount++
+			}
+		}
+	}
+	return errorsCount, warningsCount, errors.Join(errs...)
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCollectorConfig(t *testing.T) {
+	targets, err := loadConfigFile(filepath.Join("testdata", "collector.yaml"))
+	require.NoError(t, err)
+	require.Len(t, targets, 4)
+	assert.Equal(t, "testdata/collector.yaml: processors::transform/issues::log_statements[0]", targets[0].source)
+	assert.Equal(t, signalLogs, targets[0].signal)
+	assert.Equal(t, "log", targets[0].context)
+	assert.Equal(t, []string{"1 == 2"}, targets[0].conditions)
+	assert.NotNil(t, targets[0].userFunctions)
+	assert.Equal(t, signalTraces, targets[1].signal)
+	assert.Empty(t, targets[1].context)
+	assert.Equal(t, "testdata/collector.yaml: processors::transform/valid::log_statements[0]", targets[3].source)
+
+	var out bytes.Buffer
+	errorsCount, warningsCount, err := run(&out, targets)
+	require.NoError(t, err)
+	assert.Equal(t, 1, errorsCount)
+	assert.Equal(t, 3, warningsCount)
+
+	output := out.String()
+	assert.Contains(t, output, `processors::transform/issues::log_statements[0]: warning: statement 0 "set(attributes[\"x\"], \"1\")"`)
+	assert.Contains(t, output, "(shadowed-set)")
+	assert.Contains(t, output, "(unset-cache-key)")
+	assert.Contains(t, output, `processors::transform/issues::log_statements[0]: warning: condition 0 "1 == 2"`)
+	assert.Contains(t, output, `processors::transform/issues::trace_statements[0]: error: statement 0`)
+	assert.Contains(t, output, `processors::transform/valid::log_statements[0]: info: inferred context "log"`)
+}
+
+func TestRunTransformConfig(t *testing.T) {
+	targets, err := loadConfigFile(filepath.Join("testdata", "transform.yaml"))
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, signalMetrics, targets[0].signal)
+	assert.Equal(t, "datapoint", targets[0].context)
+
+	var out bytes.Buffer
+	errorsCount, warningsCount, err := run(&out, targets)
+	require.NoError(t, err)
+	assert.Equal(t, 0, errorsCount)
+	assert.Equal(t, 1, warningsCount)
+	assert.Contains(t, out.String(), "(constant-condition)")
+}
+
+func TestRunUnknownSignal(t *testing.T) {
+	_, _, err := run(&bytes.Buffer{}, []lintTarget{{source: "flags", signal: "foo", statements: []string{`set(span.name, "a")`}}})
+	assert.ErrorContains(t, err, `unknown signal "foo"`)
+}
@@ -7,7 +7,9 @@ import (
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
@@ -39,6 +41,10 @@ type Config struct {
 	// Table contains the routing table for this processor.
 	// Required.
 	Table []RoutingTableItem `mapstructure:"table"`
+	// Functions declares user functions, composed of the other OTTL functions, that can be called by
+	// the statements and conditions of the routing table like built-in functions.
+	// Optional.
+	Functions []ottl.UserFunctionConfig `mapstructure:"functions"`
 }
 
 // Validate checks if the processor configuration is valid.
@@ -74,7 +80,21 @@ func (c *Config) Validate() error {
 			return errors.New("invalid context: " + item.Context)
 		}
 	}
-	return nil
+
+	userFunctions, err := c.userFunctions()
+	if err != nil {
+		return err
+	}
+	// building the parsers rejects the user functions with the name of a built-in function
+	return (&router[any]{}).buildParsers(c.Table, component.TelemetrySettings{Logger: zap.NewNop()}, userFunctions)
+}
+
+// userFunctions returns the user functions declared by the configuration, or nil if there are none.
+func (c *Config) userFunctions() (*ottl.UserFunctions, error) {
+	if len(c.Functions) == 0 {
+		return nil, nil
+	}
+	return ottl.NewUserFunctions(c.Functions)
 }
 
 // RoutingTableItem specifies how data should be routed to the different pipelines
@@ -375,6 +375,57 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "user function",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `IsAcme(attributes["attr"])`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+				Functions: []ottl.UserFunctionConfig{
+					{Name: "IsAcme", Params: []string{"value"}, Expression: `IsMatch(value, "^acme$")`},
+				},
+			},
+		},
+		{
+			name: "invalid user function",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+				Functions: []ottl.UserFunctionConfig{
+					{Name: "IsAcme", Params: []string{"value"}, Statements: []string{`set(value, "acme")`}},
+				},
+			},
+			error: `invalid user function "IsAcme": converters must declare an expression and no statements`,
+		},
+		{
+			name: "user function with the name of a built-in function",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Context:   "log",
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+				Functions: []ottl.UserFunctionConfig{
+					{Name: "IsMatch", Params: []string{"target", "pattern"}, Expression: `Concat([target, pattern], "")`},
+				},
+			},
+			error: `user function "IsMatch" conflicts with the built-in function of the same name`,
+		},
 	}
 
 	for _, tt := range tests {
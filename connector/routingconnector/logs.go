@@ -39,10 +39,16 @@ func newLogsConnector(
 		return nil, errUnexpectedConsumer
 	}
 
+	userFunctions, err := cfg.userFunctions()
+	if err != nil {
+		return nil, err
+	}
+
 	r, err := newRouter(
 		cfg.Table,
 		cfg.DefaultPipelines,
 		lr.Consumer,
+		userFunctions,
 		set.TelemetrySettings)
 	if err != nil {
 		return nil, err
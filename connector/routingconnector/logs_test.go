@@ -19,6 +19,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/plogutiltest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 func TestLogsRegisterConsumersForValidRoute(t *testing.T) {
@@ -182,6 +183,62 @@ func TestLogsAreCorrectlySplitPerResourceAttributeWithOTTL(t *testing.T) {
 	})
 }
 
+func TestLogsRoutedWithUserFunctions(t *testing.T) {
+	logsDefault := pipeline.NewIDWithName(pipeline.SignalLogs, "default")
+	logs0 := pipeline.NewIDWithName(pipeline.SignalLogs, "0")
+
+	cfg := &Config{
+		DefaultPipelines: []pipeline.ID{logsDefault},
+		Table: []RoutingTableItem{
+			{
+				Condition: `IsAcmeTenant(attributes["X-Tenant"])`,
+				Pipelines: []pipeline.ID{logs0},
+			},
+		},
+		Functions: []ottl.UserFunctionConfig{
+			{
+				Name:       "IsAcmeTenant",
+				Params:     []string{"value"},
+				Expression: `IsMatch(value, "^acme$")`,
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0 consumertest.LogsSink
+
+	router := connector.NewLogsRouter(map[pipeline.ID]consumer.Logs{
+		logsDefault: &defaultSink,
+		logs0:       &sink0,
+	})
+
+	factory := NewFactory()
+	conn, err := factory.CreateLogsToLogs(context.Background(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Logs))
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	l := plog.NewLogs()
+	rl := l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("X-Tenant", "acme")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	rl = l.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("X-Tenant", "xacme")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), l))
+	assert.Len(t, sink0.AllLogs(), 1)
+	assert.Len(t, defaultSink.AllLogs(), 1)
+
+	require.NoError(t, conn.Shutdown(context.Background()))
+
+	// the user functions can't be called by the other components
+	otherCfg := &Config{Table: cfg.Table}
+	_, err = factory.CreateLogsToLogs(context.Background(),
+		connectortest.NewNopSettings(metadata.Type), otherCfg, router.(consumer.Logs))
+	assert.ErrorContains(t, err, `undefined function "IsAcmeTenant"`)
+}
+
 func TestLogsAreCorrectlyMatchOnceWithOTTL(t *testing.T) {
 	logsDefault := pipeline.NewIDWithName(pipeline.SignalLogs, "default")
 	logs0 := pipeline.NewIDWithName(pipeline.SignalLogs, "0")
@@ -40,10 +40,16 @@ func newMetricsConnector(
 		return nil, errUnexpectedConsumer
 	}
 
+	userFunctions, err := cfg.userFunctions()
+	if err != nil {
+		return nil, err
+	}
+
 	r, err := newRouter(
 		cfg.Table,
 		cfg.DefaultPipelines,
 		mr.Consumer,
+		userFunctions,
 		set.TelemetrySettings)
 	if err != nil {
 		return nil, err
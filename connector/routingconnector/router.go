@@ -51,6 +51,7 @@ func newRouter[C any](
 	table []RoutingTableItem,
 	defaultPipelineIDs []pipeline.ID,
 	provider consumerProvider[C],
+	userFunctions *ottl.UserFunctions,
 	settings component.TelemetrySettings,
 ) (*router[C], error) {
 	r := &router[C]{
@@ -60,7 +61,7 @@ func newRouter[C any](
 		consumerProvider: provider,
 	}
 
-	if err := r.buildParsers(table, settings); err != nil {
+	if err := r.buildParsers(table, settings, userFunctions); err != nil {
 		return nil, err
 	}
 
@@ -82,7 +83,7 @@ type routingItem[C any] struct {
 	statementContext   string
 }
 
-func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.TelemetrySettings) error {
+func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.TelemetrySettings, userFunctions *ottl.UserFunctions) error {
 	var buildResource, buildSpan, buildMetric, buildDataPoint, buildLog bool
 	for _, item := range table {
 		switch item.Context {
@@ -104,6 +105,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottlresource.NewParser(
 			common.Functions[ottlresource.TransformContext](),
 			settings,
+			parserOptions[ottlresource.TransformContext](userFunctions)...,
 		)
 		if err == nil {
 			r.resourceParser = parser
@@ -115,6 +117,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottlspan.NewParser(
 			common.Functions[ottlspan.TransformContext](),
 			settings,
+			parserOptions[ottlspan.TransformContext](userFunctions)...,
 		)
 		if err == nil {
 			r.spanParser = parser
@@ -126,6 +129,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottlmetric.NewParser(
 			common.Functions[ottlmetric.TransformContext](),
 			settings,
+			parserOptions[ottlmetric.TransformContext](userFunctions)...,
 		)
 		if err == nil {
 			r.metricParser = parser
@@ -137,6 +141,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottldatapoint.NewParser(
 			common.Functions[ottldatapoint.TransformContext](),
 			settings,
+			parserOptions[ottldatapoint.TransformContext](userFunctions)...,
 		)
 		if err == nil {
 			r.dataPointParser = parser
@@ -148,6 +153,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottllog.NewParser(
 			common.Functions[ottllog.TransformContext](),
 			settings,
+			parserOptions[ottllog.TransformContext](userFunctions)...,
 		)
 		if err == nil {
 			r.logParser = parser
@@ -158,6 +164,14 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 	return errs
 }
 
+// parserOptions returns the option making the user functions available to a parser, if there are any.
+func parserOptions[K any](userFunctions *ottl.UserFunctions) []ottl.Option[K] {
+	if userFunctions == nil {
+		return nil
+	}
+	return []ottl.Option[K]{ottl.WithUserFunctions[K](userFunctions)}
+}
+
 func (r *router[C]) registerConsumers(defaultPipelineIDs []pipeline.ID) error {
 	// register default pipelines
 	err := r.registerDefaultConsumer(defaultPipelineIDs)
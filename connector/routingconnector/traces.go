@@ -39,10 +39,16 @@ func newTracesConnector(
 		return nil, errUnexpectedConsumer
 	}
 
+	userFunctions, err := cfg.userFunctions()
+	if err != nil {
+		return nil, err
+	}
+
 	r, err := newRouter(
 		cfg.Table,
 		cfg.DefaultPipelines,
 		tr.Consumer,
+		userFunctions,
 		set.TelemetrySettings)
 	if err != nil {
 		return nil, err
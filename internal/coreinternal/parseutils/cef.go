@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const cefHeaderPrefix = "CEF:"
+
+// cefHeaderFields are the names of the CEF header fields, in order.
+var cefHeaderFields = []string{
+	"version",
+	"device_vendor",
+	"device_product",
+	"device_version",
+	"device_event_class_id",
+	"name",
+	"severity",
+}
+
+// cefIntegerExtensions are the CEF dictionary extension keys holding integer values.
+var cefIntegerExtensions = map[string]struct{}{
+	"cn1": {}, "cn2": {}, "cn3": {}, "cnt": {}, "dpid": {}, "dpt": {}, "dvcpid": {}, "fsize": {},
+	"in": {}, "oldFileSize": {}, "out": {}, "spid": {}, "spt": {}, "type": {},
+	"destinationTranslatedPort": {}, "sourceTranslatedPort": {},
+}
+
+// cefFloatExtensions are the CEF dictionary extension keys holding floating point values.
+var cefFloatExtensions = map[string]struct{}{
+	"cfp1": {}, "cfp2": {}, "cfp3": {}, "cfp4": {}, "dlat": {}, "dlong": {}, "slat": {}, "slong": {},
+}
+
+// ParseCEF parses an ArcSight Common Event Format (CEF) event. Any content before the "CEF:" header,
+// like a syslog prefix, is ignored.
+// The header fields are returned under the keys listed in cefHeaderFields, and the extensions
+// under the "extensions" key. The version, the numeric severity and the extensions holding numbers
+// according to the CEF dictionary are returned as int64 or float64 values, all others as strings.
+func ParseCEF(value string) (map[string]any, error) {
+	start := strings.Index(value, cefHeaderPrefix)
+	if start < 0 {
+		return nil, fmt.Errorf("missing %q header", cefHeaderPrefix)
+	}
+	value = value[start+len(cefHeaderPrefix):]
+
+	header, extension, err := splitCEFHeader(value)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := make(map[string]any, len(cefHeaderFields)+1)
+	version, err := strconv.ParseInt(header[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CEF version %q", header[0])
+	}
+	parsed[cefHeaderFields[0]] = version
+	for i := 1; i < len(cefHeaderFields); i++ {
+		parsed[cefHeaderFields[i]] = header[i]
+	}
+	// the severity is either an integer between 0 and 10, or one of Unknown, Low, Medium, High and Very-High
+	if severity, err := strconv.ParseInt(header[6], 10, 64); err == nil {
+		parsed["severity"] = severity
+	}
+
+	extensions := make(map[string]any)
+	for key, raw := range splitCEFExtension(extension) {
+		extensions[key] = cefExtensionValue(key, raw)
+	}
+	parsed["extensions"] = extensions
+	return parsed, nil
+}
+
+// splitCEFHeader splits the header fields on the unescaped pipes, returning the unescaped header
+// fields and the remaining extension.
+func splitCEFHeader(value string) ([]string, string, error) {
+	header := make([]string, 0, len(cefHeaderFields))
+	var current strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\\' && i+1 < len(value) && (value[i+1] == '|' || value[i+1] == '\\'):
+			current.WriteByte(value[i+1])
+			i++
+		case c == '|':
+			header = append(header, current.String())
+			current.Reset()
+			if len(header) == len(cefHeaderFields) {
+				return header, value[i+1:], nil
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+	// the pipe after the severity can be omitted when there are no extensions
+	if len(header) == len(cefHeaderFields)-1 {
+		return append(header, current.String()), "", nil
+	}
+	return nil, "", errors.New("invalid CEF header, expected 7 fields separated by '|'")
+}
+
+// splitCEFExtension splits the extension into its key value pairs, returning their unescaped
+// values. Values can contain spaces, so a value ends where the next key begins.
+func splitCEFExtension(extension string) map[string]string {
+	type keyPosition struct {
+		key                  string
+		keyStart, valueStart int
+	}
+	var keys []keyPosition
+	for i := 0; i < len(extension); i++ {
+		switch extension[i] {
+		case '\\':
+			i++
+		case '=':
+			keyStart := strings.LastIndexByte(extension[:i], ' ') + 1
+			key := extension[keyStart:i]
+			if isCEFExtensionKey(key) {
+				keys = append(keys, keyPosition{key: key, keyStart: keyStart, valueStart: i + 1})
+			}
+		}
+	}
+
+	pairs := make(map[string]string, len(keys))
+	for i, k := range keys {
+		end := len(extension)
+		if i+1 < len(keys) {
+			end = keys[i+1].keyStart
+		}
+		pairs[k.key] = unescapeCEFExtensionValue(strings.TrimSpace(extension[k.valueStart:end]))
+	}
+	return pairs
+}
+
+func isCEFExtensionKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-' || c == '[' || c == ']') {
+			return false
+		}
+	}
+	return true
+}
+
+func unescapeCEFExtensionValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			sb.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		default:
+			sb.WriteByte(value[i])
+		}
+	}
+	return sb.String()
+}
+
+func cefExtensionValue(key string, value string) any {
+	if _, ok := cefIntegerExtensions[key]; ok {
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+	}
+	if _, ok := cefFloatExtensions[key]; ok {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return value
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseCEF(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:  "full event",
+			input: `CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232 dpt=443 msg=Detected a threat. No action needed cfp1=1.5 cfp1Label=score`,
+			expected: map[string]any{
+				"version":               int64(0),
+				"device_vendor":         "Security",
+				"device_product":        "threatmanager",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "worm successfully stopped",
+				"severity":              int64(10),
+				"extensions": map[string]any{
+					"src":       "10.0.0.1",
+					"dst":       "2.1.2.2",
+					"spt":       int64(1232),
+					"dpt":       int64(443),
+					"msg":       "Detected a threat. No action needed",
+					"cfp1":      1.5,
+					"cfp1Label": "score",
+				},
+			},
+		},
+		{
+			name:  "syslog prefix and escapes",
+			input: `Sep 19 08:26:10 host CEF:1|Vendor\|Inc|Prod\\uct|2.0|sig|name|High|request=http://example.com/?a\=b act=blocked a \= b msg=line1\nline2`,
+			expected: map[string]any{
+				"version":               int64(1),
+				"device_vendor":         "Vendor|Inc",
+				"device_product":        `Prod\uct`,
+				"device_version":        "2.0",
+				"device_event_class_id": "sig",
+				"name":                  "name",
+				"severity":              "High",
+				"extensions": map[string]any{
+					"request": "http://example.com/?a=b",
+					"act":     "blocked a = b",
+					"msg":     "line1\nline2",
+				},
+			},
+		},
+		{
+			name:  "no extension",
+			input: `CEF:0|a|b|c|d|e|5`,
+			expected: map[string]any{
+				"version":               int64(0),
+				"device_vendor":         "a",
+				"device_product":        "b",
+				"device_version":        "c",
+				"device_event_class_id": "d",
+				"name":                  "e",
+				"severity":              int64(5),
+				"extensions":            map[string]any{},
+			},
+		},
+		{
+			name:  "invalid integer extension kept as string",
+			input: `CEF:0|a|b|c|d|e|5|spt=abc`,
+			expected: map[string]any{
+				"version":               int64(0),
+				"device_vendor":         "a",
+				"device_product":        "b",
+				"device_version":        "c",
+				"device_event_class_id": "d",
+				"name":                  "e",
+				"severity":              int64(5),
+				"extensions":            map[string]any{"spt": "abc"},
+			},
+		},
+		{
+			name:        "missing header",
+			input:       `0|a|b|c|d|e|5|`,
+			expectedErr: `missing "CEF:" header`,
+		},
+		{
+			name:        "missing header fields",
+			input:       `CEF:0|a|b|c`,
+			expectedErr: "invalid CEF header",
+		},
+		{
+			name:        "invalid version",
+			input:       `CEF:x|a|b|c|d|e|5|`,
+			expectedErr: `invalid CEF version "x"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := ParseCEF(tc.input)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, parsed)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	semconv "go.opentelemetry.io/collector/semconv/v1.27.0"
+)
+
+const (
+	// AttributeCommonLogIdent holds the RFC 1413 identity of the client, which has no semantic convention.
+	AttributeCommonLogIdent = "ident"
+	// AttributeCommonLogTimestamp holds the request time, formatted as "02/Jan/2006:15:04:05 -0700".
+	AttributeCommonLogTimestamp = "timestamp"
+	// AttributeHTTPRequestHeaderReferer is the http.request.header.<key> semantic convention for the Referer header.
+	AttributeHTTPRequestHeaderReferer = "http.request.header.referer"
+)
+
+// ParseCommonLogFormat parses an access log line written in the NCSA Common Log Format, or in the
+// Combined Log Format used by default by Apache httpd and nginx, which adds the referer and user
+// agent to it:
+//
+//	127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"
+//
+// The values are returned under their semantic conventions attribute names, with the status code
+// and response size as int64 values. Fields holding "-" are omitted. Any content following the
+// combined format fields is ignored.
+func ParseCommonLogFormat(value string) (map[string]any, error) {
+	s := &commonLogScanner{value: value}
+	parsed := make(map[string]any)
+
+	host, err := s.token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote host: %w", err)
+	}
+	putCommonLogValue(parsed, semconv.AttributeClientAddress, host)
+
+	ident, err := s.token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid ident: %w", err)
+	}
+	putCommonLogValue(parsed, AttributeCommonLogIdent, ident)
+
+	user, err := s.token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid user: %w", err)
+	}
+	putCommonLogValue(parsed, semconv.AttributeUserName, user)
+
+	timestamp, err := s.enclosed('[', ']')
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	parsed[AttributeCommonLogTimestamp] = timestamp
+
+	request, err := s.quoted()
+	if err != nil {
+		return nil, fmt.Errorf("invalid request line: %w", err)
+	}
+	parseCommonLogRequestLine(parsed, request)
+
+	status, err := s.token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid status code: %w", err)
+	}
+	statusCode, err := strconv.ParseInt(status, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid status code %q", status)
+	}
+	parsed[semconv.AttributeHTTPResponseStatusCode] = statusCode
+
+	size, err := s.token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid response size: %w", err)
+	}
+	if size != "-" {
+		bodySize, err := strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response size %q", size)
+		}
+		parsed[semconv.AttributeHTTPResponseBodySize] = bodySize
+	}
+
+	if s.done() {
+		return parsed, nil
+	}
+	referer, err := s.quoted()
+	if err != nil {
+		return nil, fmt.Errorf("invalid referer: %w", err)
+	}
+	if referer != "" && referer != "-" {
+		// header values are string arrays, as headers can be repeated
+		parsed[AttributeHTTPRequestHeaderReferer] = []any{referer}
+	}
+	userAgent, err := s.quoted()
+	if err != nil {
+		return nil, fmt.Errorf("invalid user agent: %w", err)
+	}
+	if userAgent != "" && userAgent != "-" {
+		parsed[semconv.AttributeUserAgentOriginal] = userAgent
+	}
+	return parsed, nil
+}
+
+// parseCommonLogRequestLine adds the method, target and protocol of the request line to parsed.
+// Malformed request lines, like the ones logged for invalid requests, are ignored.
+func parseCommonLogRequestLine(parsed map[string]any, request string) {
+	parts := strings.Fields(request)
+	if len(parts) != 3 {
+		return
+	}
+	parsed[semconv.AttributeHTTPRequestMethod] = parts[0]
+	path, query, hasQuery := strings.Cut(parts[1], "?")
+	parsed[semconv.AttributeURLPath] = path
+	if hasQuery && query != "" {
+		parsed[semconv.AttributeURLQuery] = query
+	}
+	if name, version, ok := strings.Cut(parts[2], "/"); ok {
+		parsed[semconv.AttributeNetworkProtocolName] = strings.ToLower(name)
+		parsed[semconv.AttributeNetworkProtocolVersion] = version
+	}
+}
+
+func putCommonLogValue(parsed map[string]any, key string, value string) {
+	if value != "-" {
+		parsed[key] = value
+	}
+}
+
+// commonLogScanner reads the space separated fields of a common log format line.
+type commonLogScanner struct {
+	value string
+	pos   int
+}
+
+func (s *commonLogScanner) skipSpaces() {
+	for s.pos < len(s.value) && s.value[s.pos] == ' ' {
+		s.pos++
+	}
+}
+
+func (s *commonLogScanner) done() bool {
+	s.skipSpaces()
+	return s.pos == len(s.value)
+}
+
+// token returns the next field, up to the following space.
+func (s *commonLogScanner) token() (string, error) {
+	if s.done() {
+		return "", errors.New("unexpected end of line")
+	}
+	start := s.pos
+	for s.pos < len(s.value) && s.value[s.pos] != ' ' {
+		s.pos++
+	}
+	return s.value[start:s.pos], nil
+}
+
+// enclosed returns the content of the next field, enclosed by the given characters.
+func (s *commonLogScanner) enclosed(open, closing byte) (string, error) {
+	if s.done() {
+		return "", errors.New("unexpected end of line")
+	}
+	if s.value[s.pos] != open {
+		return "", fmt.Errorf("expected %q at position %d", open, s.pos)
+	}
+	end := strings.IndexByte(s.value[s.pos+1:], closing)
+	if end < 0 {
+		return "", fmt.Errorf("missing closing %q", closing)
+	}
+	content := s.value[s.pos+1 : s.pos+1+end]
+	s.pos += end + 2
+	return content, nil
+}
+
+// quoted returns the unescaped content of the next double quoted field.
+func (s *commonLogScanner) quoted() (string, error) {
+	if s.done() {
+		return "", errors.New("unexpected end of line")
+	}
+	if s.value[s.pos] != '"' {
+		return "", fmt.Errorf("expected '\"' at position %d", s.pos)
+	}
+	var sb strings.Builder
+	for i := s.pos + 1; i < len(s.value); i++ {
+		switch c := s.value[i]; c {
+		case '\\':
+			if i+1 < len(s.value) {
+				i++
+				sb.WriteByte(s.value[i])
+			}
+		case '"':
+			s.pos = i + 1
+			return sb.String(), nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", errors.New("missing closing '\"'")
+}
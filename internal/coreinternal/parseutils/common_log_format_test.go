@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseCommonLogFormat(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:  "common",
+			input: `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`,
+			expected: map[string]any{
+				"client.address":            "127.0.0.1",
+				"user.name":                 "frank",
+				"timestamp":                 "10/Oct/2000:13:55:36 -0700",
+				"http.request.method":       "GET",
+				"url.path":                  "/apache_pb.gif",
+				"network.protocol.name":     "http",
+				"network.protocol.version":  "1.0",
+				"http.response.status_code": int64(200),
+				"http.response.body.size":   int64(2326),
+			},
+		},
+		{
+			name:  "combined",
+			input: `192.168.1.20 - - [28/Jul/2006:10:27:10 -0300] "GET /cgi-bin/try/?foo=bar&a=b HTTP/1.1" 304 - "http://192.168.1.20/" "Mozilla/5.0 (X11; \"Linux\")"`,
+			expected: map[string]any{
+				"client.address":              "192.168.1.20",
+				"timestamp":                   "28/Jul/2006:10:27:10 -0300",
+				"http.request.method":         "GET",
+				"url.path":                    "/cgi-bin/try/",
+				"url.query":                   "foo=bar&a=b",
+				"network.protocol.name":       "http",
+				"network.protocol.version":    "1.1",
+				"http.response.status_code":   int64(304),
+				"http.request.header.referer": []any{"http://192.168.1.20/"},
+				"user_agent.original":         `Mozilla/5.0 (X11; "Linux")`,
+			},
+		},
+		{
+			name:  "nginx invalid request with trailing fields",
+			input: `10.0.0.1 ident - [28/Jul/2006:10:27:10 -0300] "-" 400 0 "-" "-" "10.0.0.2"`,
+			expected: map[string]any{
+				"client.address":            "10.0.0.1",
+				"ident":                     "ident",
+				"timestamp":                 "28/Jul/2006:10:27:10 -0300",
+				"http.response.status_code": int64(400),
+				"http.response.body.size":   int64(0),
+			},
+		},
+		{
+			name:        "missing timestamp",
+			input:       `127.0.0.1 - frank "GET / HTTP/1.0" 200 2326`,
+			expectedErr: "invalid timestamp",
+		},
+		{
+			name:        "invalid status code",
+			input:       `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0" abc 2326`,
+			expectedErr: `invalid status code "abc"`,
+		},
+		{
+			name:        "unterminated request",
+			input:       `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0 200 2326`,
+			expectedErr: "invalid request line",
+		},
+		{
+			name:        "truncated line",
+			input:       `127.0.0.1 - frank`,
+			expectedErr: "invalid timestamp: unexpected end of line",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := ParseCommonLogFormat(tc.input)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, parsed)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const leefHeaderPrefix = "LEEF:"
+
+// leefHeaderFields are the names of the LEEF header fields, in order.
+var leefHeaderFields = []string{
+	"version",
+	"vendor",
+	"product",
+	"product_version",
+	"event_id",
+}
+
+// leefIntegerAttributes are the LEEF predefined attributes holding integer values.
+var leefIntegerAttributes = map[string]struct{}{
+	"sev": {}, "srcPort": {}, "dstPort": {}, "srcPreNATPort": {}, "dstPreNATPort": {},
+	"srcPostNATPort": {}, "dstPostNATPort": {}, "srcBytes": {}, "dstBytes": {},
+	"srcPackets": {}, "dstPackets": {}, "totalPackets": {},
+}
+
+// ParseLEEF parses an IBM QRadar Log Event Extended Format (LEEF) 1.0 or 2.0 event. Any content
+// before the "LEEF:" header, like a syslog prefix, is ignored.
+// The header fields are returned under the keys listed in leefHeaderFields, and the event
+// attributes under the "attributes" key. The predefined attributes holding numbers are returned
+// as int64 values, all others as strings.
+func ParseLEEF(value string) (map[string]any, error) {
+	start := strings.Index(value, leefHeaderPrefix)
+	if start < 0 {
+		return nil, fmt.Errorf("missing %q header", leefHeaderPrefix)
+	}
+	value = value[start+len(leefHeaderPrefix):]
+
+	version, _, _ := strings.Cut(value, "|")
+	headerFields := len(leefHeaderFields)
+	switch {
+	case strings.HasPrefix(version, "1."):
+	case strings.HasPrefix(version, "2."):
+		// LEEF 2.0 adds the attribute delimiter to the header
+		headerFields++
+	default:
+		return nil, fmt.Errorf("unsupported LEEF version %q", version)
+	}
+	fields := strings.SplitN(value, "|", headerFields+1)
+	if len(fields) < headerFields+1 {
+		return nil, fmt.Errorf("invalid LEEF header, expected %d fields separated by '|'", headerFields)
+	}
+	delimiter := "\t"
+	if headerFields > len(leefHeaderFields) {
+		var err error
+		if delimiter, err = parseLEEFDelimiter(fields[len(leefHeaderFields)]); err != nil {
+			return nil, err
+		}
+	}
+
+	parsed := make(map[string]any, len(leefHeaderFields)+1)
+	for i, name := range leefHeaderFields {
+		parsed[name] = fields[i]
+	}
+
+	attributes := make(map[string]any)
+	for _, pair := range strings.Split(fields[headerFields], delimiter) {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("cannot split LEEF attribute %q into a key and a value", pair)
+		}
+		attributes[key] = leefAttributeValue(key, val)
+	}
+	parsed["attributes"] = attributes
+	return parsed, nil
+}
+
+// parseLEEFDelimiter parses the LEEF 2.0 delimiter header field, either a single character or
+// its hexadecimal code prefixed with "x" or "0x". An empty field means the default tab delimiter.
+func parseLEEFDelimiter(field string) (string, error) {
+	switch {
+	case field == "":
+		return "\t", nil
+	case len(field) == 1:
+		return field, nil
+	case strings.HasPrefix(field, "x") || strings.HasPrefix(field, "0x"):
+		code, err := strconv.ParseUint(field[strings.Index(field, "x")+1:], 16, 8)
+		if err == nil && code > 0 {
+			return string(rune(code)), nil
+		}
+	}
+	return "", errors.New("invalid LEEF delimiter " + strconv.Quote(field))
+}
+
+func leefAttributeValue(key string, value string) any {
+	if _, ok := leefIntegerAttributes[key]; ok {
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+	}
+	return value
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseLEEF(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:  "version 1.0",
+			input: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tcat=anomaly\tsrcPort=81\tdstPort=21\tusrName=joe.black",
+			expected: map[string]any{
+				"version":         "1.0",
+				"vendor":          "Microsoft",
+				"product":         "MSExchange",
+				"product_version": "4.0 SP1",
+				"event_id":        "15345",
+				"attributes": map[string]any{
+					"src":     "192.0.2.0",
+					"dst":     "172.50.123.1",
+					"sev":     int64(5),
+					"cat":     "anomaly",
+					"srcPort": int64(81),
+					"dstPort": int64(21),
+					"usrName": "joe.black",
+				},
+			},
+		},
+		{
+			name:  "version 2.0 with delimiter",
+			input: "<13>Jan 18 11:07:53 host LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5^msg=a b",
+			expected: map[string]any{
+				"version":         "2.0",
+				"vendor":          "Lancope",
+				"product":         "StealthWatch",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"attributes": map[string]any{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+					"sev": int64(5),
+					"msg": "a b",
+				},
+			},
+		},
+		{
+			name:  "version 2.0 with hex delimiter",
+			input: "LEEF:2.0|Vendor|Product|1.0|41|0x3B|src=10.0.1.8;dstPort=abc",
+			expected: map[string]any{
+				"version":         "2.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"attributes": map[string]any{
+					"src":     "10.0.1.8",
+					"dstPort": "abc",
+				},
+			},
+		},
+		{
+			name:  "version 2.0 with empty delimiter",
+			input: "LEEF:2.0|Vendor|Product|1.0|41||src=10.0.1.8\tdst=10.0.0.5\tmsg=a|b",
+			expected: map[string]any{
+				"version":         "2.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"attributes": map[string]any{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+					"msg": "a|b",
+				},
+			},
+		},
+		{
+			name:  "version 2.0 with separator in attributes",
+			input: "LEEF:2.0|Vendor|Product|1.0|41|x5E|msg=a|b^src=10.0.1.8",
+			expected: map[string]any{
+				"version":         "2.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"attributes": map[string]any{
+					"msg": "a|b",
+					"src": "10.0.1.8",
+				},
+			},
+		},
+		{
+			name:        "missing header",
+			input:       "CEF:0|a|b|c|d|e|5|",
+			expectedErr: `missing "LEEF:" header`,
+		},
+		{
+			name:        "unsupported version",
+			input:       "LEEF:3.0|a|b|c|d|",
+			expectedErr: `unsupported LEEF version "3.0"`,
+		},
+		{
+			name:        "missing header fields",
+			input:       "LEEF:1.0|a|b",
+			expectedErr: "invalid LEEF header",
+		},
+		{
+			name:        "missing delimiter header field",
+			input:       "LEEF:2.0|a|b|c|d",
+			expectedErr: "invalid LEEF header, expected 6 fields separated by '|'",
+		},
+		{
+			name:        "invalid delimiter",
+			input:       "LEEF:2.0|a|b|c|d|xZZ|src=a",
+			expectedErr: `invalid LEEF delimiter "xZZ"`,
+		},
+		{
+			name:        "invalid attribute",
+			input:       "LEEF:1.0|a|b|c|d|src",
+			expectedErr: `cannot split LEEF attribute "src"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := ParseLEEF(tc.input)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, parsed)
+		})
+	}
+}
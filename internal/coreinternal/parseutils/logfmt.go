@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseLogfmt parses a logfmt line, a sequence of space separated key=value pairs.
+// Quoted values are unquoted and returned as strings. Unquoted values are returned as int64,
+// float64 or bool values when they represent one, or as strings otherwise. Keys without a value
+// are returned as true. If a key is repeated, its last value is returned.
+func ParseLogfmt(value string) (map[string]any, error) {
+	parsed := make(map[string]any)
+	for i := 0; i < len(value); {
+		if value[i] == ' ' || value[i] == '\t' {
+			i++
+			continue
+		}
+
+		keyStart := i
+		for i < len(value) && value[i] != '=' && value[i] != ' ' && value[i] != '\t' {
+			if value[i] == '"' {
+				return nil, fmt.Errorf("unexpected quote in key at position %d", i)
+			}
+			i++
+		}
+		key := value[keyStart:i]
+		if key == "" {
+			return nil, fmt.Errorf("missing key at position %d", keyStart)
+		}
+		if i == len(value) || value[i] != '=' {
+			parsed[key] = true
+			continue
+		}
+		i++
+
+		if i < len(value) && value[i] == '"' {
+			end, err := logfmtQuotedValueEnd(value, i)
+			if err != nil {
+				return nil, err
+			}
+			unquoted, err := strconv.Unquote(value[i:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted value for key %q: %w", key, err)
+			}
+			parsed[key] = unquoted
+			i = end
+			continue
+		}
+
+		valueStart := i
+		for i < len(value) && value[i] != ' ' && value[i] != '\t' {
+			i++
+		}
+		parsed[key] = logfmtValue(value[valueStart:i])
+	}
+	return parsed, nil
+}
+
+// logfmtQuotedValueEnd returns the position following the closing quote of the quoted value
+// starting at the given position.
+func logfmtQuotedValueEnd(value string, start int) (int, error) {
+	for i := start + 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("never reached the end of the quoted value starting at position %d", start)
+}
+
+func logfmtValue(value string) any {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	// ParseFloat also accepts values like "inf" or "nan", which are kept as strings
+	if strings.ContainsAny(value, "0123456789") {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return value
+}
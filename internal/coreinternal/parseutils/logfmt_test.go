@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseLogfmt(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:  "typed values",
+			input: `level=info msg="request handled" status=200 duration=0.25 cached=false retries=-1 code=007a`,
+			expected: map[string]any{
+				"level":    "info",
+				"msg":      "request handled",
+				"status":   int64(200),
+				"duration": 0.25,
+				"cached":   false,
+				"retries":  int64(-1),
+				"code":     "007a",
+			},
+		},
+		{
+			name:  "quoted values are strings",
+			input: `status="200" ok="true" escaped="a \"quoted\" value\n"`,
+			expected: map[string]any{
+				"status":  "200",
+				"ok":      "true",
+				"escaped": "a \"quoted\" value\n",
+			},
+		},
+		{
+			name:  "keys without value and empty values",
+			input: "  debug empty= \tname=ottl inf=inf  ",
+			expected: map[string]any{
+				"debug": true,
+				"empty": "",
+				"name":  "ottl",
+				"inf":   "inf",
+			},
+		},
+		{
+			name:  "repeated key",
+			input: `a=1 a=2`,
+			expected: map[string]any{
+				"a": int64(2),
+			},
+		},
+		{
+			name:        "unterminated quote",
+			input:       `msg="unterminated`,
+			expectedErr: "never reached the end of the quoted value",
+		},
+		{
+			name:        "missing key",
+			input:       `=value`,
+			expectedErr: "missing key at position 0",
+		},
+		{
+			name:        "quote in key",
+			input:       `"key"=value`,
+			expectedErr: "unexpected quote in key",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := ParseLogfmt(tc.input)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, parsed)
+		})
+	}
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
@@ -52,6 +54,14 @@ func StandardLogFuncs() map[string]ottl.Factory[ottllog.TransformContext] {
 	return ottlfuncs.StandardConverters[ottllog.TransformContext]()
 }
 
+func StandardProfileFuncs() map[string]ottl.Factory[ottlprofile.TransformContext] {
+	return ottlfuncs.StandardConverters[ottlprofile.TransformContext]()
+}
+
+func StandardSampleFuncs() map[string]ottl.Factory[ottlsample.TransformContext] {
+	return ottlfuncs.StandardConverters[ottlsample.TransformContext]()
+}
+
 func StandardResourceFuncs() map[string]ottl.Factory[ottlresource.TransformContext] {
 	return ottlfuncs.StandardConverters[ottlresource.TransformContext]()
 }
@@ -20,6 +20,8 @@ var defaultContextInferPriority = []string{
 	"metric",
 	"spanevent",
 	"span",
+	"sample",
+	"profile",
 	"resource",
 	"scope",
 	"instrumentation_scope",
@@ -218,11 +220,14 @@ func (s *priorityContextInferrer) sortContextCandidates(candidates []string) {
 // select a context in which the function/enum are supported.
 func (s *priorityContextInferrer) getParsedStatementHints(parsed *parsedStatement) ([]path, map[string]struct{}, map[enumSymbol]struct{}) {
 	visitor := newGrammarContextInferrerVisitor()
+	loopVariables := &grammarLoopVariablesVisitor{}
 	parsed.Editor.accept(&visitor)
+	parsed.Editor.accept(loopVariables)
 	if parsed.WhereClause != nil {
 		parsed.WhereClause.accept(&visitor)
+		parsed.WhereClause.accept(loopVariables)
 	}
-	return visitor.paths, visitor.functions, visitor.enumsSymbols
+	return withoutLoopVariables(visitor.paths, loopVariables), visitor.functions, visitor.enumsSymbols
 }
 
 // priorityContextInferrerHintsVisitor is a grammarVisitor implementation that collects
@@ -244,11 +249,16 @@ func newGrammarContextInferrerVisitor() priorityContextInferrerHintsVisitor {
 func (v *priorityContextInferrerHintsVisitor) visitMathExprLiteral(_ *mathExprLiteral) {}
 
 func (v *priorityContextInferrerHintsVisitor) visitEditor(e *editor) {
-	v.functions[e.Function] = struct{}{}
+	// iteration constructs are supported by all contexts
+	if !isIterationConstruct(e.Function) {
+		v.functions[e.Function] = struct{}{}
+	}
 }
 
 func (v *priorityContextInferrerHintsVisitor) visitConverter(c *converter) {
-	v.functions[c.Function] = struct{}{}
+	if !isIterationConstruct(c.Function) {
+		v.functions[c.Function] = struct{}{}
+	}
 }
 
 func (v *priorityContextInferrerHintsVisitor) visitValue(va *value) {
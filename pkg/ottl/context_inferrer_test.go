@@ -167,6 +167,8 @@ func Test_DefaultPriorityContextInferrer(t *testing.T) {
 		"metric",
 		"spanevent",
 		"span",
+		"sample",
+		"profile",
 		"resource",
 		"scope",
 		"instrumentation_scope",
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxprofile // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+)
+
+// attributable is implemented by the profile records referencing entries of the profile's attribute table.
+type attributable interface {
+	AttributeIndices() pcommon.Int32Slice
+}
+
+// Attributes returns a copy of the attributes referenced by the record. Changes made to the
+// returned map must be written back with SetAttributes.
+func Attributes(profile pprofile.Profile, record attributable) pcommon.Map {
+	return pprofile.FromAttributeIndices(profile.AttributeTable(), record)
+}
+
+// SetAttributes makes the record reference the given attributes, reusing the entries of the
+// profile's attribute table when possible and appending the missing ones.
+func SetAttributes(profile pprofile.Profile, record attributable, attrs pcommon.Map) {
+	NewAttributeIndex(profile).SetAttributes(record, attrs)
+}
+
+// attributeEntry identifies an entry of the attribute table by its key and value.
+type attributeEntry struct {
+	key       string
+	valueType pcommon.ValueType
+	value     string
+}
+
+func newAttributeEntry(key string, value pcommon.Value) attributeEntry {
+	return attributeEntry{key: key, valueType: value.Type(), value: value.AsString()}
+}
+
+// AttributeIndex looks up the entries of a profile's attribute table by key and value. The table
+// is indexed on the first lookup; the records of a profile should share the index so the table is
+// indexed only once.
+type AttributeIndex struct {
+	profile pprofile.Profile
+	// indexed is the number of entries of the table in indices. Entries appended to the table
+	// since the last lookup are indexed on the next one.
+	indexed int
+	indices map[attributeEntry]int32
+}
+
+// NewAttributeIndex returns an index of the attribute table of the given profile.
+func NewAttributeIndex(profile pprofile.Profile) *AttributeIndex {
+	return &AttributeIndex{profile: profile}
+}
+
+// Indexes reports whether the index is the one of the given profile's attribute table.
+func (ai *AttributeIndex) Indexes(profile pprofile.Profile) bool {
+	return ai.profile == profile
+}
+
+// SetAttributes makes the record reference the given attributes, reusing the entries of the
+// profile's attribute table when possible and appending the missing ones.
+func (ai *AttributeIndex) SetAttributes(record attributable, attrs pcommon.Map) {
+	indices := make([]int32, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		indices = append(indices, ai.index(k, v))
+		return true
+	})
+	record.AttributeIndices().FromRaw(indices)
+}
+
+func (ai *AttributeIndex) index(key string, value pcommon.Value) int32 {
+	table := ai.profile.AttributeTable()
+	if ai.indices == nil || ai.indexed > table.Len() {
+		ai.indices = make(map[attributeEntry]int32, table.Len())
+		ai.indexed = 0
+	}
+	for ; ai.indexed < table.Len(); ai.indexed++ {
+		attr := table.At(ai.indexed)
+		entry := newAttributeEntry(attr.Key(), attr.Value())
+		if _, ok := ai.indices[entry]; !ok {
+			ai.indices[entry] = int32(ai.indexed)
+		}
+	}
+
+	entry := newAttributeEntry(key, value)
+	if index, ok := ai.indices[entry]; ok {
+		return index
+	}
+	attr := table.AppendEmpty()
+	attr.SetKey(key)
+	value.CopyTo(attr.Value())
+	ai.indexed = table.Len()
+	ai.indices[entry] = int32(ai.indexed - 1)
+	return int32(ai.indexed - 1)
+}
+
+// String returns the entry of the profile's string table at the given index, or an empty
+// string if the index is out of range.
+func String(profile pprofile.Profile, index int32) string {
+	if index < 0 || int(index) >= profile.StringTable().Len() {
+		return ""
+	}
+	return profile.StringTable().At(int(index))
+}
+
+// StringIndex returns the index of the given string in the profile's string table, appending
+// it if it's not there yet.
+func StringIndex(profile pprofile.Profile, s string) int32 {
+	table := profile.StringTable()
+	for i := 0; i < table.Len(); i++ {
+		if table.At(i) == s {
+			return int32(i)
+		}
+	}
+	table.Append(s)
+	return int32(table.Len() - 1)
+}
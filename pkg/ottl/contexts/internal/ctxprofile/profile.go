@@ -0,0 +1,347 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxprofile // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxcache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxerror"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxutil"
+)
+
+func PathGetSetter[K Context](lowerContext string, path ottl.Path[K]) (ottl.GetSetter[K], error) {
+	if path == nil {
+		return nil, ctxerror.New("nil", "nil", Name, DocRef)
+	}
+	switch path.Name() {
+	case "profile_id":
+		nextPath := path.Next()
+		if nextPath != nil {
+			if nextPath.Name() == "string" {
+				return accessStringProfileID[K](), nil
+			}
+			return nil, ctxerror.New(nextPath.Name(), nextPath.String(), Name, DocRef)
+		}
+		return accessProfileID[K](), nil
+	case "time_unix_nano":
+		return accessTimeUnixNano[K](), nil
+	case "time":
+		return accessTime[K](), nil
+	case "duration_unix_nano":
+		return accessDurationUnixNano[K](), nil
+	case "duration":
+		return accessDuration[K](), nil
+	case "period":
+		return accessPeriod[K](), nil
+	case "period_type":
+		nextPath := path.Next()
+		if nextPath != nil {
+			switch nextPath.Name() {
+			case "type":
+				return accessPeriodTypeType[K](), nil
+			case "unit":
+				return accessPeriodTypeUnit[K](), nil
+			default:
+				return nil, ctxerror.New(nextPath.Name(), nextPath.String(), Name, DocRef)
+			}
+		}
+		return nil, ctxerror.New(path.Name(), path.String(), Name, DocRef)
+	case "sample_types":
+		return accessSampleTypes[K](), nil
+	case "default_sample_type":
+		return accessDefaultSampleType[K](), nil
+	case "sample_count":
+		return accessSampleCount[K](), nil
+	case "attributes":
+		mapKeys := path.Keys()
+		if mapKeys == nil {
+			return accessAttributes[K](), nil
+		}
+		return accessAttributesKey[K](mapKeys), nil
+	case "dropped_attributes_count":
+		return accessDroppedAttributesCount[K](), nil
+	case "original_payload_format":
+		return accessOriginalPayloadFormat[K](), nil
+	case "original_payload":
+		return accessOriginalPayload[K](), nil
+	case "cache":
+		return nil, ctxcache.NewError(lowerContext, path.Context(), path.String())
+	default:
+		return nil, ctxerror.New(path.Name(), path.String(), Name, DocRef)
+	}
+}
+
+func accessProfileID[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().ProfileID(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if newProfileID, ok := val.(pprofile.ProfileID); ok {
+				tCtx.GetProfile().SetProfileID(newProfileID)
+			}
+			return nil
+		},
+	}
+}
+
+func accessStringProfileID[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			id := tCtx.GetProfile().ProfileID()
+			return hex.EncodeToString(id[:]), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if str, ok := val.(string); ok {
+				id, err := parseProfileID(str)
+				if err != nil {
+					return err
+				}
+				tCtx.GetProfile().SetProfileID(id)
+			}
+			return nil
+		},
+	}
+}
+
+func parseProfileID(profileIDStr string) (pprofile.ProfileID, error) {
+	var id pprofile.ProfileID
+	if hex.DecodedLen(len(profileIDStr)) != len(id) {
+		return pprofile.ProfileID{}, errors.New("profile ids must be 32 hex characters")
+	}
+	_, err := hex.Decode(id[:], []byte(profileIDStr))
+	if err != nil {
+		return pprofile.ProfileID{}, err
+	}
+	return id, nil
+}
+
+func accessTimeUnixNano[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Time().AsTime().UnixNano(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().SetTime(pcommon.NewTimestampFromTime(time.Unix(0, i)))
+			}
+			return nil
+		},
+	}
+}
+
+func accessTime[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Time().AsTime(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(time.Time); ok {
+				tCtx.GetProfile().SetTime(pcommon.NewTimestampFromTime(i))
+			}
+			return nil
+		},
+	}
+}
+
+func accessDurationUnixNano[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return int64(tCtx.GetProfile().Duration()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().SetDuration(pcommon.Timestamp(uint64(i)))
+			}
+			return nil
+		},
+	}
+}
+
+func accessDuration[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return time.Duration(tCtx.GetProfile().Duration()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if d, ok := val.(time.Duration); ok {
+				tCtx.GetProfile().SetDuration(pcommon.Timestamp(uint64(d)))
+			}
+			return nil
+		},
+	}
+}
+
+func accessPeriod[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().Period(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().SetPeriod(i)
+			}
+			return nil
+		},
+	}
+}
+
+func accessPeriodTypeType[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			profile := tCtx.GetProfile()
+			return String(profile, profile.PeriodType().TypeStrindex()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if str, ok := val.(string); ok {
+				profile := tCtx.GetProfile()
+				profile.PeriodType().SetTypeStrindex(StringIndex(profile, str))
+			}
+			return nil
+		},
+	}
+}
+
+func accessPeriodTypeUnit[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			profile := tCtx.GetProfile()
+			return String(profile, profile.PeriodType().UnitStrindex()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if str, ok := val.(string); ok {
+				profile := tCtx.GetProfile()
+				profile.PeriodType().SetUnitStrindex(StringIndex(profile, str))
+			}
+			return nil
+		},
+	}
+}
+
+func accessSampleTypes[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			profile := tCtx.GetProfile()
+			sampleTypes := make([]string, 0, profile.SampleType().Len())
+			for i := 0; i < profile.SampleType().Len(); i++ {
+				sampleTypes = append(sampleTypes, String(profile, profile.SampleType().At(i).TypeStrindex()))
+			}
+			return sampleTypes, nil
+		},
+		Setter: func(_ context.Context, _ K, _ any) error {
+			return errors.New("the 'sample_types' path cannot be modified")
+		},
+	}
+}
+
+func accessDefaultSampleType[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			profile := tCtx.GetProfile()
+			return String(profile, profile.DefaultSampleTypeStrindex()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if str, ok := val.(string); ok {
+				profile := tCtx.GetProfile()
+				profile.SetDefaultSampleTypeStrindex(StringIndex(profile, str))
+			}
+			return nil
+		},
+	}
+}
+
+func accessSampleCount[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return int64(tCtx.GetProfile().Sample().Len()), nil
+		},
+		Setter: func(_ context.Context, _ K, _ any) error {
+			return errors.New("the 'sample_count' path cannot be modified")
+		},
+	}
+}
+
+func accessAttributes[K Context]() ottl.DetachedGetSetter[K] {
+	return ottl.DetachedGetSetter[K]{StandardGetSetter: ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			profile := tCtx.GetProfile()
+			return Attributes(profile, profile), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if attrs, ok := val.(pcommon.Map); ok {
+				profile := tCtx.GetProfile()
+				SetAttributes(profile, profile, attrs)
+			}
+			return nil
+		},
+	}}
+}
+
+func accessAttributesKey[K Context](keys []ottl.Key[K]) ottl.DetachedGetSetter[K] {
+	return ottl.DetachedGetSetter[K]{StandardGetSetter: ottl.StandardGetSetter[K]{
+		Getter: func(ctx context.Context, tCtx K) (any, error) {
+			profile := tCtx.GetProfile()
+			return ctxutil.GetMapValue[K](ctx, tCtx, Attributes(profile, profile), keys)
+		},
+		Setter: func(ctx context.Context, tCtx K, val any) error {
+			profile := tCtx.GetProfile()
+			attrs := Attributes(profile, profile)
+			if err := ctxutil.SetMapValue[K](ctx, tCtx, attrs, keys, val); err != nil {
+				return err
+			}
+			SetAttributes(profile, profile, attrs)
+			return nil
+		},
+	}}
+}
+
+func accessDroppedAttributesCount[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return int64(tCtx.GetProfile().DroppedAttributesCount()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if i, ok := val.(int64); ok {
+				tCtx.GetProfile().SetDroppedAttributesCount(uint32(i))
+			}
+			return nil
+		},
+	}
+}
+
+func accessOriginalPayloadFormat[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().OriginalPayloadFormat(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if str, ok := val.(string); ok {
+				tCtx.GetProfile().SetOriginalPayloadFormat(str)
+			}
+			return nil
+		},
+	}
+}
+
+func accessOriginalPayload[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetProfile().OriginalPayload().AsRaw(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if b, ok := val.([]byte); ok {
+				tCtx.GetProfile().OriginalPayload().FromRaw(b)
+			}
+			return nil
+		},
+	}
+}
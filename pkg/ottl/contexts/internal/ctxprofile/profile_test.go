@@ -0,0 +1,345 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxprofile_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/pathtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func TestPathGetSetter(t *testing.T) {
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+	profileID := pprofile.ProfileID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[*testContext]
+		orig     any
+		newVal   any
+		modified func(profile pprofile.Profile)
+	}{
+		{
+			name: "profile_id",
+			path: &pathtest.Path[*testContext]{
+				N: "profile_id",
+			},
+			orig:   pprofile.NewProfileIDEmpty(),
+			newVal: profileID,
+			modified: func(profile pprofile.Profile) {
+				profile.SetProfileID(profileID)
+			},
+		},
+		{
+			name: "profile_id string",
+			path: &pathtest.Path[*testContext]{
+				N: "profile_id",
+				NextPath: &pathtest.Path[*testContext]{
+					N: "string",
+				},
+			},
+			orig:   "00000000000000000000000000000000",
+			newVal: "0102030405060708090a0b0c0d0e0f10",
+			modified: func(profile pprofile.Profile) {
+				profile.SetProfileID(profileID)
+			},
+		},
+		{
+			name: "time_unix_nano",
+			path: &pathtest.Path[*testContext]{
+				N: "time_unix_nano",
+			},
+			orig:   int64(100_000_000),
+			newVal: int64(200_000_000),
+			modified: func(profile pprofile.Profile) {
+				profile.SetTime(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "time",
+			path: &pathtest.Path[*testContext]{
+				N: "time",
+			},
+			orig:   time.Unix(0, 100_000_000).UTC(),
+			newVal: time.Date(1970, 1, 1, 0, 0, 0, 200_000_000, time.UTC),
+			modified: func(profile pprofile.Profile) {
+				profile.SetTime(pcommon.NewTimestampFromTime(time.UnixMilli(200)))
+			},
+		},
+		{
+			name: "duration_unix_nano",
+			path: &pathtest.Path[*testContext]{
+				N: "duration_unix_nano",
+			},
+			orig:   int64(time.Second),
+			newVal: int64(2 * time.Second),
+			modified: func(profile pprofile.Profile) {
+				profile.SetDuration(pcommon.Timestamp(2 * time.Second))
+			},
+		},
+		{
+			name: "duration",
+			path: &pathtest.Path[*testContext]{
+				N: "duration",
+			},
+			orig:   time.Second,
+			newVal: 3 * time.Second,
+			modified: func(profile pprofile.Profile) {
+				profile.SetDuration(pcommon.Timestamp(3 * time.Second))
+			},
+		},
+		{
+			name: "period",
+			path: &pathtest.Path[*testContext]{
+				N: "period",
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(profile pprofile.Profile) {
+				profile.SetPeriod(20)
+			},
+		},
+		{
+			name: "period_type type",
+			path: &pathtest.Path[*testContext]{
+				N: "period_type",
+				NextPath: &pathtest.Path[*testContext]{
+					N: "type",
+				},
+			},
+			orig:   "cpu",
+			newVal: "samples",
+			modified: func(profile pprofile.Profile) {
+				profile.PeriodType().SetTypeStrindex(3)
+			},
+		},
+		{
+			name: "period_type unit",
+			path: &pathtest.Path[*testContext]{
+				N: "period_type",
+				NextPath: &pathtest.Path[*testContext]{
+					N: "unit",
+				},
+			},
+			orig:   "nanoseconds",
+			newVal: "milliseconds",
+			modified: func(profile pprofile.Profile) {
+				profile.StringTable().Append("milliseconds")
+				profile.PeriodType().SetUnitStrindex(4)
+			},
+		},
+		{
+			name: "default_sample_type",
+			path: &pathtest.Path[*testContext]{
+				N: "default_sample_type",
+			},
+			orig:   "samples",
+			newVal: "cpu",
+			modified: func(profile pprofile.Profile) {
+				profile.SetDefaultSampleTypeStrindex(1)
+			},
+		},
+		{
+			name: "attributes",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+			},
+			orig:   createAttributes(),
+			newVal: newAttrs,
+			modified: func(profile pprofile.Profile) {
+				ctxprofile.SetAttributes(profile, profile, newAttrs)
+			},
+		},
+		{
+			name: "attributes string",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("str"),
+					},
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(profile pprofile.Profile) {
+				attrs := createAttributes()
+				attrs.PutStr("str", "newVal")
+				ctxprofile.SetAttributes(profile, profile, attrs)
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: &pathtest.Path[*testContext]{
+				N: "dropped_attributes_count",
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(profile pprofile.Profile) {
+				profile.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "original_payload_format",
+			path: &pathtest.Path[*testContext]{
+				N: "original_payload_format",
+			},
+			orig:   "pprof",
+			newVal: "jfr",
+			modified: func(profile pprofile.Profile) {
+				profile.SetOriginalPayloadFormat("jfr")
+			},
+		},
+		{
+			name: "original_payload",
+			path: &pathtest.Path[*testContext]{
+				N: "original_payload",
+			},
+			orig:   []byte{1, 2, 3},
+			newVal: []byte{4, 5, 6},
+			modified: func(profile pprofile.Profile) {
+				profile.OriginalPayload().FromRaw([]byte{4, 5, 6})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := ctxprofile.PathGetSetter[*testContext](tt.path.Context(), tt.path)
+			assert.NoError(t, err)
+
+			profile := createProfile()
+
+			got, err := accessor.Get(context.Background(), newTestContext(profile))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), newTestContext(profile), tt.newVal)
+			assert.NoError(t, err)
+
+			expectedProfile := createProfile()
+			tt.modified(expectedProfile)
+
+			assert.Equal(t, expectedProfile, profile)
+		})
+	}
+}
+
+func TestPathGetSetterReadOnly(t *testing.T) {
+	tests := []struct {
+		name string
+		orig any
+	}{
+		{
+			name: "sample_types",
+			orig: []string{"samples", "cpu"},
+		},
+		{
+			name: "sample_count",
+			orig: int64(2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := ctxprofile.PathGetSetter[*testContext]("", &pathtest.Path[*testContext]{N: tt.name})
+			require.NoError(t, err)
+
+			got, err := accessor.Get(context.Background(), newTestContext(createProfile()))
+			require.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), newTestContext(createProfile()), tt.orig)
+			assert.ErrorContains(t, err, "cannot be modified")
+		})
+	}
+}
+
+func TestPathGetSetterInvalidProfileID(t *testing.T) {
+	accessor, err := ctxprofile.PathGetSetter[*testContext]("", &pathtest.Path[*testContext]{
+		N:        "profile_id",
+		NextPath: &pathtest.Path[*testContext]{N: "string"},
+	})
+	require.NoError(t, err)
+
+	err = accessor.Set(context.Background(), newTestContext(createProfile()), "invalid")
+	assert.ErrorContains(t, err, "profile ids must be 32 hex characters")
+}
+
+func TestProfilePathGetSetterCacheAccessError(t *testing.T) {
+	path := &pathtest.Path[*testContext]{
+		N: "cache",
+		C: "profile",
+		KeySlice: []ottl.Key[*testContext]{
+			&pathtest.Key[*testContext]{
+				S: ottltest.Strp("key"),
+			},
+		},
+		FullPath: "profile.cache[key]",
+	}
+
+	_, err := ctxprofile.PathGetSetter[*testContext]("sample", path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `replace "profile.cache[key]" with "sample.cache[key]"`)
+}
+
+func TestSetAttributesReusesTableEntries(t *testing.T) {
+	profile := createProfile()
+	tableLen := profile.AttributeTable().Len()
+
+	sample := profile.Sample().At(0)
+	ctxprofile.SetAttributes(profile, sample, createAttributes())
+
+	assert.Equal(t, tableLen, profile.AttributeTable().Len())
+	assert.Equal(t, createAttributes().AsRaw(), ctxprofile.Attributes(profile, sample).AsRaw())
+}
+
+func createAttributes() pcommon.Map {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("str", "val")
+	attrs.PutBool("bool", true)
+	attrs.PutInt("int", 10)
+	return attrs
+}
+
+func createProfile() pprofile.Profile {
+	profile := pprofile.NewProfile()
+	profile.StringTable().FromRaw([]string{"", "cpu", "nanoseconds", "samples"})
+	profile.SetTime(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	profile.SetDuration(pcommon.Timestamp(time.Second))
+	profile.SetPeriod(10)
+	profile.PeriodType().SetTypeStrindex(1)
+	profile.PeriodType().SetUnitStrindex(2)
+	profile.SampleType().AppendEmpty().SetTypeStrindex(3)
+	profile.SampleType().AppendEmpty().SetTypeStrindex(1)
+	profile.SetDefaultSampleTypeStrindex(3)
+	profile.SetDroppedAttributesCount(10)
+	profile.SetOriginalPayloadFormat("pprof")
+	profile.OriginalPayload().FromRaw([]byte{1, 2, 3})
+	profile.Sample().AppendEmpty()
+	profile.Sample().AppendEmpty()
+	ctxprofile.SetAttributes(profile, profile, createAttributes())
+	return profile
+}
+
+type testContext struct {
+	profile pprofile.Profile
+}
+
+func (p *testContext) GetProfile() pprofile.Profile {
+	return p.profile
+}
+
+func newTestContext(profile pprofile.Profile) *testContext {
+	return &testContext{profile: profile}
+}
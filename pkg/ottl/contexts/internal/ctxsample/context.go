@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxsample // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxsample"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+)
+
+const (
+	Name   = "sample"
+	DocRef = "https://github.com/open-telemetry/opentelemetry-collector-contrib/tree/main/pkg/ottl/contexts/ottlsample"
+)
+
+type Context interface {
+	GetSample() pprofile.Sample
+	GetProfile() pprofile.Profile
+	// GetAttributeIndex returns the index of the profile's attribute table used to set the
+	// attributes of the sample.
+	GetAttributeIndex() *ctxprofile.AttributeIndex
+}
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxsample // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxsample"
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxerror"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxutil"
+)
+
+func PathGetSetter[K Context](path ottl.Path[K]) (ottl.GetSetter[K], error) {
+	if path == nil {
+		return nil, ctxerror.New("nil", "nil", Name, DocRef)
+	}
+	switch path.Name() {
+	case "values":
+		return accessValues[K](), nil
+	case "timestamps_unix_nano":
+		return accessTimestampsUnixNano[K](), nil
+	case "attributes":
+		mapKeys := path.Keys()
+		if mapKeys == nil {
+			return accessAttributes[K](), nil
+		}
+		return accessAttributesKey[K](mapKeys), nil
+	case "locations":
+		return accessLocations[K](), nil
+	case "function_names":
+		return accessFunctionNames[K](), nil
+	case "trace_id":
+		nextPath := path.Next()
+		if nextPath != nil {
+			if nextPath.Name() == "string" {
+				return accessStringTraceID[K](), nil
+			}
+			return nil, ctxerror.New(nextPath.Name(), nextPath.String(), Name, DocRef)
+		}
+		return accessTraceID[K](), nil
+	case "span_id":
+		nextPath := path.Next()
+		if nextPath != nil {
+			if nextPath.Name() == "string" {
+				return accessStringSpanID[K](), nil
+			}
+			return nil, ctxerror.New(nextPath.Name(), nextPath.String(), Name, DocRef)
+		}
+		return accessSpanID[K](), nil
+	default:
+		return nil, ctxerror.New(path.Name(), path.String(), Name, DocRef)
+	}
+}
+
+func accessValues[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return tCtx.GetSample().Value().AsRaw(), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			values, ok := val.([]int64)
+			if !ok {
+				return fmt.Errorf("the 'values' path must be set to a []int64, got %T", val)
+			}
+			tCtx.GetSample().Value().FromRaw(values)
+			return nil
+		},
+	}
+}
+
+func accessTimestampsUnixNano[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			timestamps := tCtx.GetSample().TimestampsUnixNano()
+			result := make([]int64, 0, timestamps.Len())
+			for i := 0; i < timestamps.Len(); i++ {
+				result = append(result, int64(timestamps.At(i)))
+			}
+			return result, nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if timestamps, ok := val.([]int64); ok {
+				raw := make([]uint64, 0, len(timestamps))
+				for _, ts := range timestamps {
+					raw = append(raw, uint64(ts))
+				}
+				tCtx.GetSample().TimestampsUnixNano().FromRaw(raw)
+			}
+			return nil
+		},
+	}
+}
+
+func accessAttributes[K Context]() ottl.DetachedGetSetter[K] {
+	return ottl.DetachedGetSetter[K]{StandardGetSetter: ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			return ctxprofile.Attributes(tCtx.GetProfile(), tCtx.GetSample()), nil
+		},
+		Setter: func(_ context.Context, tCtx K, val any) error {
+			if attrs, ok := val.(pcommon.Map); ok {
+				tCtx.GetAttributeIndex().SetAttributes(tCtx.GetSample(), attrs)
+			}
+			return nil
+		},
+	}}
+}
+
+func accessAttributesKey[K Context](keys []ottl.Key[K]) ottl.DetachedGetSetter[K] {
+	return ottl.DetachedGetSetter[K]{StandardGetSetter: ottl.StandardGetSetter[K]{
+		Getter: func(ctx context.Context, tCtx K) (any, error) {
+			return ctxutil.GetMapValue[K](ctx, tCtx, ctxprofile.Attributes(tCtx.GetProfile(), tCtx.GetSample()), keys)
+		},
+		Setter: func(ctx context.Context, tCtx K, val any) error {
+			attrs := ctxprofile.Attributes(tCtx.GetProfile(), tCtx.GetSample())
+			if err := ctxutil.SetMapValue[K](ctx, tCtx, attrs, keys, val); err != nil {
+				return err
+			}
+			tCtx.GetAttributeIndex().SetAttributes(tCtx.GetSample(), attrs)
+			return nil
+		},
+	}}
+}
+
+// accessLocations resolves the stack of the sample, leaf first, into a slice of maps.
+func accessLocations[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			profile := tCtx.GetProfile()
+			result := pcommon.NewSlice()
+			for _, location := range locations(profile, tCtx.GetSample()) {
+				m := result.AppendEmpty().SetEmptyMap()
+				m.PutInt("address", int64(location.Address()))
+				m.PutBool("is_folded", location.IsFolded())
+				if location.HasMappingIndex() && int(location.MappingIndex()) < profile.MappingTable().Len() {
+					m.PutStr("mapping_filename", ctxprofile.String(profile, profile.MappingTable().At(int(location.MappingIndex())).FilenameStrindex()))
+				}
+				lines := m.PutEmptySlice("lines")
+				for i := 0; i < location.Line().Len(); i++ {
+					line := location.Line().At(i)
+					lm := lines.AppendEmpty().SetEmptyMap()
+					if fn, ok := function(profile, line); ok {
+						lm.PutStr("function_name", ctxprofile.String(profile, fn.NameStrindex()))
+						lm.PutStr("system_name", ctxprofile.String(profile, fn.SystemNameStrindex()))
+						lm.PutStr("filename", ctxprofile.String(profile, fn.FilenameStrindex()))
+					}
+					lm.PutInt("line", line.Line())
+					lm.PutInt("column", line.Column())
+				}
+			}
+			return result, nil
+		},
+		Setter: func(_ context.Context, _ K, _ any) error {
+			return errors.New("the 'locations' path cannot be modified")
+		},
+	}
+}
+
+func accessFunctionNames[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			profile := tCtx.GetProfile()
+			var names []string
+			for _, location := range locations(profile, tCtx.GetSample()) {
+				for i := 0; i < location.Line().Len(); i++ {
+					if fn, ok := function(profile, location.Line().At(i)); ok {
+						names = append(names, ctxprofile.String(profile, fn.NameStrindex()))
+					}
+				}
+			}
+			return names, nil
+		},
+		Setter: func(_ context.Context, _ K, _ any) error {
+			return errors.New("the 'function_names' path cannot be modified")
+		},
+	}
+}
+
+func locations(profile pprofile.Profile, sample pprofile.Sample) []pprofile.Location {
+	start, length := int(sample.LocationsStartIndex()), int(sample.LocationsLength())
+	indices := profile.LocationIndices()
+	result := make([]pprofile.Location, 0, length)
+	for i := start; i < start+length && i < indices.Len(); i++ {
+		index := int(indices.At(i))
+		if index < 0 || index >= profile.LocationTable().Len() {
+			continue
+		}
+		result = append(result, profile.LocationTable().At(index))
+	}
+	return result
+}
+
+func function(profile pprofile.Profile, line pprofile.Line) (pprofile.Function, bool) {
+	index := int(line.FunctionIndex())
+	if index < 0 || index >= profile.FunctionTable().Len() {
+		return pprofile.Function{}, false
+	}
+	return profile.FunctionTable().At(index), true
+}
+
+func link(tCtx Context) (pprofile.Link, bool) {
+	sample := tCtx.GetSample()
+	if !sample.HasLinkIndex() {
+		return pprofile.Link{}, false
+	}
+	links := tCtx.GetProfile().LinkTable()
+	index := int(sample.LinkIndex())
+	if index < 0 || index >= links.Len() {
+		return pprofile.Link{}, false
+	}
+	return links.At(index), true
+}
+
+func accessTraceID[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			if l, ok := link(tCtx); ok {
+				return l.TraceID(), nil
+			}
+			return pcommon.NewTraceIDEmpty(), nil
+		},
+		Setter: func(_ context.Context, _ K, _ any) error {
+			return errors.New("the 'trace_id' path cannot be modified")
+		},
+	}
+}
+
+func accessStringTraceID[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			if l, ok := link(tCtx); ok {
+				id := l.TraceID()
+				return hex.EncodeToString(id[:]), nil
+			}
+			return "", nil
+		},
+		Setter: func(_ context.Context, _ K, _ any) error {
+			return errors.New("the 'trace_id.string' path cannot be modified")
+		},
+	}
+}
+
+func accessSpanID[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			if l, ok := link(tCtx); ok {
+				return l.SpanID(), nil
+			}
+			return pcommon.NewSpanIDEmpty(), nil
+		},
+		Setter: func(_ context.Context, _ K, _ any) error {
+			return errors.New("the 'span_id' path cannot be modified")
+		},
+	}
+}
+
+func accessStringSpanID[K Context]() ottl.StandardGetSetter[K] {
+	return ottl.StandardGetSetter[K]{
+		Getter: func(_ context.Context, tCtx K) (any, error) {
+			if l, ok := link(tCtx); ok {
+				id := l.SpanID()
+				return hex.EncodeToString(id[:]), nil
+			}
+			return "", nil
+		},
+		Setter: func(_ context.Context, _ K, _ any) error {
+			return errors.New("the 'span_id.string' path cannot be modified")
+		},
+	}
+}
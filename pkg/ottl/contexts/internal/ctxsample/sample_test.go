@@ -0,0 +1,307 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ctxsample_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxsample"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/pathtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func TestPathGetSetter(t *testing.T) {
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[*testContext]
+		orig     any
+		newVal   any
+		modified func(profile pprofile.Profile, sample pprofile.Sample)
+	}{
+		{
+			name: "values",
+			path: &pathtest.Path[*testContext]{
+				N: "values",
+			},
+			orig:   []int64{1, 100},
+			newVal: []int64{2, 200},
+			modified: func(_ pprofile.Profile, sample pprofile.Sample) {
+				sample.Value().FromRaw([]int64{2, 200})
+			},
+		},
+		{
+			name: "timestamps_unix_nano",
+			path: &pathtest.Path[*testContext]{
+				N: "timestamps_unix_nano",
+			},
+			orig:   []int64{1000},
+			newVal: []int64{2000, 3000},
+			modified: func(_ pprofile.Profile, sample pprofile.Sample) {
+				sample.TimestampsUnixNano().FromRaw([]uint64{2000, 3000})
+			},
+		},
+		{
+			name: "attributes",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+			},
+			orig:   createAttributes(),
+			newVal: newAttrs,
+			modified: func(profile pprofile.Profile, sample pprofile.Sample) {
+				ctxprofile.SetAttributes(profile, sample, newAttrs)
+			},
+		},
+		{
+			name: "attributes string",
+			path: &pathtest.Path[*testContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*testContext]{
+					&pathtest.Key[*testContext]{
+						S: ottltest.Strp("thread.name"),
+					},
+				},
+			},
+			orig:   "main",
+			newVal: "worker",
+			modified: func(profile pprofile.Profile, sample pprofile.Sample) {
+				attrs := createAttributes()
+				attrs.PutStr("thread.name", "worker")
+				ctxprofile.SetAttributes(profile, sample, attrs)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := ctxsample.PathGetSetter[*testContext](tt.path)
+			assert.NoError(t, err)
+
+			profile := createProfile()
+			tCtx := newTestContext(profile)
+
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), tCtx, tt.newVal)
+			assert.NoError(t, err)
+
+			expectedProfile := createProfile()
+			tt.modified(expectedProfile, expectedProfile.Sample().At(0))
+
+			assert.Equal(t, expectedProfile, profile)
+		})
+	}
+}
+
+func TestPathGetSetterInvalidValues(t *testing.T) {
+	accessor, err := ctxsample.PathGetSetter[*testContext](&pathtest.Path[*testContext]{N: "values"})
+	require.NoError(t, err)
+
+	profile := createProfile()
+	err = accessor.Set(context.Background(), newTestContext(profile), []any{int64(1)})
+	assert.EqualError(t, err, "the 'values' path must be set to a []int64, got []interface {}")
+	assert.Equal(t, []int64{1, 100}, profile.Sample().At(0).Value().AsRaw())
+}
+
+func TestPathGetSetterReusesAttributes(t *testing.T) {
+	accessor, err := ctxsample.PathGetSetter[*testContext](&pathtest.Path[*testContext]{
+		N: "attributes",
+		KeySlice: []ottl.Key[*testContext]{
+			&pathtest.Key[*testContext]{
+				S: ottltest.Strp("thread.name"),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	profile := createProfile()
+	other := profile.Sample().AppendEmpty()
+	ctxprofile.SetAttributes(profile, other, createAttributes())
+	tableLen := profile.AttributeTable().Len()
+
+	tCtx := newTestContext(profile)
+	require.NoError(t, accessor.Set(context.Background(), tCtx, "worker"))
+	assert.Equal(t, tableLen+1, profile.AttributeTable().Len())
+
+	tCtx.sample = other
+	require.NoError(t, accessor.Set(context.Background(), tCtx, "worker"))
+	assert.Equal(t, tableLen+1, profile.AttributeTable().Len())
+	assert.Equal(t, profile.Sample().At(0).AttributeIndices().AsRaw(), other.AttributeIndices().AsRaw())
+}
+
+func TestPathGetSetterReadOnly(t *testing.T) {
+	locations := pcommon.NewSlice()
+	leaf := locations.AppendEmpty().SetEmptyMap()
+	leaf.PutInt("address", 0x10)
+	leaf.PutBool("is_folded", false)
+	leaf.PutStr("mapping_filename", "app")
+	leafLine := leaf.PutEmptySlice("lines").AppendEmpty().SetEmptyMap()
+	leafLine.PutStr("function_name", "compute")
+	leafLine.PutStr("system_name", "_compute")
+	leafLine.PutStr("filename", "compute.go")
+	leafLine.PutInt("line", 42)
+	leafLine.PutInt("column", 3)
+	root := locations.AppendEmpty().SetEmptyMap()
+	root.PutInt("address", 0x20)
+	root.PutBool("is_folded", false)
+	rootLine := root.PutEmptySlice("lines").AppendEmpty().SetEmptyMap()
+	rootLine.PutStr("function_name", "main")
+	rootLine.PutStr("system_name", "main")
+	rootLine.PutStr("filename", "main.go")
+	rootLine.PutInt("line", 7)
+	rootLine.PutInt("column", 0)
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[*testContext]
+		expected any
+	}{
+		{
+			name:     "locations",
+			path:     &pathtest.Path[*testContext]{N: "locations"},
+			expected: locations,
+		},
+		{
+			name:     "function_names",
+			path:     &pathtest.Path[*testContext]{N: "function_names"},
+			expected: []string{"compute", "main"},
+		},
+		{
+			name:     "trace_id",
+			path:     &pathtest.Path[*testContext]{N: "trace_id"},
+			expected: pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}),
+		},
+		{
+			name: "trace_id string",
+			path: &pathtest.Path[*testContext]{
+				N:        "trace_id",
+				NextPath: &pathtest.Path[*testContext]{N: "string"},
+			},
+			expected: "0102030405060708090a0b0c0d0e0f10",
+		},
+		{
+			name:     "span_id",
+			path:     &pathtest.Path[*testContext]{N: "span_id"},
+			expected: pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}),
+		},
+		{
+			name: "span_id string",
+			path: &pathtest.Path[*testContext]{
+				N:        "span_id",
+				NextPath: &pathtest.Path[*testContext]{N: "string"},
+			},
+			expected: "0102030405060708",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := ctxsample.PathGetSetter[*testContext](tt.path)
+			require.NoError(t, err)
+
+			tCtx := newTestContext(createProfile())
+			got, err := accessor.Get(context.Background(), tCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+
+			err = accessor.Set(context.Background(), tCtx, got)
+			assert.ErrorContains(t, err, "cannot be modified")
+		})
+	}
+}
+
+func TestPathGetSetterWithoutLink(t *testing.T) {
+	profile := createProfile()
+	profile.Sample().At(0).RemoveLinkIndex()
+
+	accessor, err := ctxsample.PathGetSetter[*testContext](&pathtest.Path[*testContext]{N: "trace_id"})
+	require.NoError(t, err)
+
+	got, err := accessor.Get(context.Background(), newTestContext(profile))
+	require.NoError(t, err)
+	assert.Equal(t, pcommon.NewTraceIDEmpty(), got)
+}
+
+func createAttributes() pcommon.Map {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("thread.name", "main")
+	attrs.PutInt("thread.id", 1)
+	return attrs
+}
+
+func createProfile() pprofile.Profile {
+	profile := pprofile.NewProfile()
+	profile.StringTable().FromRaw([]string{"", "compute", "_compute", "compute.go", "main", "main.go", "app"})
+
+	mapping := profile.MappingTable().AppendEmpty()
+	mapping.SetFilenameStrindex(6)
+
+	computeFn := profile.FunctionTable().AppendEmpty()
+	computeFn.SetNameStrindex(1)
+	computeFn.SetSystemNameStrindex(2)
+	computeFn.SetFilenameStrindex(3)
+	mainFn := profile.FunctionTable().AppendEmpty()
+	mainFn.SetNameStrindex(4)
+	mainFn.SetSystemNameStrindex(4)
+	mainFn.SetFilenameStrindex(5)
+
+	rootLoc := profile.LocationTable().AppendEmpty()
+	rootLoc.SetAddress(0x20)
+	rootLine := rootLoc.Line().AppendEmpty()
+	rootLine.SetFunctionIndex(1)
+	rootLine.SetLine(7)
+	leafLoc := profile.LocationTable().AppendEmpty()
+	leafLoc.SetAddress(0x10)
+	leafLoc.SetMappingIndex(0)
+	leafLine := leafLoc.Line().AppendEmpty()
+	leafLine.SetFunctionIndex(0)
+	leafLine.SetLine(42)
+	leafLine.SetColumn(3)
+	profile.LocationIndices().FromRaw([]int32{1, 0})
+
+	link := profile.LinkTable().AppendEmpty()
+	link.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	link.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	sample := profile.Sample().AppendEmpty()
+	sample.SetLocationsStartIndex(0)
+	sample.SetLocationsLength(2)
+	sample.Value().FromRaw([]int64{1, 100})
+	sample.TimestampsUnixNano().FromRaw([]uint64{1000})
+	sample.SetLinkIndex(0)
+	ctxprofile.SetAttributes(profile, sample, createAttributes())
+	return profile
+}
+
+type testContext struct {
+	profile        pprofile.Profile
+	sample         pprofile.Sample
+	attributeIndex *ctxprofile.AttributeIndex
+}
+
+func (s *testContext) GetSample() pprofile.Sample {
+	return s.sample
+}
+
+func (s *testContext) GetProfile() pprofile.Profile {
+	return s.profile
+}
+
+func (s *testContext) GetAttributeIndex() *ctxprofile.AttributeIndex {
+	return s.attributeIndex
+}
+
+func newTestContext(profile pprofile.Profile) *testContext {
+	return &testContext{profile: profile, sample: profile.Sample().At(0), attributeIndex: ctxprofile.NewAttributeIndex(profile)}
+}
@@ -9,6 +9,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zapcore"
 )
@@ -348,6 +349,16 @@ func (u UInt64Slice) MarshalLogArray(encoder zapcore.ArrayEncoder) error {
 	return nil
 }
 
+type Int64Slice pcommon.Int64Slice
+
+func (i Int64Slice) MarshalLogArray(encoder zapcore.ArrayEncoder) error {
+	is := pcommon.Int64Slice(i)
+	for j := 0; j < is.Len(); j++ {
+		encoder.AppendInt64(is.At(j))
+	}
+	return nil
+}
+
 type Float64Slice pcommon.Float64Slice
 
 func (f Float64Slice) MarshalLogArray(encoder zapcore.ArrayEncoder) error {
@@ -388,3 +399,18 @@ func (e Exemplar) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
 	}
 	return err
 }
+
+type Profile pprofile.Profile
+
+func (p Profile) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	pp := pprofile.Profile(p)
+	err := encoder.AddObject("attributes", Map(pprofile.FromAttributeIndices(pp.AttributeTable(), pp)))
+	encoder.AddUint32("dropped_attribute_count", pp.DroppedAttributesCount())
+	encoder.AddUint64("duration_unix_nano", uint64(pp.Duration()))
+	encoder.AddString("original_payload_format", pp.OriginalPayloadFormat())
+	encoder.AddInt64("period", pp.Period())
+	encoder.AddString("profile_id", pp.ProfileID().String())
+	encoder.AddInt("sample_count", pp.Sample().Len())
+	encoder.AddUint64("time_unix_nano", uint64(pp.Time()))
+	return err
+}
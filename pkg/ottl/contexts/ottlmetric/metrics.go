@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
@@ -119,15 +120,12 @@ func NewParser(functions map[string]ottl.Factory[TransformContext], telemetrySet
 		functions,
 		pep.parsePath,
 		telemetrySettings,
-		ottl.WithEnumParser[TransformContext](parseEnum),
+		append([]ottl.Option[TransformContext]{ottl.WithEnumParser[TransformContext](parseEnum)}, options...)...,
 	)
 	if err != nil {
 		return ottl.Parser[TransformContext]{}, err
 	}
-	for _, opt := range options {
-		opt(&p)
-	}
-	return p, err
+	return p, nil
 }
 
 // EnablePathContextNames enables the support to path's context names on statements.
@@ -153,6 +151,13 @@ func WithStatementSequenceErrorMode(errorMode ottl.ErrorMode) StatementSequenceO
 	}
 }
 
+// WithStatementSequenceProfiling enables the statements execution telemetry, see ottl.WithStatementSequenceProfiling.
+func WithStatementSequenceProfiling(attrs ...attribute.KeyValue) StatementSequenceOption {
+	return func(s *ottl.StatementSequence[TransformContext]) {
+		ottl.WithStatementSequenceProfiling[TransformContext](attrs...)(s)
+	}
+}
+
 func NewStatementSequence(statements []*ottl.Statement[TransformContext], telemetrySettings component.TelemetrySettings, options ...StatementSequenceOption) ottl.StatementSequence[TransformContext] {
 	s := ottl.NewStatementSequence(statements, telemetrySettings)
 	for _, op := range options {
@@ -169,6 +174,13 @@ func WithConditionSequenceErrorMode(errorMode ottl.ErrorMode) ConditionSequenceO
 	}
 }
 
+// WithConditionSequenceProfiling enables the conditions evaluation telemetry, see ottl.WithConditionSequenceProfiling.
+func WithConditionSequenceProfiling(attrs ...attribute.KeyValue) ConditionSequenceOption {
+	return func(c *ottl.ConditionSequence[TransformContext]) {
+		ottl.WithConditionSequenceProfiling[TransformContext](attrs...)(c)
+	}
+}
+
 func NewConditionSequence(conditions []*ottl.Condition[TransformContext], telemetrySettings component.TelemetrySettings, options ...ConditionSequenceOption) ottl.ConditionSequence[TransformContext] {
 	c := ottl.NewConditionSequence(conditions, telemetrySettings)
 	for _, op := range options {
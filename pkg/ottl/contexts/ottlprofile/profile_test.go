@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlprofile
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxcache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/pathtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	newCache := pcommon.NewMap()
+	newCache.PutStr("temp", "value")
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		orig     any
+		newVal   any
+		modified func(profile pprofile.Profile, cache pcommon.Map)
+	}{
+		{
+			name: "cache",
+			path: &pathtest.Path[TransformContext]{
+				N: "cache",
+			},
+			orig:   pcommon.NewMap(),
+			newVal: newCache,
+			modified: func(_ pprofile.Profile, cache pcommon.Map) {
+				newCache.CopyTo(cache)
+			},
+		},
+		{
+			name: "cache access",
+			path: &pathtest.Path[TransformContext]{
+				N: "cache",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("temp"),
+					},
+				},
+			},
+			orig:   nil,
+			newVal: "new value",
+			modified: func(_ pprofile.Profile, cache pcommon.Map) {
+				cache.PutStr("temp", "new value")
+			},
+		},
+		{
+			name: "period",
+			path: &pathtest.Path[TransformContext]{
+				N: "period",
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(profile pprofile.Profile, _ pcommon.Map) {
+				profile.SetPeriod(20)
+			},
+		},
+		{
+			name: "attributes with context",
+			path: &pathtest.Path[TransformContext]{
+				C: "profile",
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("str"),
+					},
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(profile pprofile.Profile, _ pcommon.Map) {
+				attrs := ctxprofile.Attributes(profile, profile)
+				attrs.PutStr("str", "newVal")
+				ctxprofile.SetAttributes(profile, profile, attrs)
+			},
+		},
+	}
+	// Copy all tests cases and sets the path.Context value to the generated ones.
+	// It ensures all exiting field access also work when the path context is set.
+	for _, tt := range slices.Clone(tests) {
+		if tt.path.Context() != "" {
+			continue
+		}
+		testWithContext := tt
+		testWithContext.name = "with_path_context:" + tt.name
+		pathWithContext := *tt.path.(*pathtest.Path[TransformContext])
+		pathWithContext.C = ctxprofile.Name
+		testWithContext.path = ottl.Path[TransformContext](&pathWithContext)
+		tests = append(tests, testWithContext)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCache := pcommon.NewMap()
+			cacheGetter := func(_ TransformContext) pcommon.Map {
+				return testCache
+			}
+			pep := pathExpressionParser{
+				cacheGetSetter: ctxcache.PathExpressionParser(cacheGetter),
+			}
+			accessor, err := pep.parsePath(tt.path)
+			assert.NoError(t, err)
+
+			profile, il, resource := createTelemetry()
+
+			tCtx := NewTransformContext(profile, il, resource, pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles(), WithCache(&testCache))
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), tCtx, tt.newVal)
+			assert.NoError(t, err)
+
+			exProfile, _, _ := createTelemetry()
+			exCache := pcommon.NewMap()
+			tt.modified(exProfile, exCache)
+
+			assert.Equal(t, exProfile, profile)
+			assert.Equal(t, exCache, testCache)
+		})
+	}
+}
+
+func Test_newPathGetSetter_higherContextPath(t *testing.T) {
+	profile, instrumentationScope, resource := createTelemetry()
+	ctx := NewTransformContext(profile, instrumentationScope, resource, pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles())
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		expected any
+	}{
+		{
+			name: "resource",
+			path: &pathtest.Path[TransformContext]{C: "", N: "resource", NextPath: &pathtest.Path[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("service.name"),
+					},
+				},
+			}},
+			expected: "checkout",
+		},
+		{
+			name: "resource with context",
+			path: &pathtest.Path[TransformContext]{C: "resource", N: "attributes", KeySlice: []ottl.Key[TransformContext]{
+				&pathtest.Key[TransformContext]{
+					S: ottltest.Strp("service.name"),
+				},
+			}},
+			expected: "checkout",
+		},
+		{
+			name:     "instrumentation_scope",
+			path:     &pathtest.Path[TransformContext]{N: "instrumentation_scope", NextPath: &pathtest.Path[TransformContext]{N: "name"}},
+			expected: instrumentationScope.Name(),
+		},
+		{
+			name:     "instrumentation_scope with context",
+			path:     &pathtest.Path[TransformContext]{C: "instrumentation_scope", N: "name"},
+			expected: instrumentationScope.Name(),
+		},
+	}
+
+	pep := pathExpressionParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := pep.parsePath(tt.path)
+			require.NoError(t, err)
+
+			got, err := accessor.Get(context.Background(), ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_newPathGetSetter_invalidHigherContext(t *testing.T) {
+	pep := pathExpressionParser{}
+	_, err := pep.parsePath(&pathtest.Path[TransformContext]{C: "sample", N: "values", FullPath: "sample.values"})
+	assert.ErrorContains(t, err, `segment "sample" from path "sample.values" is not a valid path`)
+}
+
+func Test_newPathGetSetter_WithCache(t *testing.T) {
+	cacheValue := pcommon.NewMap()
+	cacheValue.PutStr("test", "pass")
+
+	tCtx := NewTransformContext(
+		pprofile.NewProfile(),
+		pcommon.NewInstrumentationScope(),
+		pcommon.NewResource(),
+		pprofile.NewScopeProfiles(),
+		pprofile.NewResourceProfiles(),
+		WithCache(&cacheValue),
+	)
+
+	assert.Equal(t, cacheValue, getCache(tCtx))
+}
+
+func Test_ParseStatements(t *testing.T) {
+	parser, err := NewParser(map[string]ottl.Factory[TransformContext]{
+		"set": ottl.NewFactory[TransformContext]("set", &setArguments{}, createSetFunction),
+	}, componenttest.NewNopTelemetrySettings(), EnablePathContextNames())
+	require.NoError(t, err)
+
+	statement, err := parser.ParseStatement(`set(profile.attributes["service"], resource.attributes["service.name"]) where profile.sample_count > 0`)
+	require.NoError(t, err)
+
+	profile, il, resource := createTelemetry()
+	_, _, err = statement.Execute(context.Background(), NewTransformContext(profile, il, resource, pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles()))
+	require.NoError(t, err)
+
+	service, ok := ctxprofile.Attributes(profile, profile).Get("service")
+	require.True(t, ok)
+	assert.Equal(t, "checkout", service.Str())
+}
+
+func Test_ParseStatements_InPlaceEditor(t *testing.T) {
+	parser, err := NewParser(map[string]ottl.Factory[TransformContext]{
+		"set":        ottl.NewFactory[TransformContext]("set", &setArguments{}, createSetFunction),
+		"delete_key": ottl.NewFactory[TransformContext]("delete_key", &deleteKeyArguments{}, createDeleteKeyFunction),
+	}, componenttest.NewNopTelemetrySettings(), EnablePathContextNames())
+	require.NoError(t, err)
+
+	_, err = parser.ParseStatement(`delete_key(profile.attributes, "service")`)
+	assert.ErrorContains(t, err, "use set instead")
+	_, err = parser.ParseStatement(`delete_key(profile.attributes["nested"], "service")`)
+	assert.ErrorContains(t, err, "use set instead")
+	_, err = parser.ParseStatement(`delete_key(resource.attributes, "service")`)
+	assert.NoError(t, err)
+	_, err = parser.ParseStatement(`set(profile.attributes["service"], "checkout")`)
+	assert.NoError(t, err)
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp("NOT_AN_ENUM")))
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
+
+type setArguments struct {
+	Target ottl.Setter[TransformContext]
+	Value  ottl.Getter[TransformContext]
+}
+
+func createSetFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[TransformContext], error) {
+	args := oArgs.(*setArguments)
+	return func(ctx context.Context, tCtx TransformContext) (any, error) {
+		val, err := args.Value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, args.Target.Set(ctx, tCtx, val)
+	}, nil
+}
+
+type deleteKeyArguments struct {
+	Target ottl.PMapGetter[TransformContext]
+	Key    string
+}
+
+func createDeleteKeyFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[TransformContext], error) {
+	args := oArgs.(*deleteKeyArguments)
+	return func(ctx context.Context, tCtx TransformContext) (any, error) {
+		val, err := args.Target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		val.Remove(args.Key)
+		return nil, nil
+	}, nil
+}
+
+func createTelemetry() (pprofile.Profile, pcommon.InstrumentationScope, pcommon.Resource) {
+	profile := pprofile.NewProfile()
+	profile.StringTable().Append("")
+	profile.SetTime(pcommon.NewTimestampFromTime(time.UnixMilli(100)))
+	profile.SetPeriod(10)
+	profile.Sample().AppendEmpty().Value().Append(1)
+	attrs := pcommon.NewMap()
+	attrs.PutStr("str", "val")
+	ctxprofile.SetAttributes(profile, profile, attrs)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+
+	return profile, il, resource
+}
@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlsample // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxcache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxcommon"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxerror"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxsample"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/logging"
+)
+
+// Experimental: *NOTE* this constant is subject to change or removal in the future.
+const ContextName = ctxsample.Name
+
+var (
+	_ ctxresource.Context     = (*TransformContext)(nil)
+	_ ctxscope.Context        = (*TransformContext)(nil)
+	_ ctxprofile.Context      = (*TransformContext)(nil)
+	_ ctxsample.Context       = (*TransformContext)(nil)
+	_ zapcore.ObjectMarshaler = (*TransformContext)(nil)
+)
+
+type TransformContext struct {
+	sample               pprofile.Sample
+	profile              pprofile.Profile
+	instrumentationScope pcommon.InstrumentationScope
+	resource             pcommon.Resource
+	cache                pcommon.Map
+	scopeProfiles        pprofile.ScopeProfiles
+	resourceProfiles     pprofile.ResourceProfiles
+	attributeIndex       *ctxprofile.AttributeIndex
+}
+
+type sample pprofile.Sample
+
+func (s sample) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	ps := pprofile.Sample(s)
+	encoder.AddInt32("locations_length", ps.LocationsLength())
+	encoder.AddInt32("locations_start_index", ps.LocationsStartIndex())
+	err := encoder.AddArray("timestamps_unix_nano", logging.UInt64Slice(ps.TimestampsUnixNano()))
+	err = errors.Join(err, encoder.AddArray("values", logging.Int64Slice(ps.Value())))
+	return err
+}
+
+func (tCtx TransformContext) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	err := encoder.AddObject("resource", logging.Resource(tCtx.resource))
+	err = errors.Join(err, encoder.AddObject("scope", logging.InstrumentationScope(tCtx.instrumentationScope)))
+	err = errors.Join(err, encoder.AddObject("profile", logging.Profile(tCtx.profile)))
+	err = errors.Join(err, encoder.AddObject("sample", sample(tCtx.sample)))
+	err = errors.Join(err, encoder.AddObject("cache", logging.Map(tCtx.cache)))
+	return err
+}
+
+type TransformContextOption func(*TransformContext)
+
+func NewTransformContext(sample pprofile.Sample, profile pprofile.Profile, instrumentationScope pcommon.InstrumentationScope, resource pcommon.Resource, scopeProfiles pprofile.ScopeProfiles, resourceProfiles pprofile.ResourceProfiles, options ...TransformContextOption) TransformContext {
+	tc := TransformContext{
+		sample:               sample,
+		profile:              profile,
+		instrumentationScope: instrumentationScope,
+		resource:             resource,
+		cache:                pcommon.NewMap(),
+		scopeProfiles:        scopeProfiles,
+		resourceProfiles:     resourceProfiles,
+	}
+	for _, opt := range options {
+		opt(&tc)
+	}
+	if tc.attributeIndex == nil {
+		tc.attributeIndex = ctxprofile.NewAttributeIndex(profile)
+	}
+	return tc
+}
+
+// Experimental: *NOTE* this option is subject to change or removal in the future.
+func WithCache(cache *pcommon.Map) TransformContextOption {
+	return func(p *TransformContext) {
+		if cache != nil {
+			p.cache = *cache
+		}
+	}
+}
+
+// AttributeIndex indexes the attribute table of a profile to look up the entries referenced by the
+// samples when their attributes are set.
+//
+// Experimental: *NOTE* this type is subject to change or removal in the future.
+type AttributeIndex struct {
+	index *ctxprofile.AttributeIndex
+}
+
+// NewAttributeIndex returns an index of the attribute table of the given profile.
+//
+// Experimental: *NOTE* this function is subject to change or removal in the future.
+func NewAttributeIndex(profile pprofile.Profile) *AttributeIndex {
+	return &AttributeIndex{index: ctxprofile.NewAttributeIndex(profile)}
+}
+
+// WithAttributeIndex shares the index of the profile's attribute table between the transform
+// contexts of the samples of a profile, so the table is indexed only once. An index of another
+// profile is ignored.
+//
+// Experimental: *NOTE* this option is subject to change or removal in the future.
+func WithAttributeIndex(index *AttributeIndex) TransformContextOption {
+	return func(p *TransformContext) {
+		if index != nil && index.index.Indexes(p.profile) {
+			p.attributeIndex = index.index
+		}
+	}
+}
+
+func (tCtx TransformContext) GetSample() pprofile.Sample {
+	return tCtx.sample
+}
+
+func (tCtx TransformContext) GetProfile() pprofile.Profile {
+	return tCtx.profile
+}
+
+func (tCtx TransformContext) GetAttributeIndex() *ctxprofile.AttributeIndex {
+	return tCtx.attributeIndex
+}
+
+func (tCtx TransformContext) GetInstrumentationScope() pcommon.InstrumentationScope {
+	return tCtx.instrumentationScope
+}
+
+func (tCtx TransformContext) GetResource() pcommon.Resource {
+	return tCtx.resource
+}
+
+func (tCtx TransformContext) GetScopeSchemaURLItem() ctxcommon.SchemaURLItem {
+	return tCtx.scopeProfiles
+}
+
+func (tCtx TransformContext) GetResourceSchemaURLItem() ctxcommon.SchemaURLItem {
+	return tCtx.resourceProfiles
+}
+
+func getCache(tCtx TransformContext) pcommon.Map {
+	return tCtx.cache
+}
+
+type pathExpressionParser struct {
+	telemetrySettings component.TelemetrySettings
+	cacheGetSetter    ottl.PathExpressionParser[TransformContext]
+}
+
+func NewParser(functions map[string]ottl.Factory[TransformContext], telemetrySettings component.TelemetrySettings, options ...ottl.Option[TransformContext]) (ottl.Parser[TransformContext], error) {
+	pep := pathExpressionParser{
+		telemetrySettings: telemetrySettings,
+		cacheGetSetter:    ctxcache.PathExpressionParser(getCache),
+	}
+	p, err := ottl.NewParser[TransformContext](
+		functions,
+		pep.parsePath,
+		telemetrySettings,
+		append([]ottl.Option[TransformContext]{ottl.WithEnumParser[TransformContext](parseEnum)}, options...)...,
+	)
+	if err != nil {
+		return ottl.Parser[TransformContext]{}, err
+	}
+	return p, nil
+}
+
+// EnablePathContextNames enables the support to path's context names on statements.
+// When this option is configured, all statement's paths must have a valid context prefix,
+// otherwise an error is reported.
+//
+// Experimental: *NOTE* this option is subject to change or removal in the future.
+func EnablePathContextNames() ottl.Option[TransformContext] {
+	return func(p *ottl.Parser[TransformContext]) {
+		ottl.WithPathContextNames[TransformContext]([]string{
+			ctxsample.Name,
+			ctxprofile.Name,
+			ctxscope.LegacyName,
+			ctxresource.Name,
+		})(p)
+	}
+}
+
+type StatementSequenceOption func(*ottl.StatementSequence[TransformContext])
+
+func WithStatementSequenceErrorMode(errorMode ottl.ErrorMode) StatementSequenceOption {
+	return func(s *ottl.StatementSequence[TransformContext]) {
+		ottl.WithStatementSequenceErrorMode[TransformContext](errorMode)(s)
+	}
+}
+
+// WithStatementSequenceProfiling enables the statements execution telemetry, see ottl.WithStatementSequenceProfiling.
+func WithStatementSequenceProfiling(attrs ...attribute.KeyValue) StatementSequenceOption {
+	return func(s *ottl.StatementSequence[TransformContext]) {
+		ottl.WithStatementSequenceProfiling[TransformContext](attrs...)(s)
+	}
+}
+
+func NewStatementSequence(statements []*ottl.Statement[TransformContext], telemetrySettings component.TelemetrySettings, options ...StatementSequenceOption) ottl.StatementSequence[TransformContext] {
+	s := ottl.NewStatementSequence(statements, telemetrySettings)
+	for _, op := range options {
+		op(&s)
+	}
+	return s
+}
+
+type ConditionSequenceOption func(*ottl.ConditionSequence[TransformContext])
+
+func WithConditionSequenceErrorMode(errorMode ottl.ErrorMode) ConditionSequenceOption {
+	return func(c *ottl.ConditionSequence[TransformContext]) {
+		ottl.WithConditionSequenceErrorMode[TransformContext](errorMode)(c)
+	}
+}
+
+// WithConditionSequenceProfiling enables the conditions evaluation telemetry, see ottl.WithConditionSequenceProfiling.
+func WithConditionSequenceProfiling(attrs ...attribute.KeyValue) ConditionSequenceOption {
+	return func(c *ottl.ConditionSequence[TransformContext]) {
+		ottl.WithConditionSequenceProfiling[TransformContext](attrs...)(c)
+	}
+}
+
+func NewConditionSequence(conditions []*ottl.Condition[TransformContext], telemetrySettings component.TelemetrySettings, options ...ConditionSequenceOption) ottl.ConditionSequence[TransformContext] {
+	c := ottl.NewConditionSequence(conditions, telemetrySettings)
+	for _, op := range options {
+		op(&c)
+	}
+	return c
+}
+
+func parseEnum(_ *ottl.EnumSymbol) (*ottl.Enum, error) {
+	return nil, fmt.Errorf("sample context does not provide Enum support")
+}
+
+func (pep *pathExpressionParser) parsePath(path ottl.Path[TransformContext]) (ottl.GetSetter[TransformContext], error) {
+	if path == nil {
+		return nil, ctxerror.New("nil", "nil", ctxsample.Name, ctxsample.DocRef)
+	}
+	// Higher contexts parsing
+	if path.Context() != "" && path.Context() != ctxsample.Name {
+		return pep.parseHigherContextPath(path.Context(), path)
+	}
+	// Backward compatibility with paths without context
+	if path.Context() == "" &&
+		(path.Name() == ctxresource.Name ||
+			path.Name() == ctxscope.LegacyName ||
+			path.Name() == ctxprofile.Name) {
+		return pep.parseHigherContextPath(path.Name(), path.Next())
+	}
+
+	switch path.Name() {
+	case "cache":
+		return pep.cacheGetSetter(path)
+	default:
+		return ctxsample.PathGetSetter(path)
+	}
+}
+
+func (pep *pathExpressionParser) parseHigherContextPath(context string, path ottl.Path[TransformContext]) (ottl.GetSetter[TransformContext], error) {
+	switch context {
+	case ctxresource.Name:
+		return ctxresource.PathGetSetter(ctxsample.Name, path)
+	case ctxscope.LegacyName:
+		return ctxscope.PathGetSetter(ctxsample.Name, path)
+	case ctxprofile.Name:
+		return ctxprofile.PathGetSetter(ctxsample.Name, path)
+	default:
+		var fullPath string
+		if path != nil {
+			fullPath = path.String()
+		}
+		return nil, ctxerror.New(context, fullPath, ctxsample.Name, ctxsample.DocRef)
+	}
+}
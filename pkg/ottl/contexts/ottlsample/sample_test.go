@@ -0,0 +1,334 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlsample
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxcache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxsample"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/pathtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	newCache := pcommon.NewMap()
+	newCache.PutStr("temp", "value")
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		orig     any
+		newVal   any
+		modified func(sample pprofile.Sample, profile pprofile.Profile, cache pcommon.Map)
+	}{
+		{
+			name: "cache",
+			path: &pathtest.Path[TransformContext]{
+				N: "cache",
+			},
+			orig:   pcommon.NewMap(),
+			newVal: newCache,
+			modified: func(_ pprofile.Sample, _ pprofile.Profile, cache pcommon.Map) {
+				newCache.CopyTo(cache)
+			},
+		},
+		{
+			name: "values",
+			path: &pathtest.Path[TransformContext]{
+				N: "values",
+			},
+			orig:   []int64{5},
+			newVal: []int64{10},
+			modified: func(sample pprofile.Sample, _ pprofile.Profile, _ pcommon.Map) {
+				sample.Value().FromRaw([]int64{10})
+			},
+		},
+		{
+			name: "attributes",
+			path: &pathtest.Path[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("thread.name"),
+					},
+				},
+			},
+			orig:   "main",
+			newVal: "worker",
+			modified: func(sample pprofile.Sample, profile pprofile.Profile, _ pcommon.Map) {
+				attrs := ctxprofile.Attributes(profile, sample)
+				attrs.PutStr("thread.name", "worker")
+				ctxprofile.SetAttributes(profile, sample, attrs)
+			},
+		},
+		{
+			name: "profile period",
+			path: &pathtest.Path[TransformContext]{
+				N: "profile",
+				NextPath: &pathtest.Path[TransformContext]{
+					N: "period",
+				},
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(_ pprofile.Sample, profile pprofile.Profile, _ pcommon.Map) {
+				profile.SetPeriod(20)
+			},
+		},
+	}
+	// Copy all tests cases and sets the path.Context value to the generated ones.
+	// It ensures all exiting field access also work when the path context is set.
+	for _, tt := range slices.Clone(tests) {
+		if tt.path.Context() != "" || tt.path.Name() == ctxprofile.Name {
+			continue
+		}
+		testWithContext := tt
+		testWithContext.name = "with_path_context:" + tt.name
+		pathWithContext := *tt.path.(*pathtest.Path[TransformContext])
+		pathWithContext.C = ctxsample.Name
+		testWithContext.path = ottl.Path[TransformContext](&pathWithContext)
+		tests = append(tests, testWithContext)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCache := pcommon.NewMap()
+			cacheGetter := func(_ TransformContext) pcommon.Map {
+				return testCache
+			}
+			pep := pathExpressionParser{
+				cacheGetSetter: ctxcache.PathExpressionParser(cacheGetter),
+			}
+			accessor, err := pep.parsePath(tt.path)
+			assert.NoError(t, err)
+
+			profile, il, resource := createTelemetry()
+			sample := profile.Sample().At(0)
+
+			tCtx := NewTransformContext(sample, profile, il, resource, pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles(), WithCache(&testCache))
+			got, err := accessor.Get(context.Background(), tCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(context.Background(), tCtx, tt.newVal)
+			assert.NoError(t, err)
+
+			exProfile, _, _ := createTelemetry()
+			exCache := pcommon.NewMap()
+			tt.modified(exProfile.Sample().At(0), exProfile, exCache)
+
+			assert.Equal(t, exProfile, profile)
+			assert.Equal(t, exCache, testCache)
+		})
+	}
+}
+
+func Test_newPathGetSetter_higherContextPath(t *testing.T) {
+	profile, instrumentationScope, resource := createTelemetry()
+	ctx := NewTransformContext(profile.Sample().At(0), profile, instrumentationScope, resource, pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles())
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[TransformContext]
+		expected any
+	}{
+		{
+			name: "resource",
+			path: &pathtest.Path[TransformContext]{C: "", N: "resource", NextPath: &pathtest.Path[TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[TransformContext]{
+					&pathtest.Key[TransformContext]{
+						S: ottltest.Strp("service.name"),
+					},
+				},
+			}},
+			expected: "checkout",
+		},
+		{
+			name: "resource with context",
+			path: &pathtest.Path[TransformContext]{C: "resource", N: "attributes", KeySlice: []ottl.Key[TransformContext]{
+				&pathtest.Key[TransformContext]{
+					S: ottltest.Strp("service.name"),
+				},
+			}},
+			expected: "checkout",
+		},
+		{
+			name:     "instrumentation_scope",
+			path:     &pathtest.Path[TransformContext]{N: "instrumentation_scope", NextPath: &pathtest.Path[TransformContext]{N: "name"}},
+			expected: instrumentationScope.Name(),
+		},
+		{
+			name:     "instrumentation_scope with context",
+			path:     &pathtest.Path[TransformContext]{C: "instrumentation_scope", N: "name"},
+			expected: instrumentationScope.Name(),
+		},
+		{
+			name:     "profile",
+			path:     &pathtest.Path[TransformContext]{N: "profile", NextPath: &pathtest.Path[TransformContext]{N: "sample_count"}},
+			expected: int64(1),
+		},
+		{
+			name:     "profile with context",
+			path:     &pathtest.Path[TransformContext]{C: "profile", N: "sample_count"},
+			expected: int64(1),
+		},
+	}
+
+	pep := pathExpressionParser{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := pep.parsePath(tt.path)
+			require.NoError(t, err)
+
+			got, err := accessor.Get(context.Background(), ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_newPathGetSetter_profileCacheAccessError(t *testing.T) {
+	pep := pathExpressionParser{}
+	_, err := pep.parsePath(&pathtest.Path[TransformContext]{C: "profile", N: "cache", FullPath: "profile.cache"})
+	assert.ErrorContains(t, err, `replace "profile.cache" with "sample.cache"`)
+}
+
+func Test_newPathGetSetter_WithCache(t *testing.T) {
+	cacheValue := pcommon.NewMap()
+	cacheValue.PutStr("test", "pass")
+
+	profile := pprofile.NewProfile()
+	tCtx := NewTransformContext(
+		profile.Sample().AppendEmpty(),
+		profile,
+		pcommon.NewInstrumentationScope(),
+		pcommon.NewResource(),
+		pprofile.NewScopeProfiles(),
+		pprofile.NewResourceProfiles(),
+		WithCache(&cacheValue),
+	)
+
+	assert.Equal(t, cacheValue, getCache(tCtx))
+}
+
+func Test_NewTransformContext_WithAttributeIndex(t *testing.T) {
+	profile := pprofile.NewProfile()
+	index := NewAttributeIndex(profile)
+
+	tCtx := NewTransformContext(profile.Sample().AppendEmpty(), profile, pcommon.NewInstrumentationScope(), pcommon.NewResource(), pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles(), WithAttributeIndex(index))
+	assert.Same(t, index.index, tCtx.GetAttributeIndex())
+
+	other := pprofile.NewProfile()
+	tCtx = NewTransformContext(other.Sample().AppendEmpty(), other, pcommon.NewInstrumentationScope(), pcommon.NewResource(), pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles(), WithAttributeIndex(index))
+	assert.NotSame(t, index.index, tCtx.GetAttributeIndex())
+	assert.True(t, tCtx.GetAttributeIndex().Indexes(other))
+}
+
+func Test_ParseStatements(t *testing.T) {
+	parser, err := NewParser(map[string]ottl.Factory[TransformContext]{
+		"set": ottl.NewFactory[TransformContext]("set", &setArguments{}, createSetFunction),
+	}, componenttest.NewNopTelemetrySettings(), EnablePathContextNames())
+	require.NoError(t, err)
+
+	statement, err := parser.ParseStatement(`set(sample.attributes["profile.period"], profile.period) where sample.attributes["thread.name"] == "main"`)
+	require.NoError(t, err)
+
+	profile, il, resource := createTelemetry()
+	sample := profile.Sample().At(0)
+	_, _, err = statement.Execute(context.Background(), NewTransformContext(sample, profile, il, resource, pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles()))
+	require.NoError(t, err)
+
+	period, ok := ctxprofile.Attributes(profile, sample).Get("profile.period")
+	require.True(t, ok)
+	assert.Equal(t, int64(10), period.Int())
+}
+
+func Test_ParseStatements_InPlaceEditor(t *testing.T) {
+	parser, err := NewParser(map[string]ottl.Factory[TransformContext]{
+		"set":        ottl.NewFactory[TransformContext]("set", &setArguments{}, createSetFunction),
+		"delete_key": ottl.NewFactory[TransformContext]("delete_key", &deleteKeyArguments{}, createDeleteKeyFunction),
+	}, componenttest.NewNopTelemetrySettings(), EnablePathContextNames())
+	require.NoError(t, err)
+
+	_, err = parser.ParseStatement(`delete_key(sample.attributes, "service")`)
+	assert.ErrorContains(t, err, "use set instead")
+	_, err = parser.ParseStatement(`delete_key(sample.attributes["nested"], "service")`)
+	assert.ErrorContains(t, err, "use set instead")
+	_, err = parser.ParseStatement(`delete_key(resource.attributes, "service")`)
+	assert.NoError(t, err)
+	_, err = parser.ParseStatement(`set(sample.attributes["thread.name"], "worker")`)
+	assert.NoError(t, err)
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp("NOT_AN_ENUM")))
+	assert.Error(t, err)
+	assert.Nil(t, actual)
+}
+
+type setArguments struct {
+	Target ottl.Setter[TransformContext]
+	Value  ottl.Getter[TransformContext]
+}
+
+func createSetFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[TransformContext], error) {
+	args := oArgs.(*setArguments)
+	return func(ctx context.Context, tCtx TransformContext) (any, error) {
+		val, err := args.Value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, args.Target.Set(ctx, tCtx, val)
+	}, nil
+}
+
+type deleteKeyArguments struct {
+	Target ottl.PMapGetter[TransformContext]
+	Key    string
+}
+
+func createDeleteKeyFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[TransformContext], error) {
+	args := oArgs.(*deleteKeyArguments)
+	return func(ctx context.Context, tCtx TransformContext) (any, error) {
+		val, err := args.Target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		val.Remove(args.Key)
+		return nil, nil
+	}, nil
+}
+
+func createTelemetry() (pprofile.Profile, pcommon.InstrumentationScope, pcommon.Resource) {
+	profile := pprofile.NewProfile()
+	profile.StringTable().Append("")
+	profile.SetPeriod(10)
+
+	sample := profile.Sample().AppendEmpty()
+	sample.Value().Append(5)
+	attrs := pcommon.NewMap()
+	attrs.PutStr("thread.name", "main")
+	ctxprofile.SetAttributes(profile, sample, attrs)
+
+	il := pcommon.NewInstrumentationScope()
+	il.SetName("library")
+	il.SetVersion("version")
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+
+	return profile, il, resource
+}
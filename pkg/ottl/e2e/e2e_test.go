@@ -823,6 +823,33 @@ func Test_e2e_converters(t *testing.T) {
 				m.PutStr("k2", "v2__!__v2")
 			},
 		},
+		{
+			statement: `set(attributes["test"], ParseLogfmt("status=200"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutInt("status", 200)
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseCEF("CEF:0|Security|threatmanager|1.0|100|worm stopped|10|spt=1232")["extensions"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutInt("spt", 1232)
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseLEEF("LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|sev=5")["attributes"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutInt("sev", 5)
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseCommonLogFormat("127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] \"GET / HTTP/1.0\" 404 -")["http.response.status_code"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutInt("test", 404)
+			},
+		},
 		{
 			statement: `set(attributes["test"], ToKeyValueString(ParseKeyValue("k1=v1 k2=v2"), "=", " ", true))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
+)
+
+func Test_e2e_iteration(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(tCtx ottllog.TransformContext)
+	}{
+		{
+			statement: `for_each(log.attributes["array"], item, set(item, ToUpperCase(item)))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutEmptySlice("array").AppendEmpty().SetStr("LOOONG")
+			},
+		},
+		{
+			statement: `for_each(log.attributes, key, value, set(value, "redacted"), IsMatch(key, "^http\\."))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("http.method", "redacted")
+				tCtx.GetLogRecord().Attributes().PutStr("http.path", "redacted")
+				tCtx.GetLogRecord().Attributes().PutStr("http.url", "redacted")
+			},
+		},
+		{
+			statement: `for_each(log.attributes["things"], thing, for_each(thing, key, value, set(value, Concat([key, value], "=")), IsString(value)))`,
+			want: func(tCtx ottllog.TransformContext) {
+				things, _ := tCtx.GetLogRecord().Attributes().Get("things")
+				things.Slice().At(0).Map().PutStr("name", "name=foo")
+				things.Slice().At(1).Map().PutStr("name", "name=bar")
+			},
+		},
+		{
+			statement: `for_each(log.attributes["missing"], item, set(item, "foo"))`,
+			want:      func(_ ottllog.TransformContext) {},
+		},
+		{
+			statement: `set(log.attributes["lengths"], Map(log.attributes["foo"]["slice"], s, Len(s)))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutEmptySlice("lengths").AppendEmpty().SetInt(3)
+			},
+		},
+		{
+			statement: `set(log.attributes["parts"], Map(Split(log.attributes["flags"], "|"), index, part, Concat([part, String(index)], "")))`,
+			want: func(tCtx ottllog.TransformContext) {
+				parts := tCtx.GetLogRecord().Attributes().PutEmptySlice("parts")
+				parts.AppendEmpty().SetStr("A0")
+				parts.AppendEmpty().SetStr("B1")
+				parts.AppendEmpty().SetStr("C2")
+			},
+		},
+		{
+			statement: `set(log.attributes["http"], Filter(log.attributes, key, value, IsMatch(key, "^http\\.(method|path)$")))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("http")
+				m.PutStr("http.method", "get")
+				m.PutStr("http.path", "/health")
+			},
+		},
+		{
+			statement: `set(log.attributes["test"], "pass") where Len(Filter(log.attributes["array"], item, IsMatch(item, "^l"))) == 1`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), componenttest.NewNopTelemetrySettings(), ottllog.EnablePathContextNames())
+			require.NoError(t, err)
+			statement, err := parser.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			tCtx := constructLogTransformContext()
+			_, _, err = statement.Execute(context.Background(), tCtx)
+			require.NoError(t, err)
+
+			exTCtx := constructLogTransformContext()
+			tt.want(exTCtx)
+
+			assert.NoError(t, plogtest.CompareResourceLogs(newResourceLogs(exTCtx), newResourceLogs(tCtx)))
+		})
+	}
+}
+
+func Test_e2e_iteration_errors(t *testing.T) {
+	tests := []struct {
+		statement string
+		parseErr  string
+		execErr   string
+	}{
+		{
+			statement: `for_each(log.attributes, log.name, set(log.name, "foo"))`,
+			parseErr:  "loop variables must be bare names",
+		},
+		{
+			statement: `for_each(log.attributes, key, key, set(key, "foo"))`,
+			parseErr:  `the key and the value can't both be named "key"`,
+		},
+		{
+			statement: `for_each(log.attributes, value, Len(value))`,
+			parseErr:  `"for_each" must be called as for_each(target, [key,] value, editor[, condition])`,
+		},
+		{
+			statement: `set(log.attributes["test"], Map(log.attributes["things"], thing, thing["name"]))`,
+			parseErr:  `loop variable "thing" can't be indexed`,
+		},
+		{
+			statement: `set(log.attributes["test"], Len(set(log.body, "foo")))`,
+			parseErr:  "converter names must start with an uppercase letter",
+		},
+		{
+			statement: `for_each(log.attributes, key, value, set(key, "foo"))`,
+			execErr:   `loop variable "key" holds the key of the element and can't be set`,
+		},
+		{
+			statement: `for_each(log.body, item, set(item, "foo"))`,
+			execErr:   "expected a slice or a map to iterate over but got string",
+		},
+		{
+			statement: `set(log.attributes["test"], Filter(log.attributes["array"], item, item))`,
+			execErr:   "expected the condition to return a bool but got string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), componenttest.NewNopTelemetrySettings(), ottllog.EnablePathContextNames())
+			require.NoError(t, err)
+			statement, err := parser.ParseStatement(tt.statement)
+			if tt.parseErr != "" {
+				assert.ErrorContains(t, err, tt.parseErr)
+				return
+			}
+			require.NoError(t, err)
+
+			_, _, err = statement.Execute(context.Background(), constructLogTransformContext())
+			assert.ErrorContains(t, err, tt.execErr)
+		})
+	}
+}
+
+func Test_e2e_iteration_parser_collection(t *testing.T) {
+	pc, err := ottl.NewParserCollection[any](componenttest.NewNopTelemetrySettings(),
+		ottl.WithParserCollectionContext[ottllog.TransformContext, any](
+			ottllog.ContextName,
+			newLogParser(t),
+			func(_ *ottl.ParserCollection[any], _ *ottl.Parser[ottllog.TransformContext], _ string, _ ottl.StatementsGetter, parsed []*ottl.Statement[ottllog.TransformContext]) (any, error) {
+				return parsed, nil
+			},
+		))
+	require.NoError(t, err)
+
+	// the loop variables don't get the context prepended, unlike the other paths without context
+	parsed, err := pc.ParseStatementsWithContext(ottllog.ContextName, ottl.NewStatementsGetter([]string{
+		`for_each(attributes["array"], item, set(item, Concat([item, body], "-")))`,
+	}), true)
+	require.NoError(t, err)
+
+	tCtx := constructLogTransformContext()
+	_, _, err = parsed.([]*ottl.Statement[ottllog.TransformContext])[0].Execute(context.Background(), tCtx)
+	require.NoError(t, err)
+
+	exTCtx := constructLogTransformContext()
+	exTCtx.GetLogRecord().Attributes().PutEmptySlice("array").AppendEmpty().SetStr("looong-operationA")
+	assert.NoError(t, plogtest.CompareResourceLogs(newResourceLogs(exTCtx), newResourceLogs(tCtx)))
+}
+
+func newLogParser(t *testing.T) *ottl.Parser[ottllog.TransformContext] {
+	parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), componenttest.NewNopTelemetrySettings(), ottllog.EnablePathContextNames())
+	require.NoError(t, err)
+	return &parser
+}
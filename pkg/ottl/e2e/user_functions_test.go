@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
+)
+
+func Test_e2e_user_functions(t *testing.T) {
+	userFunctions, err := ottl.NewUserFunctions([]ottl.UserFunctionConfig{
+		{
+			Name:   "copy_attribute",
+			Params: []string{"from", "to"},
+			Statements: []string{
+				`set(log.attributes[to], log.attributes[from]) where log.attributes[from] != nil`,
+				`set(log.attributes["copied"], true)`,
+			},
+		},
+		{
+			Name:       "upper",
+			Params:     []string{"target"},
+			Statements: []string{`set(target, ToUpperCase(target))`},
+		},
+		{
+			Name:       "Greeting",
+			Params:     []string{"name"},
+			Expression: `Concat(["hello", name], " ")`,
+		},
+		{
+			Name:       "Shout",
+			Params:     []string{"name"},
+			Expression: `ToUpperCase(Greeting(name))`,
+		},
+		{
+			Name:       "IsHealthCheck",
+			Expression: `IsMatch(log.attributes["http.path"], "^/health")`,
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		statement string
+		want      func(tCtx ottllog.TransformContext)
+	}{
+		{
+			name:      "editor",
+			statement: `copy_attribute("http.method", "http.request.method")`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("http.request.method", "get")
+				tCtx.GetLogRecord().Attributes().PutBool("copied", true)
+			},
+		},
+		{
+			name:      "editor with named arguments",
+			statement: `copy_attribute(to = "http.request.method", from = "http.method")`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("http.request.method", "get")
+				tCtx.GetLogRecord().Attributes().PutBool("copied", true)
+			},
+		},
+		{
+			name:      "editor setting a parameter",
+			statement: `upper(log.attributes["http.method"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("http.method", "GET")
+			},
+		},
+		{
+			name:      "converter calling a user function",
+			statement: `set(log.attributes["greeting"], Shout(log.body))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("greeting", "HELLO OPERATIONA")
+			},
+		},
+		{
+			name:      "converter in condition",
+			statement: `set(log.attributes["test"], "pass") where IsHealthCheck()`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), componenttest.NewNopTelemetrySettings(),
+				ottllog.EnablePathContextNames(), ottl.WithUserFunctions[ottllog.TransformContext](userFunctions))
+			require.NoError(t, err)
+			statement, err := parser.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			tCtx := constructLogTransformContext()
+			_, _, err = statement.Execute(context.Background(), tCtx)
+			require.NoError(t, err)
+
+			exTCtx := constructLogTransformContext()
+			tt.want(exTCtx)
+
+			assert.NoError(t, plogtest.CompareResourceLogs(newResourceLogs(exTCtx), newResourceLogs(tCtx)))
+		})
+	}
+}
+
+func Test_e2e_user_functions_errors(t *testing.T) {
+	userFunctions, err := ottl.NewUserFunctions([]ottl.UserFunctionConfig{
+		{
+			Name:       "upper",
+			Params:     []string{"target"},
+			Statements: []string{`set(target, ToUpperCase(target))`},
+		},
+		{
+			Name:       "set_span_name",
+			Statements: []string{`set(span.name, "foo")`},
+		},
+	})
+	require.NoError(t, err)
+
+	parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), componenttest.NewNopTelemetrySettings(),
+		ottllog.EnablePathContextNames(), ottl.WithUserFunctions[ottllog.TransformContext](userFunctions))
+	require.NoError(t, err)
+
+	// the body is parsed in the context of the calling statement
+	_, err = parser.ParseStatement(`set_span_name()`)
+	assert.ErrorContains(t, err, `unable to parse user function "set_span_name"`)
+
+	// parameters can only be set when their argument is a path
+	statement, err := parser.ParseStatement(`upper("foo")`)
+	require.NoError(t, err)
+	_, _, err = statement.Execute(context.Background(), constructLogTransformContext())
+	assert.ErrorContains(t, err, `parameter "target" of user function "upper" can't be set, its argument is not a path`)
+}
@@ -61,6 +61,17 @@ func (path StandardGetSetter[K]) Set(ctx context.Context, tCtx K, val any) error
 	return path.Setter(ctx, tCtx, val)
 }
 
+// DetachedGetSetter is a StandardGetSetter whose Getter returns a copy of the value of the path,
+// which is only modified through the Setter. Editors modifying their target in place, like
+// delete_key, therefore can't target such a path.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type DetachedGetSetter[K any] struct {
+	StandardGetSetter[K]
+}
+
+func (DetachedGetSetter[K]) detached() {}
+
 type literal[K any] struct {
 	value any
 }
@@ -775,11 +786,7 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 			return &literal[K]{value: *i}, nil
 		}
 		if eL.Path != nil {
-			np, err := p.newPath(eL.Path)
-			if err != nil {
-				return nil, err
-			}
-			return p.parsePath(np)
+			return p.buildGetSetterFromPath(eL.Path)
 		}
 		if eL.Converter != nil {
 			return p.newGetterFromConverter(*eL.Converter)
@@ -10,6 +10,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/iancoleman/strcase"
 )
@@ -313,6 +315,9 @@ func (p *Parser[K]) parsePath(ip *basePath[K]) (GetSetter[K], error) {
 func (p *Parser[K]) newFunctionCall(ed editor) (Expr[K], error) {
 	f, ok := p.functions[ed.Function]
 	if !ok {
+		if construct, ok := iterationConstructs[ed.Function]; ok {
+			return p.newIterationCall(construct, ed)
+		}
 		return Expr[K]{}, fmt.Errorf("undefined function %q", ed.Function)
 	}
 	defaultArgs := f.CreateDefaultArguments()
@@ -367,17 +372,20 @@ func (p *Parser[K]) buildArgs(ed editor, argsVal reflect.Value) error {
 
 	for i, edArg := range ed.Arguments {
 		var field reflect.Value
+		var fieldName string
 		var fieldType reflect.Type
 		var isOptional bool
 		var arg argument
 
 		if edArg.Name == "" {
 			field = argsVal.Field(i)
+			fieldName = argsVal.Type().Field(i).Name
 			fieldType = field.Type()
 			isOptional = strings.HasPrefix(fieldType.Name(), "Optional")
 			arg = ed.Arguments[i]
 		} else {
-			field = argsVal.FieldByName(strcase.ToCamel(edArg.Name))
+			fieldName = strcase.ToCamel(edArg.Name)
+			field = argsVal.FieldByName(fieldName)
 			if !field.IsValid() {
 				return fmt.Errorf("no such parameter: %s", edArg.Name)
 			}
@@ -421,6 +429,9 @@ func (p *Parser[K]) buildArgs(ed editor, argsVal reflect.Value) error {
 		default:
 			val, err = p.buildArg(arg.Value, fieldType)
 		}
+		if err == nil && fieldName == "Target" && isEditor(ed.Function) {
+			err = p.checkEditorTarget(arg.Value, fieldType)
+		}
 		if err != nil {
 			return fmt.Errorf("invalid argument at position %v: %w", i, err)
 		}
@@ -526,6 +537,12 @@ func (p *Parser[K]) buildSliceArg(argVal value, argType reflect.Type) (any, erro
 }
 
 func (p *Parser[K]) buildGetSetterFromPath(path *path) (GetSetter[K], error) {
+	if variable, ok, err := p.loopVariableGetSetter(path); ok {
+		return variable, err
+	}
+	if param, ok := p.userFunctionParamGetSetter(path); ok {
+		return param, nil
+	}
 	np, err := p.newPath(path)
 	if err != nil {
 		return nil, err
@@ -537,6 +554,33 @@ func (p *Parser[K]) buildGetSetterFromPath(path *path) (GetSetter[K], error) {
 	return arg, nil
 }
 
+// isEditor reports whether the function is an editor, whose names start with a lowercase letter,
+// rather than a converter.
+func isEditor(function string) bool {
+	r, _ := utf8.DecodeRuneInString(function)
+	return unicode.IsLower(r)
+}
+
+// checkEditorTarget returns an error if the target of an editor is a path whose Getter returns a
+// copy of its value, unless the editor writes it back through a Setter.
+func (p *Parser[K]) checkEditorTarget(argVal value, argType reflect.Type) error {
+	name := argType.Name()
+	if strings.HasPrefix(name, "GetSetter") || strings.HasPrefix(name, "Setter") {
+		return nil
+	}
+	if argVal.Literal == nil || argVal.Literal.Path == nil {
+		return nil
+	}
+	getSetter, err := p.buildGetSetterFromPath(argVal.Literal.Path)
+	if err != nil {
+		return err
+	}
+	if _, ok := getSetter.(interface{ detached() }); ok {
+		return errors.New("the path can't be modified in place, as it returns a copy of its value: use set instead")
+	}
+	return nil
+}
+
 // Handle interfaces that can be passed as arguments to OTTL functions.
 func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 	name := argType.Name()
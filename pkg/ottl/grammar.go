@@ -564,6 +564,9 @@ type grammarVisitor interface {
 // grammarCustomErrorsVisitor is used to execute custom validations on the grammar AST.
 type grammarCustomErrorsVisitor struct {
 	errs []error
+	// loopBodies are the editors passed as arguments to for_each, which are the only
+	// editors allowed as arguments.
+	loopBodies map[*mathExprLiteral]struct{}
 }
 
 func (g *grammarCustomErrorsVisitor) add(err error) {
@@ -587,9 +590,22 @@ func (g *grammarCustomErrorsVisitor) visitEditor(v *editor) {
 	if v.Keys != nil {
 		g.add(fmt.Errorf("only paths and converters may be indexed, not editors, but got %s%s", v.Function, buildOriginalKeysText(v.Keys)))
 	}
+	if construct, ok := iterationConstructs[v.Function]; ok && construct == forEachConstruct {
+		for _, arg := range v.Arguments {
+			if arg.Value.Literal != nil && arg.Value.Literal.Editor != nil {
+				if g.loopBodies == nil {
+					g.loopBodies = map[*mathExprLiteral]struct{}{}
+				}
+				g.loopBodies[arg.Value.Literal] = struct{}{}
+			}
+		}
+	}
 }
 
 func (g *grammarCustomErrorsVisitor) visitMathExprLiteral(v *mathExprLiteral) {
+	if _, ok := g.loopBodies[v]; ok {
+		return
+	}
 	if v.Editor != nil {
 		g.add(fmt.Errorf("converter names must start with an uppercase letter but got '%v'", v.Editor.Function))
 	}
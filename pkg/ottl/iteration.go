@@ -0,0 +1,453 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+// iterationConstruct identifies the built-in functions iterating over the elements of a slice or a map.
+// They are part of the language rather than regular functions, as the loop variables they declare
+// must be known when parsing their body.
+type iterationConstruct int
+
+const (
+	// forEachConstruct is the `for_each(target, [key,] value, editor[, condition])` editor,
+	// which executes the editor for every element of the target.
+	forEachConstruct iterationConstruct = iota
+	// mapConstruct is the `Map(target, [key,] value, expression)` converter, which returns
+	// a new slice or map with the result of the expression for every element of the target.
+	mapConstruct
+	// filterConstruct is the `Filter(target, [key,] value, predicate)` converter, which returns
+	// a new slice or map with the elements of the target the predicate returns true for.
+	filterConstruct
+)
+
+var iterationConstructs = map[string]iterationConstruct{
+	"for_each": forEachConstruct,
+	"Map":      mapConstruct,
+	"Filter":   filterConstruct,
+}
+
+// isIterationConstruct returns true if the name is the name of an iteration construct.
+func isIterationConstruct(name string) bool {
+	_, ok := iterationConstructs[name]
+	return ok
+}
+
+// loopScope holds the names of the loop variables declared by an iteration construct.
+// The key variable is optional.
+type loopScope struct {
+	key   string
+	value string
+}
+
+// loopFrame holds the element an iteration construct is processing.
+type loopFrame struct {
+	key   any
+	value pcommon.Value
+}
+
+// loopFrameKey is the context key of the frame of an iteration construct.
+type loopFrameKey struct {
+	scope *loopScope
+}
+
+// newIterationCall parses a call to an iteration construct. The target is parsed with the Parser,
+// while the body is parsed with a copy of it that resolves the declared loop variables.
+func (p *Parser[K]) newIterationCall(construct iterationConstruct, ed editor) (Expr[K], error) {
+	for _, arg := range ed.Arguments {
+		if arg.Name != "" || arg.FunctionName != nil {
+			return Expr[K]{}, fmt.Errorf("%q only accepts positional arguments", ed.Function)
+		}
+	}
+
+	bodyIndex := loopBodyIndex(construct, ed.Arguments)
+	if construct == forEachConstruct && (bodyIndex < 0 || bodyIndex < len(ed.Arguments)-2) {
+		return Expr[K]{}, fmt.Errorf("%q must be called as %s(target, [key,] value, editor[, condition])", ed.Function, ed.Function)
+	}
+	if bodyIndex < 2 || bodyIndex > 3 {
+		return Expr[K]{}, fmt.Errorf("%q must declare one or two loop variables, the value or the key and the value, between the target and the body", ed.Function)
+	}
+
+	scope, err := newLoopScope(ed.Arguments[1:bodyIndex])
+	if err != nil {
+		return Expr[K]{}, fmt.Errorf("invalid loop variable in call to %q: %w", ed.Function, err)
+	}
+	for _, name := range []string{scope.key, scope.value} {
+		if name != "" && p.isContextPath(name) {
+			return Expr[K]{}, fmt.Errorf("invalid loop variable in call to %q: %q shadows the path of the same name", ed.Function, name)
+		}
+	}
+
+	target, err := p.newGetter(ed.Arguments[0].Value)
+	if err != nil {
+		return Expr[K]{}, fmt.Errorf("invalid target in call to %q: %w", ed.Function, err)
+	}
+
+	body := *p
+	body.loopScopes = append(slices.Clip(p.loopScopes), scope)
+
+	switch construct {
+	case forEachConstruct:
+		editorExpr, err := body.newFunctionCall(*ed.Arguments[bodyIndex].Value.Literal.Editor)
+		if err != nil {
+			return Expr[K]{}, err
+		}
+		var condition Getter[K]
+		if bodyIndex < len(ed.Arguments)-1 {
+			condition, err = body.newGetter(ed.Arguments[bodyIndex+1].Value)
+			if err != nil {
+				return Expr[K]{}, fmt.Errorf("invalid condition in call to %q: %w", ed.Function, err)
+			}
+		}
+		return Expr[K]{exprFunc: forEach(scope, target, editorExpr, condition)}, nil
+	case mapConstruct:
+		expression, err := body.newGetter(ed.Arguments[bodyIndex].Value)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("invalid expression in call to %q: %w", ed.Function, err)
+		}
+		return Expr[K]{exprFunc: mapElements(scope, target, expression)}, nil
+	default:
+		predicate, err := body.newGetter(ed.Arguments[bodyIndex].Value)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("invalid predicate in call to %q: %w", ed.Function, err)
+		}
+		return Expr[K]{exprFunc: filterElements(scope, target, predicate)}, nil
+	}
+}
+
+// loopBodyIndex returns the index of the body among the arguments of an iteration construct, which
+// follows the loop variables. The body of for_each is its editor argument, and -1 if there is none.
+func loopBodyIndex(construct iterationConstruct, args []argument) int {
+	if construct == forEachConstruct {
+		return slices.IndexFunc(args, func(arg argument) bool {
+			return arg.Value.Literal != nil && arg.Value.Literal.Editor != nil
+		})
+	}
+	return len(args) - 1
+}
+
+// isContextPath returns true if the bare name is a valid path of the context of the Parser.
+func (p *Parser[K]) isContextPath(name string) bool {
+	np, err := p.newPath(&path{Fields: []field{{Name: name}}})
+	if err != nil {
+		return false
+	}
+	_, err = p.parsePath(np)
+	return err == nil
+}
+
+func newLoopScope(args []argument) (*loopScope, error) {
+	names := make([]string, len(args))
+	for i, arg := range args {
+		name, ok := loopVariableName(arg.Value)
+		if !ok {
+			return nil, errors.New("loop variables must be bare names, such as `value`")
+		}
+		names[i] = name
+	}
+	if len(names) == 1 {
+		return &loopScope{value: names[0]}, nil
+	}
+	if names[0] == names[1] {
+		return nil, fmt.Errorf("the key and the value can't both be named %q", names[0])
+	}
+	return &loopScope{key: names[0], value: names[1]}, nil
+}
+
+// loopVariableName returns the name of the loop variable declared or used by the value, if it
+// could be one.
+func loopVariableName(val value) (string, bool) {
+	if val.Literal == nil || val.Literal.Path == nil {
+		return "", false
+	}
+	path := val.Literal.Path
+	if path.Context != "" || len(path.Fields) != 1 || len(path.Fields[0].Keys) > 0 {
+		return "", false
+	}
+	return path.Fields[0].Name, true
+}
+
+// loopVariableGetSetter returns the loop variable the path refers to, if the path is the name of a
+// variable declared by one of the iteration constructs whose body the Parser is parsing.
+func (p *Parser[K]) loopVariableGetSetter(path *path) (GetSetter[K], bool, error) {
+	if len(p.loopScopes) == 0 || path.Context != "" || len(path.Fields) != 1 {
+		return nil, false, nil
+	}
+	name := path.Fields[0].Name
+	for i := len(p.loopScopes) - 1; i >= 0; i-- {
+		scope := p.loopScopes[i]
+		if name != scope.key && name != scope.value {
+			continue
+		}
+		if len(path.Fields[0].Keys) > 0 {
+			return nil, true, fmt.Errorf("loop variable %q can't be indexed", name)
+		}
+		return &loopVariable[K]{scope: scope, name: name, key: name == scope.key}, true, nil
+	}
+	return nil, false, nil
+}
+
+// loopVariable refers to the key or the value of the element an iteration construct is processing.
+// Setting the value changes the element in place, while the key can't be set.
+type loopVariable[K any] struct {
+	scope *loopScope
+	name  string
+	key   bool
+}
+
+func (v *loopVariable[K]) frame(ctx context.Context) (*loopFrame, error) {
+	frame, ok := ctx.Value(loopFrameKey{scope: v.scope}).(*loopFrame)
+	if !ok {
+		return nil, fmt.Errorf("loop variable %q used outside of its loop", v.name)
+	}
+	return frame, nil
+}
+
+func (v *loopVariable[K]) Get(ctx context.Context, _ K) (any, error) {
+	frame, err := v.frame(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if v.key {
+		return frame.key, nil
+	}
+	return ottlcommon.GetValue(frame.value), nil
+}
+
+func (v *loopVariable[K]) Set(ctx context.Context, _ K, val any) error {
+	if v.key {
+		return fmt.Errorf("loop variable %q holds the key of the element and can't be set", v.name)
+	}
+	frame, err := v.frame(ctx)
+	if err != nil {
+		return err
+	}
+	return ottlcommon.SetValue(frame.value, val)
+}
+
+// iterate calls fn for every element of the target, which must be a slice or a map, with the frame of
+// the scope set to the element. A nil target has no elements.
+func iterate(ctx context.Context, scope *loopScope, target any, fn func(ctx context.Context, frame *loopFrame) error) error {
+	switch target.(type) {
+	case nil:
+		return nil
+	case pcommon.Slice, pcommon.Map:
+	default:
+		converted := pcommon.NewValueEmpty()
+		if err := ottlcommon.SetValue(converted, target); err != nil {
+			return err
+		}
+		switch converted.Type() {
+		case pcommon.ValueTypeSlice:
+			target = converted.Slice()
+		case pcommon.ValueTypeMap:
+			target = converted.Map()
+		default:
+			return TypeError(fmt.Sprintf("expected a slice or a map to iterate over but got %T", target))
+		}
+	}
+
+	frame := &loopFrame{}
+	ctx = context.WithValue(ctx, loopFrameKey{scope: scope}, frame)
+	if s, ok := target.(pcommon.Slice); ok {
+		for i := 0; i < s.Len(); i++ {
+			frame.key, frame.value = int64(i), s.At(i)
+			if err := fn(ctx, frame); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var err error
+	target.(pcommon.Map).Range(func(k string, v pcommon.Value) bool {
+		frame.key, frame.value = k, v
+		err = fn(ctx, frame)
+		return err == nil
+	})
+	return err
+}
+
+func forEach[K any](scope *loopScope, target Getter[K], editor Expr[K], condition Getter[K]) ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		err = iterate(ctx, scope, val, func(ctx context.Context, _ *loopFrame) error {
+			if condition != nil {
+				matched, err := evalLoopPredicate(ctx, tCtx, condition)
+				if err != nil || !matched {
+					return err
+				}
+			}
+			_, err := editor.Eval(ctx, tCtx)
+			return err
+		})
+		return nil, err
+	}
+}
+
+func mapElements[K any](scope *loopScope, target Getter[K], expression Getter[K]) ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil || val == nil {
+			return nil, err
+		}
+		result := newIterationResult(val)
+		err = iterate(ctx, scope, val, func(ctx context.Context, frame *loopFrame) error {
+			mapped, err := expression.Get(ctx, tCtx)
+			if err != nil {
+				return err
+			}
+			return ottlcommon.SetValue(result.add(frame), mapped)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.get(), nil
+	}
+}
+
+func filterElements[K any](scope *loopScope, target Getter[K], predicate Getter[K]) ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil || val == nil {
+			return nil, err
+		}
+		result := newIterationResult(val)
+		err = iterate(ctx, scope, val, func(ctx context.Context, frame *loopFrame) error {
+			matched, err := evalLoopPredicate(ctx, tCtx, predicate)
+			if err != nil || !matched {
+				return err
+			}
+			frame.value.CopyTo(result.add(frame))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.get(), nil
+	}
+}
+
+func evalLoopPredicate[K any](ctx context.Context, tCtx K, predicate Getter[K]) (bool, error) {
+	val, err := predicate.Get(ctx, tCtx)
+	if err != nil {
+		return false, err
+	}
+	matched, ok := val.(bool)
+	if !ok {
+		return false, TypeError(fmt.Sprintf("expected the condition to return a bool but got %T", val))
+	}
+	return matched, nil
+}
+
+// iterationResult builds the slice or map returned by the Map and Filter converters, which is a
+// slice if the target is a slice, and a map otherwise.
+type iterationResult struct {
+	slice *pcommon.Slice
+	m     *pcommon.Map
+}
+
+func newIterationResult(target any) *iterationResult {
+	switch target.(type) {
+	case pcommon.Map, map[string]any:
+		m := pcommon.NewMap()
+		return &iterationResult{m: &m}
+	default:
+		s := pcommon.NewSlice()
+		return &iterationResult{slice: &s}
+	}
+}
+
+func (r *iterationResult) add(frame *loopFrame) pcommon.Value {
+	if r.m != nil {
+		return r.m.PutEmpty(frame.key.(string))
+	}
+	return r.slice.AppendEmpty()
+}
+
+func (r *iterationResult) get() any {
+	if r.m != nil {
+		return *r.m
+	}
+	return *r.slice
+}
+
+// grammarLoopVariablesVisitor collects the positions of the paths referring to the loop variables
+// declared by the iteration constructs of a statement. These are the bare names of the loop variables
+// within the arguments following the target of the construct declaring them, so a path of the same
+// name outside of these arguments still refers to the telemetry.
+type grammarLoopVariablesVisitor struct {
+	offsets map[int]struct{}
+}
+
+func (v *grammarLoopVariablesVisitor) visitPath(_ *path)                       {}
+func (v *grammarLoopVariablesVisitor) visitValue(_ *value)                     {}
+func (v *grammarLoopVariablesVisitor) visitMathExprLiteral(_ *mathExprLiteral) {}
+
+func (v *grammarLoopVariablesVisitor) visitEditor(e *editor) {
+	v.visitCall(e.Function, e.Arguments)
+}
+
+func (v *grammarLoopVariablesVisitor) visitConverter(c *converter) {
+	v.visitCall(c.Function, c.Arguments)
+}
+
+func (v *grammarLoopVariablesVisitor) visitCall(function string, args []argument) {
+	construct, ok := iterationConstructs[function]
+	if !ok {
+		return
+	}
+	bodyIndex := loopBodyIndex(construct, args)
+	if bodyIndex < 2 || bodyIndex > 3 {
+		return
+	}
+	names := make(map[string]struct{}, bodyIndex-1)
+	for _, arg := range args[1:bodyIndex] {
+		name, ok := loopVariableName(arg.Value)
+		if !ok {
+			return
+		}
+		names[name] = struct{}{}
+	}
+
+	scoped := &grammarPathVisitor{}
+	for i := 1; i < len(args); i++ {
+		args[i].accept(scoped)
+	}
+	for _, it := range scoped.paths {
+		if it.Context != "" || len(it.Fields) != 1 {
+			continue
+		}
+		if _, ok := names[it.Fields[0].Name]; ok {
+			if v.offsets == nil {
+				v.offsets = map[int]struct{}{}
+			}
+			v.offsets[it.Pos.Offset] = struct{}{}
+		}
+	}
+}
+
+// withoutLoopVariables returns the paths that don't refer to loop variables, as these paths don't
+// refer to the telemetry.
+func withoutLoopVariables(paths []path, loopVariables *grammarLoopVariablesVisitor) []path {
+	if len(loopVariables.offsets) == 0 {
+		return paths
+	}
+	return slices.DeleteFunc(paths, func(it path) bool {
+		_, ok := loopVariables.offsets[it.Pos.Offset]
+		return ok
+	})
+}
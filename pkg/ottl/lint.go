@@ -0,0 +1,561 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// LintSeverity indicates how severe a LintIssue is.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type LintSeverity string
+
+const (
+	// LintSeverityError is used for issues that prevent the statements from being parsed.
+	LintSeverityError LintSeverity = "error"
+	// LintSeverityWarning is used for issues that are very likely mistakes.
+	LintSeverityWarning LintSeverity = "warning"
+	// LintSeverityInfo is used for issues that are harmless but might be simplified.
+	LintSeverityInfo LintSeverity = "info"
+)
+
+// Lint rules reported by the LintIssue.Rule field.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+const (
+	// LintRuleInvalidStatement reports statements or conditions that cannot be parsed, either
+	// because of their syntax or because of invalid paths, functions or argument types.
+	LintRuleInvalidStatement = "invalid-statement"
+	// LintRuleConstantCondition reports conditions that always evaluate to the same value.
+	LintRuleConstantCondition = "constant-condition"
+	// LintRuleShadowedSet reports set calls whose value is overwritten before being read.
+	LintRuleShadowedSet = "shadowed-set"
+	// LintRuleUnsetCacheKey reports cache keys that are read but never set.
+	LintRuleUnsetCacheKey = "unset-cache-key"
+	// LintRuleContextInference reports statements whose context cannot be inferred, or that
+	// are configured with a different context than the one they would be inferred to.
+	LintRuleContextInference = "context-inference"
+)
+
+// LintIssue describes a problem found while linting OTTL statements or conditions.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type LintIssue struct {
+	// Index is the position of the offending statement in the linted list, or -1 if the issue
+	// applies to the whole list.
+	Index int
+	// Statement is the offending statement text, or empty if the issue applies to the whole list.
+	Statement string
+	Severity  LintSeverity
+	Rule      string
+	Message   string
+}
+
+func (i LintIssue) String() string {
+	if i.Index < 0 {
+		return fmt.Sprintf("%s: %s (%s)", i.Severity, i.Message, i.Rule)
+	}
+	return fmt.Sprintf("%s: statement %d %q: %s (%s)", i.Severity, i.Index, i.Statement, i.Message, i.Rule)
+}
+
+// LintResult holds the outcome of linting a list of statements with a ParserCollection.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type LintResult struct {
+	// Context is the context the statements were linted with, either configured or inferred.
+	// It is empty if no context could be determined.
+	Context string
+	Issues  []LintIssue
+}
+
+// HasErrors returns true if any of the issues has the LintSeverityError severity.
+func (r LintResult) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == LintSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// LintStatements statically analyzes the given statements, reporting parsing errors, conditions
+// that are always true or false, set calls which value is overwritten before being read, and
+// cache keys that are read but never set.
+// The statements are not executed, and the returned issues are sorted by statement index.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func (p *Parser[K]) LintStatements(statements []string) []LintIssue {
+	var issues []LintIssue
+	analyzer := newLintStatementsAnalyzer()
+	for i, statement := range statements {
+		if _, err := p.ParseStatement(statement); err != nil {
+			issues = append(issues, LintIssue{Index: i, Statement: statement, Severity: LintSeverityError, Rule: LintRuleInvalidStatement, Message: err.Error()})
+			// the statement might still have a valid syntax, keeping track of its paths
+			// avoids reporting false positives for the statements around it.
+		}
+		parsed, err := parseStatement(statement)
+		if err != nil {
+			analyzer.reset()
+			continue
+		}
+		if parsed.WhereClause != nil {
+			if issue, ok := p.lintConstantCondition(parsed.WhereClause, "the statement is never executed"); ok {
+				issue.Index, issue.Statement = i, statement
+				issues = append(issues, issue)
+			}
+		}
+		analyzer.add(i, parsed)
+	}
+	issues = append(issues, analyzer.issues(statements)...)
+	sortLintIssues(issues)
+	return issues
+}
+
+// LintConditions statically analyzes the given conditions, reporting parsing errors and
+// conditions that are always true or false.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func (p *Parser[K]) LintConditions(conditions []string) []LintIssue {
+	var issues []LintIssue
+	for i, condition := range conditions {
+		if _, err := p.ParseCondition(condition); err != nil {
+			issues = append(issues, LintIssue{Index: i, Statement: condition, Severity: LintSeverityError, Rule: LintRuleInvalidStatement, Message: err.Error()})
+			continue
+		}
+		parsed, err := parseCondition(condition)
+		if err != nil {
+			continue
+		}
+		if issue, ok := p.lintConstantCondition(parsed, "no telemetry ever matches it"); ok {
+			issue.Index, issue.Statement = i, condition
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// LintStatements statically analyzes the given statements using the parser configured for
+// the given context, see Parser.LintStatements for the list of reported issues.
+// If the context is empty, it is inferred from the statements the same way ParseStatements
+// does. Otherwise, context-less paths are prefixed with the given context, and a warning is
+// reported if the statements would be inferred to a different context.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func (pc *ParserCollection[R]) LintStatements(context string, statements []string) LintResult {
+	lintingStatements := statements
+	if context == "" {
+		inferredContext, err := pc.contextInferrer.infer(statements)
+		if err != nil {
+			return LintResult{Issues: pc.lintStatementsSyntax(statements)}
+		}
+		if _, ok := pc.contextParsers[inferredContext]; !ok {
+			return LintResult{Issues: []LintIssue{{
+				Index:    -1,
+				Severity: LintSeverityError,
+				Rule:     LintRuleContextInference,
+				Message:  fmt.Sprintf("unable to infer a supported context from the statements, paths must be prefixed with a valid context name and functions must be available in that context: %+q", pc.supportedContextNames()),
+			}}}
+		}
+		context = inferredContext
+	} else {
+		contextParser, ok := pc.contextParsers[context]
+		if !ok {
+			return LintResult{Issues: []LintIssue{{
+				Index:    -1,
+				Severity: LintSeverityError,
+				Rule:     LintRuleContextInference,
+				Message:  fmt.Sprintf("unknown context %q, valid options are: %+q", context, pc.supportedContextNames()),
+			}}}
+		}
+		prepended, err := contextParser.ottlParser.prependContextToStatementsPaths(context, statements)
+		if err != nil {
+			return LintResult{Context: context, Issues: pc.lintStatementsSyntax(statements)}
+		}
+		lintingStatements = prepended
+	}
+
+	issues := pc.contextParsers[context].ottlParser.lintStatements(lintingStatements)
+	for i := range issues {
+		if issues[i].Index >= 0 {
+			issues[i].Statement = statements[issues[i].Index]
+		}
+	}
+
+	if inferredContext, err := pc.contextInferrer.infer(lintingStatements); err == nil && inferredContext != "" && inferredContext != context {
+		issues = append(issues, LintIssue{
+			Index:    -1,
+			Severity: LintSeverityWarning,
+			Rule:     LintRuleContextInference,
+			Message:  fmt.Sprintf("statements are configured with the %q context, but would be inferred as %q, which executes them a different number of times", context, inferredContext),
+		})
+	}
+
+	sortLintIssues(issues)
+	return LintResult{Context: context, Issues: issues}
+}
+
+// lintStatementsSyntax reports the syntax errors of the given statements, or a context
+// inference error if all of them have a valid syntax.
+func (pc *ParserCollection[R]) lintStatementsSyntax(statements []string) []LintIssue {
+	var issues []LintIssue
+	for i, statement := range statements {
+		if _, err := parseStatement(statement); err != nil {
+			issues = append(issues, LintIssue{Index: i, Statement: statement, Severity: LintSeverityError, Rule: LintRuleInvalidStatement, Message: err.Error()})
+		}
+	}
+	if len(issues) == 0 {
+		issues = append(issues, LintIssue{
+			Index:    -1,
+			Severity: LintSeverityError,
+			Rule:     LintRuleContextInference,
+			Message:  fmt.Sprintf("unable to infer context from statements, valid context names are: %+q", pc.supportedContextNames()),
+		})
+	}
+	return issues
+}
+
+// lintConstantCondition reports the given boolean expression if it always evaluates to the
+// same value. The neverMessage describes the consequence of the expression being always false.
+func (p *Parser[K]) lintConstantCondition(be *booleanExpression, neverMessage string) (LintIssue, bool) {
+	result, ok := p.staticBooleanExpression(be)
+	if !ok {
+		return LintIssue{}, false
+	}
+	if result {
+		return LintIssue{Severity: LintSeverityInfo, Rule: LintRuleConstantCondition, Message: "condition is always true and can be removed"}, true
+	}
+	return LintIssue{Severity: LintSeverityWarning, Rule: LintRuleConstantCondition, Message: "condition is always false, " + neverMessage}, true
+}
+
+// staticBooleanExpression evaluates the given boolean expression without a transform context.
+// The second returned value is false if the result depends on the telemetry.
+func (p *Parser[K]) staticBooleanExpression(be *booleanExpression) (bool, bool) {
+	result, known := p.staticTerm(be.Left)
+	if known && result {
+		return true, true
+	}
+	allKnown := known
+	for _, rhs := range be.Right {
+		result, known = p.staticTerm(rhs.Term)
+		if known && result {
+			return true, true
+		}
+		allKnown = allKnown && known
+	}
+	return false, allKnown
+}
+
+func (p *Parser[K]) staticTerm(t *term) (bool, bool) {
+	result, known := p.staticBooleanValue(t.Left)
+	if known && !result {
+		return false, true
+	}
+	allKnown := known
+	for _, rhs := range t.Right {
+		result, known = p.staticBooleanValue(rhs.Value)
+		if known && !result {
+			return false, true
+		}
+		allKnown = allKnown && known
+	}
+	return true, allKnown
+}
+
+func (p *Parser[K]) staticBooleanValue(b *booleanValue) (bool, bool) {
+	var result, known bool
+	switch {
+	case b.Comparison != nil:
+		result, known = p.staticComparison(b.Comparison)
+	case b.ConstExpr != nil && b.ConstExpr.Boolean != nil:
+		result, known = bool(*b.ConstExpr.Boolean), true
+	case b.SubExpr != nil:
+		result, known = p.staticBooleanExpression(b.SubExpr)
+	}
+	if known && b.Negation != nil {
+		result = !result
+	}
+	return result, known
+}
+
+func (p *Parser[K]) staticComparison(c *comparison) (bool, bool) {
+	left, leftKnown := staticValue(c.Left)
+	right, rightKnown := staticValue(c.Right)
+	if leftKnown && rightKnown {
+		return p.compare(left, right, c.Op), true
+	}
+	// comparing a path with itself does not depend on its value
+	leftPath, rightPath := valuePath(c.Left), valuePath(c.Right)
+	if leftPath != nil && rightPath != nil {
+		leftSegments, leftStatic := lintPathSegments(leftPath)
+		rightSegments, rightStatic := lintPathSegments(rightPath)
+		if leftStatic && rightStatic && slicesEqual(leftSegments, rightSegments) {
+			return c.Op == eq || c.Op == lte || c.Op == gte, true
+		}
+	}
+	return false, false
+}
+
+// staticValue returns the literal value held by the given value, if any.
+func staticValue(v value) (any, bool) {
+	switch {
+	case v.IsNil != nil:
+		return nil, true
+	case v.String != nil:
+		return *v.String, true
+	case v.Bool != nil:
+		return bool(*v.Bool), true
+	case v.Bytes != nil:
+		return []byte(*v.Bytes), true
+	case v.Literal != nil && v.Literal.Int != nil:
+		return *v.Literal.Int, true
+	case v.Literal != nil && v.Literal.Float != nil:
+		return *v.Literal.Float, true
+	}
+	return nil, false
+}
+
+func valuePath(v value) *path {
+	if v.Literal != nil {
+		return v.Literal.Path
+	}
+	return nil
+}
+
+// lintPathSegments returns the context, fields names and keys of the given path as a list of
+// segments. Keys that are not literals are returned as the "[*]" wildcard segment, in which case
+// the second returned value is false.
+func lintPathSegments(p *path) ([]string, bool) {
+	var segments []string
+	static := true
+	if p.Context != "" {
+		segments = append(segments, p.Context)
+	}
+	for _, f := range p.Fields {
+		segments = append(segments, f.Name)
+		for _, k := range f.Keys {
+			switch {
+			case k.String != nil:
+				segments = append(segments, "["+strconv.Quote(*k.String)+"]")
+			case k.Int != nil:
+				segments = append(segments, "["+strconv.FormatInt(*k.Int, 10)+"]")
+			default:
+				segments = append(segments, "[*]")
+				static = false
+			}
+		}
+	}
+	return segments, static
+}
+
+// lintPathsOverlap returns true if one of the given paths segments is a prefix of the other,
+// meaning that reading or writing one of them might affect the other.
+func lintPathsOverlap(a, b []string) bool {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] && a[i] != "[*]" && b[i] != "[*]" {
+			return false
+		}
+	}
+	return true
+}
+
+func slicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lintStatementsAnalyzer keeps track of the paths read and written by a sequence of statements,
+// reporting the set calls which value is overwritten before being read, and the cache keys
+// that are read but never set.
+type lintStatementsAnalyzer struct {
+	// pendingSets are the unconditional set targets not read yet, and the index of the statement
+	// setting them.
+	pendingSets map[string]lintPendingSet
+	shadowed    []LintIssue
+	// cacheReads are the static cache paths read by the statements, and the index of the first
+	// statement reading them.
+	cacheReads   map[string]int
+	cacheWritten map[string]struct{}
+	// cacheWrittenByContext holds the contexts whose whole cache map is modified by a statement.
+	cacheWrittenByContext map[string]struct{}
+}
+
+type lintPendingSet struct {
+	index    int
+	segments []string
+}
+
+func newLintStatementsAnalyzer() *lintStatementsAnalyzer {
+	return &lintStatementsAnalyzer{
+		pendingSets:           map[string]lintPendingSet{},
+		cacheReads:            map[string]int{},
+		cacheWritten:          map[string]struct{}{},
+		cacheWrittenByContext: map[string]struct{}{},
+	}
+}
+
+// reset forgets the pending set calls, so statements which paths are unknown are not
+// considered when looking for shadowed set calls.
+func (a *lintStatementsAnalyzer) reset() {
+	clear(a.pendingSets)
+}
+
+func (a *lintStatementsAnalyzer) add(index int, parsed *parsedStatement) {
+	target := lintTargetPath(parsed)
+	var targetSegments []string
+	targetStatic := false
+	if target != nil {
+		targetSegments, targetStatic = lintPathSegments(target)
+	}
+
+	for _, p := range getParsedStatementPaths(parsed) {
+		segments, static := lintPathSegments(&p)
+		if target != nil && p.Pos == target.Pos {
+			a.addCacheWrite(segments, static, parsed.Editor.Function == "set")
+			continue
+		}
+		for key, pending := range a.pendingSets {
+			if lintPathsOverlap(segments, pending.segments) {
+				delete(a.pendingSets, key)
+			}
+		}
+		if static && lintIsCachePath(segments) {
+			if _, ok := a.cacheReads[strings.Join(segments, ".")]; !ok {
+				a.cacheReads[strings.Join(segments, ".")] = index
+			}
+		}
+	}
+
+	if target == nil {
+		return
+	}
+	// editors other than set might read their target, or only modify part of it
+	if parsed.Editor.Function != "set" || !targetStatic {
+		for key, pending := range a.pendingSets {
+			if lintPathsOverlap(targetSegments, pending.segments) {
+				delete(a.pendingSets, key)
+			}
+		}
+		return
+	}
+
+	key := strings.Join(targetSegments, ".")
+	unconditional := parsed.WhereClause == nil
+	for pendingKey, pending := range a.pendingSets {
+		if pendingKey == key {
+			if unconditional {
+				a.shadowed = append(a.shadowed, LintIssue{
+					Index:    pending.index,
+					Severity: LintSeverityWarning,
+					Rule:     LintRuleShadowedSet,
+					Message:  fmt.Sprintf("the value set to %s is overwritten by statement %d before being read", lintPathText(targetSegments), index),
+				})
+				delete(a.pendingSets, pendingKey)
+			}
+			continue
+		}
+		if lintPathsOverlap(targetSegments, pending.segments) {
+			delete(a.pendingSets, pendingKey)
+		}
+	}
+	if unconditional {
+		a.pendingSets[key] = lintPendingSet{index: index, segments: targetSegments}
+	}
+}
+
+func (a *lintStatementsAnalyzer) addCacheWrite(segments []string, static bool, isSet bool) {
+	if !lintIsCachePath(segments) {
+		return
+	}
+	// writes to the whole cache map, or to a dynamic key, might set any key
+	if !static || !isSet || len(segments) <= lintCacheFieldIndex(segments)+1 {
+		a.cacheWrittenByContext[strings.Join(segments[:lintCacheFieldIndex(segments)], ".")] = struct{}{}
+		return
+	}
+	a.cacheWritten[strings.Join(segments, ".")] = struct{}{}
+}
+
+func (a *lintStatementsAnalyzer) issues(statements []string) []LintIssue {
+	issues := make([]LintIssue, 0, len(a.shadowed)+len(a.cacheReads))
+	for _, issue := range a.shadowed {
+		issue.Statement = statements[issue.Index]
+		issues = append(issues, issue)
+	}
+	for key, index := range a.cacheReads {
+		segments := strings.Split(key, ".")
+		if _, ok := a.cacheWritten[key]; ok {
+			continue
+		}
+		if _, ok := a.cacheWrittenByContext[strings.Join(segments[:lintCacheFieldIndex(segments)], ".")]; ok {
+			continue
+		}
+		// reading the whole cache map is always valid
+		if len(segments) <= lintCacheFieldIndex(segments)+1 {
+			continue
+		}
+		issues = append(issues, LintIssue{
+			Index:     index,
+			Statement: statements[index],
+			Severity:  LintSeverityWarning,
+			Rule:      LintRuleUnsetCacheKey,
+			Message:   fmt.Sprintf("%s is read but never set by the statements", lintPathText(segments)),
+		})
+	}
+	return issues
+}
+
+// lintTargetPath returns the path passed as first argument to the statement's editor, if any.
+func lintTargetPath(parsed *parsedStatement) *path {
+	if isIterationConstruct(parsed.Editor.Function) || len(parsed.Editor.Arguments) == 0 {
+		return nil
+	}
+	return valuePath(parsed.Editor.Arguments[0].Value)
+}
+
+// lintCacheFieldIndex returns the index of the cache field in the given path segments, or -1.
+func lintCacheFieldIndex(segments []string) int {
+	for i, s := range segments {
+		if s == "cache" {
+			return i
+		}
+		if i > 0 {
+			break
+		}
+	}
+	return -1
+}
+
+func lintIsCachePath(segments []string) bool {
+	return lintCacheFieldIndex(segments) >= 0
+}
+
+func lintPathText(segments []string) string {
+	var sb strings.Builder
+	for i, s := range segments {
+		if i > 0 && !strings.HasPrefix(s, "[") {
+			sb.WriteString(".")
+		}
+		sb.WriteString(s)
+	}
+	return sb.String()
+}
+
+func sortLintIssues(issues []LintIssue) {
+	// the stable sort keeps the issues of the same statement in the order they were found
+	slices.SortStableFunc(issues, func(a, b LintIssue) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+}
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type lintMergeMapsArguments[K any] struct {
+	Target Setter[K]
+	Source Getter[K]
+}
+
+func lintTestParsePath[K any](p Path[K]) (GetSetter[any], error) {
+	switch p.Name() {
+	case "name", "attributes", "body", "cache":
+		p.Keys()
+		return &StandardGetSetter[any]{
+			Getter: func(_ context.Context, tCtx any) (any, error) {
+				return tCtx, nil
+			},
+			Setter: func(_ context.Context, _ any, _ any) error {
+				return nil
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid path %q", p.Name())
+}
+
+func lintTestParser(t *testing.T, options ...Option[any]) *Parser[any] {
+	editor := func(_ FunctionContext, _ Arguments) (ExprFunc[any], error) {
+		return func(_ context.Context, _ any) (any, error) {
+			return nil, nil
+		}, nil
+	}
+	ps, err := NewParser(
+		CreateFactoryMap[any](
+			NewFactory("set", &mockSetArguments[any]{}, editor),
+			NewFactory("merge_maps", &lintMergeMapsArguments[any]{}, editor),
+		),
+		lintTestParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		append([]Option[any]{WithEnumParser[any](testParseEnum)}, options...)...,
+	)
+	require.NoError(t, err)
+	return &ps
+}
+
+func Test_LintStatements(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements []string
+		expected   []LintIssue
+	}{
+		{
+			name:       "no issues",
+			statements: []string{`set(attributes["a"], "b") where name == "foo"`, `set(name, attributes["a"])`},
+		},
+		{
+			name:       "invalid syntax",
+			statements: []string{`set(attributes["a"], "b"`},
+			expected:   []LintIssue{{Index: 0, Severity: LintSeverityError, Rule: LintRuleInvalidStatement}},
+		},
+		{
+			name:       "unknown function",
+			statements: []string{`unknown(attributes["a"])`},
+			expected:   []LintIssue{{Index: 0, Severity: LintSeverityError, Rule: LintRuleInvalidStatement}},
+		},
+		{
+			name:       "invalid path",
+			statements: []string{`set(foo, "bar")`},
+			expected:   []LintIssue{{Index: 0, Severity: LintSeverityError, Rule: LintRuleInvalidStatement}},
+		},
+		{
+			name:       "always false condition",
+			statements: []string{`set(name, "a") where 1 == 2`},
+			expected:   []LintIssue{{Index: 0, Severity: LintSeverityWarning, Rule: LintRuleConstantCondition}},
+		},
+		{
+			name:       "always false and condition",
+			statements: []string{`set(name, "a") where name == "foo" and false`},
+			expected:   []LintIssue{{Index: 0, Severity: LintSeverityWarning, Rule: LintRuleConstantCondition}},
+		},
+		{
+			name:       "always true or condition",
+			statements: []string{`set(name, "a") where name == "foo" or "a" != "b"`},
+			expected:   []LintIssue{{Index: 0, Severity: LintSeverityInfo, Rule: LintRuleConstantCondition}},
+		},
+		{
+			name:       "negated sub expression",
+			statements: []string{`set(name, "a") where not (true)`},
+			expected:   []LintIssue{{Index: 0, Severity: LintSeverityWarning, Rule: LintRuleConstantCondition}},
+		},
+		{
+			name:       "path compared with itself",
+			statements: []string{`set(name, "a") where attributes["a"] == attributes["a"]`},
+			expected:   []LintIssue{{Index: 0, Severity: LintSeverityInfo, Rule: LintRuleConstantCondition}},
+		},
+		{
+			name:       "telemetry dependent condition",
+			statements: []string{`set(name, "a") where attributes["a"] == attributes["b"] or nil == name`},
+		},
+		{
+			name:       "shadowed set",
+			statements: []string{`set(attributes["a"], "b")`, `set(name, "c")`, `set(attributes["a"], "d")`},
+			expected:   []LintIssue{{Index: 0, Severity: LintSeverityWarning, Rule: LintRuleShadowedSet}},
+		},
+		{
+			name:       "set read before overwritten",
+			statements: []string{`set(attributes["a"], "b")`, `set(name, attributes["a"])`, `set(attributes["a"], "d")`},
+		},
+		{
+			name:       "set read by condition before overwritten",
+			statements: []string{`set(attributes["a"], "b")`, `set(name, "c") where attributes["a"] != nil`, `set(attributes["a"], "d")`},
+		},
+		{
+			name:       "set read through parent path before overwritten",
+			statements: []string{`set(attributes["a"], "b")`, `set(body, attributes)`, `set(attributes["a"], "d")`},
+		},
+		{
+			name:       "set overwritten conditionally",
+			statements: []string{`set(attributes["a"], "b")`, `set(attributes["a"], "d") where name == "foo"`},
+		},
+		{
+			name:       "set modified by other editor",
+			statements: []string{`set(attributes["a"], "b")`, `merge_maps(attributes, body)`, `set(attributes["a"], "d")`},
+		},
+		{
+			name:       "cache key set",
+			statements: []string{`set(cache["a"], name)`, `set(name, cache["a"])`},
+		},
+		{
+			name:       "cache key never set",
+			statements: []string{`set(cache["a"], name)`, `set(name, cache["b"])`},
+			expected:   []LintIssue{{Index: 1, Severity: LintSeverityWarning, Rule: LintRuleUnsetCacheKey}},
+		},
+		{
+			name:       "cache map written",
+			statements: []string{`merge_maps(cache, attributes)`, `set(name, cache["b"])`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := lintTestParser(t).LintStatements(tt.statements)
+			require.Len(t, issues, len(tt.expected), "%v", issues)
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected.Index, issues[i].Index)
+				assert.Equal(t, tt.statements[expected.Index], issues[i].Statement)
+				assert.Equal(t, expected.Severity, issues[i].Severity)
+				assert.Equal(t, expected.Rule, issues[i].Rule)
+				assert.NotEmpty(t, issues[i].Message)
+			}
+		})
+	}
+}
+
+func Test_LintConditions(t *testing.T) {
+	issues := lintTestParser(t).LintConditions([]string{`name == "foo"`, `1 > 2`, `name ==`, `"a" == "a"`})
+	require.Len(t, issues, 3)
+	assert.Equal(t, 1, issues[0].Index)
+	assert.Equal(t, LintRuleConstantCondition, issues[0].Rule)
+	assert.Equal(t, LintSeverityWarning, issues[0].Severity)
+	assert.Equal(t, 2, issues[1].Index)
+	assert.Equal(t, LintRuleInvalidStatement, issues[1].Rule)
+	assert.Equal(t, LintSeverityError, issues[1].Severity)
+	assert.Equal(t, 3, issues[2].Index)
+	assert.Equal(t, LintRuleConstantCondition, issues[2].Rule)
+	assert.Equal(t, LintSeverityInfo, issues[2].Severity)
+}
+
+func Test_ParserCollection_LintStatements(t *testing.T) {
+	pc, err := NewParserCollection[any](
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("foo", lintTestParser(t, WithPathContextNames[any]([]string{"foo", "bar"})), newNopParsedStatementConverter[any]()),
+		WithParserCollectionContext("bar", lintTestParser(t, WithPathContextNames[any]([]string{"bar"})), newNopParsedStatementConverter[any]()),
+	)
+	require.NoError(t, err)
+	pc.contextInferrer = newPriorityContextInferrer(componenttest.NewNopTelemetrySettings(), map[string]*priorityContextInferrerCandidate{
+		"foo": {hasFunctionName: func(string) bool { return true }, hasEnumSymbol: func(*EnumSymbol) bool { return true }, getLowerContexts: func(string) []string { return nil }},
+		"bar": {hasFunctionName: func(string) bool { return true }, hasEnumSymbol: func(*EnumSymbol) bool { return true }, getLowerContexts: func(string) []string { return []string{"foo"} }},
+	}, withContextInferrerPriorities([]string{"foo", "bar"}))
+
+	t.Run("inferred context", func(t *testing.T) {
+		result := pc.LintStatements("", []string{`set(foo.name, "a") where 1 == 2`})
+		assert.Equal(t, "foo", result.Context)
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, LintRuleConstantCondition, result.Issues[0].Rule)
+		assert.Equal(t, `set(foo.name, "a") where 1 == 2`, result.Issues[0].Statement)
+		assert.False(t, result.HasErrors())
+	})
+
+	t.Run("configured context", func(t *testing.T) {
+		statements := []string{`set(name, "a")`, `set(name, "b")`}
+		result := pc.LintStatements("foo", statements)
+		assert.Equal(t, "foo", result.Context)
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, LintRuleShadowedSet, result.Issues[0].Rule)
+		assert.Equal(t, statements[0], result.Issues[0].Statement)
+	})
+
+	t.Run("configured context differs from inferred", func(t *testing.T) {
+		result := pc.LintStatements("foo", []string{`set(bar.name, "a")`})
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, -1, result.Issues[0].Index)
+		assert.Equal(t, LintRuleContextInference, result.Issues[0].Rule)
+		assert.Equal(t, LintSeverityWarning, result.Issues[0].Severity)
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		result := pc.LintStatements("baz", []string{`set(name, "a")`})
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, LintRuleContextInference, result.Issues[0].Rule)
+		assert.True(t, result.HasErrors())
+	})
+
+	t.Run("context not inferred", func(t *testing.T) {
+		result := pc.LintStatements("", []string{`set(name, "a")`})
+		assert.Empty(t, result.Context)
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, LintRuleContextInference, result.Issues[0].Rule)
+		assert.True(t, result.HasErrors())
+	})
+
+	t.Run("invalid syntax", func(t *testing.T) {
+		result := pc.LintStatements("", []string{`set(foo.name, "a"`})
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, 0, result.Issues[0].Index)
+		assert.Equal(t, LintRuleInvalidStatement, result.Issues[0].Rule)
+		assert.True(t, result.HasErrors())
+	})
+}
+
+func Test_LintIssue_String(t *testing.T) {
+	assert.Equal(t, `warning: statement 1 "set(name, 1)": message (rule)`, LintIssue{Index: 1, Statement: "set(name, 1)", Severity: LintSeverityWarning, Rule: "rule", Message: "message"}.String())
+	assert.Equal(t, `error: message (rule)`, LintIssue{Index: -1, Severity: LintSeverityError, Rule: "rule", Message: "message"}.String())
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseCEF(t *testing.T) {
+	target := ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return `CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 spt=1232 msg=Detected a threat`, nil
+		},
+	}
+	exprFunc := parseCEF[any](target)
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+
+	expected := map[string]any{
+		"version":               int64(0),
+		"device_vendor":         "Security",
+		"device_product":        "threatmanager",
+		"device_version":        "1.0",
+		"device_event_class_id": "100",
+		"name":                  "worm successfully stopped",
+		"severity":              int64(10),
+		"extensions": map[string]any{
+			"src": "10.0.0.1",
+			"spt": int64(1232),
+			"msg": "Detected a threat",
+		},
+	}
+	assert.Equal(t, expected, result.(pcommon.Map).AsRaw())
+}
+
+func Test_parseCEF_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		err    string
+	}{
+		{
+			name:   "empty target",
+			target: "",
+			err:    "cannot parse from empty target",
+		},
+		{
+			name:   "invalid event",
+			target: "CEF:0|a|b",
+			err:    `failed to parse CEF "CEF:0|a|b"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := parseCEF[any](target)
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_parseCEF_bad_target(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return 1, nil
+		},
+	}
+	exprFunc := parseCEF[any](target)
+	_, err := exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
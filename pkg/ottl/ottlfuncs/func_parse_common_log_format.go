@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseCommonLogFormatArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseCommonLogFormatFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseCommonLogFormat", &ParseCommonLogFormatArguments[K]{}, createParseCommonLogFormatFunction[K])
+}
+
+func createParseCommonLogFormatFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseCommonLogFormatArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseCommonLogFormatFactory args must be of type *ParseCommonLogFormatArguments[K]")
+	}
+
+	return parseCommonLogFormat(args.Target), nil
+}
+
+func parseCommonLogFormat[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if source == "" {
+			return nil, fmt.Errorf("cannot parse from empty target")
+		}
+
+		parsed, err := parseutils.ParseCommonLogFormat(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse common log format %q: %w", source, err)
+		}
+
+		result := pcommon.NewMap()
+		err = result.FromRaw(parsed)
+		return result, err
+	}
+}
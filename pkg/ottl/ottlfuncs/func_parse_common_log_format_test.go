@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseCommonLogFormat(t *testing.T) {
+	target := ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /index.html?a=b HTTP/1.1" 200 2326 "-" "curl/8.0"`, nil
+		},
+	}
+	exprFunc := parseCommonLogFormat[any](target)
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+
+	expected := map[string]any{
+		"client.address":            "127.0.0.1",
+		"user.name":                 "frank",
+		"timestamp":                 "10/Oct/2000:13:55:36 -0700",
+		"http.request.method":       "GET",
+		"url.path":                  "/index.html",
+		"url.query":                 "a=b",
+		"network.protocol.name":     "http",
+		"network.protocol.version":  "1.1",
+		"http.response.status_code": int64(200),
+		"http.response.body.size":   int64(2326),
+		"user_agent.original":       "curl/8.0",
+	}
+	assert.Equal(t, expected, result.(pcommon.Map).AsRaw())
+}
+
+func Test_parseCommonLogFormat_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		err    string
+	}{
+		{
+			name:   "empty target",
+			target: "",
+			err:    "cannot parse from empty target",
+		},
+		{
+			name:   "invalid event",
+			target: "127.0.0.1 - frank",
+			err:    `failed to parse common log format "127.0.0.1 - frank"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := parseCommonLogFormat[any](target)
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_parseCommonLogFormat_bad_target(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return 1, nil
+		},
+	}
+	exprFunc := parseCommonLogFormat[any](target)
+	_, err := exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
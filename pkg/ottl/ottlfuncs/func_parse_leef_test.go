@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseLEEF(t *testing.T) {
+	target := ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^sev=5", nil
+		},
+	}
+	exprFunc := parseLEEF[any](target)
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+
+	expected := map[string]any{
+		"version":         "2.0",
+		"vendor":          "Lancope",
+		"product":         "StealthWatch",
+		"product_version": "1.0",
+		"event_id":        "41",
+		"attributes": map[string]any{
+			"src": "10.0.1.8",
+			"sev": int64(5),
+		},
+	}
+	assert.Equal(t, expected, result.(pcommon.Map).AsRaw())
+}
+
+func Test_parseLEEF_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		err    string
+	}{
+		{
+			name:   "empty target",
+			target: "",
+			err:    "cannot parse from empty target",
+		},
+		{
+			name:   "invalid event",
+			target: "LEEF:1.0|a|b",
+			err:    `failed to parse LEEF "LEEF:1.0|a|b"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := parseLEEF[any](target)
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_parseLEEF_bad_target(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return 1, nil
+		},
+	}
+	exprFunc := parseLEEF[any](target)
+	_, err := exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseLogfmtArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseLogfmtFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseLogfmt", &ParseLogfmtArguments[K]{}, createParseLogfmtFunction[K])
+}
+
+func createParseLogfmtFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseLogfmtArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseLogfmtFactory args must be of type *ParseLogfmtArguments[K]")
+	}
+
+	return parseLogfmt(args.Target), nil
+}
+
+func parseLogfmt[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if source == "" {
+			return nil, fmt.Errorf("cannot parse from empty target")
+		}
+
+		parsed, err := parseutils.ParseLogfmt(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse logfmt %q: %w", source, err)
+		}
+
+		result := pcommon.NewMap()
+		err = result.FromRaw(parsed)
+		return result, err
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseLogfmt(t *testing.T) {
+	target := ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return `level=info msg="request handled" status=200 duration=0.25 cached`, nil
+		},
+	}
+	exprFunc := parseLogfmt[any](target)
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+
+	expected := map[string]any{
+		"level":    "info",
+		"msg":      "request handled",
+		"status":   int64(200),
+		"duration": 0.25,
+		"cached":   true,
+	}
+	assert.Equal(t, expected, result.(pcommon.Map).AsRaw())
+}
+
+func Test_parseLogfmt_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		err    string
+	}{
+		{
+			name:   "empty target",
+			target: "",
+			err:    "cannot parse from empty target",
+		},
+		{
+			name:   "invalid event",
+			target: `msg="unterminated`,
+			err:    "failed to parse logfmt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := parseLogfmt[any](target)
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_parseLogfmt_bad_target(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return 1, nil
+		},
+	}
+	exprFunc := parseLogfmt[any](target)
+	_, err := exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+}
@@ -71,9 +71,13 @@ func converters[K any]() []ottl.Factory[K] {
 		NewNanosecondFactory[K](),
 		NewNanosecondsFactory[K](),
 		NewNowFactory[K](),
+		NewParseCEFFactory[K](),
+		NewParseCommonLogFormatFactory[K](),
 		NewParseCSVFactory[K](),
 		NewParseJSONFactory[K](),
 		NewParseKeyValueFactory[K](),
+		NewParseLEEFFactory[K](),
+		NewParseLogfmtFactory[K](),
 		NewParseSimplifiedXMLFactory[K](),
 		NewParseXMLFactory[K](),
 		NewRemoveXMLFactory[K](),
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/participle/v2"
 	"go.opentelemetry.io/collector/component"
@@ -67,6 +68,12 @@ type Parser[K any] struct {
 	enumParser        EnumParser
 	telemetrySettings component.TelemetrySettings
 	pathContextNames  map[string]struct{}
+	// userFunction is the user function whose body is being parsed, if any.
+	userFunction *userFunction
+	// loopScopes are the scopes of the iteration constructs whose body is being parsed, innermost last.
+	loopScopes []*loopScope
+	// optionErr is the error of the options the Parser was configured with, returned by NewParser.
+	optionErr error
 }
 
 func NewParser[K any](
@@ -89,6 +96,9 @@ func NewParser[K any](
 	for _, opt := range options {
 		opt(&p)
 	}
+	if p.optionErr != nil {
+		return Parser[K]{}, p.optionErr
+	}
 	return p, nil
 }
 
@@ -322,6 +332,7 @@ type StatementSequence[K any] struct {
 	statements        []*Statement[K]
 	errorMode         ErrorMode
 	telemetrySettings component.TelemetrySettings
+	profiler          *sequenceProfiler
 }
 
 type StatementSequenceOption[K any] func(*StatementSequence[K])
@@ -354,8 +365,15 @@ func NewStatementSequence[K any](statements []*Statement[K], telemetrySettings c
 // When the ErrorMode of the StatementSequence is `silent`, errors are not logged and execution continues to the next statement.
 func (s *StatementSequence[K]) Execute(ctx context.Context, tCtx K) error {
 	s.telemetrySettings.Logger.Debug("initial TransformContext before executing StatementSequence", zap.Any("TransformContext", tCtx))
-	for _, statement := range s.statements {
-		_, _, err := statement.Execute(ctx, tCtx)
+	for i, statement := range s.statements {
+		var start time.Time
+		if s.profiler != nil {
+			start = time.Now()
+		}
+		_, matched, err := statement.Execute(ctx, tCtx)
+		if s.profiler != nil {
+			s.profiler.record(ctx, i, start, matched, err)
+		}
 		if err != nil {
 			if s.errorMode == PropagateError {
 				err = fmt.Errorf("failed to execute statement: %v, %w", statement.origText, err)
@@ -377,6 +395,7 @@ type ConditionSequence[K any] struct {
 	errorMode         ErrorMode
 	telemetrySettings component.TelemetrySettings
 	logicOp           LogicOperation
+	profiler          *sequenceProfiler
 }
 
 type ConditionSequenceOption[K any] func(*ConditionSequence[K])
@@ -423,8 +442,15 @@ func NewConditionSequence[K any](conditions []*Condition[K], telemetrySettings c
 // When using the AND LogicOperation with the `ignore` ErrorMode the sequence will evaluate to false if all conditions error.
 func (c *ConditionSequence[K]) Eval(ctx context.Context, tCtx K) (bool, error) {
 	var atLeastOneMatch bool
-	for _, condition := range c.conditions {
+	for i, condition := range c.conditions {
+		var start time.Time
+		if c.profiler != nil {
+			start = time.Now()
+		}
 		match, err := condition.Eval(ctx, tCtx)
+		if c.profiler != nil {
+			c.profiler.record(ctx, i, start, match, err)
+		}
 		c.telemetrySettings.Logger.Debug("condition evaluation result", zap.String("condition", condition.origText), zap.Bool("match", match), zap.Any("TransformContext", tCtx))
 		if err != nil {
 			if c.errorMode == PropagateError {
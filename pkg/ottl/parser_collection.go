@@ -55,12 +55,14 @@ func NewStatementsGetter(statements []string) StatementsGetter {
 type ottlParserWrapper struct {
 	parser                         reflect.Value
 	prependContextToStatementPaths func(context string, statement string) (string, error)
+	lintStatements                 func(statements []string) []LintIssue
 }
 
 func newParserWrapper[K any](parser *Parser[K]) *ottlParserWrapper {
 	return &ottlParserWrapper{
 		parser:                         reflect.ValueOf(parser),
 		prependContextToStatementPaths: parser.prependContextToStatementPaths,
+		lintStatements:                 parser.LintStatements,
 	}
 }
 
@@ -138,6 +140,7 @@ type ParserCollection[R any] struct {
 	modifiedStatementLogging  bool
 	Settings                  component.TelemetrySettings
 	ErrorMode                 ErrorMode
+	Profiling                 bool
 }
 
 // ParserCollectionOption is a configurable ParserCollection option.
@@ -253,6 +256,18 @@ func WithParserCollectionErrorMode[R any](errorMode ErrorMode) ParserCollectionO
 	}
 }
 
+// WithParserCollectionProfiling has no effect on the ParserCollection, but might be used
+// by the ParsedStatementConverter functions to enable the execution telemetry of the
+// created StatementSequence, see WithStatementSequenceProfiling.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func WithParserCollectionProfiling[R any](enabled bool) ParserCollectionOption[R] {
+	return func(tp *ParserCollection[R]) error {
+		tp.Profiling = enabled
+		return nil
+	}
+}
+
 // EnableParserCollectionModifiedStatementLogging controls the statements modification logs.
 // When enabled, it logs any statements modifications performed by the parsing operations,
 // instructing users to rewrite the statements accordingly.
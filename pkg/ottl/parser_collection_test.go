@@ -133,6 +133,16 @@ func Test_WithParserCollectionErrorMode(t *testing.T) {
 	require.Equal(t, PropagateError, pc.ErrorMode)
 }
 
+func Test_WithParserCollectionProfiling(t *testing.T) {
+	pc, err := NewParserCollection[any](
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionProfiling[any](true),
+	)
+
+	require.NoError(t, err)
+	require.True(t, pc.Profiling)
+}
+
 func Test_EnableParserCollectionModifiedStatementLogging_True(t *testing.T) {
 	ps := mockParser(t, WithPathContextNames[any]([]string{"dummy"}))
 	core, observedLogs := observer.New(zap.InfoLevel)
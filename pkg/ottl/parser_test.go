@@ -1560,6 +1560,22 @@ func Test_parseCondition_full(t *testing.T) {
 	}
 }
 
+func Test_parseStatement_loopVariableShadowingPath(t *testing.T) {
+	ps, err := NewParser(
+		defaultFunctionsForTests(),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[any](testParseEnum),
+	)
+	require.NoError(t, err)
+
+	_, err = ps.ParseStatement(`for_each(attributes, name, testing_getsetter(name))`)
+	assert.ErrorContains(t, err, `invalid loop variable in call to "for_each": "name" shadows the path of the same name`)
+
+	_, err = ps.ParseStatement(`for_each(attributes, item, testing_getsetter(item))`)
+	assert.NoError(t, err)
+}
+
 func testParsePath[K any](p Path[K]) (GetSetter[any], error) {
 	if p != nil && (p.Name() == "name" || p.Name() == "attributes") {
 		if p.Name() == "attributes" {
@@ -2983,6 +2999,20 @@ func Test_prependContextToStatementPaths_Success(t *testing.T) {
 			pathContextNames: []string{"log", "resource"},
 			expected:         `set(log.attributes["test"], "pass") where IsMatch(resource.name, "operation[AC]")`,
 		},
+		{
+			name:             "iteration construct loop variables",
+			statement:        `for_each(attributes, key, value, set(value, Concat([key, name], ""))) where Len(Filter(attributes, item, IsMatch(item, "a"))) > 0`,
+			context:          "log",
+			pathContextNames: []string{"log"},
+			expected:         `for_each(log.attributes, key, value, set(value, Concat([key, log.name], ""))) where Len(Filter(log.attributes, item, IsMatch(item, "a"))) > 0`,
+		},
+		{
+			name:             "loop variable with the name of a path used outside of the loop",
+			statement:        `set(name, Concat(Map(attributes["list"], name, ToUpperCase(name)), "")) where name != nil`,
+			context:          "log",
+			pathContextNames: []string{"log"},
+			expected:         `set(log.name, Concat(Map(log.attributes["list"], name, ToUpperCase(name)), "")) where log.name != nil`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -19,15 +19,20 @@ func (v *grammarPathVisitor) visitPath(value *path) {
 
 func getParsedStatementPaths(ps *parsedStatement) []path {
 	visitor := &grammarPathVisitor{}
+	loopVariables := &grammarLoopVariablesVisitor{}
 	ps.Editor.accept(visitor)
+	ps.Editor.accept(loopVariables)
 	if ps.WhereClause != nil {
 		ps.WhereClause.accept(visitor)
+		ps.WhereClause.accept(loopVariables)
 	}
-	return visitor.paths
+	return withoutLoopVariables(visitor.paths, loopVariables)
 }
 
 func getBooleanExpressionPaths(be *booleanExpression) []path {
 	visitor := &grammarPathVisitor{}
+	loopVariables := &grammarLoopVariablesVisitor{}
 	be.accept(visitor)
-	return visitor.paths
+	be.accept(loopVariables)
+	return withoutLoopVariables(visitor.paths, loopVariables)
 }
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/metadata"
+)
+
+const (
+	// AttributeStatementIndex is the attribute holding the index of a statement within its StatementSequence.
+	AttributeStatementIndex = "ottl.statement.index"
+	// AttributeStatement is the attribute holding the text of a statement.
+	AttributeStatement = "ottl.statement"
+	// AttributeConditionIndex is the attribute holding the index of a condition within its ConditionSequence.
+	AttributeConditionIndex = "ottl.condition.index"
+	// AttributeCondition is the attribute holding the text of a condition.
+	AttributeCondition = "ottl.condition"
+	// AttributeContext is the attribute holding the name of the context of a statement or condition,
+	// which components can add to tell apart the sequences of different contexts.
+	AttributeContext = "ottl.context"
+)
+
+// sequenceProfiler records the execution telemetry of the statements or conditions of a sequence.
+type sequenceProfiler struct {
+	executions metric.Int64Counter
+	matches    metric.Int64Counter
+	errors     metric.Int64Counter
+	duration   metric.Float64Counter
+	// attributes holds the measurement options of each statement or condition, by index,
+	// so recording a measurement does not allocate.
+	attributes []metric.MeasurementOption
+}
+
+func newStatementSequenceProfiler[K any](set component.TelemetrySettings, statements []*Statement[K], attrs []attribute.KeyValue) (*sequenceProfiler, error) {
+	tb, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	texts := make([]string, len(statements))
+	for i, statement := range statements {
+		texts[i] = statement.origText
+	}
+	return &sequenceProfiler{
+		executions: tb.OttlStatementExecutions,
+		matches:    tb.OttlStatementMatches,
+		errors:     tb.OttlStatementErrors,
+		duration:   tb.OttlStatementDuration,
+		attributes: profilerAttributes(AttributeStatementIndex, AttributeStatement, texts, attrs),
+	}, nil
+}
+
+func newConditionSequenceProfiler[K any](set component.TelemetrySettings, conditions []*Condition[K], attrs []attribute.KeyValue) (*sequenceProfiler, error) {
+	tb, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	texts := make([]string, len(conditions))
+	for i, condition := range conditions {
+		texts[i] = condition.origText
+	}
+	return &sequenceProfiler{
+		executions: tb.OttlConditionEvaluations,
+		matches:    tb.OttlConditionMatches,
+		errors:     tb.OttlConditionErrors,
+		duration:   tb.OttlConditionDuration,
+		attributes: profilerAttributes(AttributeConditionIndex, AttributeCondition, texts, attrs),
+	}, nil
+}
+
+func profilerAttributes(indexKey string, textKey string, texts []string, attrs []attribute.KeyValue) []metric.MeasurementOption {
+	options := make([]metric.MeasurementOption, len(texts))
+	for i, text := range texts {
+		kvs := make([]attribute.KeyValue, 0, len(attrs)+2)
+		kvs = append(kvs, attrs...)
+		kvs = append(kvs, attribute.Int(indexKey, i), attribute.String(textKey, text))
+		options[i] = metric.WithAttributeSet(attribute.NewSet(kvs...))
+	}
+	return options
+}
+
+// record records a single execution of the statement or condition at the given index, which started at the given time.
+func (p *sequenceProfiler) record(ctx context.Context, index int, start time.Time, matched bool, err error) {
+	elapsed := time.Since(start).Seconds()
+	attrs := p.attributes[index]
+	p.executions.Add(ctx, 1, attrs)
+	if matched {
+		p.matches.Add(ctx, 1, attrs)
+	}
+	if err != nil {
+		p.errors.Add(ctx, 1, attrs)
+	}
+	p.duration.Add(ctx, elapsed, attrs)
+}
+
+// WithStatementSequenceProfiling enables the execution telemetry of a StatementSequence. For each statement,
+// the number of executions, of executions whose condition matched, of errors, and the cumulative execution
+// time are recorded as metrics of the StatementSequence component.TelemetrySettings, with the
+// AttributeStatementIndex and AttributeStatement attributes.
+// The given attributes are added to all measurements, allowing to tell apart the statements of different sequences.
+//
+// Experimental: *NOTE* this option is subject to change or removal in the future.
+func WithStatementSequenceProfiling[K any](attrs ...attribute.KeyValue) StatementSequenceOption[K] {
+	return func(s *StatementSequence[K]) {
+		profiler, err := newStatementSequenceProfiler(s.telemetrySettings, s.statements, attrs)
+		if err != nil {
+			s.telemetrySettings.Logger.Warn("failed to create the statements telemetry, profiling is disabled", zap.Error(err))
+			return
+		}
+		s.profiler = profiler
+	}
+}
+
+// WithConditionSequenceProfiling enables the evaluation telemetry of a ConditionSequence. For each condition,
+// the number of evaluations, of evaluations that returned true, of errors, and the cumulative evaluation
+// time are recorded as metrics of the ConditionSequence component.TelemetrySettings, with the
+// AttributeConditionIndex and AttributeCondition attributes.
+// Conditions that are not evaluated because the result of the sequence is already known are not recorded.
+// The given attributes are added to all measurements, allowing to tell apart the conditions of different sequences.
+//
+// Experimental: *NOTE* this option is subject to change or removal in the future.
+func WithConditionSequenceProfiling[K any](attrs ...attribute.KeyValue) ConditionSequenceOption[K] {
+	return func(c *ConditionSequence[K]) {
+		profiler, err := newConditionSequenceProfiler(c.telemetrySettings, c.conditions, attrs)
+		if err != nil {
+			c.telemetrySettings.Logger.Warn("failed to create the conditions telemetry, profiling is disabled", zap.Error(err))
+			return
+		}
+		c.profiler = profiler
+	}
+}
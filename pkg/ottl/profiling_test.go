@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/metadatatest"
+)
+
+func Test_StatementSequence_Profiling(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	set := tel.NewTelemetrySettings()
+
+	newStatement := func(text string, condition bool, err error) *Statement[any] {
+		return &Statement[any]{
+			condition: BoolExpr[any]{func(context.Context, any) (bool, error) {
+				return condition, nil
+			}},
+			function: Expr[any]{exprFunc: func(context.Context, any) (any, error) {
+				return nil, err
+			}},
+			origText:          text,
+			telemetrySettings: set,
+		}
+	}
+	statements := NewStatementSequence(
+		[]*Statement[any]{
+			newStatement(`set(attributes["a"], 1)`, true, nil),
+			newStatement(`set(attributes["b"], 2) where false`, false, nil),
+			newStatement(`set(attributes["c"], 3)`, true, errors.New("failed")),
+		},
+		set,
+		WithStatementSequenceErrorMode[any](IgnoreError),
+		WithStatementSequenceProfiling[any](attribute.String("sequence", "test")),
+	)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, statements.Execute(context.Background(), nil))
+	}
+
+	attrs := func(index int, text string) attribute.Set {
+		return attribute.NewSet(
+			attribute.String("sequence", "test"),
+			attribute.Int(AttributeStatementIndex, index),
+			attribute.String(AttributeStatement, text),
+		)
+	}
+	metadatatest.AssertEqualOttlStatementExecutions(t, tel, []metricdata.DataPoint[int64]{
+		{Value: 2, Attributes: attrs(0, `set(attributes["a"], 1)`)},
+		{Value: 2, Attributes: attrs(1, `set(attributes["b"], 2) where false`)},
+		{Value: 2, Attributes: attrs(2, `set(attributes["c"], 3)`)},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOttlStatementMatches(t, tel, []metricdata.DataPoint[int64]{
+		{Value: 2, Attributes: attrs(0, `set(attributes["a"], 1)`)},
+		{Value: 2, Attributes: attrs(2, `set(attributes["c"], 3)`)},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOttlStatementErrors(t, tel, []metricdata.DataPoint[int64]{
+		{Value: 2, Attributes: attrs(2, `set(attributes["c"], 3)`)},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOttlStatementDuration(t, tel, []metricdata.DataPoint[float64]{
+		{Attributes: attrs(0, `set(attributes["a"], 1)`)},
+		{Attributes: attrs(1, `set(attributes["b"], 2) where false`)},
+		{Attributes: attrs(2, `set(attributes["c"], 3)`)},
+	}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+}
+
+func Test_StatementSequence_NoProfiling(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	statements := NewStatementSequence(
+		[]*Statement[any]{
+			{
+				condition:         BoolExpr[any]{alwaysTrue[any]},
+				function:          Expr[any]{exprFunc: func(context.Context, any) (any, error) { return nil, nil }},
+				telemetrySettings: tel.NewTelemetrySettings(),
+			},
+		},
+		tel.NewTelemetrySettings(),
+	)
+	require.NoError(t, statements.Execute(context.Background(), nil))
+
+	_, err := tel.GetMetric("otelcol_ottl_statement_executions")
+	assert.Error(t, err)
+}
+
+func Test_ConditionSequence_Profiling(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	set := tel.NewTelemetrySettings()
+
+	newCondition := func(text string, match bool, err error) *Condition[any] {
+		return &Condition[any]{
+			condition: BoolExpr[any]{func(context.Context, any) (bool, error) {
+				return match, err
+			}},
+			origText: text,
+		}
+	}
+	conditions := NewConditionSequence(
+		[]*Condition[any]{
+			newCondition(`attributes["a"] == nil`, false, errors.New("failed")),
+			newCondition(`attributes["b"] == 1`, true, nil),
+			newCondition(`attributes["c"] == 2`, true, nil),
+		},
+		set,
+		WithConditionSequenceErrorMode[any](IgnoreError),
+		WithConditionSequenceProfiling[any](),
+	)
+
+	match, err := conditions.Eval(context.Background(), nil)
+	require.NoError(t, err)
+	assert.True(t, match)
+
+	attrs := func(index int, text string) attribute.Set {
+		return attribute.NewSet(
+			attribute.Int(AttributeConditionIndex, index),
+			attribute.String(AttributeCondition, text),
+		)
+	}
+	// the third condition is not evaluated, as the second one matched
+	metadatatest.AssertEqualOttlConditionEvaluations(t, tel, []metricdata.DataPoint[int64]{
+		{Value: 1, Attributes: attrs(0, `attributes["a"] == nil`)},
+		{Value: 1, Attributes: attrs(1, `attributes["b"] == 1`)},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOttlConditionMatches(t, tel, []metricdata.DataPoint[int64]{
+		{Value: 1, Attributes: attrs(1, `attributes["b"] == 1`)},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOttlConditionErrors(t, tel, []metricdata.DataPoint[int64]{
+		{Value: 1, Attributes: attrs(0, `attributes["a"] == nil`)},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOttlConditionDuration(t, tel, []metricdata.DataPoint[float64]{
+		{Attributes: attrs(0, `attributes["a"] == nil`)},
+		{Attributes: attrs(1, `attributes["b"] == 1`)},
+	}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+}
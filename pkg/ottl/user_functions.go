@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/iancoleman/strcase"
+)
+
+var (
+	editorNameRegexp    = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+	converterNameRegexp = regexp.MustCompile(`^[A-Z][a-zA-Z0-9_]*$`)
+	paramNameRegexp     = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+	reservedParamNames = map[string]struct{}{
+		"nil": {}, "true": {}, "false": {}, "not": {}, "and": {}, "or": {}, "where": {},
+	}
+)
+
+// UserFunctionConfig declares a named, parameterized function composed of other OTTL functions,
+// that statements can call like any built-in function.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type UserFunctionConfig struct {
+	// Name is the name statements call the function by. Editors must start with a lowercase letter
+	// and converters with an uppercase letter.
+	Name string `mapstructure:"name"`
+	// Params are the names of the function parameters. The function body refers to a parameter by
+	// its bare name, e.g. `key` rather than `log.attributes["key"]`.
+	Params []string `mapstructure:"params"`
+	// Statements are the statements an editor executes, in order. Only valid for editors.
+	Statements []string `mapstructure:"statements"`
+	// Expression is the value expression a converter returns. Only valid for converters.
+	Expression string `mapstructure:"expression"`
+}
+
+// UserFunctions holds a set of validated user function declarations. It does not depend on any
+// OTTL context, so the same UserFunctions can be shared by the parsers of all the contexts of a
+// component, see WithUserFunctions.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type UserFunctions struct {
+	functions map[string]*userFunction
+}
+
+type userFunction struct {
+	UserFunctionConfig
+	converter bool
+	// params maps the name of every parameter to its position.
+	params map[string]int
+}
+
+// NewUserFunctions validates the given declarations and returns them as UserFunctions.
+// A user function may call built-in functions and other user functions, but not itself,
+// directly or through other user functions.
+//
+// The declarations are only validated syntactically, as the functions and paths they use depend
+// on the context of the statements calling them. These are validated when parsing the calling
+// statements.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func NewUserFunctions(configs []UserFunctionConfig) (*UserFunctions, error) {
+	uf := &UserFunctions{functions: make(map[string]*userFunction, len(configs))}
+	calls := make(map[string][]string, len(configs))
+
+	var errs []error
+	for _, cfg := range configs {
+		fn, called, err := newUserFunction(cfg)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid user function %q: %w", cfg.Name, err))
+			continue
+		}
+		if _, ok := uf.functions[cfg.Name]; ok {
+			errs = append(errs, fmt.Errorf("user function %q is declared more than once", cfg.Name))
+			continue
+		}
+		uf.functions[cfg.Name] = fn
+		calls[cfg.Name] = called
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	for _, cfg := range configs {
+		if cycle := findUserFunctionCycle(cfg.Name, calls, nil); cycle != nil {
+			return nil, fmt.Errorf("user function %q calls itself: %v", cfg.Name, cycle)
+		}
+	}
+	return uf, nil
+}
+
+func newUserFunction(cfg UserFunctionConfig) (*userFunction, []string, error) {
+	fn := &userFunction{
+		UserFunctionConfig: cfg,
+		params:             make(map[string]int, len(cfg.Params)),
+	}
+
+	switch {
+	case editorNameRegexp.MatchString(cfg.Name):
+		if len(cfg.Statements) == 0 || cfg.Expression != "" {
+			return nil, nil, errors.New("editors must declare statements and no expression")
+		}
+	case converterNameRegexp.MatchString(cfg.Name):
+		if cfg.Expression == "" || len(cfg.Statements) > 0 {
+			return nil, nil, errors.New("converters must declare an expression and no statements")
+		}
+		fn.converter = true
+	default:
+		return nil, nil, errors.New("name must start with a letter and only contain letters, digits and underscores")
+	}
+
+	fields := make(map[string]string, len(cfg.Params))
+	for i, param := range cfg.Params {
+		if _, ok := reservedParamNames[param]; ok || !paramNameRegexp.MatchString(param) {
+			return nil, nil, fmt.Errorf("invalid parameter name %q, it must start with a lowercase letter and only contain lowercase letters, digits and underscores", param)
+		}
+		// named arguments are matched against the camel case parameter name
+		field := strcase.ToCamel(param)
+		if other, ok := fields[field]; ok {
+			return nil, nil, fmt.Errorf("parameter %q conflicts with parameter %q", param, other)
+		}
+		fields[field] = param
+		fn.params[param] = i
+	}
+
+	visitor := &grammarFunctionNamesVisitor{}
+	if fn.converter {
+		parsed, err := parseValueExpression(cfg.Expression)
+		if err != nil {
+			return nil, nil, err
+		}
+		parsed.accept(visitor)
+	}
+	for _, statement := range cfg.Statements {
+		parsed, err := parseStatement(statement)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse OTTL statement %q: %w", statement, err)
+		}
+		parsed.Editor.accept(visitor)
+		if parsed.WhereClause != nil {
+			parsed.WhereClause.accept(visitor)
+		}
+	}
+	return fn, visitor.names, nil
+}
+
+// findUserFunctionCycle returns the chain of calls leading from name back to a function
+// of the path, or nil if there is none.
+func findUserFunctionCycle(name string, calls map[string][]string, path []string) []string {
+	for i, seen := range path {
+		if seen == name {
+			return append(path[i:], name)
+		}
+	}
+	path = append(path, name)
+	for _, called := range calls[name] {
+		if cycle := findUserFunctionCycle(called, calls, path); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// WithUserFunctions makes the given user functions callable from the statements, conditions and
+// value expressions of the Parser, like its built-in functions. A user function can't have the name
+// of a built-in function of the Parser, NewParser returns an error if it does.
+//
+// Experimental: *NOTE* this option is subject to change or removal in the future.
+func WithUserFunctions[K any](functions *UserFunctions) Option[K] {
+	return func(p *Parser[K]) {
+		merged := make(map[string]Factory[K], len(p.functions)+len(functions.functions))
+		for name, f := range p.functions {
+			merged[name] = f
+		}
+		for name, fn := range functions.functions {
+			if _, ok := p.functions[name]; ok {
+				p.optionErr = errors.Join(p.optionErr, fmt.Errorf("user function %q conflicts with the built-in function of the same name", name))
+				continue
+			}
+			merged[name] = newUserFunctionFactory(p, fn)
+		}
+		p.functions = merged
+	}
+}
+
+// newUserFunctionFactory returns the Factory of a user function. The function body is parsed by
+// the given Parser, once for every statement calling the function, so that it is parsed with the
+// options the Parser ends up being configured with.
+func newUserFunctionFactory[K any](p *Parser[K], fn *userFunction) Factory[K] {
+	var args Arguments
+	if len(fn.Params) > 0 {
+		fields := make([]reflect.StructField, len(fn.Params))
+		for i, param := range fn.Params {
+			fields[i] = reflect.StructField{
+				Name: strcase.ToCamel(param),
+				Type: reflect.TypeFor[Getter[K]](),
+			}
+		}
+		args = reflect.New(reflect.StructOf(fields)).Interface()
+	}
+
+	return NewFactory(fn.Name, args, func(_ FunctionContext, args Arguments) (ExprFunc[K], error) {
+		getters := make([]Getter[K], len(fn.Params))
+		if args != nil {
+			argsVal := reflect.ValueOf(args).Elem()
+			for i := range getters {
+				getters[i] = argsVal.Field(i).Interface().(Getter[K])
+			}
+		}
+
+		body := *p
+		body.userFunction = fn
+
+		if fn.converter {
+			expr, err := body.ParseValueExpression(fn.Expression)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse user function %q: %w", fn.Name, err)
+			}
+			return func(ctx context.Context, tCtx K) (any, error) {
+				return expr.Eval(withUserFunctionFrame(ctx, fn, getters), tCtx)
+			}, nil
+		}
+
+		statements, err := body.ParseStatements(fn.Statements)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse user function %q: %w", fn.Name, err)
+		}
+		return func(ctx context.Context, tCtx K) (any, error) {
+			ctx = withUserFunctionFrame(ctx, fn, getters)
+			for _, statement := range statements {
+				if _, _, err := statement.Execute(ctx, tCtx); err != nil {
+					return nil, fmt.Errorf("failed to execute statement of user function %q: %v, %w", fn.Name, statement.origText, err)
+				}
+			}
+			return nil, nil
+		}, nil
+	})
+}
+
+// userFunctionFrameKey is the context key of the arguments of the user function being executed.
+type userFunctionFrameKey struct {
+	fn *userFunction
+}
+
+// userFunctionFrame holds the arguments of a user function call, and the context they are
+// evaluated with, which is the context of the calling statement.
+type userFunctionFrame[K any] struct {
+	ctx  context.Context
+	args []Getter[K]
+}
+
+func withUserFunctionFrame[K any](ctx context.Context, fn *userFunction, args []Getter[K]) context.Context {
+	return context.WithValue(ctx, userFunctionFrameKey{fn: fn}, &userFunctionFrame[K]{ctx: ctx, args: args})
+}
+
+// userFunctionParam refers to a parameter of a user function within its body. The argument of the
+// parameter is evaluated every time the parameter is used, and can be set if it is a path.
+type userFunctionParam[K any] struct {
+	fn    *userFunction
+	name  string
+	index int
+}
+
+func (p *userFunctionParam[K]) frame(ctx context.Context) (*userFunctionFrame[K], error) {
+	frame, ok := ctx.Value(userFunctionFrameKey{fn: p.fn}).(*userFunctionFrame[K])
+	if !ok {
+		return nil, fmt.Errorf("parameter %q of user function %q used outside of the function", p.name, p.fn.Name)
+	}
+	return frame, nil
+}
+
+func (p *userFunctionParam[K]) Get(ctx context.Context, tCtx K) (any, error) {
+	frame, err := p.frame(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return frame.args[p.index].Get(frame.ctx, tCtx)
+}
+
+func (p *userFunctionParam[K]) Set(ctx context.Context, tCtx K, val any) error {
+	frame, err := p.frame(ctx)
+	if err != nil {
+		return err
+	}
+	setter, ok := frame.args[p.index].(Setter[K])
+	if !ok {
+		return fmt.Errorf("parameter %q of user function %q can't be set, its argument is not a path", p.name, p.fn.Name)
+	}
+	return setter.Set(frame.ctx, tCtx, val)
+}
+
+// userFunctionParamGetSetter returns the parameter the path refers to if the Parser is parsing the
+// body of a user function, and the path is the bare name of one of its parameters.
+func (p *Parser[K]) userFunctionParamGetSetter(path *path) (GetSetter[K], bool) {
+	if p.userFunction == nil || path.Context != "" || len(path.Fields) != 1 || len(path.Fields[0].Keys) > 0 {
+		return nil, false
+	}
+	index, ok := p.userFunction.params[path.Fields[0].Name]
+	if !ok {
+		return nil, false
+	}
+	return &userFunctionParam[K]{fn: p.userFunction, name: path.Fields[0].Name, index: index}, true
+}
+
+// grammarFunctionNamesVisitor collects the names of the editors and converters called by a statement.
+type grammarFunctionNamesVisitor struct {
+	names []string
+}
+
+func (v *grammarFunctionNamesVisitor) visitPath(_ *path)                       {}
+func (v *grammarFunctionNamesVisitor) visitValue(_ *value)                     {}
+func (v *grammarFunctionNamesVisitor) visitMathExprLiteral(_ *mathExprLiteral) {}
+
+func (v *grammarFunctionNamesVisitor) visitEditor(e *editor) {
+	v.names = append(v.names, e.Function)
+}
+
+func (v *grammarFunctionNamesVisitor) visitConverter(c *converter) {
+	v.names = append(v.names, c.Function)
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func Test_NewUserFunctions(t *testing.T) {
+	tests := []struct {
+		name      string
+		functions []UserFunctionConfig
+		wantErr   string
+	}{
+		{
+			name: "valid editor and converter",
+			functions: []UserFunctionConfig{
+				{Name: "rename", Params: []string{"from", "to"}, Statements: []string{`set(attributes[to], attributes[from])`, `delete_key(attributes, from)`}},
+				{Name: "Greeting", Params: []string{"name"}, Expression: `Concat(["hello", name], " ")`},
+				{Name: "Shout", Params: []string{"name"}, Expression: `ToUpperCase(Greeting(name))`},
+			},
+		},
+		{
+			name:      "invalid name",
+			functions: []UserFunctionConfig{{Name: "_rename", Statements: []string{`testing_noop()`}}},
+			wantErr:   "name must start with a letter",
+		},
+		{
+			name:      "editor with expression",
+			functions: []UserFunctionConfig{{Name: "rename", Expression: `"foo"`}},
+			wantErr:   "editors must declare statements and no expression",
+		},
+		{
+			name:      "converter with statements",
+			functions: []UserFunctionConfig{{Name: "Rename", Statements: []string{`testing_noop()`}}},
+			wantErr:   "converters must declare an expression and no statements",
+		},
+		{
+			name:      "reserved parameter name",
+			functions: []UserFunctionConfig{{Name: "Identity", Params: []string{"nil"}, Expression: `"foo"`}},
+			wantErr:   `invalid parameter name "nil"`,
+		},
+		{
+			name:      "uppercase parameter name",
+			functions: []UserFunctionConfig{{Name: "Identity", Params: []string{"Value"}, Expression: `"foo"`}},
+			wantErr:   `invalid parameter name "Value"`,
+		},
+		{
+			name:      "conflicting parameter names",
+			functions: []UserFunctionConfig{{Name: "Identity", Params: []string{"a_b", "a__b"}, Expression: `"foo"`}},
+			wantErr:   `parameter "a__b" conflicts with parameter "a_b"`,
+		},
+		{
+			name:      "invalid statement",
+			functions: []UserFunctionConfig{{Name: "rename", Statements: []string{`set(`}}},
+			wantErr:   "statement has invalid syntax",
+		},
+		{
+			name: "duplicate name",
+			functions: []UserFunctionConfig{
+				{Name: "Identity", Params: []string{"value"}, Expression: `value`},
+				{Name: "Identity", Params: []string{"value"}, Expression: `value`},
+			},
+			wantErr: `user function "Identity" is declared more than once`,
+		},
+		{
+			name: "recursive",
+			functions: []UserFunctionConfig{
+				{Name: "Ping", Expression: `Pong()`},
+				{Name: "Pong", Expression: `Concat([Ping()], "")`},
+			},
+			wantErr: `user function "Ping" calls itself: [Ping Pong Ping]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uf, err := NewUserFunctions(tt.functions)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, uf.functions, len(tt.functions))
+		})
+	}
+}
+
+func Test_WithUserFunctions_conflictingName(t *testing.T) {
+	uf, err := NewUserFunctions([]UserFunctionConfig{{Name: "testing_noop", Statements: []string{`testing_string_slice(["a"])`}}})
+	require.NoError(t, err)
+
+	_, err = NewParser(defaultFunctionsForTests(), testParsePath[any], componenttest.NewNopTelemetrySettings(), WithUserFunctions[any](uf))
+	assert.ErrorContains(t, err, `user function "testing_noop" conflicts with the built-in function of the same name`)
+}
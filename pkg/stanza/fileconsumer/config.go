@@ -23,6 +23,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/ratelimit"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/scanner"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
@@ -88,10 +89,32 @@ type Config struct {
 	DeleteAfterRead         bool            `mapstructure:"delete_after_read,omitempty"`
 	IncludeFileRecordNumber bool            `mapstructure:"include_file_record_number,omitempty"`
 	Compression             string          `mapstructure:"compression,omitempty"`
-	PollsToArchive          int             `mapstructure:"-"` // TODO: activate this config once archiving is set up
+	RotationSets            bool            `mapstructure:"rotation_sets,omitempty"`
+	RateLimit               RateLimitConfig `mapstructure:"rate_limit,omitempty"`
+	PollsToArchive          int             `mapstructure:"polls_to_archive,omitempty"`
 	AcquireFSLock           bool            `mapstructure:"acquire_fs_lock,omitempty"`
 }
 
+// RateLimitConfig limits the rate at which each file, or each group of files, is read.
+// A file whose limit is reached is not read any further until the next poll.
+type RateLimitConfig struct {
+	BytesPerSecond   helper.ByteSize `mapstructure:"bytes_per_second,omitempty"`
+	EntriesPerSecond int64           `mapstructure:"entries_per_second,omitempty"`
+	GroupBy          string          `mapstructure:"group_by,omitempty"`
+}
+
+func (c RateLimitConfig) build() (*ratelimit.Registry, error) {
+	registry, err := ratelimit.NewRegistry(ratelimit.Config{
+		BytesPerSecond:   int64(c.BytesPerSecond),
+		EntriesPerSecond: c.EntriesPerSecond,
+		GroupBy:          c.GroupBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config for 'rate_limit': %w", err)
+	}
+	return registry, nil
+}
+
 type HeaderConfig struct {
 	Pattern           string            `mapstructure:"pattern"`
 	MetadataOperators []operator.Config `mapstructure:"metadata_operators"`
@@ -151,6 +174,11 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 		return nil, err
 	}
 
+	rateLimits, err := c.RateLimit.build()
+	if err != nil {
+		return nil, err
+	}
+
 	set.Logger = set.Logger.With(zap.String("component", "fileconsumer"))
 	readerFactory := reader.Factory{
 		TelemetrySettings:       set,
@@ -169,6 +197,7 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 		IncludeFileRecordNumber: c.IncludeFileRecordNumber,
 		Compression:             c.Compression,
 		AcquireFSLock:           c.AcquireFSLock,
+		RateLimits:              rateLimits,
 	}
 
 	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
@@ -184,6 +213,8 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 		maxBatches:       c.MaxBatches,
 		telemetryBuilder: telemetryBuilder,
 		noTracking:       o.noTracking,
+		rotationSets:     c.RotationSets,
+		pollsToArchive:   c.PollsToArchive,
 	}, nil
 }
 
@@ -208,11 +239,27 @@ func (c Config) validate() error {
 		return errors.New("'max_batches' must not be negative")
 	}
 
+	if c.PollsToArchive < 0 {
+		return errors.New("'polls_to_archive' must not be negative")
+	}
+
 	enc, err := textutils.LookupEncoding(c.Encoding)
 	if err != nil {
 		return err
 	}
 
+	if err := reader.ValidateCompression(c.Compression); err != nil {
+		return err
+	}
+
+	if c.RotationSets && c.Compression != reader.CompressionAuto {
+		return fmt.Errorf("'rotation_sets' requires 'compression: %s'", reader.CompressionAuto)
+	}
+
+	if _, err := c.RateLimit.build(); err != nil {
+		return err
+	}
+
 	if c.DeleteAfterRead {
 		if !allowFileDeletion.IsEnabled() {
 			return fmt.Errorf("'delete_after_read' requires feature gate '%s'", allowFileDeletion.ID())
@@ -420,6 +420,26 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "polls_to_archive",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.PollsToArchive = 100
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "rate_limit",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.RateLimit = RateLimitConfig{
+						BytesPerSecond:   1024 * 1024,
+						EntriesPerSecond: 1000,
+						GroupBy:          "/var/log/pods/([^/]+)/",
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "header_config",
 				Expect: func() *mockOperatorConfig {
@@ -524,6 +544,35 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"ValidCompression",
+			func(cfg *Config) {
+				cfg.Compression = "auto"
+				cfg.RotationSets = true
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, "auto", m.readerFactory.Compression)
+				require.True(t, m.rotationSets)
+			},
+		},
+		{
+			"RotationSetsWithoutAutoCompression",
+			func(cfg *Config) {
+				cfg.Compression = "gzip"
+				cfg.RotationSets = true
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"InvalidCompression",
+			func(cfg *Config) {
+				cfg.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"LineStartAndEnd",
 			func(cfg *Config) {
@@ -582,6 +631,52 @@ func TestBuild(t *testing.T) {
 				require.Equal(t, 6, m.maxBatches)
 			},
 		},
+		{
+			"ValidPollsToArchive",
+			func(cfg *Config) {
+				cfg.PollsToArchive = 100
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, 100, m.pollsToArchive)
+			},
+		},
+		{
+			"InvalidPollsToArchive",
+			func(cfg *Config) {
+				cfg.PollsToArchive = -1
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"ValidRateLimit",
+			func(cfg *Config) {
+				cfg.RateLimit.BytesPerSecond = 1024
+				cfg.RateLimit.GroupBy = "/var/log/pods/([^/]+)/"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.NotNil(t, m.readerFactory.RateLimits)
+			},
+		},
+		{
+			"InvalidRateLimit",
+			func(cfg *Config) {
+				cfg.RateLimit.EntriesPerSecond = -1
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"InvalidRateLimitGroupBy",
+			func(cfg *Config) {
+				cfg.RateLimit.EntriesPerSecond = 10
+				cfg.RateLimit.GroupBy = "("
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"HeaderConfigNoFlag",
 			func(cfg *Config) {
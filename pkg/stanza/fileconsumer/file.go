@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/rotation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/tracker"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -31,12 +33,15 @@ type Manager struct {
 	fileMatcher   *matcher.Matcher
 	tracker       tracker.Tracker
 	noTracking    bool
+	rotationSets  bool
 
 	pollInterval   time.Duration
 	persister      operator.Persister
 	maxBatches     int
 	maxBatchFiles  int
 	pollsToArchive int
+	// nextPath is the first path which could not be read during the last poll because of max_batches
+	nextPath string
 
 	telemetryBuilder *metadata.TelemetryBuilder
 }
@@ -122,6 +127,15 @@ func (m *Manager) poll(ctx context.Context) {
 	if err != nil {
 		m.set.Logger.Debug("finding files", zap.Error(err))
 	}
+	if m.rotationSets {
+		// read the files of each rotation set one after another, from the oldest one
+		matches = rotation.Order(matches)
+	}
+	if m.maxBatches != 0 {
+		// start with the files which could not be read during the last poll, so that all files get their turn
+		matches = startAt(matches, m.nextPath)
+		m.nextPath = ""
+	}
 	m.set.Logger.Debug("matched files", zap.Strings("paths", matches))
 
 	for len(matches) > m.maxBatchFiles {
@@ -131,6 +145,7 @@ func (m *Manager) poll(ctx context.Context) {
 		if m.maxBatches != 0 {
 			batchesProcessed++
 			if batchesProcessed >= m.maxBatches {
+				m.nextPath = matches[m.maxBatchFiles]
 				return
 			}
 		}
@@ -151,6 +166,18 @@ func (m *Manager) poll(ctx context.Context) {
 	}
 	// rotate at end of every poll()
 	m.tracker.EndPoll()
+	m.readerFactory.RateLimits.Prune()
+}
+
+// startAt rotates the paths so that they start with the given path. The paths are returned unchanged
+// if they do not contain the given path.
+func startAt(paths []string, path string) []string {
+	for i, p := range paths {
+		if p == path {
+			return slices.Concat(paths[i:], paths[:i])
+		}
+	}
+	return paths
 }
 
 func (m *Manager) consume(ctx context.Context, paths []string) {
@@ -161,20 +188,63 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 
 	// read new readers to end
 	var wg sync.WaitGroup
-	for _, r := range m.tracker.CurrentPollFiles() {
+	for _, readers := range m.readerGroups(m.tracker.CurrentPollFiles()) {
 		wg.Add(1)
-		go func(r *reader.Reader) {
+		go func(readers []*reader.Reader) {
 			defer wg.Done()
-			m.telemetryBuilder.FileconsumerReadingFiles.Add(ctx, 1)
-			r.ReadToEnd(ctx)
-			m.telemetryBuilder.FileconsumerReadingFiles.Add(ctx, -1)
-		}(r)
+			for _, r := range readers {
+				m.telemetryBuilder.FileconsumerReadingFiles.Add(ctx, 1)
+				r.ReadToEnd(ctx)
+				m.telemetryBuilder.FileconsumerReadingFiles.Add(ctx, -1)
+				if r.Throttled() {
+					m.telemetryBuilder.FileconsumerThrottledFiles.Add(ctx, 1)
+					// the next files of a rotation set must not be read before this one is done
+					break
+				}
+			}
+		}(readers)
 	}
 	wg.Wait()
 
 	m.telemetryBuilder.FileconsumerOpenFiles.Add(ctx, int64(0-m.tracker.EndConsume()))
 }
 
+// readerGroups returns the groups of readers that must be read one after another. When rotation sets
+// are enabled, the readers of the files of a rotation set are grouped in their rotation order,
+// otherwise all readers are read concurrently.
+func (m *Manager) readerGroups(readers []*reader.Reader) [][]*reader.Reader {
+	if !m.rotationSets {
+		groups := make([][]*reader.Reader, 0, len(readers))
+		for _, r := range readers {
+			groups = append(groups, []*reader.Reader{r})
+		}
+		return groups
+	}
+
+	// the readers are not necessarily tracked in the order of their paths, e.g. when some of them are new files
+	paths := make([]string, 0, len(readers))
+	readersByPath := make(map[string]*reader.Reader, len(readers))
+	for _, r := range readers {
+		paths = append(paths, r.GetFileName())
+		readersByPath[r.GetFileName()] = r
+	}
+
+	var groups [][]*reader.Reader
+	groupIndexes := make(map[string]int)
+	for _, path := range rotation.Order(paths) {
+		r := readersByPath[path]
+		key := rotation.Key(path)
+		i, ok := groupIndexes[key]
+		if !ok {
+			i = len(groups)
+			groupIndexes[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], r)
+	}
+	return groups
+}
+
 func (m *Manager) makeFingerprint(path string) (*fingerprint.Fingerprint, *os.File) {
 	file, err := os.Open(path) // #nosec - operator must read in files defined by user
 	if err != nil {
@@ -204,6 +274,8 @@ func (m *Manager) makeFingerprint(path string) (*fingerprint.Fingerprint, *os.Fi
 // discarding any that have a duplicate fingerprint to other files that have already
 // been read this polling interval
 func (m *Manager) makeReaders(ctx context.Context, paths []string) {
+	var unmatchedFiles []*os.File
+	var unmatchedFingerprints []*fingerprint.Fingerprint
 	for _, path := range paths {
 		fp, file := m.makeFingerprint(path)
 		if fp == nil {
@@ -212,13 +284,7 @@ func (m *Manager) makeReaders(ctx context.Context, paths []string) {
 
 		// Exclude duplicate paths with the same content. This can happen when files are
 		// being rotated with copy/truncate strategy. (After copy, prior to truncate.)
-		if r := m.tracker.GetCurrentFile(fp); r != nil {
-			m.set.Logger.Debug("Skipping duplicate file", zap.String("path", file.Name()))
-			// re-add the reader as Match() removes duplicates
-			m.tracker.Add(r)
-			if err := file.Close(); err != nil {
-				m.set.Logger.Debug("problem closing file", zap.Error(err))
-			}
+		if m.skipDuplicate(file, fp) {
 			continue
 		}
 
@@ -227,11 +293,68 @@ func (m *Manager) makeReaders(ctx context.Context, paths []string) {
 			m.set.Logger.Error("Failed to create reader", zap.Error(err))
 			continue
 		}
+		if r == nil {
+			// the file is not tracked anymore, or is a new file
+			unmatchedFiles = append(unmatchedFiles, file)
+			unmatchedFingerprints = append(unmatchedFingerprints, fp)
+			continue
+		}
 
 		m.tracker.Add(r)
 	}
+
+	if len(unmatchedFiles) > 0 {
+		m.processUnmatchedFiles(ctx, unmatchedFiles, unmatchedFingerprints)
+	}
 }
 
+// processUnmatchedFiles creates the readers of the files which did not match any recently tracked file.
+// Their offsets are looked up in the archive all at once, to read each archived fileset at most once.
+func (m *Manager) processUnmatchedFiles(ctx context.Context, files []*os.File, fps []*fingerprint.Fingerprint) {
+	archivedMetadata := m.tracker.FindFiles(fps)
+	for i, file := range files {
+		if m.skipDuplicate(file, fps[i]) {
+			continue
+		}
+
+		var r *reader.Reader
+		var err error
+		if i < len(archivedMetadata) && archivedMetadata[i] != nil {
+			m.set.Logger.Debug("Resuming file from the archive", zap.String("path", file.Name()))
+			r, err = m.readerFactory.NewReaderFromMetadata(file, archivedMetadata[i])
+			if err == nil {
+				m.telemetryBuilder.FileconsumerOpenFiles.Add(ctx, 1)
+			}
+		} else {
+			r, err = m.newReaderFromScratch(ctx, file, fps[i])
+		}
+		if err != nil {
+			m.set.Logger.Error("Failed to create reader", zap.Error(err))
+			continue
+		}
+
+		m.tracker.Add(r)
+	}
+}
+
+// skipDuplicate reports whether a file with the same fingerprint has already been found
+// during this poll, in which case the file is closed.
+func (m *Manager) skipDuplicate(file *os.File, fp *fingerprint.Fingerprint) bool {
+	r := m.tracker.GetCurrentFile(fp)
+	if r == nil {
+		return false
+	}
+	m.set.Logger.Debug("Skipping duplicate file", zap.String("path", file.Name()))
+	// re-add the reader as Match() removes duplicates
+	m.tracker.Add(r)
+	if err := file.Close(); err != nil {
+		m.set.Logger.Debug("problem closing file", zap.Error(err))
+	}
+	return true
+}
+
+// newReader creates a reader for a file which matches a file tracked during the last polls.
+// It returns nil if the file does not match any of them.
 func (m *Manager) newReader(ctx context.Context, file *os.File, fp *fingerprint.Fingerprint) (*reader.Reader, error) {
 	// Check previous poll cycle for match
 	if oldReader := m.tracker.GetOpenFile(fp); oldReader != nil {
@@ -260,17 +383,37 @@ func (m *Manager) newReader(ctx context.Context, file *os.File, fp *fingerprint.
 		m.telemetryBuilder.FileconsumerOpenFiles.Add(ctx, 1)
 		return r, nil
 	}
+	return nil, nil
+}
 
-	// If we don't match any previously known files, create a new reader from scratch
+// newReaderFromScratch creates a reader for a file which does not match any known file.
+func (m *Manager) newReaderFromScratch(ctx context.Context, file *os.File, fp *fingerprint.Fingerprint) (*reader.Reader, error) {
 	m.set.Logger.Info("Started watching file", zap.String("path", file.Name()))
 	r, err := m.readerFactory.NewReader(file, fp)
 	if err != nil {
 		return nil, err
 	}
+	if m.rotationSets && m.isCompressedCopy(file) {
+		// The file was compressed from a rotated file that has already been read. Its content
+		// must not be emitted again, but the compressed file must still be tracked.
+		m.set.Logger.Debug("Skipping compressed copy of a known file", zap.String("path", file.Name()))
+		info, err := file.Stat()
+		if err != nil {
+			r.Close()
+			return nil, fmt.Errorf("stat: %w", err)
+		}
+		r.Offset = info.Size()
+	}
 	m.telemetryBuilder.FileconsumerOpenFiles.Add(ctx, 1)
 	return r, nil
 }
 
+// isCompressedCopy reports whether the file is compressed and its decompressed content is the content of a known file.
+func (m *Manager) isCompressedCopy(file *os.File) bool {
+	contentFingerprint := m.readerFactory.NewContentFingerprint(file)
+	return contentFingerprint != nil && m.tracker.HasFile(contentFingerprint)
+}
+
 func (m *Manager) instantiateTracker(ctx context.Context, persister operator.Persister) {
 	var t tracker.Tracker
 	if m.noTracking {
@@ -57,6 +57,8 @@ OUTER:
 		m.set.Logger.Debug("Reading lost file", zap.String("path", lostReader.GetFileName()))
 		go func(r *reader.Reader) {
 			defer lostWG.Done()
+			// lost files are not read again, read them to the end to avoid losing logs
+			r.IgnoreRateLimit()
 			m.telemetryBuilder.FileconsumerReadingFiles.Add(ctx, 1)
 			r.ReadToEnd(ctx)
 			m.telemetryBuilder.FileconsumerReadingFiles.Add(ctx, -1)
@@ -17,11 +17,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/emittest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/metadatatest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/filetest"
@@ -1145,6 +1149,78 @@ func TestMaxBatching(t *testing.T) {
 	require.Len(t, actualTokens, numExpectedTokens)
 }
 
+// TestMaxBatchingFairness tests that files which could not be read during a poll because of
+// max_batches are read first during the next poll.
+func TestMaxBatchingFairness(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.MaxConcurrentFiles = 2
+	cfg.MaxBatches = 1
+	operator, sink := testManager(t, cfg)
+
+	expected := make([][]byte, 0, 4)
+	for i := 0; i < 4; i++ {
+		temp := filetest.OpenTemp(t, tempDir)
+		token := fmt.Sprintf("file%d", i)
+		filetest.WriteString(t, temp, token+"\n")
+		expected = append(expected, []byte(token))
+	}
+
+	// a single file is read during each poll, every file gets its turn
+	actualTokens := make([][]byte, 0, len(expected))
+	for range expected {
+		operator.poll(context.Background())
+		actualTokens = append(actualTokens, sink.NextToken(t))
+	}
+	require.ElementsMatch(t, expected, actualTokens)
+}
+
+// TestRateLimit tests that a file whose rate limit is reached does not prevent other files from
+// being read, and that it is read further during the next polls.
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.RateLimit.EntriesPerSecond = 5
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	sink := emittest.NewSink()
+	operator, err := cfg.Build(tel.NewTelemetrySettings(), sink.Callback)
+	require.NoError(t, err)
+	operator.persister = testutil.NewUnscopedMockPersister()
+	operator.instantiateTracker(context.Background(), operator.persister)
+	t.Cleanup(func() { operator.tracker.ClosePreviousFiles() })
+
+	hot := filetest.OpenTemp(t, tempDir)
+	hotTokens := make([][]byte, 0, 10)
+	for i := 0; i < 10; i++ {
+		token := fmt.Sprintf("hot%d", i)
+		filetest.WriteString(t, hot, token+"\n")
+		hotTokens = append(hotTokens, []byte(token))
+	}
+	quiet := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, quiet, "quiet\n")
+
+	operator.poll(context.Background())
+	sink.ExpectTokens(t, append([][]byte{[]byte("quiet")}, hotTokens[:5]...)...)
+	sink.ExpectNoCalls(t)
+	metadatatest.AssertEqualFileconsumerThrottledFiles(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+
+	// the hot file is read from where it was throttled once its limit is replenished
+	time.Sleep(time.Second)
+	operator.poll(context.Background())
+	sink.ExpectTokens(t, hotTokens[5:]...)
+	sink.ExpectNoCalls(t)
+}
+
 // TestReadExistingLogsWithHeader tests that, when starting from beginning, we
 // read all the lines that are already there, and parses the headers
 func TestReadExistingLogsWithHeader(t *testing.T) {
@@ -1580,3 +1656,41 @@ func TestReadGzipCompressedLogsFromEnd(t *testing.T) {
 	operator.poll(context.TODO())
 	sink.ExpectToken(t, []byte("testlog4"))
 }
+
+// TestArchive tests that a file which reappears after falling off the known files is resumed
+// from its archived offset instead of being read again from the beginning.
+func TestArchive(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Moving open files is not supported on Windows")
+	}
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	otherDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.PollsToArchive = 10
+	operator, sink := testManager(t, cfg)
+
+	temp := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp, "testlog1\n")
+
+	operator.poll(context.Background())
+	sink.ExpectToken(t, []byte("testlog1"))
+
+	// move the file out of the matching pattern for more polls than the number of known filesets
+	movedPath := filepath.Join(otherDir, filepath.Base(temp.Name()))
+	require.NoError(t, os.Rename(temp.Name(), movedPath))
+	for i := 0; i < 5; i++ {
+		operator.poll(context.Background())
+	}
+	sink.ExpectNoCalls(t)
+
+	// the file reappears with new content
+	require.NoError(t, os.Rename(movedPath, temp.Name()))
+	filetest.WriteString(t, temp, "testlog2\n")
+
+	operator.poll(context.Background())
+	sink.ExpectToken(t, []byte("testlog2"))
+	sink.ExpectNoCalls(t)
+}
@@ -23,11 +23,12 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                    metric.Meter
-	mu                       sync.Mutex
-	registrations            []metric.Registration
-	FileconsumerOpenFiles    metric.Int64UpDownCounter
-	FileconsumerReadingFiles metric.Int64UpDownCounter
+	meter                      metric.Meter
+	mu                         sync.Mutex
+	registrations              []metric.Registration
+	FileconsumerOpenFiles      metric.Int64UpDownCounter
+	FileconsumerReadingFiles   metric.Int64UpDownCounter
+	FileconsumerThrottledFiles metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -71,5 +72,11 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.FileconsumerThrottledFiles, err = builder.meter.Int64Counter(
+		"otelcol_fileconsumer_throttled_files",
+		metric.WithDescription("Number of times the reading of a file was paused because its rate limit was reached"),
+		metric.WithUnit("{files}"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -22,12 +22,16 @@ func TestSetupTelemetry(t *testing.T) {
 	defer tb.Shutdown()
 	tb.FileconsumerOpenFiles.Add(context.Background(), 1)
 	tb.FileconsumerReadingFiles.Add(context.Background(), 1)
+	tb.FileconsumerThrottledFiles.Add(context.Background(), 1)
 	AssertEqualFileconsumerOpenFiles(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
 	AssertEqualFileconsumerReadingFiles(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualFileconsumerThrottledFiles(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 
 	require.NoError(t, testTel.Shutdown(context.Background()))
 }
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit limits the rate at which the content of files is read.
+//
+// Each file, or each group of files sharing the same key, is given a token bucket of bytes and
+// a token bucket of entries. Both buckets are refilled at a constant rate and can hold up to one
+// second worth of tokens, which allows short bursts while keeping the average rate under the limit.
+package ratelimit // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/ratelimit"
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	// BytesPerSecond is the maximum number of bytes read per second, or 0 for no limit.
+	BytesPerSecond int64
+	// EntriesPerSecond is the maximum number of entries read per second, or 0 for no limit.
+	EntriesPerSecond int64
+	// GroupBy is a regular expression applied to the path of the files. Files whose paths
+	// have the same first capture group, or the same match when the expression has no
+	// capture group, share the same limit. Each file has its own limit when empty.
+	GroupBy string
+}
+
+// Enabled reports whether a limit is set.
+func (c Config) Enabled() bool {
+	return c.BytesPerSecond > 0 || c.EntriesPerSecond > 0
+}
+
+// Registry holds the limiters of the files being read.
+type Registry struct {
+	config   Config
+	groupBy  *regexp.Regexp
+	now      func() time.Time
+	mu       sync.Mutex
+	limiters map[string]*Limiter
+}
+
+// NewRegistry returns a registry of limiters, or nil if the config does not set any limit.
+func NewRegistry(c Config) (*Registry, error) {
+	if c.BytesPerSecond < 0 {
+		return nil, fmt.Errorf("'bytes_per_second' must not be negative")
+	}
+	if c.EntriesPerSecond < 0 {
+		return nil, fmt.Errorf("'entries_per_second' must not be negative")
+	}
+	var groupBy *regexp.Regexp
+	if c.GroupBy != "" {
+		var err error
+		if groupBy, err = regexp.Compile(c.GroupBy); err != nil {
+			return nil, fmt.Errorf("compile 'group_by' regex: %w", err)
+		}
+	}
+	if !c.Enabled() {
+		return nil, nil
+	}
+	return &Registry{
+		config:   c,
+		groupBy:  groupBy,
+		now:      time.Now,
+		limiters: make(map[string]*Limiter),
+	}, nil
+}
+
+// Key returns the key of the limiter of the file at the given path.
+func (r *Registry) Key(path string) string {
+	if r.groupBy == nil {
+		return path
+	}
+	match := r.groupBy.FindStringSubmatch(path)
+	switch {
+	case match == nil:
+		return path
+	case len(match) > 1:
+		return match[1]
+	default:
+		return match[0]
+	}
+}
+
+// Get returns the limiter of the file at the given path. It returns nil if the registry is nil.
+func (r *Registry) Get(path string) *Limiter {
+	if r == nil {
+		return nil
+	}
+	key := r.Key(path)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.limiters[key]
+	if !ok {
+		now := r.now()
+		l = &Limiter{
+			now:     r.now,
+			bytes:   newBucket(r.config.BytesPerSecond, now),
+			entries: newBucket(r.config.EntriesPerSecond, now),
+		}
+		r.limiters[key] = l
+	}
+	return l
+}
+
+// Prune forgets the limiters whose buckets are full. Such limiters behave exactly like new
+// ones, so forgetting them only keeps the registry from growing with the files it has seen.
+func (r *Registry) Prune() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	for key, l := range r.limiters {
+		if l.idle(now) {
+			delete(r.limiters, key)
+		}
+	}
+}
+
+// Limiter limits the rate at which one or more files are read. It is safe for concurrent use.
+type Limiter struct {
+	now     func() time.Time
+	mu      sync.Mutex
+	bytes   *bucket
+	entries *bucket
+}
+
+// Allow reports whether an entry of the given size can be read now, in which case
+// the entry and its bytes are taken from the limits.
+func (l *Limiter) Allow(size int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.bytes.refill(now)
+	l.entries.refill(now)
+	if !l.bytes.has(float64(size)) || !l.entries.has(1) {
+		return false
+	}
+	l.bytes.take(float64(size))
+	l.entries.take(1)
+	return true
+}
+
+func (l *Limiter) idle(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bytes.refill(now)
+	l.entries.refill(now)
+	return l.bytes.full() && l.entries.full()
+}
+
+// bucket is a token bucket which holds up to one second worth of tokens.
+// A nil bucket has no limit.
+type bucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate int64, now time.Time) *bucket {
+	if rate <= 0 {
+		return nil
+	}
+	return &bucket{rate: float64(rate), tokens: float64(rate), last: now}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if b == nil || !now.After(b.last) {
+		return
+	}
+	b.tokens = min(b.rate, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// has reports whether n tokens are available. A request larger than the capacity
+// of the bucket is allowed once the bucket is full, so that it is never blocked forever.
+func (b *bucket) has(n float64) bool {
+	return b == nil || b.tokens >= min(n, b.rate)
+}
+
+func (b *bucket) take(n float64) {
+	if b != nil {
+		b.tokens -= n
+	}
+}
+
+func (b *bucket) full() bool {
+	return b == nil || b.tokens >= b.rate
+}
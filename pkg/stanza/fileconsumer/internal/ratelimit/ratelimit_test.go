@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T, c Config) (*Registry, *time.Time) {
+	r, err := NewRegistry(c)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	return r, &now
+}
+
+func TestNewRegistry(t *testing.T) {
+	r, err := NewRegistry(Config{})
+	require.NoError(t, err)
+	assert.Nil(t, r)
+	assert.Nil(t, r.Get("/var/log/app.log"))
+	r.Prune()
+
+	_, err = NewRegistry(Config{BytesPerSecond: -1})
+	assert.ErrorContains(t, err, "'bytes_per_second' must not be negative")
+	_, err = NewRegistry(Config{EntriesPerSecond: -1})
+	assert.ErrorContains(t, err, "'entries_per_second' must not be negative")
+	_, err = NewRegistry(Config{BytesPerSecond: 1, GroupBy: "("})
+	assert.ErrorContains(t, err, "compile 'group_by' regex")
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		groupBy  string
+		path     string
+		expected string
+	}{
+		{name: "NoGroupBy", path: "/var/log/pods/app-1/0.log", expected: "/var/log/pods/app-1/0.log"},
+		{name: "CaptureGroup", groupBy: `/var/log/pods/([^/]+)/`, path: "/var/log/pods/app-1/0.log", expected: "app-1"},
+		{name: "Match", groupBy: `app-\d+`, path: "/var/log/pods/app-1/0.log", expected: "app-1"},
+		{name: "NoMatch", groupBy: `/var/log/pods/([^/]+)/`, path: "/var/log/app.log", expected: "/var/log/app.log"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, _ := newTestRegistry(t, Config{EntriesPerSecond: 1, GroupBy: tc.groupBy})
+			assert.Equal(t, tc.expected, r.Key(tc.path))
+		})
+	}
+}
+
+func TestLimitEntries(t *testing.T) {
+	r, now := newTestRegistry(t, Config{EntriesPerSecond: 2})
+	l := r.Get("a.log")
+	assert.True(t, l.Allow(100))
+	assert.True(t, l.Allow(100))
+	assert.False(t, l.Allow(100))
+
+	*now = now.Add(500 * time.Millisecond)
+	assert.True(t, l.Allow(100))
+	assert.False(t, l.Allow(100))
+
+	// other files have their own limit
+	assert.True(t, r.Get("b.log").Allow(100))
+}
+
+func TestLimitBytes(t *testing.T) {
+	r, now := newTestRegistry(t, Config{BytesPerSecond: 10})
+	l := r.Get("a.log")
+	assert.True(t, l.Allow(6))
+	assert.False(t, l.Allow(6))
+	assert.True(t, l.Allow(4))
+	assert.False(t, l.Allow(1))
+
+	// an entry larger than the limit is allowed once the bucket is full
+	*now = now.Add(time.Second)
+	assert.True(t, l.Allow(25))
+	*now = now.Add(time.Second)
+	assert.False(t, l.Allow(1))
+	*now = now.Add(2 * time.Second)
+	assert.True(t, l.Allow(1))
+}
+
+func TestGroupsShareLimit(t *testing.T) {
+	r, _ := newTestRegistry(t, Config{EntriesPerSecond: 1, GroupBy: `/pods/([^/]+)/`})
+	assert.True(t, r.Get("/pods/app/0.log").Allow(1))
+	assert.False(t, r.Get("/pods/app/1.log").Allow(1))
+	assert.True(t, r.Get("/pods/other/0.log").Allow(1))
+}
+
+func TestPrune(t *testing.T) {
+	r, now := newTestRegistry(t, Config{BytesPerSecond: 10, EntriesPerSecond: 10})
+	assert.True(t, r.Get("a.log").Allow(10))
+	r.Get("b.log")
+	r.Prune()
+	assert.Len(t, r.limiters, 1)
+	assert.Contains(t, r.limiters, "a.log")
+
+	*now = now.Add(time.Second)
+	r.Prune()
+	assert.Empty(t, r.limiters)
+}
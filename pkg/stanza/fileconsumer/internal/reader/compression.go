@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+)
+
+const (
+	CompressionNone  = ""
+	CompressionGzip  = "gzip"
+	CompressionZstd  = "zstd"
+	CompressionBzip2 = "bzip2"
+	CompressionXz    = "xz"
+	// CompressionAuto selects the compression of each file from its extension.
+	// Files without a known compressed extension are read as is.
+	CompressionAuto = "auto"
+)
+
+// compressionExtensions maps the extensions of compressed files to their compression.
+var compressionExtensions = map[string]string{
+	".gz":   CompressionGzip,
+	".zst":  CompressionZstd,
+	".zstd": CompressionZstd,
+	".bz2":  CompressionBzip2,
+	".xz":   CompressionXz,
+}
+
+// ValidateCompression returns an error if the compression is not supported.
+func ValidateCompression(compression string) error {
+	switch compression {
+	case CompressionNone, CompressionGzip, CompressionZstd, CompressionBzip2, CompressionXz, CompressionAuto:
+		return nil
+	}
+	return fmt.Errorf("invalid compression %q, must be one of '', %q, %q, %q, %q or %q",
+		compression, CompressionGzip, CompressionZstd, CompressionBzip2, CompressionXz, CompressionAuto)
+}
+
+// CompressionFromExtension returns the compression matching the extension of the given path,
+// or CompressionNone if the extension is not a known compressed extension.
+func CompressionFromExtension(path string) string {
+	return compressionExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
+// resolveCompression returns the compression to use to read the file at the given path.
+func resolveCompression(compression string, path string) string {
+	if compression == CompressionAuto {
+		return CompressionFromExtension(path)
+	}
+	return compression
+}
+
+// newDecompressor returns a reader of the decompressed content of src.
+// Concatenated compressed streams are read one after another, which allows
+// reading files to which new compressed content has been appended.
+func newDecompressor(compression string, src io.Reader) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewReader(src)
+	case CompressionZstd:
+		d, err := zstd.NewReader(src, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case CompressionBzip2:
+		return io.NopCloser(bzip2.NewReader(src)), nil
+	case CompressionXz:
+		r, err := xz.NewReader(src)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(r), nil
+	}
+	return nil, fmt.Errorf("unsupported compression %q", compression)
+}
+
+// NewContentFingerprint returns the fingerprint of the decompressed content of a compressed file,
+// which is the fingerprint the file had before it was compressed. It returns nil if the file
+// is not compressed or cannot be decompressed.
+func (f *Factory) NewContentFingerprint(file *os.File) *fingerprint.Fingerprint {
+	compression := resolveCompression(f.Compression, file.Name())
+	if compression == CompressionNone {
+		return nil
+	}
+	decompressor, err := newDecompressor(compression, io.NewSectionReader(file, 0, 1<<63-1))
+	if err != nil {
+		return nil
+	}
+	defer decompressor.Close()
+
+	buf := make([]byte, f.FingerprintSize)
+	n, err := io.ReadFull(decompressor, buf)
+	if n == 0 || (err != nil && !errors.Is(err, io.ErrUnexpectedEOF)) {
+		return nil
+	}
+	return fingerprint.New(buf[:n])
+}
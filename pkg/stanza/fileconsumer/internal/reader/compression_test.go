@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+)
+
+func compress(t *testing.T, compression string, content string) []byte {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch compression {
+	case CompressionGzip:
+		w = gzip.NewWriter(&buf)
+	case CompressionZstd:
+		zw, err := zstd.NewWriter(&buf)
+		require.NoError(t, err)
+		w = zw
+	case CompressionXz:
+		xw, err := xz.NewWriter(&buf)
+		require.NoError(t, err)
+		w = xw
+	case CompressionBzip2:
+		// there is no bzip2 writer in the standard library, use a file compressed beforehand
+		require.Equal(t, "testlog1\ntestlog2\n", content)
+		b, err := os.ReadFile(filepath.Join("testdata", "testlog.bz2"))
+		require.NoError(t, err)
+		return b
+	default:
+		t.Fatalf("unsupported compression %q", compression)
+	}
+	_, err := w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestValidateCompression(t *testing.T) {
+	for _, compression := range []string{CompressionNone, CompressionGzip, CompressionZstd, CompressionBzip2, CompressionXz, CompressionAuto} {
+		assert.NoError(t, ValidateCompression(compression), compression)
+	}
+	assert.ErrorContains(t, ValidateCompression("lz4"), `invalid compression "lz4"`)
+}
+
+func TestCompressionFromExtension(t *testing.T) {
+	tests := map[string]string{
+		"app.log":          CompressionNone,
+		"app.log.1":        CompressionNone,
+		"app.log.1.gz":     CompressionGzip,
+		"app.log.2.GZ":     CompressionGzip,
+		"app.log.3.zst":    CompressionZstd,
+		"app.log.4.zstd":   CompressionZstd,
+		"app.log.5.bz2":    CompressionBzip2,
+		"app.log-2024.xz":  CompressionXz,
+		"/var/log/app.tgz": CompressionNone,
+	}
+	for path, expected := range tests {
+		assert.Equal(t, expected, CompressionFromExtension(path), path)
+	}
+}
+
+func TestReadCompressedFile(t *testing.T) {
+	for _, compression := range []string{CompressionGzip, CompressionZstd, CompressionBzip2, CompressionXz} {
+		t.Run(compression, func(t *testing.T) {
+			t.Parallel()
+
+			content := compress(t, compression, "testlog1\ntestlog2\n")
+			path := filepath.Join(t.TempDir(), "test.log")
+			require.NoError(t, os.WriteFile(path, content, 0o600))
+			file, err := os.Open(path)
+			require.NoError(t, err)
+
+			f, sink := testFactory(t)
+			f.Compression = compression
+			fp, err := f.NewFingerprint(file)
+			require.NoError(t, err)
+			r, err := f.NewReader(file, fp)
+			require.NoError(t, err)
+			defer r.Close()
+
+			r.ReadToEnd(context.Background())
+			sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+			assert.Equal(t, int64(len(content)), r.Offset)
+
+			// reading again must not emit anything
+			r.ReadToEnd(context.Background())
+			sink.ExpectNoCalls(t)
+		})
+	}
+}
+
+func TestReadAutoCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	compressedPath := filepath.Join(tempDir, "test.log.1.zst")
+	require.NoError(t, os.WriteFile(compressedPath, compress(t, CompressionZstd, "testlog1\n"), 0o600))
+	plainPath := filepath.Join(tempDir, "test.log")
+	require.NoError(t, os.WriteFile(plainPath, []byte("testlog2\n"), 0o600))
+
+	f, sink := testFactory(t)
+	f.Compression = CompressionAuto
+	for _, path := range []string{compressedPath, plainPath} {
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		fp, err := f.NewFingerprint(file)
+		require.NoError(t, err)
+		r, err := f.NewReader(file, fp)
+		require.NoError(t, err)
+		r.ReadToEnd(context.Background())
+		r.Close()
+	}
+	sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+}
+
+func TestNewContentFingerprint(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	compressedPath := filepath.Join(tempDir, "test.log.1.xz")
+	require.NoError(t, os.WriteFile(compressedPath, compress(t, CompressionXz, "testlog1\ntestlog2\n"), 0o600))
+	plainPath := filepath.Join(tempDir, "test.log")
+	require.NoError(t, os.WriteFile(plainPath, []byte("testlog1\ntestlog2\n"), 0o600))
+
+	f, _ := testFactory(t)
+	f.Compression = CompressionAuto
+
+	compressed, err := os.Open(compressedPath)
+	require.NoError(t, err)
+	defer compressed.Close()
+	assert.True(t, fingerprint.New([]byte("testlog1\ntestlog2\n")).Equal(f.NewContentFingerprint(compressed)))
+
+	plain, err := os.Open(plainPath)
+	require.NoError(t, err)
+	defer plain.Close()
+	assert.Nil(t, f.NewContentFingerprint(plain))
+}
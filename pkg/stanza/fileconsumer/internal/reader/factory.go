@@ -18,6 +18,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/ratelimit"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/flush"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/tokenlen"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/trim"
@@ -46,6 +47,7 @@ type Factory struct {
 	IncludeFileRecordNumber bool
 	Compression             string
 	AcquireFSLock           bool
+	RateLimits              *ratelimit.Registry
 }
 
 func (f *Factory) NewFingerprint(file *os.File) (*fingerprint.Fingerprint, error) {
@@ -80,12 +82,16 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 		decoder:              f.Encoding.NewDecoder(),
 		deleteAtEOF:          f.DeleteAtEOF,
 		includeFileRecordNum: f.IncludeFileRecordNumber,
-		compression:          f.Compression,
+		compression:          resolveCompression(f.Compression, file.Name()),
 		acquireFSLock:        f.AcquireFSLock,
 		maxBatchSize:         DefaultMaxBatchSize,
 		emitFunc:             f.EmitFunc,
 	}
 	r.set.Logger = r.set.Logger.With(zap.String("path", r.fileName))
+	if r.compression == CompressionNone {
+		// compressed files are always read to the end since their offset cannot point into their content
+		r.limiter = f.RateLimits.Get(r.fileName)
+	}
 
 	if r.Fingerprint.Len() > r.fingerprintSize {
 		// User has reconfigured fingerprint_size
@@ -5,7 +5,6 @@ package reader // import "github.com/open-telemetry/opentelemetry-collector-cont
 
 import (
 	"bufio"
-	"compress/gzip"
 	"context"
 	"errors"
 	"io"
@@ -19,6 +18,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/ratelimit"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/scanner"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/flush"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/tokenlen"
@@ -55,10 +55,13 @@ type Reader struct {
 	compression            string
 	acquireFSLock          bool
 	maxBatchSize           int
+	limiter                *ratelimit.Limiter
+	throttled              bool
 }
 
-// ReadToEnd will read until the end of the file
+// ReadToEnd will read until the end of the file, or until the rate limit of the file is reached
 func (r *Reader) ReadToEnd(ctx context.Context) {
+	r.throttled = false
 	if r.acquireFSLock {
 		if !r.tryLockFile() {
 			return
@@ -67,8 +70,10 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	}
 
 	switch r.compression {
-	case "gzip":
-		// We need to create a gzip reader each time ReadToEnd is called because the underlying
+	case CompressionNone:
+		r.reader = r.file
+	default:
+		// We need to create a decompressor each time ReadToEnd is called because the underlying
 		// SectionReader can only read a fixed window (from previous offset to EOF).
 		info, err := r.file.Stat()
 		if err != nil {
@@ -76,25 +81,26 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 			return
 		}
 		currentEOF := info.Size()
+		if r.Offset >= currentEOF {
+			return
+		}
 
-		// use a gzip Reader with an underlying SectionReader to pick up at the last
-		// offset of a gzip compressed file
-		gzipReader, err := gzip.NewReader(io.NewSectionReader(r.file, r.Offset, currentEOF))
+		// use a decompressor with an underlying SectionReader to pick up at the last
+		// offset of a compressed file
+		decompressor, err := newDecompressor(r.compression, io.NewSectionReader(r.file, r.Offset, currentEOF))
 		if err != nil {
 			if !errors.Is(err, io.EOF) {
-				r.set.Logger.Error("failed to create gzip reader", zap.Error(err))
+				r.set.Logger.Error("failed to create decompressor", zap.String("compression", r.compression), zap.Error(err))
 			}
 			return
-		} else {
-			r.reader = gzipReader
 		}
+		defer decompressor.Close()
+		r.reader = decompressor
 		// Offset tracking in an uncompressed file is based on the length of emitted tokens, but in this case
 		// we need to set the offset to the end of the file.
 		defer func() {
 			r.Offset = currentEOF
 		}()
-	default:
-		r.reader = r.file
 	}
 
 	if _, err := r.file.Seek(r.Offset, 0); err != nil {
@@ -198,6 +204,8 @@ func (r *Reader) readContents(ctx context.Context) {
 		default:
 		}
 
+		// position of the end of the previous token
+		pos := s.Pos()
 		ok := s.Scan()
 		if !ok {
 			if err := s.Error(); err != nil {
@@ -216,6 +224,19 @@ func (r *Reader) readContents(ctx context.Context) {
 			return
 		}
 
+		if r.limiter != nil && !r.limiter.Allow(len(s.Bytes())) {
+			// Stop reading and resume from the current token during the next poll.
+			r.throttled = true
+			if numTokensBatched > 0 {
+				err := r.emitFunc(ctx, tokenBodies[:numTokensBatched], r.FileAttributes, r.RecordNum)
+				if err != nil {
+					r.set.Logger.Error("failed to emit token", zap.Error(err))
+				}
+				r.Offset = pos
+			}
+			return
+		}
+
 		var err error
 		tokenBodies[numTokensBatched], err = r.decoder.Bytes(s.Bytes())
 		if err != nil {
@@ -284,6 +305,17 @@ func (r *Reader) Read(dst []byte) (n int, err error) {
 	return
 }
 
+// Throttled reports whether the last read stopped before the end of the file because the rate limit of the file was reached.
+func (r *Reader) Throttled() bool {
+	return r.throttled
+}
+
+// IgnoreRateLimit lets the reader read to the end of the file regardless of its rate limit.
+// This is used for files which will not be read again.
+func (r *Reader) IgnoreRateLimit() {
+	r.limiter = nil
+}
+
 func (r *Reader) NameEquals(other *Reader) bool {
 	return r.fileName == other.fileName
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/ratelimit"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/scanner"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/filetest"
 	internaltime "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/time"
@@ -386,3 +387,43 @@ func newTestFactory(tb testing.TB, callback emit.Callback) *Factory {
 		},
 	}
 }
+
+func TestReadWithRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	temp := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp, "testlog1\ntestlog2\ntestlog3\ntestlog4\n")
+
+	f, sink := testFactory(t)
+	var err error
+	f.RateLimits, err = ratelimit.NewRegistry(ratelimit.Config{EntriesPerSecond: 2})
+	require.NoError(t, err)
+
+	fp, err := f.NewFingerprint(temp)
+	require.NoError(t, err)
+	r, err := f.NewReader(temp, fp)
+	require.NoError(t, err)
+	defer r.Close()
+
+	r.ReadToEnd(context.Background())
+	sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+	assert.True(t, r.Throttled())
+	assert.Equal(t, int64(len("testlog1\ntestlog2\n")), r.Offset)
+
+	r.ReadToEnd(context.Background())
+	sink.ExpectNoCalls(t)
+	assert.True(t, r.Throttled())
+
+	// the limit is replenished after a second
+	time.Sleep(time.Second)
+	r.ReadToEnd(context.Background())
+	sink.ExpectTokens(t, []byte("testlog3"), []byte("testlog4"))
+	assert.False(t, r.Throttled())
+
+	filetest.WriteString(t, temp, "testlog5\ntestlog6\ntestlog7\n")
+	r.IgnoreRateLimit()
+	r.ReadToEnd(context.Background())
+	sink.ExpectTokens(t, []byte("testlog5"), []byte("testlog6"), []byte("testlog7"))
+	assert.False(t, r.Throttled())
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rotation groups a live log file with the files it was rotated to.
+//
+// A rotation set is made of a live file, like "app.log", and its rotated siblings, named after
+// the live file with a rotation suffix and an optional compression extension, like "app.log.1",
+// "app.log.2.gz" or "app.log-20240102.zst". Numeric suffixes shorter than 8 digits are rotation
+// indexes, the higher index being the oldest file, while longer suffixes are dates, the lower
+// date being the oldest file.
+package rotation // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/rotation"
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// compressedExtensions are the extensions added to rotated files when they are compressed.
+	compressedExtensions = []string{".gz", ".zst", ".zstd", ".bz2", ".xz"}
+
+	// suffixRegex matches the rotation suffix of a rotated file, either an index or a date.
+	suffixRegex = regexp.MustCompile(`[.-](\d+(?:-\d+)*)$`)
+)
+
+// minDateLength is the minimum number of digits of a date suffix, like "20240102".
+const minDateLength = 8
+
+type member struct {
+	path string
+	// live is true for the live file of the set, which is always the most recent one.
+	live bool
+	// date is true when the rotation suffix is a date, false when it is an index.
+	date  bool
+	value uint64
+}
+
+// Key returns the key of the rotation set of the given path, which is the path of the live file of the set.
+func Key(path string) string {
+	key, _ := parse(path)
+	return key
+}
+
+func parse(path string) (string, member) {
+	m := member{path: path, live: true}
+	base := path
+	ext := strings.ToLower(filepath.Ext(base))
+	for _, compressed := range compressedExtensions {
+		if ext == compressed {
+			base = base[:len(base)-len(ext)]
+			m.live = false
+			break
+		}
+	}
+
+	match := suffixRegex.FindStringSubmatchIndex(base)
+	if match == nil {
+		return base, m
+	}
+	digits := strings.ReplaceAll(base[match[2]:match[3]], "-", "")
+	value, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return base, m
+	}
+	m.live = false
+	m.date = len(digits) >= minDateLength
+	m.value = value
+	return base[:match[0]], m
+}
+
+// older reports whether a was rotated before b.
+func (a member) older(b member) bool {
+	switch {
+	case a.live != b.live:
+		return b.live
+	case a.date != b.date:
+		// files are unlikely to be rotated with both styles, consider dates as older
+		return a.date
+	case a.value != b.value:
+		if a.date {
+			return a.value < b.value
+		}
+		return a.value > b.value
+	}
+	// a compressed file is older than the uncompressed file it is being compressed from
+	return strings.Compare(a.path, b.path) > 0
+}
+
+// Order groups the given paths by rotation set and returns them with the members of each set
+// next to each other, ordered from the oldest to the most recent one. The sets are ordered by the
+// first occurrence of one of their members in the given paths.
+func Order(paths []string) []string {
+	var keys []string
+	sets := make(map[string][]member)
+	for _, path := range paths {
+		key, m := parse(path)
+		if _, ok := sets[key]; !ok {
+			keys = append(keys, key)
+		}
+		sets[key] = append(sets[key], m)
+	}
+
+	ordered := make([]string, 0, len(paths))
+	for _, key := range keys {
+		members := sets[key]
+		sort.SliceStable(members, func(i, j int) bool {
+			return members[i].older(members[j])
+		})
+		for _, m := range members {
+			ordered = append(ordered, m.path)
+		}
+	}
+	return ordered
+}
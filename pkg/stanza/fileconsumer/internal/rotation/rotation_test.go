@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rotation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	tests := map[string]string{
+		"/var/log/app.log":                "/var/log/app.log",
+		"/var/log/app.log.1":              "/var/log/app.log",
+		"/var/log/app.log.2.gz":           "/var/log/app.log",
+		"/var/log/app.log.3.ZST":          "/var/log/app.log",
+		"/var/log/app.log-20240102":       "/var/log/app.log",
+		"/var/log/app.log-20240102.bz2":   "/var/log/app.log",
+		"/var/log/app.log-2024-01-02.xz":  "/var/log/app.log",
+		"/var/log/app.log-20240102-1.gz":  "/var/log/app.log",
+		"/var/log/other.log.gz":           "/var/log/other.log",
+		"/var/log/app.log.old":            "/var/log/app.log.old",
+		"/var/log/app-20240102.log":       "/var/log/app-20240102.log",
+		"/var/log/app-20240102.log.1.zst": "/var/log/app-20240102.log",
+	}
+	for path, expected := range tests {
+		assert.Equal(t, expected, Key(path), path)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    []string
+		expected []string
+	}{
+		{
+			name:     "no rotated files",
+			paths:    []string{"b.log", "a.log"},
+			expected: []string{"b.log", "a.log"},
+		},
+		{
+			name:     "numeric indexes",
+			paths:    []string{"app.log", "app.log.1", "app.log.10.gz", "app.log.2.gz", "app.log.3.bz2"},
+			expected: []string{"app.log.10.gz", "app.log.3.bz2", "app.log.2.gz", "app.log.1", "app.log"},
+		},
+		{
+			name:     "dates",
+			paths:    []string{"app.log", "app.log-20240103.gz", "app.log-20240101.gz", "app.log-20240102"},
+			expected: []string{"app.log-20240101.gz", "app.log-20240102", "app.log-20240103.gz", "app.log"},
+		},
+		{
+			name:     "file being compressed",
+			paths:    []string{"app.log", "app.log.1", "app.log.1.gz"},
+			expected: []string{"app.log.1.gz", "app.log.1", "app.log"},
+		},
+		{
+			name:     "multiple sets",
+			paths:    []string{"a.log", "a.log.1.gz", "b.log", "b.log.2.xz", "a.log.2.gz", "b.log.1.zst"},
+			expected: []string{"a.log.2.gz", "a.log.1.gz", "a.log", "b.log.2.xz", "b.log.1.zst", "b.log"},
+		},
+		{
+			name:     "archives without live file",
+			paths:    []string{"app.log.1.gz", "app.log.2.gz"},
+			expected: []string{"app.log.2.gz", "app.log.1.gz"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Order(tt.paths))
+		})
+	}
+}
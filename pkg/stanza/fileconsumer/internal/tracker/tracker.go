@@ -31,6 +31,7 @@ type Tracker interface {
 	GetCurrentFile(fp *fingerprint.Fingerprint) *reader.Reader
 	GetOpenFile(fp *fingerprint.Fingerprint) *reader.Reader
 	GetClosedFile(fp *fingerprint.Fingerprint) *reader.Metadata
+	HasFile(fp *fingerprint.Fingerprint) bool
 	GetMetadata() []*reader.Metadata
 	LoadMetadata(metadata []*reader.Metadata)
 	CurrentPollFiles() []*reader.Reader
@@ -106,6 +107,28 @@ func (t *fileTracker) GetClosedFile(fp *fingerprint.Fingerprint) *reader.Metadat
 	return nil
 }
 
+// HasFile reports whether a tracked file matches the fingerprint, without removing it from the tracker.
+func (t *fileTracker) HasFile(fp *fingerprint.Fingerprint) bool {
+	for _, r := range t.currentPollFiles.Get() {
+		if fp.StartsWith(r.GetFingerprint()) {
+			return true
+		}
+	}
+	for _, r := range t.previousPollFiles.Get() {
+		if fp.StartsWith(r.GetFingerprint()) {
+			return true
+		}
+	}
+	for _, knownFiles := range t.knownFiles {
+		for _, m := range knownFiles.Get() {
+			if fp.StartsWith(m.GetFingerprint()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (t *fileTracker) GetMetadata() []*reader.Metadata {
 	// return all known metadata for checkpoining
 	allCheckpoints := make([]*reader.Metadata, 0, t.TotalReaders())
@@ -244,6 +267,10 @@ func (t *fileTracker) getPreviousPollsToArchive(ctx context.Context) (int, error
 		t.set.Logger.Error("error while reading the archiveIndexKey", zap.Error(err))
 		return 0, err
 	}
+	if byteIndex == nil {
+		// the archive has never been used
+		return t.pollsToArchive, nil
+	}
 	previousPollsToArchive, err := decodeIndex(byteIndex)
 	if err != nil {
 		t.set.Logger.Error("error while decoding previousPollsToArchive", zap.Error(err))
@@ -257,6 +284,9 @@ func (t *fileTracker) getArchiveIndex(ctx context.Context) (int, error) {
 	if err != nil {
 		return 0, err
 	}
+	if byteIndex == nil {
+		return 0, nil
+	}
 	archiveIndex, err := decodeIndex(byteIndex)
 	if err != nil {
 		return 0, err
@@ -316,7 +346,12 @@ func (t *fileTracker) isSet(ctx context.Context, index int) bool {
 }
 
 // FindFiles goes through archive, one fileset at a time and tries to match all fingerprints against that loaded set.
+// It returns nil if the archive is not enabled.
 func (t *fileTracker) FindFiles(fps []*fingerprint.Fingerprint) []*reader.Metadata {
+	if !t.archiveEnabled() {
+		return nil
+	}
+
 	// To minimize disk access, we first access the index, then review unmatched files and update the metadata, if found.
 	// We exit if all fingerprints are matched.
 
@@ -408,6 +443,8 @@ func (t *noStateTracker) GetOpenFile(_ *fingerprint.Fingerprint) *reader.Reader
 
 func (t *noStateTracker) GetClosedFile(_ *fingerprint.Fingerprint) *reader.Metadata { return nil }
 
+func (t *noStateTracker) HasFile(_ *fingerprint.Fingerprint) bool { return false }
+
 func (t *noStateTracker) GetMetadata() []*reader.Metadata { return nil }
 
 func (t *noStateTracker) LoadMetadata(_ []*reader.Metadata) {}
@@ -46,6 +46,41 @@ func TestFindFilesOrder(t *testing.T) {
 	}
 }
 
+func TestFindArchivedFile(t *testing.T) {
+	persister := testutil.NewUnscopedMockPersister()
+	tracker := NewFileTracker(context.Background(), componenttest.NewNopTelemetrySettings(), 0, 5, persister)
+
+	fp := fingerprint.New([]byte("archived file"))
+	tracker.LoadMetadata([]*reader.Metadata{{Fingerprint: fp, Offset: 42}})
+
+	// the file is still known, it is not looked up in the archive
+	tracker.EndPoll()
+	tracker.EndPoll()
+	require.True(t, tracker.HasFile(fp))
+
+	// the file falls off the known files and is archived
+	tracker.EndPoll()
+	require.False(t, tracker.HasFile(fp))
+	require.Nil(t, tracker.GetClosedFile(fp))
+
+	unknown := fingerprint.New([]byte("unknown file"))
+	matched := tracker.FindFiles([]*fingerprint.Fingerprint{unknown, fp})
+	require.Len(t, matched, 2)
+	require.Nil(t, matched[0])
+	require.NotNil(t, matched[1])
+	require.Equal(t, int64(42), matched[1].Offset)
+
+	// a matched file is removed from the archive, as it is tracked again
+	matched = tracker.FindFiles([]*fingerprint.Fingerprint{fp})
+	require.Nil(t, matched[0])
+}
+
+func TestFindFilesArchiveDisabled(t *testing.T) {
+	fps := []*fingerprint.Fingerprint{fingerprint.New([]byte("file"))}
+	require.Nil(t, NewFileTracker(context.Background(), componenttest.NewNopTelemetrySettings(), 0, 0, testutil.NewUnscopedMockPersister()).FindFiles(fps))
+	require.Nil(t, NewFileTracker(context.Background(), componenttest.NewNopTelemetrySettings(), 0, 5, nil).FindFiles(fps))
+}
+
 func TestIndexInBounds(t *testing.T) {
 	persister := testutil.NewUnscopedMockPersister()
 	pollsToArchive := 100
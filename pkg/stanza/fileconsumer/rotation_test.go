@@ -4,6 +4,7 @@
 package fileconsumer
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -496,3 +498,92 @@ func TestFileMovedWhileOff_BigFiles(t *testing.T) {
 	sink2.ExpectTokens(t, log2, log3)
 	require.NoError(t, operator2.Stop())
 }
+
+func writeCompressed(t *testing.T, path string, content string) {
+	file := filetest.OpenFile(t, path)
+	var w io.WriteCloser
+	switch filepath.Ext(path) {
+	case ".gz":
+		w = gzip.NewWriter(file)
+	case ".zst":
+		zw, err := zstd.NewWriter(file)
+		require.NoError(t, err)
+		w = zw
+	default:
+		t.Fatalf("unsupported compressed file %q", path)
+	}
+	_, err := w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, file.Close())
+}
+
+func TestRotationSetsReadInOrder(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "auto"
+	cfg.RotationSets = true
+	operator, sink := testManager(t, cfg)
+
+	writeCompressed(t, filepath.Join(tempDir, "app.log.3.gz"), "line 1\nline 2\n")
+	writeCompressed(t, filepath.Join(tempDir, "app.log.2.zst"), "line 3\n")
+	rotated := filetest.OpenFile(t, filepath.Join(tempDir, "app.log.1"))
+	filetest.WriteString(t, rotated, "line 4\n")
+	live := filetest.OpenFile(t, filepath.Join(tempDir, "app.log"))
+	filetest.WriteString(t, live, "line 5\n")
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	require.Equal(t, [][]byte{
+		[]byte("line 1"),
+		[]byte("line 2"),
+		[]byte("line 3"),
+		[]byte("line 4"),
+		[]byte("line 5"),
+	}, sink.NextTokens(t, 5))
+	sink.ExpectNoCalls(t)
+}
+
+func TestRotationSetsSkipCompressedCopy(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Rotation tests have been flaky on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/16331")
+	}
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "auto"
+	cfg.RotationSets = true
+	operator, sink := testManager(t, cfg)
+
+	rotatedPath := filepath.Join(tempDir, "app.log.1")
+	rotated := filetest.OpenFile(t, rotatedPath)
+	filetest.WriteString(t, rotated, "line 1\nline 2\n")
+	require.NoError(t, rotated.Close())
+	live := filetest.OpenFile(t, filepath.Join(tempDir, "app.log"))
+	filetest.WriteString(t, live, "line 3\n")
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	require.Equal(t, [][]byte{[]byte("line 1"), []byte("line 2"), []byte("line 3")}, sink.NextTokens(t, 3))
+
+	// compress the rotated file, its content must not be read again
+	writeCompressed(t, rotatedPath+".gz", "line 1\nline 2\n")
+	require.NoError(t, os.Remove(rotatedPath))
+	operator.poll(context.Background())
+	sink.ExpectNoCalls(t)
+
+	filetest.WriteString(t, live, "line 4\n")
+	operator.poll(context.Background())
+	sink.ExpectToken(t, []byte("line 4"))
+	sink.ExpectNoCalls(t)
+}
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/cef"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Parser is an operator that parses an ArcSight Common Event Format (CEF) event.
+type Parser struct {
+	helper.ParserOperator
+}
+
+func (p *Parser) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return p.ProcessBatchWith(ctx, entries, p.Process)
+}
+
+// Process will parse an entry.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ParserOperator.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as an ArcSight Common Event Format (CEF) event.
+func (p *Parser) parse(value any) (any, error) {
+	switch m := value.(type) {
+	case string:
+		return parseutils.ParseCEF(m)
+	default:
+		return nil, fmt.Errorf("type '%T' cannot be parsed as CEF event", value)
+	}
+}
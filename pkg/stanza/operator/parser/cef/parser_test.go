@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cef
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	cfg := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("cef_parser")
+	require.True(t, ok, "expected cef_parser to be registered")
+	require.Equal(t, "cef_parser", builder().Type())
+}
+
+func TestParserBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestParserStringFailure(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("CEF:0|a")
+	require.ErrorContains(t, err, "invalid CEF header")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type '[]int' cannot be parsed as CEF event")
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name   string
+		op     func() (operator.Operator, error)
+		input  *entry.Entry
+		expect *entry.Entry
+	}{
+		{
+			"default",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: "CEF:0|Security|threatmanager|1.0|100|worm stopped|10|src=10.0.0.1 spt=1232",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":               int64(0),
+					"device_vendor":         "Security",
+					"device_product":        "threatmanager",
+					"device_version":        "1.0",
+					"device_event_class_id": "100",
+					"name":                  "worm stopped",
+					"severity":              int64(10),
+					"extensions": map[string]any{
+						"src": "10.0.0.1",
+						"spt": int64(1232),
+					},
+				},
+				Body: "CEF:0|Security|threatmanager|1.0|100|worm stopped|10|src=10.0.0.1 spt=1232",
+			},
+		},
+		{
+			"parse-from-to",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.ParseFrom = entry.NewBodyField("message")
+				cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("parsed")}
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": "CEF:0|Security|threatmanager|1.0|100|worm stopped|10|src=10.0.0.1 spt=1232",
+				},
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": "CEF:0|Security|threatmanager|1.0|100|worm stopped|10|src=10.0.0.1 spt=1232",
+					"parsed": map[string]any{
+						"version":               int64(0),
+						"device_vendor":         "Security",
+						"device_product":        "threatmanager",
+						"device_version":        "1.0",
+						"device_event_class_id": "100",
+						"name":                  "worm stopped",
+						"severity":              int64(10),
+						"extensions": map[string]any{
+							"src": "10.0.0.1",
+							"spt": int64(1232),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, err := tc.op()
+			require.NoError(t, err, "did not expect operator function to return an error, this is a bug with the test case")
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, tc.input)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package commonlogformat // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/commonlogformat"
+
+import (
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "common_log_format_parser"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new common log format parser config with default values.
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new common log format parser config with default values.
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a common log format parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+}
+
+// Build will build a common log format parser operator.
+func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+	}, nil
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package commonlogformat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	cfg := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("common_log_format_parser")
+	require.True(t, ok, "expected common_log_format_parser to be registered")
+	require.Equal(t, "common_log_format_parser", builder().Type())
+}
+
+func TestParserBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestParserStringFailure(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("127.0.0.1 - frank")
+	require.ErrorContains(t, err, "invalid timestamp")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type '[]int' cannot be parsed as common log format line")
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name   string
+		op     func() (operator.Operator, error)
+		input  *entry.Entry
+		expect *entry.Entry
+	}{
+		{
+			"default",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /index.html HTTP/1.0" 200 2326 "-" "curl/8.0"`,
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"client.address":            "127.0.0.1",
+					"user.name":                 "frank",
+					"timestamp":                 "10/Oct/2000:13:55:36 -0700",
+					"http.request.method":       "GET",
+					"url.path":                  "/index.html",
+					"network.protocol.name":     "http",
+					"network.protocol.version":  "1.0",
+					"http.response.status_code": int64(200),
+					"http.response.body.size":   int64(2326),
+					"user_agent.original":       "curl/8.0",
+				},
+				Body: `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /index.html HTTP/1.0" 200 2326 "-" "curl/8.0"`,
+			},
+		},
+		{
+			"parse-from-to",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.ParseFrom = entry.NewBodyField("message")
+				cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("parsed")}
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /index.html HTTP/1.0" 200 2326 "-" "curl/8.0"`,
+				},
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /index.html HTTP/1.0" 200 2326 "-" "curl/8.0"`,
+					"parsed": map[string]any{
+						"client.address":            "127.0.0.1",
+						"user.name":                 "frank",
+						"timestamp":                 "10/Oct/2000:13:55:36 -0700",
+						"http.request.method":       "GET",
+						"url.path":                  "/index.html",
+						"network.protocol.name":     "http",
+						"network.protocol.version":  "1.0",
+						"http.response.status_code": int64(200),
+						"http.response.body.size":   int64(2326),
+						"user_agent.original":       "curl/8.0",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, err := tc.op()
+			require.NoError(t, err, "did not expect operator function to return an error, this is a bug with the test case")
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, tc.input)
+		})
+	}
+}
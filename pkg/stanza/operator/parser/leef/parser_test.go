@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leef
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	cfg := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("leef_parser")
+	require.True(t, ok, "expected leef_parser to be registered")
+	require.Equal(t, "leef_parser", builder().Type())
+}
+
+func TestParserBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestParserStringFailure(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("LEEF:1.0|a")
+	require.ErrorContains(t, err, "invalid LEEF header")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type '[]int' cannot be parsed as LEEF event")
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name   string
+		op     func() (operator.Operator, error)
+		input  *entry.Entry
+		expect *entry.Entry
+	}{
+		{
+			"default",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tsev=5",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":         "1.0",
+					"vendor":          "Microsoft",
+					"product":         "MSExchange",
+					"product_version": "4.0 SP1",
+					"event_id":        "15345",
+					"attributes": map[string]any{
+						"src": "192.0.2.0",
+						"sev": int64(5),
+					},
+				},
+				Body: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tsev=5",
+			},
+		},
+		{
+			"parse-from-to",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.ParseFrom = entry.NewBodyField("message")
+				cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("parsed")}
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tsev=5",
+				},
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tsev=5",
+					"parsed": map[string]any{
+						"version":         "1.0",
+						"vendor":          "Microsoft",
+						"product":         "MSExchange",
+						"product_version": "4.0 SP1",
+						"event_id":        "15345",
+						"attributes": map[string]any{
+							"src": "192.0.2.0",
+							"sev": int64(5),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, err := tc.op()
+			require.NoError(t, err, "did not expect operator function to return an error, this is a bug with the test case")
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, tc.input)
+		})
+	}
+}
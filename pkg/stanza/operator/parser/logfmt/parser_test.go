@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logfmt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	cfg := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := cfg.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("logfmt_parser")
+	require.True(t, ok, "expected logfmt_parser to be registered")
+	require.Equal(t, "logfmt_parser", builder().Type())
+}
+
+func TestParserBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestParserStringFailure(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse(`msg="unterminated`)
+	require.ErrorContains(t, err, "never reached the end of the quoted value")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type '[]int' cannot be parsed as logfmt line")
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name   string
+		op     func() (operator.Operator, error)
+		input  *entry.Entry
+		expect *entry.Entry
+	}{
+		{
+			"default",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: `level=info msg="request handled" status=200 cached`,
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"level":  "info",
+					"msg":    "request handled",
+					"status": int64(200),
+					"cached": true,
+				},
+				Body: `level=info msg="request handled" status=200 cached`,
+			},
+		},
+		{
+			"parse-from-to",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.ParseFrom = entry.NewBodyField("message")
+				cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("parsed")}
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": `level=info msg="request handled" status=200 cached`,
+				},
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"message": `level=info msg="request handled" status=200 cached`,
+					"parsed": map[string]any{
+						"level":  "info",
+						"msg":    "request handled",
+						"status": int64(200),
+						"cached": true,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, err := tc.op()
+			require.NoError(t, err, "did not expect operator function to return an error, this is a bug with the test case")
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, tc.input)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SaveState encodes the state of an operator as JSON and persists it under the given key.
+// It reports whether the state can be loaded back, which is not the case when the persister
+// does not store any data, e.g. when the receiver is not configured with a storage extension.
+func SaveState(ctx context.Context, persister Persister, key string, state any) (bool, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return false, fmt.Errorf("encode state: %w", err)
+	}
+	if err = persister.Set(ctx, key, data); err != nil {
+		return false, fmt.Errorf("persist state: %w", err)
+	}
+	stored, err := persister.Get(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("read persisted state: %w", err)
+	}
+	return stored != nil, nil
+}
+
+// LoadState decodes the state persisted under the given key into state.
+// It reports whether a state was found.
+func LoadState(ctx context.Context, persister Persister, key string, state any) (bool, error) {
+	data, err := persister.Get(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("read persisted state: %w", err)
+	}
+	if data == nil {
+		return false, nil
+	}
+	if err = json.Unmarshal(data, state); err != nil {
+		return false, fmt.Errorf("decode state: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+type testState struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestSaveAndLoadState(t *testing.T) {
+	ctx := context.Background()
+	persister := testutil.NewMockPersister("op")
+
+	var loaded testState
+	found, err := operator.LoadState(ctx, persister, "state", &loaded)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	saved, err := operator.SaveState(ctx, persister, "state", testState{Name: "a", Count: 2})
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	found, err = operator.LoadState(ctx, persister, "state", &loaded)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, testState{Name: "a", Count: 2}, loaded)
+}
+
+func TestSaveStateWithoutStorage(t *testing.T) {
+	ctx := context.Background()
+	persister := operator.NewScopedPersister("op", storage.NewNopClient())
+
+	saved, err := operator.SaveState(ctx, persister, "state", testState{Name: "a"})
+	require.NoError(t, err)
+	assert.False(t, saved)
+
+	var loaded testState
+	found, err := operator.LoadState(ctx, persister, "state", &loaded)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestStateErrors(t *testing.T) {
+	ctx := context.Background()
+	persister := testutil.NewErrPersister(map[string]error{"state": errors.New("boom")})
+
+	_, err := operator.SaveState(ctx, persister, "state", testState{})
+	require.ErrorContains(t, err, "persist state: boom")
+	_, err = operator.LoadState(ctx, persister, "state", &testState{})
+	require.ErrorContains(t, err, "read persisted state: boom")
+
+	persister = testutil.NewUnscopedMockPersister()
+	require.NoError(t, persister.Set(ctx, "state", []byte("{")))
+	_, err = operator.LoadState(ctx, persister, "state", &testState{})
+	require.ErrorContains(t, err, "decode state")
+
+	_, err = operator.SaveState(ctx, persister, "state", make(chan int))
+	require.ErrorContains(t, err, "encode state")
+}
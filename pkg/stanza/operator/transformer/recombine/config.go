@@ -49,17 +49,19 @@ func NewConfigWithID(operatorID string) *Config {
 // Config is the configuration of a recombine operator
 type Config struct {
 	helper.TransformerConfig `mapstructure:",squash"`
-	IsFirstEntry             string          `mapstructure:"is_first_entry"`
-	IsLastEntry              string          `mapstructure:"is_last_entry"`
-	MaxBatchSize             int             `mapstructure:"max_batch_size"`
-	MaxUnmatchedBatchSize    int             `mapstructure:"max_unmatched_batch_size"`
-	CombineField             entry.Field     `mapstructure:"combine_field"`
-	CombineWith              string          `mapstructure:"combine_with"`
-	SourceIdentifier         entry.Field     `mapstructure:"source_identifier"`
-	OverwriteWith            string          `mapstructure:"overwrite_with"`
-	ForceFlushTimeout        time.Duration   `mapstructure:"force_flush_period"`
-	MaxSources               int             `mapstructure:"max_sources"`
-	MaxLogSize               helper.ByteSize `mapstructure:"max_log_size,omitempty"`
+	IsFirstEntry             string            `mapstructure:"is_first_entry"`
+	IsLastEntry              string            `mapstructure:"is_last_entry"`
+	MaxBatchSize             int               `mapstructure:"max_batch_size"`
+	MaxUnmatchedBatchSize    int               `mapstructure:"max_unmatched_batch_size"`
+	CombineField             entry.Field       `mapstructure:"combine_field"`
+	CombineWith              string            `mapstructure:"combine_with"`
+	SourceIdentifier         entry.Field       `mapstructure:"source_identifier"`
+	OverwriteWith            string            `mapstructure:"overwrite_with"`
+	ForceFlushTimeout        time.Duration     `mapstructure:"force_flush_period"`
+	MaxSources               int               `mapstructure:"max_sources"`
+	MaxLogSize               helper.ByteSize   `mapstructure:"max_log_size,omitempty"`
+	Stacktrace               *StacktraceConfig `mapstructure:"stacktrace"`
+	PersistBatches           bool              `mapstructure:"persist_batches"`
 }
 
 // Build creates a new Transformer from a config
@@ -69,23 +71,31 @@ func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, erro
 		return nil, fmt.Errorf("failed to build transformer config: %w", err)
 	}
 
-	if c.IsLastEntry != "" && c.IsFirstEntry != "" {
-		return nil, fmt.Errorf("only one of is_first_entry and is_last_entry can be set")
-	}
-
-	if c.IsLastEntry == "" && c.IsFirstEntry == "" {
-		return nil, fmt.Errorf("one of is_first_entry and is_last_entry must be set")
-	}
-
 	var matchesFirst bool
 	var prog *vm.Program
-	if c.IsFirstEntry != "" {
+	var stacktrace *stacktraceDetector
+	switch {
+	case c.Stacktrace != nil:
+		if c.IsLastEntry != "" || c.IsFirstEntry != "" {
+			return nil, fmt.Errorf("is_first_entry and is_last_entry cannot be set with stacktrace")
+		}
+		// every line which is not part of a stack trace is the first entry of a new batch
+		matchesFirst = true
+		stacktrace, err = c.Stacktrace.build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build stacktrace: %w", err)
+		}
+	case c.IsLastEntry != "" && c.IsFirstEntry != "":
+		return nil, fmt.Errorf("only one of is_first_entry and is_last_entry can be set")
+	case c.IsLastEntry == "" && c.IsFirstEntry == "":
+		return nil, fmt.Errorf("one of is_first_entry, is_last_entry and stacktrace must be set")
+	case c.IsFirstEntry != "":
 		matchesFirst = true
 		prog, err = helper.ExprCompileBool(c.IsFirstEntry)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile is_first_entry: %w", err)
 		}
-	} else {
+	default:
 		matchesFirst = false
 		prog, err = helper.ExprCompileBool(c.IsLastEntry)
 		if err != nil {
@@ -111,6 +121,7 @@ func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, erro
 		TransformerOperator:   transformer,
 		matchFirstLine:        matchesFirst,
 		prog:                  prog,
+		stacktrace:            stacktrace,
 		maxBatchSize:          c.MaxBatchSize,
 		maxUnmatchedBatchSize: c.MaxUnmatchedBatchSize,
 		maxSources:            c.MaxSources,
@@ -130,5 +141,6 @@ func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, erro
 		chClose:           make(chan struct{}),
 		sourceIdentifier:  c.SourceIdentifier,
 		maxLogSize:        int64(c.MaxLogSize),
+		persistBatches:    c.PersistBatches,
 	}, nil
 }
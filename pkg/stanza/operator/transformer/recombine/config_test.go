@@ -93,6 +93,27 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "stacktrace",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Stacktrace = &StacktraceConfig{
+						Languages: []string{LanguageJava, LanguagePython},
+						Parse:     true,
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name:      "persist_batches",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.PersistBatches = true
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recombine // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+)
+
+const (
+	LanguageJava   = "java"
+	LanguagePython = "python"
+	LanguageGo     = "go"
+	LanguageDotnet = "dotnet"
+	LanguageRuby   = "ruby"
+	LanguageNodejs = "nodejs"
+)
+
+// StacktraceConfig is the configuration of the stacktrace mode, which recombines
+// the lines of stack traces without requiring is_first_entry or is_last_entry.
+type StacktraceConfig struct {
+	// Languages are the languages whose stack traces are detected. All languages are detected when empty.
+	Languages []string `mapstructure:"languages"`
+	// Parse sets the exception.type, exception.message and exception.stacktrace attributes
+	// of the entries which contain a stack trace.
+	Parse bool `mapstructure:"parse"`
+}
+
+var (
+	exceptionTypeField       = entry.NewAttributeField("exception.type")
+	exceptionMessageField    = entry.NewAttributeField("exception.message")
+	exceptionStacktraceField = entry.NewAttributeField("exception.stacktrace")
+)
+
+// The families of stack traces, which share the same layout and are parsed the same way.
+const (
+	familyException = "exception"
+	familyPython    = "python"
+	familyGo        = "go"
+	familyRuby      = "ruby"
+)
+
+// The states of the stack trace state machine. A line which does not match any rule from the
+// current state ends the stack trace, and is checked against the rules of the start state.
+const (
+	stateStart           = ""
+	stateExceptionHeader = "exception_header"
+	stateExceptionFrames = "exception_frames"
+	statePython          = "python"
+	statePythonCode      = "python_code"
+	statePythonAfter     = "python_after"
+	statePythonChained   = "python_chained"
+	stateGoAfterPanic    = "go_after_panic"
+	stateGoAfterSignal   = "go_after_signal"
+	stateGoGoroutine     = "go_goroutine"
+	stateGoFrameFunction = "go_frame_function"
+	stateGoFrameLocation = "go_frame_location"
+	stateRubyBeforeRails = "ruby_before_rails"
+	stateRubyFrames      = "ruby_frames"
+)
+
+type stacktraceRule struct {
+	from    []string
+	pattern *regexp.Regexp
+	to      string
+	// family is the family of the stack traces started by the rule, only set on rules from the start state.
+	family string
+}
+
+func rule(from []string, pattern string, to string) stacktraceRule {
+	return stacktraceRule{from: from, pattern: regexp.MustCompile(pattern), to: to}
+}
+
+func startRule(pattern string, to string, family string) stacktraceRule {
+	r := rule([]string{stateStart}, pattern, to)
+	r.family = family
+	return r
+}
+
+var (
+	exceptionStates = []string{stateExceptionHeader, stateExceptionFrames}
+
+	// exceptionRules are shared by the languages whose stack traces are made of an exception
+	// header followed by indented "at" frames.
+	exceptionRules = []stacktraceRule{
+		startRule(`(?:Exception|Error|Throwable)(?::|$)`, stateExceptionHeader, familyException),
+		rule(exceptionStates, `^[\t ]+(?:eval )?at `, stateExceptionFrames),
+	}
+
+	stacktraceRules = map[string][]stacktraceRule{
+		LanguageJava: {
+			rule(exceptionStates, `^[\t ]*(?:Caused by|Suppressed):`, stateExceptionHeader),
+			rule(exceptionStates, `^[\t ]*nested exception is:`, stateExceptionHeader),
+			rule(exceptionStates, `^[\t ]*\.\.\. \d+ (?:more|common frames omitted)`, stateExceptionFrames),
+		},
+		LanguageDotnet: {
+			rule(exceptionStates, `^[\t ]*--- End of (?:inner exception stack trace|stack trace from previous location)`, stateExceptionFrames),
+			rule(exceptionStates, `^[\t ]*---> `, stateExceptionHeader),
+		},
+		LanguageNodejs: {
+			rule(exceptionStates, `^[\t ]*\.\.\. \d+ lines matching cause stack trace \.\.\.`, stateExceptionFrames),
+			rule(exceptionStates, `^[\t ]*\[cause\]: `, stateExceptionHeader),
+		},
+		LanguagePython: {
+			startRule(`^Traceback \(most recent call last\):$`, statePython, familyPython),
+			rule([]string{statePython}, `^[\t ]+File `, statePythonCode),
+			rule([]string{statePythonCode}, `^[\t ]+\S`, statePython),
+			rule([]string{statePython}, `^[\t ]+[~^]+$`, statePython),
+			rule([]string{statePython}, `^[\t ]*\[Previous line repeated \d+ more times?\]$`, statePython),
+			rule([]string{statePython}, `^(?:[^\s.():]+\.)*[^\s.():]+(?::|$)`, statePythonAfter),
+			rule([]string{statePythonAfter}, `^$`, statePythonAfter),
+			rule([]string{statePythonAfter}, `^(?:During handling of the above exception, another exception occurred|The above exception was the direct cause of the following exception):$`, statePythonChained),
+			rule([]string{statePythonChained}, `^$`, statePythonChained),
+			rule([]string{statePythonChained}, `^Traceback \(most recent call last\):$`, statePython),
+		},
+		LanguageGo: {
+			startRule(`\bpanic: `, stateGoAfterPanic, familyGo),
+			startRule(`http: panic serving`, stateGoGoroutine, familyGo),
+			rule([]string{stateGoAfterPanic}, `^\tpanic: `, stateGoAfterPanic),
+			rule([]string{stateGoAfterPanic}, `^\[signal `, stateGoAfterSignal),
+			rule([]string{stateGoAfterPanic, stateGoAfterSignal, stateGoFrameFunction}, `^$`, stateGoGoroutine),
+			rule([]string{stateGoGoroutine}, `^goroutine \d+ \[[^\]]+\]:$`, stateGoFrameFunction),
+			rule([]string{stateGoFrameFunction}, `^(?:[^\s.:]+\.)*[^\s.():]+\(|^created by `, stateGoFrameLocation),
+			rule([]string{stateGoFrameLocation}, `^\s`, stateGoFrameFunction),
+		},
+		LanguageRuby: {
+			startRule(`^.+:\d+:in .+ \([\w:]+\)$`, stateRubyFrames, familyRuby),
+			startRule(`Error \(.*\):$`, stateRubyBeforeRails, familyRuby),
+			rule([]string{stateRubyBeforeRails}, `^[\t ]*$`, stateRubyFrames),
+			rule([]string{stateRubyBeforeRails, stateRubyFrames}, `^[\t ]+.*?\.rb:\d+:in `, stateRubyFrames),
+			rule([]string{stateRubyFrames}, `^[\t ]+from .+:\d+:in `, stateRubyFrames),
+		},
+	}
+
+	allLanguages = []string{LanguagePython, LanguageGo, LanguageRuby, LanguageJava, LanguageDotnet, LanguageNodejs}
+)
+
+// stacktraceDetector is a state machine which detects whether lines are part of a stack trace.
+type stacktraceDetector struct {
+	rules map[string][]stacktraceRule
+	parse bool
+}
+
+// stacktraceState is the state of the detection for a source.
+type stacktraceState struct {
+	name string
+	// family is the family of the current stack trace, or empty when not in a stack trace.
+	family string
+}
+
+func (c StacktraceConfig) build() (*stacktraceDetector, error) {
+	languages := c.Languages
+	if len(languages) == 0 {
+		languages = allLanguages
+	}
+
+	var rules []stacktraceRule
+	var withExceptionRules bool
+	for _, language := range languages {
+		languageRules, ok := stacktraceRules[language]
+		if !ok {
+			return nil, fmt.Errorf("unsupported stacktrace language '%s', must be one of %s", language, strings.Join(allLanguages, ", "))
+		}
+		if !withExceptionRules && (language == LanguageJava || language == LanguageDotnet || language == LanguageNodejs) {
+			withExceptionRules = true
+			rules = append(rules, exceptionRules...)
+		}
+		rules = append(rules, languageRules...)
+	}
+
+	d := &stacktraceDetector{
+		rules: make(map[string][]stacktraceRule),
+		parse: c.Parse,
+	}
+	for _, r := range rules {
+		for _, from := range r.from {
+			d.rules[from] = append(d.rules[from], r)
+		}
+	}
+	return d, nil
+}
+
+// update moves the state to the given line, and reports whether the line starts a new entry.
+func (d *stacktraceDetector) update(state *stacktraceState, line string) bool {
+	if state.name != stateStart {
+		for _, r := range d.rules[state.name] {
+			if r.pattern.MatchString(line) {
+				state.name = r.to
+				return false
+			}
+		}
+	}
+
+	*state = stacktraceState{}
+	for _, r := range d.rules[stateStart] {
+		if r.pattern.MatchString(line) {
+			state.name = r.to
+			state.family = r.family
+			break
+		}
+	}
+	return true
+}
+
+var (
+	exceptionTypeRegex   = regexp.MustCompile(`(?:^|[^\w$.])((?:[A-Za-z_$][\w$]*\.)*[A-Za-z_$][\w$]*(?:Exception|Error|Throwable))(?::\s*(.*))?$`)
+	pythonExceptionRegex = regexp.MustCompile(`^((?:[^\s.():]+\.)*[^\s.():]+)(?::\s*(.*))?$`)
+	goPanicRegex         = regexp.MustCompile(`\bpanic: (.*?)(?: \[recovered\])?$`)
+	rubyExceptionRegex   = regexp.MustCompile(`^.+:\d+:in .+?: (.*) \(([\w:]+)\)$`)
+	rubyRailsRegex       = regexp.MustCompile(`(?:^|\s)([\w:]+) \((.*)\):$`)
+)
+
+// parseStacktrace returns the type and the message of the exception of a stack trace of the given family,
+// whose lines were combined with the given separator.
+func parseStacktrace(family string, stacktrace string, combineWith string) (exceptionType string, message string) {
+	lines := []string{stacktrace}
+	if combineWith != "" {
+		lines = strings.Split(stacktrace, combineWith)
+	}
+	switch family {
+	case familyException:
+		if match := exceptionTypeRegex.FindStringSubmatch(lines[0]); match != nil {
+			return match[1], match[2]
+		}
+	case familyPython:
+		// the exception which was raised last is the last line which is not indented
+		for i := len(lines) - 1; i > 0; i-- {
+			if match := pythonExceptionRegex.FindStringSubmatch(lines[i]); match != nil {
+				return match[1], match[2]
+			}
+		}
+	case familyGo:
+		if match := goPanicRegex.FindStringSubmatch(lines[0]); match != nil {
+			return "panic", match[1]
+		}
+		return "panic", ""
+	case familyRuby:
+		if match := rubyExceptionRegex.FindStringSubmatch(lines[0]); match != nil {
+			return match[2], match[1]
+		}
+		if match := rubyRailsRegex.FindStringSubmatch(lines[0]); match != nil {
+			return match[1], match[2]
+		}
+	}
+	return "", ""
+}
+
+// setStacktraceAttributes sets the exception attributes of an entry containing a stack trace.
+func setStacktraceAttributes(e *entry.Entry, family string, stacktrace string, combineWith string) error {
+	exceptionType, message := parseStacktrace(family, stacktrace, combineWith)
+	if exceptionType != "" {
+		if err := e.Set(exceptionTypeField, exceptionType); err != nil {
+			return err
+		}
+	}
+	if message != "" {
+		if err := e.Set(exceptionMessageField, message); err != nil {
+			return err
+		}
+	}
+	return e.Set(exceptionStacktraceField, stacktrace)
+}
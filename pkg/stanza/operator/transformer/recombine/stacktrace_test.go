@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recombine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const (
+	javaStacktrace = `Exception in thread "main" java.lang.IllegalStateException: failed to start
+	at com.example.App.start(App.java:42)
+	at com.example.App.main(App.java:10)
+Caused by: java.io.FileNotFoundException: config.yaml
+	at java.base/java.io.FileInputStream.open0(Native Method)
+	... 2 more`
+
+	pythonStacktrace = `Traceback (most recent call last):
+  File "/app/main.py", line 10, in <module>
+    load()
+  File "/app/main.py", line 6, in load
+    return 1 / 0
+           ~~^~~
+ZeroDivisionError: division by zero`
+
+	pythonChainedStacktrace = `Traceback (most recent call last):
+  File "/app/main.py", line 3, in <module>
+    config["key"]
+KeyError: 'key'
+
+During handling of the above exception, another exception occurred:
+
+Traceback (most recent call last):
+  File "/app/main.py", line 5, in <module>
+    raise ValueError("missing key")
+ValueError: missing key`
+
+	goStacktrace = `panic: runtime error: index out of range [3] with length 2
+
+goroutine 1 [running]:
+main.lookup(...)
+	/app/main.go:12
+main.main()
+	/app/main.go:7 +0x1d`
+
+	dotnetStacktrace = `System.InvalidOperationException: Operation is not valid
+ ---> System.ArgumentNullException: Value cannot be null. (Parameter 'name')
+   at Example.Service.Validate(String name) in /src/Service.cs:line 20
+   --- End of inner exception stack trace ---
+   at Example.Service.Run() in /src/Service.cs:line 12
+   at Example.Program.Main(String[] args) in /src/Program.cs:line 8`
+
+	rubyStacktrace = `app.rb:3:in 'divide': divided by 0 (ZeroDivisionError)
+	from app.rb:3:in 'Integer#/'
+	from app.rb:7:in '<main>'`
+
+	rubyRailsStacktrace = `ActionController::RoutingError (No route matches [GET] "/missing"):
+
+  actionpack (7.0.4) lib/action_dispatch/middleware/debug_exceptions.rb:28:in 'call'
+  railties (7.0.4) lib/rails/rack/logger.rb:40:in 'call_app'`
+
+	nodejsStacktrace = `TypeError: Cannot read properties of undefined (reading 'id')
+    at getUser (/app/index.js:5:20)
+    at async Server.<anonymous> (/app/server.js:12:3)`
+)
+
+func TestStacktrace(t *testing.T) {
+	cases := []struct {
+		name       string
+		languages  []string
+		stacktrace string
+		// expected are the exception type and message, or nil if the stack trace must not be parsed
+		expected []string
+	}{
+		{name: "Java", stacktrace: javaStacktrace, expected: []string{"java.lang.IllegalStateException", "failed to start"}},
+		{name: "Python", stacktrace: pythonStacktrace, expected: []string{"ZeroDivisionError", "division by zero"}},
+		{name: "PythonChained", stacktrace: pythonChainedStacktrace, expected: []string{"ValueError", "missing key"}},
+		{name: "Go", stacktrace: goStacktrace, expected: []string{"panic", "runtime error: index out of range [3] with length 2"}},
+		{name: "Dotnet", stacktrace: dotnetStacktrace, expected: []string{"System.InvalidOperationException", "Operation is not valid"}},
+		{name: "Ruby", stacktrace: rubyStacktrace, expected: []string{"ZeroDivisionError", "divided by 0"}},
+		{name: "RubyRails", stacktrace: rubyRailsStacktrace, expected: []string{"ActionController::RoutingError", `No route matches [GET] "/missing"`}},
+		{name: "Nodejs", stacktrace: nodejsStacktrace, expected: []string{"TypeError", "Cannot read properties of undefined (reading 'id')"}},
+		{name: "SelectedLanguage", languages: []string{LanguagePython}, stacktrace: pythonStacktrace, expected: []string{"ZeroDivisionError", "division by zero"}},
+		{name: "OtherLanguage", languages: []string{LanguageGo}, stacktrace: javaStacktrace},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.CombineField = entry.NewBodyField()
+			cfg.Stacktrace = &StacktraceConfig{Languages: tc.languages, Parse: true}
+			cfg.OutputIDs = []string{"fake"}
+			op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			recombine := op.(*Transformer)
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, recombine.SetOutputs([]operator.Operator{fake}))
+
+			lines := []string{"starting"}
+			lines = append(lines, strings.Split(tc.stacktrace, "\n")...)
+			lines = append(lines, "stopping")
+			for _, line := range lines {
+				e := entry.New()
+				e.Body = line
+				require.NoError(t, recombine.Process(context.Background(), e))
+			}
+			require.NoError(t, recombine.Stop())
+
+			var received []*entry.Entry
+			for len(fake.Received) > 0 {
+				received = append(received, <-fake.Received)
+			}
+
+			if tc.expected == nil {
+				// lines are not combined when their language is not detected
+				require.Len(t, received, len(lines))
+				for _, e := range received {
+					assert.Empty(t, e.Attributes)
+				}
+				return
+			}
+
+			require.Len(t, received, 3)
+			assert.Equal(t, "starting", received[0].Body)
+			assert.Empty(t, received[0].Attributes)
+			assert.Equal(t, "stopping", received[2].Body)
+			assert.Empty(t, received[2].Attributes)
+
+			assert.Equal(t, tc.stacktrace, received[1].Body)
+			assert.Equal(t, map[string]any{
+				"exception.type":       tc.expected[0],
+				"exception.message":    tc.expected[1],
+				"exception.stacktrace": tc.stacktrace,
+			}, received[1].Attributes)
+		})
+	}
+}
+
+func TestStacktraceCombineWith(t *testing.T) {
+	cfg := NewConfig()
+	cfg.CombineField = entry.NewBodyField()
+	cfg.CombineWith = " | "
+	cfg.Stacktrace = &StacktraceConfig{Parse: true}
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	recombine := op.(*Transformer)
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, recombine.SetOutputs([]operator.Operator{fake}))
+
+	lines := strings.Split(pythonStacktrace, "\n")
+	for _, line := range lines {
+		e := entry.New()
+		e.Body = line
+		require.NoError(t, recombine.Process(context.Background(), e))
+	}
+	require.NoError(t, recombine.Stop())
+
+	require.Len(t, fake.Received, 1)
+	e := <-fake.Received
+	stacktrace := strings.Join(lines, " | ")
+	assert.Equal(t, stacktrace, e.Body)
+	assert.Equal(t, map[string]any{
+		"exception.type":       "ZeroDivisionError",
+		"exception.message":    "division by zero",
+		"exception.stacktrace": stacktrace,
+	}, e.Attributes)
+}
+
+func TestStacktraceWithoutParse(t *testing.T) {
+	cfg := NewConfig()
+	cfg.CombineField = entry.NewBodyField()
+	cfg.Stacktrace = &StacktraceConfig{}
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	recombine := op.(*Transformer)
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, recombine.SetOutputs([]operator.Operator{fake}))
+
+	// consecutive stack traces are not combined together
+	for _, stacktrace := range []string{pythonStacktrace, pythonStacktrace} {
+		for _, line := range strings.Split(stacktrace, "\n") {
+			e := entry.New()
+			e.Body = line
+			require.NoError(t, recombine.Process(context.Background(), e))
+		}
+	}
+	require.NoError(t, recombine.Stop())
+
+	require.Len(t, fake.Received, 2)
+	for len(fake.Received) > 0 {
+		e := <-fake.Received
+		assert.Equal(t, pythonStacktrace, e.Body)
+		assert.Empty(t, e.Attributes)
+	}
+}
+
+func TestStacktraceConfig(t *testing.T) {
+	cfg := NewConfig()
+	cfg.CombineField = entry.NewBodyField()
+	cfg.Stacktrace = &StacktraceConfig{Languages: []string{"cobol"}}
+	_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "unsupported stacktrace language 'cobol'")
+
+	cfg.Stacktrace = &StacktraceConfig{}
+	cfg.IsFirstEntry = MatchAll
+	_, err = cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "is_first_entry and is_last_entry cannot be set with stacktrace")
+}
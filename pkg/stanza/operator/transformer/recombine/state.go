@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recombine // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+// batchesKey is the key under which the batches are persisted, in the scope of the operator.
+const batchesKey = "batches"
+
+// persistedBatch is the state of a batch which was still being combined when the operator stopped.
+type persistedBatch struct {
+	Source           string       `json:"source"`
+	BaseEntry        *entry.Entry `json:"base_entry"`
+	NumEntries       int          `json:"num_entries"`
+	Recombined       string       `json:"recombined"`
+	MatchDetected    bool         `json:"match_detected"`
+	Stacktrace       string       `json:"stacktrace,omitempty"`
+	StacktraceFamily string       `json:"stacktrace_family,omitempty"`
+	// BatchStacktraceFamily is the family of the stack trace which started the batch
+	BatchStacktraceFamily string `json:"batch_stacktrace_family,omitempty"`
+}
+
+// saveBatches persists the batches which are still being combined, and reports whether they were persisted.
+func (t *Transformer) saveBatches(ctx context.Context) (bool, error) {
+	batches := make([]persistedBatch, 0, len(t.batchMap))
+	for source, batch := range t.batchMap {
+		if batch.baseEntry == nil {
+			continue
+		}
+		batches = append(batches, persistedBatch{
+			Source:                source,
+			BaseEntry:             batch.baseEntry,
+			NumEntries:            batch.numEntries,
+			Recombined:            batch.recombined.String(),
+			MatchDetected:         batch.matchDetected,
+			Stacktrace:            batch.stacktrace.name,
+			StacktraceFamily:      batch.stacktrace.family,
+			BatchStacktraceFamily: batch.stacktraceFamily,
+		})
+	}
+	if len(batches) == 0 {
+		return true, nil
+	}
+	return operator.SaveState(ctx, t.persister, batchesKey, batches)
+}
+
+// restoreBatches restores the batches persisted when the operator last stopped. They are removed
+// from the persister so that they are not restored again if the operator does not stop gracefully.
+func (t *Transformer) restoreBatches(ctx context.Context) error {
+	var batches []persistedBatch
+	found, err := operator.LoadState(ctx, t.persister, batchesKey, &batches)
+	if err != nil || !found {
+		return err
+	}
+
+	// the force flush period starts over, so that the next entries of the batches can be read
+	now := time.Now()
+	for _, b := range batches {
+		batch := t.batchPool.Get().(*sourceBatch)
+		batch.baseEntry = b.BaseEntry
+		batch.numEntries = b.NumEntries
+		batch.recombined.Reset()
+		batch.recombined.WriteString(b.Recombined)
+		batch.firstEntryObservedTime = now
+		batch.matchDetected = b.MatchDetected
+		batch.stacktrace = stacktraceState{name: b.Stacktrace, family: b.StacktraceFamily}
+		batch.stacktraceFamily = b.BatchStacktraceFamily
+		t.batchMap[b.Source] = batch
+	}
+	return t.persister.Delete(ctx, batchesKey)
+}
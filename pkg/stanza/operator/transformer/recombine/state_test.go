@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recombine
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newPersistingTransformer(t *testing.T, cfg *Config) (*Transformer, *testutil.FakeOutput) {
+	cfg.CombineField = entry.NewBodyField()
+	cfg.PersistBatches = true
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	recombine := op.(*Transformer)
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, recombine.SetOutputs([]operator.Operator{fake}))
+	return recombine, fake
+}
+
+func processLines(t *testing.T, recombine *Transformer, source string, lines ...string) {
+	for _, line := range lines {
+		e := entry.New()
+		e.Body = line
+		e.AddAttribute("log.file.path", source)
+		require.NoError(t, recombine.Process(context.Background(), e))
+	}
+}
+
+func TestPersistBatches(t *testing.T) {
+	persister := testutil.NewMockPersister("recombine")
+	cfg := NewConfig()
+	cfg.IsFirstEntry = "body matches '^start'"
+
+	recombine, fake := newPersistingTransformer(t, cfg)
+	require.NoError(t, recombine.Start(persister))
+	processLines(t, recombine, "a.log", "start a", "a1")
+	processLines(t, recombine, "b.log", "start b")
+	require.NoError(t, recombine.Stop())
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	recombine, fake = newPersistingTransformer(t, cfg)
+	require.NoError(t, recombine.Start(persister))
+	// the restored batches are not restored twice
+	data, err := persister.Get(context.Background(), batchesKey)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	processLines(t, recombine, "a.log", "a2", "start a")
+	e := <-fake.Received
+	assert.Equal(t, "start a\na1\na2", e.Body)
+	assert.Equal(t, map[string]any{"log.file.path": "a.log"}, e.Attributes)
+	require.NoError(t, recombine.Stop())
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestPersistBatchesWithoutStorage(t *testing.T) {
+	cfg := NewConfig()
+	cfg.IsFirstEntry = "body matches '^start'"
+
+	recombine, fake := newPersistingTransformer(t, cfg)
+	require.NoError(t, recombine.Start(operator.NewScopedPersister("recombine", storage.NewNopClient())))
+	processLines(t, recombine, "a.log", "start a", "a1")
+	require.NoError(t, recombine.Stop())
+	fake.ExpectBody(t, "start a\na1")
+}
+
+func TestPersistStacktraceBatches(t *testing.T) {
+	persister := testutil.NewMockPersister("recombine")
+	cfg := NewConfig()
+	cfg.Stacktrace = &StacktraceConfig{Parse: true}
+
+	lines := strings.Split(goStacktrace, "\n")
+	recombine, fake := newPersistingTransformer(t, cfg)
+	require.NoError(t, recombine.Start(persister))
+	processLines(t, recombine, "a.log", lines[:3]...)
+	require.NoError(t, recombine.Stop())
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	recombine, fake = newPersistingTransformer(t, cfg)
+	require.NoError(t, recombine.Start(persister))
+	processLines(t, recombine, "a.log", lines[3:]...)
+	processLines(t, recombine, "a.log", "done")
+
+	e := <-fake.Received
+	assert.Equal(t, goStacktrace, e.Body)
+	assert.Equal(t, "panic", e.Attributes["exception.type"])
+	require.NoError(t, recombine.Stop())
+}
+
+func TestRestoreBatchesError(t *testing.T) {
+	persister := testutil.NewUnscopedMockPersister()
+	require.NoError(t, persister.Set(context.Background(), batchesKey, []byte("{")))
+
+	cfg := NewConfig()
+	cfg.IsFirstEntry = MatchAll
+	recombine, _ := newPersistingTransformer(t, cfg)
+	require.ErrorContains(t, recombine.Start(persister), "restore batches")
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -26,6 +27,7 @@ type Transformer struct {
 	helper.TransformerOperator
 	matchFirstLine        bool
 	prog                  *vm.Program
+	stacktrace            *stacktraceDetector
 	maxBatchSize          int
 	maxUnmatchedBatchSize int
 	maxSources            int
@@ -36,6 +38,8 @@ type Transformer struct {
 	forceFlushTimeout     time.Duration
 	chClose               chan struct{}
 	sourceIdentifier      entry.Field
+	persistBatches        bool
+	persister             operator.Persister
 
 	sync.Mutex
 	batchPool  sync.Pool
@@ -50,9 +54,21 @@ type sourceBatch struct {
 	recombined             *bytes.Buffer
 	firstEntryObservedTime time.Time
 	matchDetected          bool
+	// stacktrace is the state of the stack trace detection after the last entry of the batch
+	stacktrace stacktraceState
+	// stacktraceFamily is the family of the stack trace which started the batch, if any
+	stacktraceFamily string
 }
 
-func (t *Transformer) Start(_ operator.Persister) error {
+func (t *Transformer) Start(persister operator.Persister) error {
+	if t.persistBatches && persister != nil {
+		t.persister = persister
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := t.restoreBatches(ctx); err != nil {
+			return fmt.Errorf("restore batches: %w", err)
+		}
+	}
 	go t.flushLoop()
 	return nil
 }
@@ -88,6 +104,17 @@ func (t *Transformer) Stop() error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
+	if t.persister != nil {
+		saved, err := t.saveBatches(ctx)
+		switch {
+		case err != nil:
+			t.Logger().Error("failed to persist batches, flushing them", zap.Error(err))
+		case !saved:
+			t.Logger().Warn("batches cannot be persisted without a storage extension, flushing them")
+		default:
+			t.clearBatches()
+		}
+	}
 	t.flushAllSources(ctx)
 
 	close(t.chClose)
@@ -103,6 +130,21 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	t.Lock()
 	defer t.Unlock()
 
+	var s string
+	err := e.Read(t.sourceIdentifier, &s)
+	if err != nil {
+		t.Logger().Warn("entry does not contain the source_identifier, so it may be pooled with other sources")
+		s = DefaultSourceIdentifier
+	}
+
+	if s == "" {
+		s = DefaultSourceIdentifier
+	}
+
+	if t.stacktrace != nil {
+		return t.processStacktrace(ctx, e, s)
+	}
+
 	// Get the environment for executing the expression.
 	// In the future, we may want to provide access to the currently
 	// batched entries so users can do comparisons to other entries
@@ -117,16 +159,6 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 
 	// this is guaranteed to be a boolean because of expr.AsBool
 	matches := m.(bool)
-	var s string
-	err = e.Read(t.sourceIdentifier, &s)
-	if err != nil {
-		t.Logger().Warn("entry does not contain the source_identifier, so it may be pooled with other sources")
-		s = DefaultSourceIdentifier
-	}
-
-	if s == "" {
-		s = DefaultSourceIdentifier
-	}
 
 	switch {
 	// This is the first entry in the next batch
@@ -151,6 +183,37 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	return nil
 }
 
+// processStacktrace combines the entries which are part of the same stack trace. Every entry which
+// is not the continuation of a stack trace is the first entry of a new batch.
+func (t *Transformer) processStacktrace(ctx context.Context, e *entry.Entry, source string) error {
+	var line string
+	if err := e.Read(t.combineField, &line); err != nil {
+		t.Logger().Error("entry does not contain the combine_field")
+		return t.Write(ctx, e)
+	}
+
+	var state stacktraceState
+	if batch, ok := t.batchMap[source]; ok {
+		state = batch.stacktrace
+	}
+	first := t.stacktrace.update(&state, line)
+	if first {
+		if err := t.flushSource(ctx, source); err != nil {
+			return err
+		}
+	}
+
+	t.addToBatch(ctx, e, source, first)
+	// the batch may have been flushed because of its size
+	if batch, ok := t.batchMap[source]; ok {
+		batch.stacktrace = state
+		if first {
+			batch.stacktraceFamily = state.family
+		}
+	}
+	return nil
+}
+
 // addToBatch adds the current entry to the current batch of entries that will be combined
 func (t *Transformer) addToBatch(ctx context.Context, e *entry.Entry, source string, matches bool) {
 	batch, ok := t.batchMap[source]
@@ -227,6 +290,12 @@ func (t *Transformer) flushSource(ctx context.Context, source string) error {
 		return err
 	}
 
+	if t.stacktrace != nil && t.stacktrace.parse && batch.stacktraceFamily != "" && batch.numEntries > 1 {
+		if err = setStacktraceAttributes(batch.baseEntry, batch.stacktraceFamily, batch.recombined.String(), t.combineWith); err != nil {
+			return err
+		}
+	}
+
 	err = t.Write(ctx, batch.baseEntry)
 	t.removeBatch(source)
 	return err
@@ -240,10 +309,19 @@ func (t *Transformer) addNewBatch(source string, e *entry.Entry) *sourceBatch {
 	batch.recombined.Reset()
 	batch.firstEntryObservedTime = e.ObservedTimestamp
 	batch.matchDetected = false
+	batch.stacktrace = stacktraceState{}
+	batch.stacktraceFamily = ""
 	t.batchMap[source] = batch
 	return batch
 }
 
+// clearBatches removes all batches without flushing them.
+func (t *Transformer) clearBatches() {
+	for source := range t.batchMap {
+		t.removeBatch(source)
+	}
+}
+
 // removeBatch removes the batch for the given source.
 func (t *Transformer) removeBatch(source string) {
 	batch := t.batchMap[source]
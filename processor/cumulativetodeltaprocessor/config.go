@@ -41,6 +41,18 @@ type Config struct {
 	// Cannot be used with deprecated Metrics config option.
 	Include MatchMetrics `mapstructure:"include"`
 	Exclude MatchMetrics `mapstructure:"exclude"`
+
+	// Storage is the ID of the storage extension the previous values of the tracked metrics are persisted to,
+	// so that deltas spanning a restart of the collector are not lost. If not set, they are only kept in memory.
+	Storage *component.ID `mapstructure:"storage"`
+
+	// CheckpointInterval is how often the tracked values are written to Storage, in addition to shutdown.
+	// Set to 0 to only write them on shutdown.
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
+
+	// RestoreMaxStaleness is how long before the start of the processor a persisted value may have been last
+	// seen to be restored. Set to 0 to restore all persisted values.
+	RestoreMaxStaleness time.Duration `mapstructure:"restore_max_staleness"`
 }
 
 type MatchMetrics struct {
@@ -65,6 +77,13 @@ func (config *Config) Validate() error {
 		return fmt.Errorf("metrics must be supplied if match_type is set")
 	}
 
+	if config.CheckpointInterval < 0 {
+		return fmt.Errorf("checkpoint_interval must not be negative")
+	}
+	if config.RestoreMaxStaleness < 0 {
+		return fmt.Errorf("restore_max_staleness must not be negative")
+	}
+
 	for _, metricType := range config.Exclude.MetricTypes {
 		if valid := validMetricTypes[strings.ToLower(metricType)]; !valid {
 			return fmt.Errorf(
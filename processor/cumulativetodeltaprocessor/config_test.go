@@ -23,6 +23,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.MustNewID("file_storage")
+
 	tests := []struct {
 		id           component.ID
 		expected     component.Config
@@ -149,6 +151,18 @@ func TestLoadConfig(t *testing.T) {
 				InitialValue: tracking.InitialValueDrop,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "storage"),
+			expected: &Config{
+				Storage:             &storageID,
+				CheckpointInterval:  time.Minute,
+				RestoreMaxStaleness: 10 * time.Minute,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "negative_checkpoint_interval"),
+			errorMessage: "checkpoint_interval must not be negative",
+		},
 	}
 
 	for _, tt := range tests {
@@ -40,7 +40,7 @@ func createMetricsProcessor(
 		return nil, fmt.Errorf("configuration parsing error")
 	}
 
-	metricsProcessor, err := newCumulativeToDeltaProcessor(processorConfig, set.Logger)
+	metricsProcessor, err := newCumulativeToDeltaProcessor(processorConfig, set.ID, set.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -52,5 +52,6 @@ func createMetricsProcessor(
 		nextConsumer,
 		metricsProcessor.processMetrics,
 		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(metricsProcessor.start),
 		processorhelper.WithShutdown(metricsProcessor.shutdown))
 }
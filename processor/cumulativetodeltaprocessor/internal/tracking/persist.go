@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// statesVersion is the version of the encoding produced by MarshalStates.
+const statesVersion = 1
+
+const (
+	pointNumber byte = iota
+	pointHistogram
+)
+
+var errInvalidStates = errors.New("invalid encoded states")
+
+// MarshalStates encodes the previous point of every tracked metric, so that
+// the tracker can be restored with UnmarshalStates.
+func (t *MetricTracker) MarshalStates() []byte {
+	buf := []byte{statesVersion}
+	t.states.Range(func(key, value any) bool {
+		s := value.(*State)
+		s.Lock()
+		point := s.PrevPoint
+		s.Unlock()
+
+		k := key.(string)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(k)))
+		buf = append(buf, k...)
+		buf = binary.BigEndian.AppendUint64(buf, uint64(point.ObservedTimestamp))
+		if point.HistogramValue == nil {
+			buf = append(buf, pointNumber)
+			buf = binary.BigEndian.AppendUint64(buf, uint64(point.IntValue))
+			buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(point.FloatValue))
+			return true
+		}
+		buf = append(buf, pointHistogram)
+		buf = binary.BigEndian.AppendUint64(buf, point.HistogramValue.Count)
+		buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(point.HistogramValue.Sum))
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(point.HistogramValue.Buckets)))
+		for _, b := range point.HistogramValue.Buckets {
+			buf = binary.BigEndian.AppendUint64(buf, b)
+		}
+		return true
+	})
+	return buf
+}
+
+// UnmarshalStates restores the states encoded by MarshalStates. States last
+// observed before staleBefore are skipped, as are the metrics that are already
+// tracked. It returns the number of restored states.
+func (t *MetricTracker) UnmarshalStates(data []byte, staleBefore pcommon.Timestamp) (int, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+	if data[0] != statesVersion {
+		return 0, fmt.Errorf("unsupported encoded states version %d", data[0])
+	}
+
+	r := reader{buf: data[1:]}
+	restored := 0
+	for len(r.buf) > 0 {
+		key := string(r.bytes(int(r.uint32())))
+		point := ValuePoint{ObservedTimestamp: pcommon.Timestamp(r.uint64())}
+		switch r.byte() {
+		case pointNumber:
+			point.IntValue = int64(r.uint64())
+			point.FloatValue = math.Float64frombits(r.uint64())
+		case pointHistogram:
+			hist := &HistogramPoint{
+				Count: r.uint64(),
+				Sum:   math.Float64frombits(r.uint64()),
+			}
+			n := int(r.uint32())
+			if n > len(r.buf)/8 {
+				return restored, errInvalidStates
+			}
+			hist.Buckets = make([]uint64, n)
+			for i := range hist.Buckets {
+				hist.Buckets[i] = r.uint64()
+			}
+			point.HistogramValue = hist
+		default:
+			r.err = errInvalidStates
+		}
+		if r.err != nil {
+			return restored, r.err
+		}
+
+		if point.ObservedTimestamp < staleBefore {
+			continue
+		}
+		if _, loaded := t.states.LoadOrStore(key, &State{PrevPoint: point}); !loaded {
+			restored++
+		}
+	}
+	return restored, nil
+}
+
+// reader decodes the encoded states, recording the first error encountered.
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil || n > len(r.buf) {
+		r.err = errInvalidStates
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *reader) byte() byte {
+	b := r.bytes(1)
+	if b == nil {
+		return 0xff
+	}
+	return b[0]
+}
+
+func (r *reader) uint32() uint32 {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (r *reader) uint64() uint64 {
+	b := r.bytes(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracking
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func TestMetricTracker_MarshalStates(t *testing.T) {
+	miSum := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		MetricName:             "sum",
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+	}
+	miHist := miSum
+	miHist.MetricType = pmetric.MetricTypeHistogram
+	miHist.MetricName = "histogram"
+	miStale := miSum
+	miStale.MetricName = "stale"
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueDrop)
+	m.Convert(MetricPoint{Identity: miSum, Value: ValuePoint{ObservedTimestamp: 100, IntValue: 10}})
+	m.Convert(MetricPoint{Identity: miHist, Value: ValuePoint{ObservedTimestamp: 100, HistogramValue: &HistogramPoint{
+		Count:   3,
+		Sum:     math.NaN(),
+		Buckets: []uint64{1, 2},
+	}}})
+	m.Convert(MetricPoint{Identity: miStale, Value: ValuePoint{ObservedTimestamp: 10, IntValue: 10}})
+
+	// The restored tracker converts the next points as if it never stopped.
+	restored := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueDrop)
+	n, err := restored.UnmarshalStates(m.MarshalStates(), 50)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	out, valid := restored.Convert(MetricPoint{Identity: miSum, Value: ValuePoint{ObservedTimestamp: 200, IntValue: 15}})
+	require.True(t, valid)
+	assert.Equal(t, pcommon.Timestamp(100), out.StartTimestamp)
+	assert.Equal(t, int64(5), out.IntValue)
+
+	out, valid = restored.Convert(MetricPoint{Identity: miHist, Value: ValuePoint{ObservedTimestamp: 200, HistogramValue: &HistogramPoint{
+		Count:   5,
+		Sum:     4,
+		Buckets: []uint64{2, 3},
+	}}})
+	require.True(t, valid)
+	assert.Equal(t, uint64(2), out.HistogramValue.Count)
+	assert.Equal(t, []uint64{1, 1}, out.HistogramValue.Buckets)
+
+	// States older than the cutoff are not restored, so the first point is dropped again.
+	_, valid = restored.Convert(MetricPoint{Identity: miStale, Value: ValuePoint{ObservedTimestamp: 200, IntValue: 15}})
+	assert.False(t, valid)
+}
+
+func TestMetricTracker_UnmarshalStatesInvalid(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueAuto)
+
+	n, err := m.UnmarshalStates(nil, 0)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	_, err = m.UnmarshalStates([]byte{statesVersion + 1}, 0)
+	assert.Error(t, err)
+
+	_, err = m.UnmarshalStates([]byte{statesVersion, 0, 0, 0, 10, 'a'}, 0)
+	assert.ErrorIs(t, err, errInvalidStates)
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+// statesKey is the storage key holding the previous values of the tracked metrics.
+const statesKey = "tracked_metrics"
+
+// restore loads the values persisted by a previous run of the processor into the tracker,
+// skipping the ones last seen before the configured staleness cutoff.
+func (ctdp *cumulativeToDeltaProcessor) restore(ctx context.Context) error {
+	data, err := ctdp.client.Get(ctx, statesKey)
+	if err != nil {
+		return fmt.Errorf("failed to read tracked metrics from storage: %w", err)
+	}
+
+	var staleBefore pcommon.Timestamp
+	if ctdp.config.RestoreMaxStaleness > 0 {
+		staleBefore = pcommon.NewTimestampFromTime(time.Now().Add(-ctdp.config.RestoreMaxStaleness))
+	}
+	restored, err := ctdp.deltaCalculator.UnmarshalStates(data, staleBefore)
+	if err != nil {
+		// A corrupted checkpoint only costs the first point of every metric, don't prevent the start.
+		ctdp.logger.Warn("Failed to restore tracked metrics", zap.Error(err))
+	}
+	ctdp.logger.Debug("Restored tracked metrics", zap.Int("metrics", restored))
+	return nil
+}
+
+// checkpoint writes the values of all tracked metrics to the storage.
+func (ctdp *cumulativeToDeltaProcessor) checkpoint(ctx context.Context) error {
+	return ctdp.client.Set(ctx, statesKey, ctdp.deltaCalculator.MarshalStates())
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, componentID component.ID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+)
+
+func cumulativeSum(name string, start, ts time.Time, value int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	dp.SetIntValue(value)
+	return md
+}
+
+func TestPersistedStatesSurviveRestart(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("test")
+	cfg := &Config{
+		InitialValue:        tracking.InitialValueDrop,
+		Storage:             &storageID,
+		RestoreMaxStaleness: time.Hour,
+	}
+
+	start := func(sink *consumertest.MetricsSink) processor.Metrics {
+		proc, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, sink)
+		require.NoError(t, err)
+		host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", storageDir)
+		require.NoError(t, proc.Start(context.Background(), host))
+		return proc
+	}
+
+	now := time.Now()
+	reset := now.Add(-3 * time.Hour)
+	sink := new(consumertest.MetricsSink)
+	proc := start(sink)
+	require.NoError(t, proc.ConsumeMetrics(context.Background(), cumulativeSum("recent", reset, now.Add(-time.Minute), 10)))
+	require.NoError(t, proc.ConsumeMetrics(context.Background(), cumulativeSum("stale", reset, now.Add(-2*time.Hour), 10)))
+	require.NoError(t, proc.Shutdown(context.Background()))
+
+	// The restarted processor converts the recent metric as if it never stopped,
+	// while the first point of the stale one is dropped again.
+	sink = new(consumertest.MetricsSink)
+	proc = start(sink)
+	require.NoError(t, proc.ConsumeMetrics(context.Background(), cumulativeSum("stale", reset, now, 15)))
+	require.NoError(t, proc.ConsumeMetrics(context.Background(), cumulativeSum("recent", reset, now, 15)))
+	require.NoError(t, proc.Shutdown(context.Background()))
+
+	var names []string
+	var sum pmetric.Sum
+	for _, md := range sink.AllMetrics() {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+			for j := 0; j < ms.Len(); j++ {
+				names = append(names, ms.At(j).Name())
+				sum = ms.At(j).Sum()
+			}
+		}
+	}
+	require.Equal(t, []string{"recent"}, names)
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, sum.AggregationTemporality())
+	dp := sum.DataPoints().At(0)
+	assert.EqualValues(t, 5, dp.IntValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now.Add(-time.Minute)), dp.StartTimestamp())
+}
+
+func TestStartWithMissingStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	cfg := &Config{Storage: &storageID}
+	proc, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, new(consumertest.MetricsSink))
+	require.NoError(t, err)
+	assert.ErrorContains(t, proc.Start(context.Background(), storagetest.NewStorageHost()), "storage extension 'test_storage/missing' not found")
+}
@@ -5,10 +5,15 @@ package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentele
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 
@@ -24,14 +29,24 @@ type cumulativeToDeltaProcessor struct {
 	logger             *zap.Logger
 	deltaCalculator    *tracking.MetricTracker
 	cancelFunc         context.CancelFunc
+
+	id     component.ID
+	config *Config
+	// client is only set when a storage extension is configured
+	client storage.Client
+	ctx    context.Context
+	wg     sync.WaitGroup
 }
 
-func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) (*cumulativeToDeltaProcessor, error) {
+func newCumulativeToDeltaProcessor(config *Config, id component.ID, logger *zap.Logger) (*cumulativeToDeltaProcessor, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	p := &cumulativeToDeltaProcessor{
 		logger:     logger,
 		cancelFunc: cancel,
+		id:         id,
+		config:     config,
+		ctx:        ctx,
 	}
 	if len(config.Include.Metrics) > 0 {
 		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
@@ -144,11 +159,51 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 	return md, nil
 }
 
-func (ctdp *cumulativeToDeltaProcessor) shutdown(context.Context) error {
-	ctdp.cancelFunc()
+func (ctdp *cumulativeToDeltaProcessor) start(ctx context.Context, host component.Host) error {
+	if ctdp.config.Storage == nil {
+		return nil
+	}
+	client, err := getStorageClient(ctx, host, *ctdp.config.Storage, ctdp.id)
+	if err != nil {
+		return err
+	}
+	ctdp.client = client
+
+	if err = ctdp.restore(ctx); err != nil {
+		return err
+	}
+
+	if ctdp.config.CheckpointInterval > 0 {
+		ctdp.wg.Add(1)
+		go func() {
+			defer ctdp.wg.Done()
+			ticker := time.NewTicker(ctdp.config.CheckpointInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					if err := ctdp.checkpoint(ctdp.ctx); err != nil {
+						ctdp.logger.Warn("Failed to persist tracked metrics", zap.Error(err))
+					}
+				case <-ctdp.ctx.Done():
+					return
+				}
+			}
+		}()
+	}
 	return nil
 }
 
+func (ctdp *cumulativeToDeltaProcessor) shutdown(ctx context.Context) error {
+	ctdp.cancelFunc()
+	ctdp.wg.Wait()
+	if ctdp.client == nil {
+		return nil
+	}
+	err := ctdp.checkpoint(ctx)
+	return errors.Join(err, ctdp.client.Close(ctx))
+}
+
 func (ctdp *cumulativeToDeltaProcessor) shouldConvertMetric(metric pmetric.Metric) bool {
 	return (ctdp.includeFS == nil || ctdp.includeFS.Matches(metric.Name())) &&
 		(len(ctdp.includeMetricTypes) == 0 || ctdp.includeMetricTypes[metric.Type()]) &&
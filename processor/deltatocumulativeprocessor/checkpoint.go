@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metrics"
+)
+
+const (
+	// streamsKey is the storage key holding the checkpointed streams
+	streamsKey = "streams"
+
+	// lastSeenKey is the metadata key of the checkpointed metrics holding the
+	// last time each of their datapoints was seen, in unix nanoseconds
+	lastSeenKey = "deltatocumulative.last_seen"
+)
+
+// origin is what is needed, besides its value, to rebuild a stream: the
+// resource, scope and metric it belongs to, and when it was last seen.
+type origin struct {
+	res    pcommon.Resource
+	scope  pcommon.InstrumentationScope
+	metric pmetric.Metric
+
+	last time.Time
+}
+
+func newOrigin(m metrics.Metric) *origin {
+	o := &origin{
+		res:    pcommon.NewResource(),
+		scope:  pcommon.NewInstrumentationScope(),
+		metric: pmetric.NewMetric(),
+	}
+	m.Resource().CopyTo(o.res)
+	m.Scope().CopyTo(o.scope)
+	copyDescriptor(m.Metric, o.metric)
+	return o
+}
+
+// checkpointer periodically writes the cumulative state of all streams to a
+// storage.Client, so that it can be restored after a restart of the collector.
+//
+// Every checkpoint holds the complete state, encoded as pmetric.Metrics with
+// one datapoint per stream.
+type checkpointer struct {
+	client  storage.Client
+	origins map[identity.Stream]*origin
+}
+
+func newCheckpointer(client storage.Client) *checkpointer {
+	return &checkpointer{
+		client:  client,
+		origins: make(map[identity.Stream]*origin),
+	}
+}
+
+// Track records that the stream was seen at the given time.
+func (c *checkpointer) Track(now time.Time, id identity.Stream, m metrics.Metric) {
+	o, ok := c.origins[id]
+	if !ok {
+		o = newOrigin(m)
+		c.origins[id] = o
+	}
+	o.last = now
+}
+
+func (c *checkpointer) Delete(id identity.Stream) {
+	delete(c.origins, id)
+}
+
+// Snapshot encodes the given state. It must be called while holding the lock
+// of the processor, the returned data can be written at any time.
+func (c *checkpointer) Snapshot(last state) ([]byte, error) {
+	md := pmetric.NewMetrics()
+	out := make(map[identity.Metric]pmetric.Metric)
+
+	for id, o := range c.origins {
+		m, ok := out[id.Metric()]
+		if !ok {
+			rm := md.ResourceMetrics().AppendEmpty()
+			o.res.CopyTo(rm.Resource())
+			sm := rm.ScopeMetrics().AppendEmpty()
+			o.scope.CopyTo(sm.Scope())
+			m = sm.Metrics().AppendEmpty()
+			o.metric.CopyTo(m)
+			m.Metadata().PutEmptySlice(lastSeenKey)
+			out[id.Metric()] = m
+		}
+
+		switch m.Type() {
+		case pmetric.MetricTypeSum:
+			dp, ok := last.nums[id]
+			if !ok {
+				continue
+			}
+			dp.CopyTo(m.Sum().DataPoints().AppendEmpty())
+		case pmetric.MetricTypeHistogram:
+			dp, ok := last.hist[id]
+			if !ok {
+				continue
+			}
+			dp.CopyTo(m.Histogram().DataPoints().AppendEmpty())
+		case pmetric.MetricTypeExponentialHistogram:
+			dp, ok := last.expo[id]
+			if !ok {
+				continue
+			}
+			dp.CopyTo(m.ExponentialHistogram().DataPoints().AppendEmpty())
+		default:
+			continue
+		}
+		seen, _ := m.Metadata().Get(lastSeenKey)
+		seen.Slice().AppendEmpty().SetInt(o.last.UnixNano())
+	}
+
+	return (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+}
+
+// Save writes the given snapshot to the storage.
+func (c *checkpointer) Save(ctx context.Context, data []byte) error {
+	return c.client.Set(ctx, streamsKey, data)
+}
+
+// Restore reads the last checkpoint from the storage and calls restore for
+// every stream it holds, which tells whether the stream was restored.
+func (c *checkpointer) Restore(ctx context.Context, restore func(id identity.Stream, dp any, last time.Time) bool) error {
+	data, err := c.client.Get(ctx, streamsKey)
+	if err != nil || data == nil {
+		return err
+	}
+	md, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(data)
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint: %w", err)
+	}
+
+	metrics.Filter(md, func(m metrics.Metric) bool {
+		seen, ok := m.Metadata().Get(lastSeenKey)
+		if !ok || seen.Type() != pcommon.ValueTypeSlice {
+			return false
+		}
+		i := 0
+		m.Filter(func(id identity.Stream, dp any) bool {
+			last := time.Now()
+			if i < seen.Slice().Len() {
+				last = time.Unix(0, seen.Slice().At(i).Int())
+			}
+			i++
+			if restore(id, dp, last) {
+				c.Track(last, id, m)
+			}
+			return true
+		})
+		return true
+	})
+	return nil
+}
+
+func (c *checkpointer) Close(ctx context.Context) error {
+	return c.client.Close(ctx)
+}
+
+// copyDescriptor copies everything but the datapoints of the metric.
+func copyDescriptor(src, dst pmetric.Metric) {
+	dst.SetName(src.Name())
+	dst.SetDescription(src.Description())
+	dst.SetUnit(src.Unit())
+	switch src.Type() {
+	case pmetric.MetricTypeSum:
+		sum := dst.SetEmptySum()
+		sum.SetIsMonotonic(src.Sum().IsMonotonic())
+		sum.SetAggregationTemporality(src.Sum().AggregationTemporality())
+	case pmetric.MetricTypeHistogram:
+		dst.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dst.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	}
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, componentID component.ID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metadata"
+)
+
+func deltaMetrics(sum int64, histCount uint64, ts pcommon.Timestamp) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkpoint")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("test")
+
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("requests")
+	s := m.SetEmptySum()
+	s.SetIsMonotonic(true)
+	s.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	for _, path := range []string{"/a", "/b"} {
+		dp := s.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("path", path)
+		dp.SetStartTimestamp(ts - 10)
+		dp.SetTimestamp(ts)
+		dp.SetIntValue(sum)
+	}
+
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("latency")
+	h := m.SetEmptyHistogram()
+	h.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	dp := h.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(ts - 10)
+	dp.SetTimestamp(ts)
+	dp.SetCount(histCount)
+	dp.SetSum(float64(histCount))
+	dp.ExplicitBounds().FromRaw([]float64{1})
+	dp.BucketCounts().FromRaw([]uint64{histCount, 0})
+	return md
+}
+
+func TestCheckpointSurvivesRestart(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("test")
+	cfg := &Config{MaxStale: 0, MaxStreams: math.MaxInt, Storage: &storageID, CheckpointInterval: time.Hour}
+
+	start := func(sink *consumertest.MetricsSink) *Processor {
+		proc, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, sink)
+		require.NoError(t, err)
+		host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", storageDir)
+		require.NoError(t, proc.Start(context.Background(), host))
+		return proc.(*Processor)
+	}
+
+	sink := new(consumertest.MetricsSink)
+	proc := start(sink)
+	require.NoError(t, proc.ConsumeMetrics(context.Background(), deltaMetrics(5, 2, 1000)))
+	require.NoError(t, proc.ConsumeMetrics(context.Background(), deltaMetrics(3, 1, 1100)))
+	require.NoError(t, proc.Shutdown(context.Background()))
+
+	// The restarted processor continues from the checkpointed cumulative values.
+	sink = new(consumertest.MetricsSink)
+	proc = start(sink)
+	assert.Equal(t, 3, proc.last.Len())
+	require.NoError(t, proc.ConsumeMetrics(context.Background(), deltaMetrics(4, 3, 1200)))
+	require.NoError(t, proc.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	sm := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0)
+
+	sum := sm.Metrics().At(0).Sum()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+	for i := 0; i < sum.DataPoints().Len(); i++ {
+		dp := sum.DataPoints().At(i)
+		assert.EqualValues(t, 12, dp.IntValue())
+		assert.Equal(t, pcommon.Timestamp(990), dp.StartTimestamp())
+		assert.Equal(t, pcommon.Timestamp(1200), dp.Timestamp())
+	}
+
+	hist := sm.Metrics().At(1).Histogram().DataPoints().At(0)
+	assert.EqualValues(t, 6, hist.Count())
+	assert.Equal(t, []uint64{6, 0}, hist.BucketCounts().AsRaw())
+}
+
+func TestCheckpointRestoresLastSeen(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("test")
+	cfg := &Config{MaxStale: time.Minute, MaxStreams: 2, Storage: &storageID, CheckpointInterval: time.Hour}
+	host := func() *storagetest.StorageHost {
+		return storagetest.NewStorageHost().WithFileBackedStorageExtension("test", storageDir)
+	}
+
+	proc, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, new(consumertest.MetricsSink))
+	require.NoError(t, err)
+	require.NoError(t, proc.Start(context.Background(), host()))
+	require.NoError(t, proc.ConsumeMetrics(context.Background(), deltaMetrics(1, 1, 1000)))
+	seen := proc.(*Processor).ckpt.origins
+	require.Len(t, seen, 2)
+	require.NoError(t, proc.Shutdown(context.Background()))
+
+	proc, err = NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, new(consumertest.MetricsSink))
+	require.NoError(t, err)
+	require.NoError(t, proc.Start(context.Background(), host()))
+	defer func() { require.NoError(t, proc.Shutdown(context.Background())) }()
+
+	// The streams within max_streams are restored, with the time they were last seen.
+	restored := proc.(*Processor).ckpt.origins
+	require.Len(t, restored, 2)
+	for id, o := range restored {
+		require.Contains(t, seen, id)
+		assert.True(t, seen[id].last.Equal(o.last))
+	}
+}
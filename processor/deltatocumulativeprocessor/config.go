@@ -20,6 +20,24 @@ var _ xconfmap.Validator = (*Config)(nil)
 type Config struct {
 	MaxStale   time.Duration `mapstructure:"max_stale"`
 	MaxStreams int           `mapstructure:"max_streams"`
+
+	// Storage is the ID of the storage extension the state of all streams is
+	// checkpointed to, so cumulative values continue monotonically across
+	// restarts. If not set, the state is only kept in memory.
+	Storage *component.ID `mapstructure:"storage"`
+	// CheckpointInterval is how often the state is written to Storage. It is
+	// also written on shutdown.
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
+
+	// ReorderWindow is how long delta datapoints are held back before being
+	// aggregated, so that datapoints of a stream arriving out of order within
+	// the window are aggregated in timestamp order instead of being dropped.
+	// Disabled when zero.
+	ReorderWindow time.Duration `mapstructure:"reorder_window"`
+	// ReorderMaxDatapoints is the maximum number of datapoints held back by
+	// the reorder window. Once reached, all held back datapoints are
+	// aggregated and passed on without waiting for the window to elapse.
+	ReorderMaxDatapoints int `mapstructure:"reorder_max_datapoints"`
 }
 
 func (c *Config) Validate() error {
@@ -29,6 +47,15 @@ func (c *Config) Validate() error {
 	if c.MaxStreams < 0 {
 		return fmt.Errorf("max_streams must be a positive number (got %d)", c.MaxStreams)
 	}
+	if c.ReorderWindow < 0 {
+		return fmt.Errorf("reorder_window must not be negative (got %s)", c.ReorderWindow)
+	}
+	if c.ReorderWindow > 0 && c.ReorderMaxDatapoints <= 0 {
+		return fmt.Errorf("reorder_max_datapoints must be a positive number (got %d)", c.ReorderMaxDatapoints)
+	}
+	if c.Storage != nil && c.CheckpointInterval <= 0 {
+		return fmt.Errorf("checkpoint_interval must be a positive duration (got %s)", c.CheckpointInterval)
+	}
 	return nil
 }
 
@@ -39,6 +66,9 @@ func createDefaultConfig() component.Config {
 		// TODO: find good default
 		// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/31603
 		MaxStreams: math.MaxInt,
+
+		CheckpointInterval:   30 * time.Second,
+		ReorderMaxDatapoints: 100_000,
 	}
 }
 
@@ -24,6 +24,8 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
+	storageID := component.MustNewIDWithName("file_storage", "deltatocumulative")
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -33,6 +35,11 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   1 * time.Minute,
 				MaxStreams: 10,
+
+				Storage:              &storageID,
+				CheckpointInterval:   10 * time.Second,
+				ReorderWindow:        5 * time.Second,
+				ReorderMaxDatapoints: 1000,
 			},
 		},
 		{
@@ -40,6 +47,9 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   2 * time.Minute,
 				MaxStreams: math.MaxInt,
+
+				CheckpointInterval:   30 * time.Second,
+				ReorderMaxDatapoints: 100_000,
 			},
 		},
 		{
@@ -47,6 +57,9 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				MaxStale:   5 * time.Minute,
 				MaxStreams: 20,
+
+				CheckpointInterval:   30 * time.Second,
+				ReorderMaxDatapoints: 100_000,
 			},
 		},
 	}
@@ -25,13 +25,15 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                            metric.Meter
-	mu                               sync.Mutex
-	registrations                    []metric.Registration
-	DeltatocumulativeDatapoints      metric.Int64Counter
-	DeltatocumulativeStreamsLimit    metric.Int64Gauge
-	DeltatocumulativeStreamsMaxStale metric.Int64Gauge
-	DeltatocumulativeStreamsTracked  metric.Int64ObservableUpDownCounter
+	meter                                        metric.Meter
+	mu                                           sync.Mutex
+	registrations                                []metric.Registration
+	DeltatocumulativeDatapoints                  metric.Int64Counter
+	DeltatocumulativeDatapointsDroppedOutOfOrder metric.Int64Counter
+	DeltatocumulativeDatapointsReordered         metric.Int64Counter
+	DeltatocumulativeStreamsLimit                metric.Int64Gauge
+	DeltatocumulativeStreamsMaxStale             metric.Int64Gauge
+	DeltatocumulativeStreamsTracked              metric.Int64ObservableUpDownCounter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -94,6 +96,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{datapoint}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.DeltatocumulativeDatapointsDroppedOutOfOrder, err = builder.meter.Int64Counter(
+		"otelcol_deltatocumulative_datapoints_dropped_out_of_order",
+		metric.WithDescription("number of datapoints dropped because a more recent datapoint of their stream was already processed"),
+		metric.WithUnit("{datapoint}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.DeltatocumulativeDatapointsReordered, err = builder.meter.Int64Counter(
+		"otelcol_deltatocumulative_datapoints_reordered",
+		metric.WithDescription("number of datapoints that arrived out of order and were put back in order by the reorder window"),
+		metric.WithUnit("{datapoint}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.DeltatocumulativeStreamsLimit, err = builder.meter.Int64Gauge(
 		"otelcol_deltatocumulative_streams_limit",
 		metric.WithDescription("upper limit of tracked streams"),
@@ -26,11 +26,19 @@ func TestSetupTelemetry(t *testing.T) {
 		return nil
 	}))
 	tb.DeltatocumulativeDatapoints.Add(context.Background(), 1)
+	tb.DeltatocumulativeDatapointsDroppedOutOfOrder.Add(context.Background(), 1)
+	tb.DeltatocumulativeDatapointsReordered.Add(context.Background(), 1)
 	tb.DeltatocumulativeStreamsLimit.Record(context.Background(), 1)
 	tb.DeltatocumulativeStreamsMaxStale.Record(context.Background(), 1)
 	AssertEqualDeltatocumulativeDatapoints(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualDeltatocumulativeDatapointsDroppedOutOfOrder(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualDeltatocumulativeDatapointsReordered(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualDeltatocumulativeStreamsLimit(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
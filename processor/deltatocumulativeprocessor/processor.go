@@ -5,6 +5,8 @@ package deltatocumulativeprocessor // import "github.com/open-telemetry/opentele
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/staleness"
@@ -26,6 +29,8 @@ var _ processor.Metrics = (*Processor)(nil)
 type Processor struct {
 	next consumer.Metrics
 	cfg  Config
+	id   component.ID
+	log  *zap.Logger
 
 	last state
 	aggr data.Aggregator
@@ -36,14 +41,22 @@ type Processor struct {
 
 	stale staleness.Tracker
 	tel   telemetry.Metrics
+
+	// ckpt is only set when a storage extension is configured
+	ckpt *checkpointer
+	// reorder is only set when a reorder window is configured
+	reorder *reorderBuffer
+	wg      sync.WaitGroup
 }
 
-func newProcessor(cfg *Config, tel telemetry.Metrics, next consumer.Metrics) *Processor {
+func newProcessor(cfg *Config, set processor.Settings, tel telemetry.Metrics, next consumer.Metrics) *Processor {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	proc := Processor{
 		next: next,
 		cfg:  *cfg,
+		id:   set.ID,
+		log:  set.Logger,
 		last: state{
 			nums: make(map[identity.Stream]pmetric.NumberDataPoint),
 			hist: make(map[identity.Stream]pmetric.HistogramDataPoint),
@@ -57,6 +70,10 @@ func newProcessor(cfg *Config, tel telemetry.Metrics, next consumer.Metrics) *Pr
 		tel:   tel,
 	}
 
+	if cfg.ReorderWindow > 0 {
+		proc.reorder = newReorderBuffer(cfg.ReorderWindow, cfg.ReorderMaxDatapoints)
+	}
+
 	tel.WithTracked(proc.last.Len)
 	cfg.Metrics(tel)
 
@@ -68,11 +85,9 @@ func (p *Processor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 	defer p.mtx.Unlock()
 
 	now := time.Now()
-
-	const (
-		keep = true
-		drop = false
-	)
+	// early are the datapoints passed on before their reorder window elapsed,
+	// because the reorder buffer was full
+	early := pmetric.NewMetrics()
 
 	metrics.Filter(md, func(m metrics.Metric) bool {
 		if m.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
@@ -83,52 +98,19 @@ func (p *Processor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 		// using filter here, as the pmetric.*DataPoint are reference types so
 		// we can modify them using their "value".
 		m.Filter(func(id identity.Stream, dp any) bool {
-			// count the processed datatype.
-			// uses whatever value of attrs has at return-time
-			var attrs telemetry.Attributes
-			defer func() { p.tel.Datapoints().Inc(ctx, attrs...) }()
-
-			// if stream new and state capacity reached, reject
-			exist := p.last.Has(id)
-			if !exist && p.last.Len() >= p.cfg.MaxStreams {
-				attrs.Set(telemetry.Error("limit"))
-				return drop
-			}
-
-			// stream is ok and active, update stale tracker
-			p.stale.Refresh(now, id)
-
-			// this is the first sample of the stream. there is nothing to
-			// aggregate with, so clone this value into the state and done
-			if !exist {
-				p.last.BeginWith(id, dp)
-				return keep
-			}
-
-			// aggregate with state from previous requests.
-			// delta.AccumulateInto(state, dp) stores result in `state`.
-			// this is then copied into `dp` (the value passed onto the pipeline)
-			var err error
-			switch dp := dp.(type) {
-			case pmetric.NumberDataPoint:
-				state := p.last.nums[id]
-				err = p.aggr.Numbers(state, dp)
-				state.CopyTo(dp)
-			case pmetric.HistogramDataPoint:
-				state := p.last.hist[id]
-				err = p.aggr.Histograms(state, dp)
-				state.CopyTo(dp)
-			case pmetric.ExponentialHistogramDataPoint:
-				state := p.last.expo[id]
-				err = p.aggr.Exponential(state, dp)
-				state.CopyTo(dp)
-			}
-			if err != nil {
-				attrs.Set(telemetry.Cause(err))
+			// hold the datapoint back, it is aggregated once the reorder
+			// window elapsed, in timestamp order with the other datapoints
+			// of its stream
+			if p.reorder != nil {
+				if p.reorder.Add(now, id, m, dp) {
+					p.tel.DeltatocumulativeDatapointsReordered.Add(ctx, 1)
+				}
+				if p.reorder.Full() {
+					p.aggregateDue(ctx, now, true, early)
+				}
 				return drop
 			}
-
-			return keep
+			return p.aggregate(ctx, now, id, m, dp)
 		})
 
 		// all remaining datapoints of this metric are now cumulative
@@ -137,6 +119,12 @@ func (p *Processor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 		return m.Typed().Len() > 0
 	})
 
+	if early.MetricCount() > 0 {
+		if err := p.next.ConsumeMetrics(ctx, early); err != nil {
+			return err
+		}
+	}
+
 	// no need to continue pipeline if we dropped all metrics
 	if md.MetricCount() == 0 {
 		return nil
@@ -144,10 +132,159 @@ func (p *Processor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) erro
 	return p.next.ConsumeMetrics(ctx, md)
 }
 
-func (p *Processor) Start(_ context.Context, _ component.Host) error {
+const (
+	keep = true
+	drop = false
+)
+
+// aggregate accumulates the delta datapoint into the state of its stream, and
+// replaces its value with the resulting cumulative one. It must be called
+// while holding p.mtx.
+func (p *Processor) aggregate(ctx context.Context, now time.Time, id identity.Stream, m metrics.Metric, dp any) bool {
+	// count the processed datatype.
+	// uses whatever value of attrs has at return-time
+	var attrs telemetry.Attributes
+	defer func() { p.tel.Datapoints().Inc(ctx, attrs...) }()
+
+	// if stream new and state capacity reached, reject
+	exist := p.last.Has(id)
+	if !exist && p.last.Len() >= p.cfg.MaxStreams {
+		attrs.Set(telemetry.Error("limit"))
+		return drop
+	}
+
+	// stream is ok and active, update stale tracker
+	p.stale.Refresh(now, id)
+	if p.ckpt != nil {
+		p.ckpt.Track(now, id, m)
+	}
+
+	// this is the first sample of the stream. there is nothing to
+	// aggregate with, so clone this value into the state and done
+	if !exist {
+		p.last.BeginWith(id, dp)
+		return keep
+	}
+
+	// aggregate with state from previous requests.
+	// delta.AccumulateInto(state, dp) stores result in `state`.
+	// this is then copied into `dp` (the value passed onto the pipeline)
+	var err error
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		state := p.last.nums[id]
+		err = p.aggr.Numbers(state, dp)
+		state.CopyTo(dp)
+	case pmetric.HistogramDataPoint:
+		state := p.last.hist[id]
+		err = p.aggr.Histograms(state, dp)
+		state.CopyTo(dp)
+	case pmetric.ExponentialHistogramDataPoint:
+		state := p.last.expo[id]
+		err = p.aggr.Exponential(state, dp)
+		state.CopyTo(dp)
+	}
+	if err != nil {
+		if errors.As(err, new(delta.ErrOutOfOrder)) {
+			p.tel.DeltatocumulativeDatapointsDroppedOutOfOrder.Add(ctx, 1)
+		}
+		attrs.Set(telemetry.Cause(err))
+		return drop
+	}
+
+	return keep
+}
+
+// flush aggregates the datapoints whose reorder window elapsed, or all of
+// them if all is set, and passes the resulting cumulative datapoints on.
+func (p *Processor) flush(ctx context.Context, all bool) error {
+	p.mtx.Lock()
+	md := pmetric.NewMetrics()
+	p.aggregateDue(ctx, time.Now(), all, md)
+	p.mtx.Unlock()
+
+	if md.MetricCount() == 0 {
+		return nil
+	}
+	return p.next.ConsumeMetrics(ctx, md)
+}
+
+// aggregateDue aggregates the datapoints whose reorder window elapsed, or all
+// of them if all is set, and appends the resulting cumulative datapoints to
+// md. It must be called while holding p.mtx.
+func (p *Processor) aggregateDue(ctx context.Context, now time.Time, all bool, md pmetric.Metrics) {
+	out := make(map[identity.Metric]pmetric.Metric)
+	for _, ready := range p.reorder.Due(now, all) {
+		m := metrics.From(ready.res, ready.scope, ready.metric)
+		for _, dp := range ready.points {
+			if !p.aggregate(ctx, now, ready.id, m, dp) {
+				continue
+			}
+			om, ok := out[ready.id.Metric()]
+			if !ok {
+				om = appendCumulative(md, ready.origin)
+				out[ready.id.Metric()] = om
+			}
+			appendDatapoint(om, dp)
+		}
+	}
+}
+
+func (p *Processor) Start(ctx context.Context, host component.Host) error {
+	if p.cfg.Storage != nil {
+		client, err := getStorageClient(ctx, host, *p.cfg.Storage, p.id)
+		if err != nil {
+			return err
+		}
+		p.ckpt = newCheckpointer(client)
+		if err := p.restore(ctx); err != nil {
+			return err
+		}
+
+		// write the state of all streams once per checkpoint_interval
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			tick := time.NewTicker(p.cfg.CheckpointInterval)
+			defer tick.Stop()
+			for {
+				select {
+				case <-p.ctx.Done():
+					return
+				case <-tick.C:
+					if err := p.checkpoint(p.ctx); err != nil {
+						p.log.Warn("failed to checkpoint streams", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
+	if p.reorder != nil {
+		// aggregate the datapoints whose reorder window elapsed
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			tick := time.NewTicker(max(p.cfg.ReorderWindow/2, time.Millisecond))
+			defer tick.Stop()
+			for {
+				select {
+				case <-p.ctx.Done():
+					return
+				case <-tick.C:
+					if err := p.flush(p.ctx, false); err != nil {
+						p.log.Warn("failed to pass on reordered datapoints", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
 	if p.cfg.MaxStale != 0 {
 		// delete stale streams once per minute
+		p.wg.Add(1)
 		go func() {
+			defer p.wg.Done()
 			tick := time.NewTicker(time.Minute)
 			defer tick.Stop()
 			for {
@@ -159,6 +296,9 @@ func (p *Processor) Start(_ context.Context, _ component.Host) error {
 					stale := p.stale.Collect(p.cfg.MaxStale)
 					for _, id := range stale {
 						p.last.Delete(id)
+						if p.ckpt != nil {
+							p.ckpt.Delete(id)
+						}
 					}
 					p.mtx.Unlock()
 				}
@@ -169,8 +309,52 @@ func (p *Processor) Start(_ context.Context, _ component.Host) error {
 	return nil
 }
 
-func (p *Processor) Shutdown(_ context.Context) error {
+func (p *Processor) Shutdown(ctx context.Context) error {
 	p.cancel()
+	p.wg.Wait()
+
+	var err error
+	if p.reorder != nil {
+		err = p.flush(ctx, true)
+	}
+	if p.ckpt == nil {
+		return err
+	}
+	err = errors.Join(err, p.checkpoint(ctx))
+	return errors.Join(err, p.ckpt.Close(ctx))
+}
+
+// checkpoint writes the state of all streams to the storage
+func (p *Processor) checkpoint(ctx context.Context) error {
+	p.mtx.Lock()
+	data, err := p.ckpt.Snapshot(p.last)
+	p.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	return p.ckpt.Save(ctx, data)
+}
+
+// restore loads the streams of the last checkpoint, so that their cumulative
+// values continue where they left off before the restart.
+func (p *Processor) restore(ctx context.Context) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	restored := 0
+	err := p.ckpt.Restore(ctx, func(id identity.Stream, dp any, last time.Time) bool {
+		if p.last.Has(id) || p.last.Len() >= p.cfg.MaxStreams {
+			return false
+		}
+		p.last.BeginWith(id, dp)
+		p.stale.Refresh(last, id)
+		restored++
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore streams: %w", err)
+	}
+	p.log.Debug("restored streams from checkpoint", zap.Int("streams", restored))
 	return nil
 }
 
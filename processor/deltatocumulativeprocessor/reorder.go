@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metrics"
+)
+
+// reorderBuffer holds delta datapoints back for the duration of the reorder
+// window, so that datapoints of a stream arriving out of order can still be
+// aggregated in timestamp order.
+type reorderBuffer struct {
+	window time.Duration
+	// max is the number of buffered datapoints at which the buffer is full
+	max int
+	len int
+
+	streams map[identity.Stream]*pending
+	// order keeps the streams in the order they were first buffered in, so
+	// that datapoints are passed on in a stable order
+	order []identity.Stream
+}
+
+// pending are the buffered datapoints of a stream, in arrival order.
+type pending struct {
+	*origin
+	points []arrival
+}
+
+type arrival struct {
+	at time.Time
+	dp any
+}
+
+// ready are the datapoints of a stream to aggregate, in timestamp order.
+type ready struct {
+	*origin
+	id     identity.Stream
+	points []any
+}
+
+func newReorderBuffer(window time.Duration, maxDatapoints int) *reorderBuffer {
+	return &reorderBuffer{
+		window:  window,
+		max:     maxDatapoints,
+		streams: make(map[identity.Stream]*pending),
+	}
+}
+
+// Full tells whether the buffer holds its maximum number of datapoints.
+func (b *reorderBuffer) Full() bool {
+	return b.len >= b.max
+}
+
+// Add buffers a copy of the datapoint, and tells whether it arrived after a
+// more recent datapoint of its stream that is still buffered.
+func (b *reorderBuffer) Add(now time.Time, id identity.Stream, m metrics.Metric, dp any) bool {
+	p, ok := b.streams[id]
+	if !ok {
+		p = &pending{origin: newOrigin(m)}
+		b.streams[id] = p
+		b.order = append(b.order, id)
+	}
+
+	reordered := false
+	ts := timestamp(dp)
+	for _, a := range p.points {
+		if timestamp(a.dp) > ts {
+			reordered = true
+			break
+		}
+	}
+	p.points = append(p.points, arrival{at: now, dp: clone(dp)})
+	b.len++
+	return reordered
+}
+
+// Due removes and returns the datapoints whose reorder window elapsed, or all
+// of them if all is set. Buffered datapoints older than a due one are returned
+// too, as they could not be aggregated anymore once it was.
+func (b *reorderBuffer) Due(now time.Time, all bool) []ready {
+	var due []ready
+	order := b.order[:0]
+	for _, id := range b.order {
+		p := b.streams[id]
+		slices.SortStableFunc(p.points, func(x, y arrival) int {
+			return cmp.Compare(timestamp(x.dp), timestamp(y.dp))
+		})
+
+		n := 0
+		for i, a := range p.points {
+			if all || now.Sub(a.at) >= b.window {
+				n = i + 1
+			}
+		}
+		if n > 0 {
+			r := ready{origin: p.origin, id: id, points: make([]any, n)}
+			for i, a := range p.points[:n] {
+				r.points[i] = a.dp
+			}
+			due = append(due, r)
+			p.points = slices.Delete(p.points, 0, n)
+			b.len -= n
+		}
+
+		if len(p.points) == 0 {
+			delete(b.streams, id)
+			continue
+		}
+		order = append(order, id)
+	}
+	clear(b.order[len(order):])
+	b.order = order
+	return due
+}
+
+// appendCumulative appends an empty cumulative metric with the descriptor of
+// the origin to md.
+func appendCumulative(md pmetric.Metrics, o *origin) pmetric.Metric {
+	rm := md.ResourceMetrics().AppendEmpty()
+	o.res.CopyTo(rm.Resource())
+	sm := rm.ScopeMetrics().AppendEmpty()
+	o.scope.CopyTo(sm.Scope())
+	m := sm.Metrics().AppendEmpty()
+	copyDescriptor(o.metric, m)
+	metrics.From(rm.Resource(), sm.Scope(), m).Typed().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	return m
+}
+
+func appendDatapoint(m pmetric.Metric, dp any) {
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		dp.CopyTo(m.Sum().DataPoints().AppendEmpty())
+	case pmetric.HistogramDataPoint:
+		dp.CopyTo(m.Histogram().DataPoints().AppendEmpty())
+	case pmetric.ExponentialHistogramDataPoint:
+		dp.CopyTo(m.ExponentialHistogram().DataPoints().AppendEmpty())
+	}
+}
+
+func clone(dp any) any {
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		c := pmetric.NewNumberDataPoint()
+		dp.CopyTo(c)
+		return c
+	case pmetric.HistogramDataPoint:
+		c := pmetric.NewHistogramDataPoint()
+		dp.CopyTo(c)
+		return c
+	case pmetric.ExponentialHistogramDataPoint:
+		c := pmetric.NewExponentialHistogramDataPoint()
+		dp.CopyTo(c)
+		return c
+	}
+	return dp
+}
+
+func timestamp(dp any) pcommon.Timestamp {
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		return dp.Timestamp()
+	case pmetric.HistogramDataPoint:
+		return dp.Timestamp()
+	case pmetric.ExponentialHistogramDataPoint:
+		return dp.Timestamp()
+	}
+	return 0
+}
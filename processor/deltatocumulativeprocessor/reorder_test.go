@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metadatatest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metrics"
+)
+
+func deltaSum(points ...int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	// points are pairs of timestamp and value
+	for i := 0; i < len(points); i += 2 {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(points[i] - 100))
+		dp.SetTimestamp(pcommon.Timestamp(points[i]))
+		dp.SetIntValue(points[i+1])
+	}
+	return md
+}
+
+func cumulativePoints(t *testing.T, sink *consumertest.MetricsSink) [][2]int64 {
+	var out [][2]int64
+	for _, md := range sink.AllMetrics() {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			sms := rms.At(i).ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				ms := sms.At(j).Metrics()
+				for k := 0; k < ms.Len(); k++ {
+					sum := ms.At(k).Sum()
+					require.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+					for l := 0; l < sum.DataPoints().Len(); l++ {
+						dp := sum.DataPoints().At(l)
+						out = append(out, [2]int64{int64(dp.Timestamp()), dp.IntValue()})
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+func TestReorderWindow(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	set := processortest.NewNopSettings(metadata.Type)
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+
+	sink := new(consumertest.MetricsSink)
+	cfg := &Config{MaxStale: 0, MaxStreams: math.MaxInt, ReorderWindow: time.Hour, ReorderMaxDatapoints: 100}
+	proc, err := NewFactory().CreateMetrics(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+	p := proc.(*Processor)
+
+	// datapoints are held back until the window elapsed
+	require.NoError(t, p.ConsumeMetrics(context.Background(), deltaSum(1000, 1, 1200, 2)))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), deltaSum(1100, 4)))
+	require.NoError(t, p.flush(context.Background(), false))
+	assert.Empty(t, sink.AllMetrics())
+
+	// and are then aggregated in timestamp order
+	require.NoError(t, p.flush(context.Background(), true))
+	assert.Equal(t, [][2]int64{{1000, 1}, {1100, 5}, {1200, 7}}, cumulativePoints(t, sink))
+
+	// datapoints older than the ones already aggregated are still dropped
+	sink.Reset()
+	require.NoError(t, p.ConsumeMetrics(context.Background(), deltaSum(1150, 8, 1300, 1)))
+	require.NoError(t, p.flush(context.Background(), true))
+	assert.Equal(t, [][2]int64{{1300, 8}}, cumulativePoints(t, sink))
+
+	metadatatest.AssertEqualDeltatocumulativeDatapointsReordered(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualDeltatocumulativeDatapointsDroppedOutOfOrder(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestReorderBufferFull(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := &Config{MaxStale: 0, MaxStreams: math.MaxInt, ReorderWindow: time.Hour, ReorderMaxDatapoints: 3}
+	proc, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	p := proc.(*Processor)
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), deltaSum(1000, 1, 1200, 2)))
+	assert.Empty(t, sink.AllMetrics())
+
+	// once full, all held back datapoints are passed on without waiting for
+	// the window to elapse
+	require.NoError(t, p.ConsumeMetrics(context.Background(), deltaSum(1100, 4, 1300, 8)))
+	assert.Equal(t, [][2]int64{{1000, 1}, {1100, 5}, {1200, 7}}, cumulativePoints(t, sink))
+	assert.False(t, p.reorder.Full())
+
+	require.NoError(t, p.flush(context.Background(), true))
+	assert.Equal(t, [][2]int64{{1000, 1}, {1100, 5}, {1200, 7}, {1300, 15}}, cumulativePoints(t, sink))
+	assert.Zero(t, p.reorder.len)
+}
+
+func TestReorderBufferDue(t *testing.T) {
+	b := newReorderBuffer(time.Second, 10)
+	start := time.Now()
+
+	md := deltaSum(2000, 1, 1000, 1)
+	rm := md.ResourceMetrics().At(0)
+	sm := rm.ScopeMetrics().At(0)
+	m := metrics.From(rm.Resource(), sm.Scope(), sm.Metrics().At(0))
+	dps := m.Sum().DataPoints()
+	id := identity.OfStream(m.Ident(), dps.At(0))
+
+	assert.False(t, b.Add(start, id, m, dps.At(0)))
+	// the second datapoint arrives later, but is older than the first one
+	assert.True(t, b.Add(start.Add(900*time.Millisecond), id, m, dps.At(1)))
+
+	assert.Empty(t, b.Due(start.Add(500*time.Millisecond), false))
+
+	// once the newest datapoint is due, the older one is returned with it even
+	// though its own window did not elapse yet
+	due := b.Due(start.Add(time.Second), false)
+	require.Len(t, due, 1)
+	require.Len(t, due[0].points, 2)
+	assert.Equal(t, pcommon.Timestamp(1000), timestamp(due[0].points[0]))
+	assert.Equal(t, pcommon.Timestamp(2000), timestamp(due[0].points[1]))
+	assert.Empty(t, b.streams)
+	assert.Empty(t, b.order)
+	assert.Zero(t, b.len)
+}
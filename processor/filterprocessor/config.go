@@ -18,6 +18,13 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset/regexp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 )
 
 // Config defines configuration for Resource processor.
@@ -36,6 +43,16 @@ type Config struct {
 	Spans filterconfig.MatchConfig `mapstructure:"spans"`
 
 	Traces TraceFilters `mapstructure:"traces"`
+
+	Profiles ProfileFilters `mapstructure:"profiles"`
+
+	// Functions declares user functions, composed of the other OTTL functions, that can be called by the
+	// conditions of all contexts like built-in functions.
+	Functions []ottl.UserFunctionConfig `mapstructure:"functions"`
+
+	// Profiling enables the evaluation telemetry of the OTTL conditions, reporting for each condition the number
+	// of evaluations, matches and errors, and the cumulative evaluation time.
+	Profiling bool `mapstructure:"profiling"`
 }
 
 // MetricFilters filters by Metric properties.
@@ -77,6 +94,19 @@ type TraceFilters struct {
 	SpanEventConditions []string `mapstructure:"spanevent"`
 }
 
+// ProfileFilters filters by OTTL conditions
+type ProfileFilters struct {
+	// ProfileConditions is a list of OTTL conditions for an ottlprofile context.
+	// If any condition resolves to true, the profile will be dropped.
+	// Supports `and`, `or`, and `()`
+	ProfileConditions []string `mapstructure:"profile"`
+
+	// SampleConditions is a list of OTTL conditions for an ottlsample context.
+	// If any condition resolves to true, the sample will be dropped.
+	// Supports `and`, `or`, and `()`
+	SampleConditions []string `mapstructure:"sample"`
+}
+
 // LogFilters filters by Log properties.
 type LogFilters struct {
 	// Include match properties describe logs that should be included in the Collector Service pipeline,
@@ -278,28 +308,43 @@ func (cfg *Config) Validate() error {
 
 	var errors error
 
+	userFunctions, err := cfg.userFunctions()
+	if err != nil {
+		return err
+	}
+
 	if cfg.Traces.SpanConditions != nil {
-		_, err := filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := filterottl.NewBoolExprForSpanWithOptions(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, parserOptions[ottlspan.TransformContext](userFunctions))
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Traces.SpanEventConditions != nil {
-		_, err := filterottl.NewBoolExprForSpanEvent(cfg.Traces.SpanEventConditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := filterottl.NewBoolExprForSpanEventWithOptions(cfg.Traces.SpanEventConditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, parserOptions[ottlspanevent.TransformContext](userFunctions))
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.MetricConditions != nil {
-		_, err := filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := filterottl.NewBoolExprForMetricWithOptions(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, parserOptions[ottlmetric.TransformContext](userFunctions))
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.DataPointConditions != nil {
-		_, err := filterottl.NewBoolExprForDataPoint(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := filterottl.NewBoolExprForDataPointWithOptions(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, parserOptions[ottldatapoint.TransformContext](userFunctions))
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Logs.LogConditions != nil {
-		_, err := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := filterottl.NewBoolExprForLogWithOptions(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, parserOptions[ottllog.TransformContext](userFunctions))
+		errors = multierr.Append(errors, err)
+	}
+
+	if cfg.Profiles.ProfileConditions != nil {
+		_, err := filterottl.NewBoolExprForProfileWithOptions(cfg.Profiles.ProfileConditions, filterottl.StandardProfileFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, parserOptions[ottlprofile.TransformContext](userFunctions))
+		errors = multierr.Append(errors, err)
+	}
+
+	if cfg.Profiles.SampleConditions != nil {
+		_, err := filterottl.NewBoolExprForSampleWithOptions(cfg.Profiles.SampleConditions, filterottl.StandardSampleFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, parserOptions[ottlsample.TransformContext](userFunctions))
 		errors = multierr.Append(errors, err)
 	}
 
@@ -313,3 +358,19 @@ func (cfg *Config) Validate() error {
 
 	return errors
 }
+
+// userFunctions returns the user functions declared by the configuration, or nil if there are none.
+func (cfg *Config) userFunctions() (*ottl.UserFunctions, error) {
+	if len(cfg.Functions) == 0 {
+		return nil, nil
+	}
+	return ottl.NewUserFunctions(cfg.Functions)
+}
+
+// parserOptions returns the option making the user functions available to a parser, if there are any.
+func parserOptions[K any](userFunctions *ottl.UserFunctions) []ottl.Option[K] {
+	if userFunctions == nil {
+		return nil
+	}
+	return []ottl.Option[K]{ottl.WithUserFunctions[K](userFunctions)}
+}
@@ -862,6 +862,14 @@ func TestLoadingConfigOTTL(t *testing.T) {
 						`attributes["test"] == "pass"`,
 					},
 				},
+				Profiles: ProfileFilters{
+					ProfileConditions: []string{
+						`attributes["test"] == "pass"`,
+					},
+					SampleConditions: []string{
+						`attributes["test"] == "pass"`,
+					},
+				},
 			},
 		},
 		{
@@ -903,6 +911,16 @@ func TestLoadingConfigOTTL(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_log"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_profile"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_sample"),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "built_in_user_function"),
+			errorMessage: `user function "IsMatch" conflicts with the built-in function of the same name`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -927,3 +945,22 @@ func TestLoadingConfigOTTL(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigUserFunctionsScopedToComponent(t *testing.T) {
+	functions := []ottl.UserFunctionConfig{
+		{Name: "IsOperation", Params: []string{"value"}, Expression: `IsMatch(value, "^operation")`},
+	}
+	declaring := &Config{
+		ErrorMode: ottl.PropagateError,
+		Logs:      LogFilters{LogConditions: []string{`IsOperation(body)`}},
+		Functions: functions,
+	}
+	require.NoError(t, xconfmap.Validate(declaring))
+
+	// the user functions of a component aren't visible to the other components
+	other := &Config{
+		ErrorMode: ottl.PropagateError,
+		Logs:      LogFilters{LogConditions: []string{`IsOperation(body)`}},
+	}
+	assert.ErrorContains(t, xconfmap.Validate(other), `undefined function "IsOperation"`)
+}
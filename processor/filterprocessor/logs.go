@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterlog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 )
 
@@ -39,10 +41,17 @@ func newFilterLogsProcessor(set processor.Settings, cfg *Config) (*filterLogProc
 	flp.telemetry = fpt
 
 	if cfg.Logs.LogConditions != nil {
-		skipExpr, errBoolExpr := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+		userFunctions, err := cfg.userFunctions()
+		if err != nil {
+			return nil, err
+		}
+		skipExpr, errBoolExpr := filterottl.NewBoolExprForLogWithOptions(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set.TelemetrySettings, parserOptions[ottllog.TransformContext](userFunctions))
 		if errBoolExpr != nil {
 			return nil, errBoolExpr
 		}
+		if cfg.Profiling {
+			ottllog.WithConditionSequenceProfiling(attribute.String(ottl.AttributeContext, ottllog.ContextName))(skipExpr)
+		}
 		flp.skipExpr = skipExpr
 		return flp, nil
 	}
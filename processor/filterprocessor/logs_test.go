@@ -706,6 +706,7 @@ func TestFilterLogProcessorWithOTTL(t *testing.T) {
 	tests := []struct {
 		name             string
 		conditions       []string
+		functions        []ottl.UserFunctionConfig
 		filterEverything bool
 		want             func(ld plog.Logs)
 		errorMode        ottl.ErrorMode
@@ -750,10 +751,32 @@ func TestFilterLogProcessorWithOTTL(t *testing.T) {
 			want:      func(_ plog.Logs) {},
 			errorMode: ottl.IgnoreError,
 		},
+		{
+			name: "with user functions",
+			conditions: []string{
+				`IsOperationA(body)`,
+			},
+			functions: []ottl.UserFunctionConfig{
+				{
+					Name:       "IsOperationA",
+					Params:     []string{"value"},
+					Expression: `IsMatch(value, "^operationA$")`,
+				},
+			},
+			want: func(ld plog.Logs) {
+				ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().RemoveIf(func(log plog.LogRecord) bool {
+					return log.Body().AsString() == "operationA"
+				})
+				ld.ResourceLogs().At(0).ScopeLogs().At(1).LogRecords().RemoveIf(func(log plog.LogRecord) bool {
+					return log.Body().AsString() == "operationA"
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			processor, err := newFilterLogsProcessor(processortest.NewNopSettings(metadata.Type), &Config{Logs: LogFilters{LogConditions: tt.conditions}})
+			processor, err := newFilterLogsProcessor(processortest.NewNopSettings(metadata.Type), &Config{Logs: LogFilters{LogConditions: tt.conditions}, Functions: tt.functions})
 			assert.NoError(t, err)
 
 			got, err := processor.processLogs(context.Background(), constructLogs())
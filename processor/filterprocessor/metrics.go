@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filtermetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
@@ -47,18 +49,30 @@ func newFilterMetricProcessor(set processor.Settings, cfg *Config) (*filterMetri
 	fsp.telemetry = fpt
 
 	if cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil {
+		userFunctions, err := cfg.userFunctions()
+		if err != nil {
+			return nil, err
+		}
 		if cfg.Metrics.MetricConditions != nil {
-			fsp.skipMetricExpr, err = filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			metricExpr, err := filterottl.NewBoolExprForMetricWithOptions(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), cfg.ErrorMode, set.TelemetrySettings, parserOptions[ottlmetric.TransformContext](userFunctions))
 			if err != nil {
 				return nil, err
 			}
+			if cfg.Profiling {
+				ottlmetric.WithConditionSequenceProfiling(attribute.String(ottl.AttributeContext, ottlmetric.ContextName))(metricExpr)
+			}
+			fsp.skipMetricExpr = metricExpr
 		}
 
 		if cfg.Metrics.DataPointConditions != nil {
-			fsp.skipDataPointExpr, err = filterottl.NewBoolExprForDataPoint(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			dataPointExpr, err := filterottl.NewBoolExprForDataPointWithOptions(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), cfg.ErrorMode, set.TelemetrySettings, parserOptions[ottldatapoint.TransformContext](userFunctions))
 			if err != nil {
 				return nil, err
 			}
+			if cfg.Profiling {
+				ottldatapoint.WithConditionSequenceProfiling(attribute.String(ottl.AttributeContext, ottldatapoint.ContextName))(dataPointExpr)
+			}
+			fsp.skipDataPointExpr = dataPointExpr
 		}
 
 		return fsp, nil
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
+)
+
+type filterProfileProcessor struct {
+	skipProfileExpr expr.BoolExpr[ottlprofile.TransformContext]
+	skipSampleExpr  expr.BoolExpr[ottlsample.TransformContext]
+	telemetry       *filterTelemetry
+	logger          *zap.Logger
+}
+
+func newFilterProfilesProcessor(set processor.Settings, cfg *Config) (*filterProfileProcessor, error) {
+	fpp := &filterProfileProcessor{
+		logger: set.Logger,
+	}
+
+	fpt, err := newFilterTelemetry(set, xpipeline.SignalProfiles)
+	if err != nil {
+		return nil, fmt.Errorf("error creating filter processor telemetry: %w", err)
+	}
+	fpp.telemetry = fpt
+
+	if cfg.Profiles.ProfileConditions == nil && cfg.Profiles.SampleConditions == nil {
+		return fpp, nil
+	}
+
+	userFunctions, err := cfg.userFunctions()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Profiles.ProfileConditions != nil {
+		profileExpr, err := filterottl.NewBoolExprForProfileWithOptions(cfg.Profiles.ProfileConditions, filterottl.StandardProfileFuncs(), cfg.ErrorMode, set.TelemetrySettings, parserOptions[ottlprofile.TransformContext](userFunctions))
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Profiling {
+			ottlprofile.WithConditionSequenceProfiling(attribute.String(ottl.AttributeContext, ottlprofile.ContextName))(profileExpr)
+		}
+		fpp.skipProfileExpr = profileExpr
+	}
+	if cfg.Profiles.SampleConditions != nil {
+		sampleExpr, err := filterottl.NewBoolExprForSampleWithOptions(cfg.Profiles.SampleConditions, filterottl.StandardSampleFuncs(), cfg.ErrorMode, set.TelemetrySettings, parserOptions[ottlsample.TransformContext](userFunctions))
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Profiling {
+			ottlsample.WithConditionSequenceProfiling(attribute.String(ottl.AttributeContext, ottlsample.ContextName))(sampleExpr)
+		}
+		fpp.skipSampleExpr = sampleExpr
+	}
+	return fpp, nil
+}
+
+// processProfiles filters the given profiles and samples based off the filterProfileProcessor's filters.
+func (fpp *filterProfileProcessor) processProfiles(ctx context.Context, pd pprofile.Profiles) (pprofile.Profiles, error) {
+	if fpp.skipProfileExpr == nil && fpp.skipSampleExpr == nil {
+		return pd, nil
+	}
+
+	profileCountBeforeFilters := profileCount(pd)
+
+	var errors error
+	pd.ResourceProfiles().RemoveIf(func(rp pprofile.ResourceProfiles) bool {
+		resource := rp.Resource()
+		rp.ScopeProfiles().RemoveIf(func(sp pprofile.ScopeProfiles) bool {
+			scope := sp.Scope()
+			sp.Profiles().RemoveIf(func(profile pprofile.Profile) bool {
+				if fpp.skipProfileExpr != nil {
+					skip, err := fpp.skipProfileExpr.Eval(ctx, ottlprofile.NewTransformContext(profile, scope, resource, sp, rp))
+					if err != nil {
+						errors = multierr.Append(errors, err)
+						return false
+					}
+					if skip {
+						return true
+					}
+				}
+				if fpp.skipSampleExpr != nil {
+					profile.Sample().RemoveIf(func(sample pprofile.Sample) bool {
+						skip, err := fpp.skipSampleExpr.Eval(ctx, ottlsample.NewTransformContext(sample, profile, scope, resource, sp, rp))
+						if err != nil {
+							errors = multierr.Append(errors, err)
+							return false
+						}
+						return skip
+					})
+				}
+				return false
+			})
+			return sp.Profiles().Len() == 0
+		})
+		return rp.ScopeProfiles().Len() == 0
+	})
+
+	profileCountAfterFilters := profileCount(pd)
+	fpp.telemetry.record(ctx, int64(profileCountBeforeFilters-profileCountAfterFilters))
+
+	if errors != nil {
+		fpp.logger.Error("failed processing profiles", zap.Error(errors))
+		return pd, errors
+	}
+	if pd.ResourceProfiles().Len() == 0 {
+		return pd, processorhelper.ErrSkipProcessingData
+	}
+	return pd, nil
+}
+
+// profileCount returns the number of profiles held by pd.
+func profileCount(pd pprofile.Profiles) int {
+	count := 0
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		sps := pd.ResourceProfiles().At(i).ScopeProfiles()
+		for j := 0; j < sps.Len(); j++ {
+			count += sps.At(j).Profiles().Len()
+		}
+	}
+	return count
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/processor/xprocessor"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor/internal/metadatatest"
+)
+
+func TestFilterProfileProcessorWithOTTL(t *testing.T) {
+	tests := []struct {
+		name             string
+		conditions       ProfileFilters
+		filterEverything bool
+		want             func(pd pprofile.Profiles)
+		errorMode        ottl.ErrorMode
+	}{
+		{
+			name: "drop profiles",
+			conditions: ProfileFilters{
+				ProfileConditions: []string{
+					`period_type.type == "cpu"`,
+				},
+			},
+			want: func(pd pprofile.Profiles) {
+				pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().RemoveIf(func(profile pprofile.Profile) bool {
+					return profile.Period() == 10
+				})
+				pd.ResourceProfiles().At(0).ScopeProfiles().At(1).Profiles().RemoveIf(func(profile pprofile.Profile) bool {
+					return profile.Period() == 10
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
+		{
+			name: "drop everything by dropping all profiles",
+			conditions: ProfileFilters{
+				ProfileConditions: []string{
+					`period > 0`,
+				},
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "drop samples",
+			conditions: ProfileFilters{
+				SampleConditions: []string{
+					`Len(function_names) == 1`,
+				},
+			},
+			want: func(pd pprofile.Profiles) {
+				pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0).Sample().RemoveIf(func(sample pprofile.Sample) bool {
+					return sample.LocationsLength() == 1
+				})
+				pd.ResourceProfiles().At(0).ScopeProfiles().At(1).Profiles().At(0).Sample().RemoveIf(func(sample pprofile.Sample) bool {
+					return sample.LocationsLength() == 1
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
+		{
+			name: "multiple conditions",
+			conditions: ProfileFilters{
+				ProfileConditions: []string{
+					`period == 1000`,
+					`sample_count >= 0`,
+				},
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "with error conditions",
+			conditions: ProfileFilters{
+				ProfileConditions: []string{
+					`Substring("", 0, 100) == "test"`,
+				},
+			},
+			want:      func(_ pprofile.Profiles) {},
+			errorMode: ottl.IgnoreError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := newFilterProfilesProcessor(processortest.NewNopSettings(metadata.Type), &Config{Profiles: tt.conditions, ErrorMode: tt.errorMode})
+			assert.NoError(t, err)
+
+			got, err := processor.processProfiles(context.Background(), constructProfiles())
+
+			if tt.filterEverything {
+				assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+			} else {
+				exPd := constructProfiles()
+				tt.want(exPd)
+				assert.Equal(t, exPd, got)
+			}
+		})
+	}
+}
+
+func TestFilterProfileProcessorTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	processor, err := newFilterProfilesProcessor(metadatatest.NewSettings(tel), &Config{
+		Profiles: ProfileFilters{
+			ProfileConditions: []string{
+				`period_type.type == "cpu"`,
+			},
+		}, ErrorMode: ottl.IgnoreError,
+	})
+	assert.NoError(t, err)
+
+	_, err = processor.processProfiles(context.Background(), constructProfiles())
+	assert.NoError(t, err)
+
+	metadatatest.AssertEqualProcessorFilterProfilesFiltered(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Value:      2,
+			Attributes: attribute.NewSet(attribute.String("filter", "filter")),
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestFilterProfileProcessorFactory(t *testing.T) {
+	factory := NewFactory().(xprocessor.Factory)
+	cfg := &Config{
+		ErrorMode: ottl.PropagateError,
+		Profiles: ProfileFilters{
+			SampleConditions: []string{`Len(function_names) == 1`},
+		},
+	}
+	sink := new(consumertest.ProfilesSink)
+	fpp, err := factory.CreateProfiles(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NotNil(t, fpp)
+
+	require.NoError(t, fpp.ConsumeProfiles(context.Background(), constructProfiles()))
+	require.Len(t, sink.AllProfiles(), 1)
+	assert.Equal(t, 2, sink.AllProfiles()[0].SampleCount())
+}
+
+func constructProfiles() pprofile.Profiles {
+	pd := pprofile.NewProfiles()
+	rp0 := pd.ResourceProfiles().AppendEmpty()
+	rp0.Resource().Attributes().PutStr("host.name", "localhost")
+	rp0sp0 := rp0.ScopeProfiles().AppendEmpty()
+	rp0sp0.Scope().SetName("scope1")
+	fillProfileOne(rp0sp0.Profiles().AppendEmpty())
+	fillProfileTwo(rp0sp0.Profiles().AppendEmpty())
+	rp0sp1 := rp0.ScopeProfiles().AppendEmpty()
+	rp0sp1.Scope().SetName("scope2")
+	fillProfileOne(rp0sp1.Profiles().AppendEmpty())
+	fillProfileTwo(rp0sp1.Profiles().AppendEmpty())
+	return pd
+}
+
+func fillProfileOne(profile pprofile.Profile) {
+	profile.StringTable().FromRaw([]string{"", "cpu", "nanoseconds", "main", "compute"})
+	profile.SetPeriod(10)
+	profile.PeriodType().SetTypeStrindex(1)
+	profile.PeriodType().SetUnitStrindex(2)
+
+	profile.FunctionTable().AppendEmpty().SetNameStrindex(3)
+	profile.FunctionTable().AppendEmpty().SetNameStrindex(4)
+	profile.LocationTable().AppendEmpty().Line().AppendEmpty().SetFunctionIndex(0)
+	profile.LocationTable().AppendEmpty().Line().AppendEmpty().SetFunctionIndex(1)
+	profile.LocationIndices().FromRaw([]int32{1, 0})
+
+	sample0 := profile.Sample().AppendEmpty()
+	sample0.SetLocationsStartIndex(0)
+	sample0.SetLocationsLength(2)
+	sample0.Value().FromRaw([]int64{100})
+
+	sample1 := profile.Sample().AppendEmpty()
+	sample1.SetLocationsStartIndex(1)
+	sample1.SetLocationsLength(1)
+	sample1.Value().FromRaw([]int64{5})
+}
+
+func fillProfileTwo(profile pprofile.Profile) {
+	profile.StringTable().Append("")
+	profile.SetPeriod(20)
+}
@@ -11,12 +11,14 @@ import (
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 )
@@ -41,17 +43,29 @@ func newFilterSpansProcessor(set processor.Settings, cfg *Config) (*filterSpanPr
 	fsp.telemetry = fpt
 
 	if cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil {
+		userFunctions, err := cfg.userFunctions()
+		if err != nil {
+			return nil, err
+		}
 		if cfg.Traces.SpanConditions != nil {
-			fsp.skipSpanExpr, err = filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			spanExpr, err := filterottl.NewBoolExprForSpanWithOptions(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), cfg.ErrorMode, set.TelemetrySettings, parserOptions[ottlspan.TransformContext](userFunctions))
 			if err != nil {
 				return nil, err
 			}
+			if cfg.Profiling {
+				ottlspan.WithConditionSequenceProfiling(attribute.String(ottl.AttributeContext, ottlspan.ContextName))(spanExpr)
+			}
+			fsp.skipSpanExpr = spanExpr
 		}
 		if cfg.Traces.SpanEventConditions != nil {
-			fsp.skipSpanEventExpr, err = filterottl.NewBoolExprForSpanEvent(cfg.Traces.SpanEventConditions, filterottl.StandardSpanEventFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			spanEventExpr, err := filterottl.NewBoolExprForSpanEventWithOptions(cfg.Traces.SpanEventConditions, filterottl.StandardSpanEventFuncs(), cfg.ErrorMode, set.TelemetrySettings, parserOptions[ottlspanevent.TransformContext](userFunctions))
 			if err != nil {
 				return nil, err
 			}
+			if cfg.Profiling {
+				ottlspanevent.WithConditionSequenceProfiling(attribute.String(ottl.AttributeContext, ottlspanevent.ContextName))(spanEventExpr)
+			}
+			fsp.skipSpanEventExpr = spanEventExpr
 		}
 		return fsp, nil
 	}
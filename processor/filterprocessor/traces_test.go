@@ -308,6 +308,41 @@ func TestFilterTraceProcessorTelemetry(t *testing.T) {
 	}, metricdatatest.IgnoreTimestamp())
 }
 
+func TestFilterTraceProcessorProfiling(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	processor, err := newFilterSpansProcessor(metadatatest.NewSettings(tel), &Config{
+		Traces: TraceFilters{
+			SpanConditions: []string{
+				`name == "operationA"`,
+			},
+		},
+		ErrorMode: ottl.IgnoreError,
+		Profiling: true,
+	})
+	assert.NoError(t, err)
+
+	_, err = processor.processTraces(context.Background(), constructTraces())
+	assert.NoError(t, err)
+
+	matches, err := tel.GetMetric("otelcol_ottl_condition_matches")
+	require.NoError(t, err)
+	metricdatatest.AssertAggregationsEqual(t, metricdata.Sum[int64]{
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+		DataPoints: []metricdata.DataPoint[int64]{
+			{
+				Value: 2,
+				Attributes: attribute.NewSet(
+					attribute.String(ottl.AttributeContext, "span"),
+					attribute.Int(ottl.AttributeConditionIndex, 0),
+					attribute.String(ottl.AttributeCondition, `name == "operationA"`),
+				),
+			},
+		},
+	}, matches.Data, metricdatatest.IgnoreTimestamp())
+}
+
 func constructTraces() ptrace.Traces {
 	td := ptrace.NewTraces()
 	rs0 := td.ResourceSpans().AppendEmpty()
@@ -17,7 +17,9 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	csv "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
+	mmdb "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/mmdbprovider"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -51,6 +53,27 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_providers"),
+			expected: &Config{
+				Context: resource,
+				Providers: map[string]provider.Config{
+					"maxmind": &maxmind.Config{DatabasePath: "/tmp/city.mmdb", AutoReload: true},
+					"mmdb": &mmdb.Config{
+						DatabasePath: "/tmp/asn.mmdb",
+						Attributes: map[string]string{
+							"autonomous_system_number": "network.asn",
+							"traits.isp":               "network.isp",
+						},
+						AutoReload: true,
+					},
+					"csv": &csv.Config{
+						DatabasePath: "/tmp/ranges.csv",
+						Columns:      []string{csv.ColumnRangeStart, csv.ColumnRangeEnd, "", "network.organization"},
+					},
+				},
+			},
+		},
 		{
 			id:                    component.NewIDWithName(metadata.Type, "invalid_providers_config"),
 			unmarshalErrorMessage: "unexpected sub-config value kind for key:providers value:this should be a map kind:string",
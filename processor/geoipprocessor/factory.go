@@ -16,7 +16,9 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	csv "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
+	mmdb "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/mmdbprovider"
 )
 
 var (
@@ -31,6 +33,8 @@ var (
 // providerFactories is a map that stores GeoIPProviderFactory instances, keyed by the provider type.
 var providerFactories = map[string]provider.GeoIPProviderFactory{
 	maxmind.TypeStr: &maxmind.Factory{},
+	mmdb.TypeStr:    &mmdb.Factory{},
+	csv.TypeStr:     &csv.Factory{},
 }
 
 // NewFactory creates a new processor factory with default configuration,
@@ -88,7 +92,8 @@ func createMetricsProcessor(ctx context.Context, set processor.Settings, cfg com
 	if err != nil {
 		return nil, err
 	}
-	return processorhelper.NewMetrics(ctx, set, cfg, nextConsumer, newGeoIPProcessor(geoCfg, defaultResourceAttributes, providers, set).processMetrics, processorhelper.WithCapabilities(processorCapabilities))
+	geoProcessor := newGeoIPProcessor(geoCfg, defaultResourceAttributes, providers, set)
+	return processorhelper.NewMetrics(ctx, set, cfg, nextConsumer, geoProcessor.processMetrics, processorhelper.WithCapabilities(processorCapabilities), processorhelper.WithShutdown(geoProcessor.shutdown))
 }
 
 func createTracesProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Traces) (processor.Traces, error) {
@@ -97,7 +102,8 @@ func createTracesProcessor(ctx context.Context, set processor.Settings, cfg comp
 	if err != nil {
 		return nil, err
 	}
-	return processorhelper.NewTraces(ctx, set, cfg, nextConsumer, newGeoIPProcessor(geoCfg, defaultResourceAttributes, providers, set).processTraces, processorhelper.WithCapabilities(processorCapabilities))
+	geoProcessor := newGeoIPProcessor(geoCfg, defaultResourceAttributes, providers, set)
+	return processorhelper.NewTraces(ctx, set, cfg, nextConsumer, geoProcessor.processTraces, processorhelper.WithCapabilities(processorCapabilities), processorhelper.WithShutdown(geoProcessor.shutdown))
 }
 
 func createLogsProcessor(ctx context.Context, set processor.Settings, cfg component.Config, nextConsumer consumer.Logs) (processor.Logs, error) {
@@ -106,5 +112,6 @@ func createLogsProcessor(ctx context.Context, set processor.Settings, cfg compon
 	if err != nil {
 		return nil, err
 	}
-	return processorhelper.NewLogs(ctx, set, cfg, nextConsumer, newGeoIPProcessor(geoCfg, defaultResourceAttributes, providers, set).processLogs, processorhelper.WithCapabilities(processorCapabilities))
+	geoProcessor := newGeoIPProcessor(geoCfg, defaultResourceAttributes, providers, set)
+	return processorhelper.NewLogs(ctx, set, cfg, nextConsumer, geoProcessor.processLogs, processorhelper.WithCapabilities(processorCapabilities), processorhelper.WithShutdown(geoProcessor.shutdown))
 }
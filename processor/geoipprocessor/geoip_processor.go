@@ -42,6 +42,15 @@ func newGeoIPProcessor(processorConfig *Config, resourceAttributes []attribute.K
 	}
 }
 
+// shutdown closes the providers of the processor.
+func (g *geoIPProcessor) shutdown(ctx context.Context) error {
+	var errs []error
+	for _, geoProvider := range g.providers {
+		errs = append(errs, geoProvider.Close(ctx))
+	}
+	return errors.Join(errs...)
+}
+
 // parseIP parses a string to a net.IP type and returns an error if the IP is invalid or unspecified.
 func parseIP(strIP string) (net.IP, error) {
 	ip := net.ParseIP(strIP)
@@ -112,6 +121,10 @@ func (g *geoIPProcessor) processAttributes(ctx context.Context, metadata pcommon
 			metadata.PutDouble(string(geoAttr.Key), geoAttr.Value.AsFloat64())
 		case attribute.STRING:
 			metadata.PutStr(string(geoAttr.Key), geoAttr.Value.AsString())
+		case attribute.INT64:
+			metadata.PutInt(string(geoAttr.Key), geoAttr.Value.AsInt64())
+		case attribute.BOOL:
+			metadata.PutBool(string(geoAttr.Key), geoAttr.Value.AsBool())
 		}
 	}
 
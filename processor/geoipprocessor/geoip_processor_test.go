@@ -5,6 +5,7 @@ package geoipprocessor
 
 import (
 	"context"
+	"errors"
 	"net"
 	"path/filepath"
 	"testing"
@@ -37,6 +38,7 @@ type providerFactoryMock struct {
 
 type providerMock struct {
 	LocationF func(context.Context, net.IP) (attribute.Set, error)
+	CloseF    func(context.Context) error
 }
 
 var (
@@ -61,6 +63,13 @@ func (pm *providerMock) Location(ctx context.Context, ip net.IP) (attribute.Set,
 	return pm.LocationF(ctx, ip)
 }
 
+func (pm *providerMock) Close(ctx context.Context) error {
+	if pm.CloseF == nil {
+		return nil
+	}
+	return pm.CloseF(ctx)
+}
+
 var baseMockProvider = providerMock{
 	LocationF: func(context.Context, net.IP) (attribute.Set, error) {
 		return attribute.Set{}, nil
@@ -232,3 +241,22 @@ func TestProcessor(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessorShutdown(t *testing.T) {
+	var closed int
+	closeErr := errors.New("close error")
+	providers := []provider.GeoIPProvider{
+		&providerMock{CloseF: func(context.Context) error {
+			closed++
+			return closeErr
+		}},
+		&providerMock{CloseF: func(context.Context) error {
+			closed++
+			return nil
+		}},
+	}
+
+	processor := newGeoIPProcessor(&Config{}, defaultResourceAttributes, providers, processortest.NewNopSettings(metadata.Type))
+	require.ErrorIs(t, processor.shutdown(context.Background()), closeErr)
+	require.Equal(t, 2, closed)
+}
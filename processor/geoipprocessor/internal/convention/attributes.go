@@ -37,4 +37,43 @@ const (
 
 	// AttributeGeoLocationLon represents the attribute name for the longitude.
 	AttributeGeoLocationLon = "geo.location.lon"
+
+	// AttributeNetworkASN represents the attribute name for the autonomous system number of the network.
+	AttributeNetworkASN = "network.asn"
+
+	// AttributeNetworkASOrganization represents the attribute name for the organization of the autonomous system.
+	AttributeNetworkASOrganization = "network.as_organization"
+
+	// AttributeNetworkISP represents the attribute name for the internet service provider.
+	AttributeNetworkISP = "network.isp"
+
+	// AttributeNetworkOrganization represents the attribute name for the organization the IP address is assigned to.
+	AttributeNetworkOrganization = "network.organization"
+
+	// AttributeNetworkMobileCountryCode represents the attribute name for the mobile country code (MCC).
+	AttributeNetworkMobileCountryCode = "network.mobile_country_code"
+
+	// AttributeNetworkMobileNetworkCode represents the attribute name for the mobile network code (MNC).
+	AttributeNetworkMobileNetworkCode = "network.mobile_network_code"
+
+	// AttributeNetworkConnectionType represents the attribute name for the connection type, e.g. "Cable/DSL".
+	AttributeNetworkConnectionType = "network.connection_type"
+
+	// AttributeNetworkAnonymous represents the attribute name for whether the IP address belongs to any anonymous network.
+	AttributeNetworkAnonymous = "network.anonymous"
+
+	// AttributeNetworkAnonymousVPN represents the attribute name for whether the IP address belongs to an anonymous VPN provider.
+	AttributeNetworkAnonymousVPN = "network.anonymous_vpn"
+
+	// AttributeNetworkHostingProvider represents the attribute name for whether the IP address belongs to a hosting provider.
+	AttributeNetworkHostingProvider = "network.hosting_provider"
+
+	// AttributeNetworkPublicProxy represents the attribute name for whether the IP address belongs to a public proxy.
+	AttributeNetworkPublicProxy = "network.public_proxy"
+
+	// AttributeNetworkResidentialProxy represents the attribute name for whether the IP address belongs to a residential proxy.
+	AttributeNetworkResidentialProxy = "network.residential_proxy"
+
+	// AttributeNetworkTorExitNode represents the attribute name for whether the IP address is a Tor exit node.
+	AttributeNetworkTorExitNode = "network.tor_exit_node"
 )
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csv // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+const (
+	// ColumnRangeStart is the column holding the first IP address of a range.
+	ColumnRangeStart = "ip_range_start"
+	// ColumnRangeEnd is the column holding the last IP address of a range.
+	ColumnRangeEnd = "ip_range_end"
+)
+
+// formats are the columns of the supported well-known CSV databases. The empty columns are ignored.
+var formats = map[string][]string{
+	"dbip_city_lite": {
+		ColumnRangeStart, ColumnRangeEnd,
+		conventions.AttributeGeoContinentCode,
+		conventions.AttributeGeoCountryIsoCode,
+		conventions.AttributeGeoRegionName,
+		conventions.AttributeGeoCityName,
+		conventions.AttributeGeoLocationLat,
+		conventions.AttributeGeoLocationLon,
+	},
+	"dbip_country_lite": {
+		ColumnRangeStart, ColumnRangeEnd,
+		conventions.AttributeGeoCountryIsoCode,
+	},
+	"dbip_asn_lite": {
+		ColumnRangeStart, ColumnRangeEnd,
+		conventions.AttributeNetworkASN,
+		conventions.AttributeNetworkASOrganization,
+	},
+	"ip2location_db1": {
+		ColumnRangeStart, ColumnRangeEnd,
+		conventions.AttributeGeoCountryIsoCode,
+		conventions.AttributeGeoCountryName,
+	},
+	"ip2location_db11": {
+		ColumnRangeStart, ColumnRangeEnd,
+		conventions.AttributeGeoCountryIsoCode,
+		conventions.AttributeGeoCountryName,
+		conventions.AttributeGeoRegionName,
+		conventions.AttributeGeoCityName,
+		conventions.AttributeGeoLocationLat,
+		conventions.AttributeGeoLocationLon,
+		conventions.AttributeGeoPostalCode,
+		// the time zone is a UTC offset rather than a time zone name
+		"",
+	},
+	"ip2location_asn": {
+		ColumnRangeStart, ColumnRangeEnd,
+		// CIDR of the range
+		"",
+		conventions.AttributeNetworkASN,
+		conventions.AttributeNetworkASOrganization,
+	},
+}
+
+// Config defines configuration for the CSV provider.
+type Config struct {
+	// DatabasePath section allows specifying a local CSV file in which every row
+	// holds the metadata of a range of IP addresses.
+	DatabasePath string `mapstructure:"database_path"`
+
+	// Format is the well-known database format of the file, e.g. dbip_city_lite or ip2location_db11.
+	Format string `mapstructure:"format"`
+
+	// Columns lists the content of the columns of the file, when it does not have a well-known format:
+	// ip_range_start and ip_range_end for the bounds of the range, an attribute name for
+	// the columns to add as attributes, or an empty string for the columns to ignore.
+	Columns []string `mapstructure:"columns"`
+
+	// AutoReload reloads the database when its file changes, without restarting the pipeline.
+	AutoReload bool `mapstructure:"auto_reload"`
+}
+
+var _ provider.Config = (*Config)(nil)
+
+// Validate implements provider.Config.
+func (c *Config) Validate() error {
+	if c.DatabasePath == "" {
+		return errors.New("a local CSV database path must be provided")
+	}
+	switch {
+	case c.Format == "" && len(c.Columns) == 0:
+		return errors.New("either a format or columns must be provided")
+	case c.Format != "" && len(c.Columns) > 0:
+		return errors.New("format and columns cannot be both provided")
+	case c.Format != "":
+		if _, ok := formats[c.Format]; !ok {
+			return fmt.Errorf("unknown format %q, available values: %s", c.Format, strings.Join(formatNames(), ", "))
+		}
+		return nil
+	}
+
+	var starts, ends int
+	for _, column := range c.Columns {
+		switch column {
+		case ColumnRangeStart:
+			starts++
+		case ColumnRangeEnd:
+			ends++
+		}
+	}
+	if starts != 1 || ends != 1 {
+		return fmt.Errorf("columns must contain %s and %s exactly once", ColumnRangeStart, ColumnRangeEnd)
+	}
+	return nil
+}
+
+// columns returns the content of the columns of the database.
+func (c *Config) columns() []string {
+	if c.Format != "" {
+		return formats[c.Format]
+	}
+	return c.Columns
+}
+
+func formatNames() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         Config
+		expectedErrMsg string
+	}{
+		{
+			name:   "format",
+			config: Config{DatabasePath: "db.csv", Format: "dbip_city_lite"},
+		},
+		{
+			name:   "columns",
+			config: Config{DatabasePath: "db.csv", Columns: []string{ColumnRangeStart, ColumnRangeEnd, "", "network.organization"}},
+		},
+		{
+			name:           "missing database path",
+			config:         Config{Format: "dbip_city_lite"},
+			expectedErrMsg: "a local CSV database path must be provided",
+		},
+		{
+			name:           "missing format and columns",
+			config:         Config{DatabasePath: "db.csv"},
+			expectedErrMsg: "either a format or columns must be provided",
+		},
+		{
+			name:           "both format and columns",
+			config:         Config{DatabasePath: "db.csv", Format: "dbip_city_lite", Columns: []string{ColumnRangeStart, ColumnRangeEnd}},
+			expectedErrMsg: "format and columns cannot be both provided",
+		},
+		{
+			name:           "unknown format",
+			config:         Config{DatabasePath: "db.csv", Format: "maxmind"},
+			expectedErrMsg: `unknown format "maxmind", available values: dbip_asn_lite, dbip_city_lite, dbip_country_lite, ip2location_asn, ip2location_db1, ip2location_db11`,
+		},
+		{
+			name:           "missing range end",
+			config:         Config{DatabasePath: "db.csv", Columns: []string{ColumnRangeStart, "network.organization"}},
+			expectedErrMsg: "columns must contain ip_range_start and ip_range_end exactly once",
+		},
+		{
+			name:           "duplicated range start",
+			config:         Config{DatabasePath: "db.csv", Columns: []string{ColumnRangeStart, ColumnRangeStart, ColumnRangeEnd}},
+			expectedErrMsg: "columns must contain ip_range_start and ip_range_end exactly once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectedErrMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErrMsg)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csv // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
+
+import (
+	"context"
+	stdcsv "encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// reloadDelay is the delay after the last change of the database file before it is reloaded
+var reloadDelay = provider.DefaultReloadDelay
+
+// ipRange is a row of the database.
+type ipRange struct {
+	start      netip.Addr
+	end        netip.Addr
+	attributes attribute.Set
+}
+
+type csvProvider struct {
+	// mu protects ranges, which are replaced when the database is reloaded
+	mu      sync.RWMutex
+	ranges  []ipRange
+	watcher *provider.FileWatcher
+}
+
+var _ provider.GeoIPProvider = (*csvProvider)(nil)
+
+func newCSVProvider(cfg *Config, logger *zap.Logger) (*csvProvider, error) {
+	columns := cfg.columns()
+	ranges, err := loadDatabase(cfg.DatabasePath, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &csvProvider{ranges: ranges}
+	if cfg.AutoReload {
+		p.watcher, err = provider.WatchFile(cfg.DatabasePath, reloadDelay, logger, func() error {
+			ranges, err := loadDatabase(cfg.DatabasePath, columns)
+			if err != nil {
+				return err
+			}
+			p.mu.Lock()
+			p.ranges = ranges
+			p.mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Close implements provider.GeoIPProvider for CSV.
+func (p *csvProvider) Close(context.Context) error {
+	if p.watcher != nil {
+		return p.watcher.Close()
+	}
+	return nil
+}
+
+// Location implements provider.GeoIPProvider for CSV. The attributes of the range holding
+// the IP address are returned.
+func (p *csvProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	if ipAddress == nil {
+		return attribute.Set{}, errors.New("IP passed to Lookup cannot be nil")
+	}
+	addr, ok := netip.AddrFromSlice(ipAddress.To16())
+	if !ok {
+		return attribute.Set{}, fmt.Errorf("invalid IP address: %s", ipAddress)
+	}
+
+	p.mu.RLock()
+	ranges := p.ranges
+	p.mu.RUnlock()
+
+	// the ranges are sorted by their first address, the candidate is the last range starting before the address
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].start.Compare(addr) > 0
+	}) - 1
+	if i < 0 || ranges[i].end.Compare(addr) < 0 || ranges[i].attributes.Len() == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return ranges[i].attributes, nil
+}
+
+// loadDatabase reads the ranges of a CSV database and sorts them by their first address.
+func loadDatabase(path string, columns []string) ([]ipRange, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open CSV database: %w", err)
+	}
+	defer file.Close()
+
+	reader := stdcsv.NewReader(file)
+	reader.FieldsPerRecord = len(columns)
+	reader.ReuseRecord = true
+
+	var ranges []ipRange
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read CSV database: %w", err)
+		}
+
+		r, err := parseRange(columns, record)
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			// the first line is a header when its first address is not valid
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("invalid CSV database line %d: %w", line, err)
+		}
+		ranges = append(ranges, r)
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Less(ranges[j].start)
+	})
+	return ranges, nil
+}
+
+// parseRange parses a row of the database.
+func parseRange(columns []string, record []string) (ipRange, error) {
+	var r ipRange
+	attributes := make([]attribute.KeyValue, 0, len(columns))
+	for i, column := range columns {
+		value := strings.TrimSpace(record[i])
+		switch column {
+		case "":
+			continue
+		case ColumnRangeStart, ColumnRangeEnd:
+			addr, err := parseAddr(value)
+			if err != nil {
+				return ipRange{}, err
+			}
+			if column == ColumnRangeStart {
+				r.start = addr
+			} else {
+				r.end = addr
+			}
+		default:
+			// unknown values, such as the country of reserved ranges, are marked with a dash
+			if value == "" || value == "-" {
+				continue
+			}
+			kv, err := toAttribute(column, value)
+			if err != nil {
+				return ipRange{}, err
+			}
+			attributes = append(attributes, kv)
+		}
+	}
+	if r.end.Less(r.start) {
+		return ipRange{}, fmt.Errorf("range end %s is before its start %s", r.end, r.start)
+	}
+	r.attributes = attribute.NewSet(attributes...)
+	return r, nil
+}
+
+// maxIPv4 is the greatest IPv4 address as an integer.
+var maxIPv4 = big.NewInt(1<<32 - 1)
+
+// parseAddr parses an IP address written either as text or as an integer, as IP2Location databases do.
+// The addresses are returned in their 16 bytes form, so that IPv4 addresses can be compared with the
+// IPv4-mapped IPv6 addresses of the lookups.
+func parseAddr(value string) (netip.Addr, error) {
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return netip.AddrFrom16(addr.As16()), nil
+	}
+
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok || n.Sign() < 0 || n.BitLen() > 128 {
+		return netip.Addr{}, fmt.Errorf("invalid IP address %q", value)
+	}
+	if n.Cmp(maxIPv4) <= 0 {
+		var ipv4 [4]byte
+		n.FillBytes(ipv4[:])
+		return netip.AddrFrom16(netip.AddrFrom4(ipv4).As16()), nil
+	}
+	var ipv6 [16]byte
+	n.FillBytes(ipv6[:])
+	return netip.AddrFrom16(ipv6), nil
+}
+
+// toAttribute converts a value of the database to an attribute of the type of its convention.
+func toAttribute(key string, value string) (attribute.KeyValue, error) {
+	switch key {
+	case conventions.AttributeGeoLocationLat, conventions.AttributeGeoLocationLon:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return attribute.KeyValue{}, fmt.Errorf("invalid %s %q", key, value)
+		}
+		return attribute.Float64(key, f), nil
+	case conventions.AttributeNetworkASN:
+		asn, err := strconv.ParseInt(strings.TrimPrefix(strings.ToUpper(value), "AS"), 10, 64)
+		if err != nil {
+			return attribute.KeyValue{}, fmt.Errorf("invalid %s %q", key, value)
+		}
+		return attribute.Int64(key, asn), nil
+	default:
+		return attribute.String(key, value), nil
+	}
+}
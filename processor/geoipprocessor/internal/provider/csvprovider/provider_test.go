@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csv
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+)
+
+func writeDatabase(t *testing.T, path string, content string) {
+	// the database is written next to its destination and renamed over it, like database updaters do
+	require.NoError(t, os.WriteFile(path+".tmp", []byte(content), 0o600))
+	require.NoError(t, os.Rename(path+".tmp", path))
+}
+
+func TestInvalidNewProvider(t *testing.T) {
+	_, err := newCSVProvider(&Config{DatabasePath: "no valid path", Format: "dbip_country_lite"}, zap.NewNop())
+	require.ErrorContains(t, err, "could not open CSV database")
+
+	dbPath := filepath.Join(t.TempDir(), "db.csv")
+	for content, expectedErr := range map[string]string{
+		"1.0.0.0,1.0.0.255,AU\n1.0.1.0,CN\n":             "could not read CSV database: record on line 2: wrong number of fields",
+		"1.0.0.0,1.0.0.255,AU\n1.0.1.0,invalid,CN\n":     `invalid CSV database line 2: invalid IP address "invalid"`,
+		"1.0.0.0,1.0.0.255,AU\n1.0.1.255,1.0.1.0,CN\n":   "invalid CSV database line 2: range end ::ffff:1.0.1.0 is before its start ::ffff:1.0.1.255",
+		"1.0.0.0,1.0.0.255,AU\n1.0.1.0,1.0.1.255,CN,x\n": "could not read CSV database: record on line 2: wrong number of fields",
+	} {
+		writeDatabase(t, dbPath, content)
+		_, err = newCSVProvider(&Config{DatabasePath: dbPath, Format: "dbip_country_lite"}, zap.NewNop())
+		assert.EqualError(t, err, expectedErr)
+	}
+
+	writeDatabase(t, dbPath, "1.0.0.0,1.0.0.255,AS13335,Cloudflare\n1.0.1.0,1.0.1.255,unknown,\n")
+	_, err = newCSVProvider(&Config{DatabasePath: dbPath, Format: "dbip_asn_lite"}, zap.NewNop())
+	assert.EqualError(t, err, `invalid CSV database line 2: invalid network.asn "unknown"`)
+}
+
+func TestProviderLocation(t *testing.T) {
+	dir := t.TempDir()
+	dbipCity := filepath.Join(dir, "dbip-city-lite.csv")
+	writeDatabase(t, dbipCity, `1.0.0.0,1.0.0.255,OC,AU,Queensland,"South Brisbane",-27.4767,153.017
+1.0.4.0,1.0.7.255,OC,AU,Victoria,Melbourne,-37.814,144.963
+2001:200::,2001:200:ffff:ffff:ffff:ffff:ffff:ffff,AS,JP,Tokyo,Tokyo,35.6895,139.692
+`)
+	ip2locationASN := filepath.Join(dir, "ip2location-asn.csv")
+	writeDatabase(t, ip2locationASN, `"ip_from","ip_to","cidr","asn","as"
+"16777216","16777471","1.0.0.0/24","13335","CloudFlare Inc"
+"16777472","16778239","1.0.1.0/24","-","-"
+"281470698520576","281470698520831","::ffff:1.0.0.0/120","13335","CloudFlare Inc"
+"58569107296622255421594597096899477504","58569107296622255421594597096899477504","2c0f:fff0::/128","37125","Layer3"
+`)
+	custom := filepath.Join(dir, "custom.csv")
+	writeDatabase(t, custom, `10.0.0.0,10.255.255.255,ignored,Intranet,true
+`)
+
+	tests := []struct {
+		name               string
+		config             Config
+		sourceIP           net.IP
+		expectedAttributes attribute.Set
+		expectedErrMsg     string
+	}{
+		{
+			name:           "nil IP address",
+			config:         Config{DatabasePath: dbipCity, Format: "dbip_city_lite"},
+			expectedErrMsg: "IP passed to Lookup cannot be nil",
+		},
+		{
+			name:           "IP address before the first range",
+			config:         Config{DatabasePath: dbipCity, Format: "dbip_city_lite"},
+			sourceIP:       net.IPv4(0, 0, 0, 1),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:           "IP address between ranges",
+			config:         Config{DatabasePath: dbipCity, Format: "dbip_city_lite"},
+			sourceIP:       net.IPv4(1, 0, 1, 1),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:     "IPv4 address using DB-IP city lite database",
+			config:   Config{DatabasePath: dbipCity, Format: "dbip_city_lite"},
+			sourceIP: net.IPv4(1, 0, 7, 255),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoContinentCode, "OC"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "AU"),
+				attribute.String(conventions.AttributeGeoRegionName, "Victoria"),
+				attribute.String(conventions.AttributeGeoCityName, "Melbourne"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, -37.814),
+				attribute.Float64(conventions.AttributeGeoLocationLon, 144.963),
+			),
+		},
+		{
+			name:     "IPv6 address using DB-IP city lite database",
+			config:   Config{DatabasePath: dbipCity, Format: "dbip_city_lite"},
+			sourceIP: net.ParseIP("2001:200::1"),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoContinentCode, "AS"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "JP"),
+				attribute.String(conventions.AttributeGeoRegionName, "Tokyo"),
+				attribute.String(conventions.AttributeGeoCityName, "Tokyo"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 35.6895),
+				attribute.Float64(conventions.AttributeGeoLocationLon, 139.692),
+			),
+		},
+		{
+			name:     "IPv4 address using IP2Location ASN database",
+			config:   Config{DatabasePath: ip2locationASN, Format: "ip2location_asn"},
+			sourceIP: net.ParseIP("1.0.0.1"),
+			expectedAttributes: attribute.NewSet(
+				attribute.Int64(conventions.AttributeNetworkASN, 13335),
+				attribute.String(conventions.AttributeNetworkASOrganization, "CloudFlare Inc"),
+			),
+		},
+		{
+			name:     "IPv6 address using IP2Location ASN database",
+			config:   Config{DatabasePath: ip2locationASN, Format: "ip2location_asn"},
+			sourceIP: net.ParseIP("2c0f:fff0::"),
+			expectedAttributes: attribute.NewSet(
+				attribute.Int64(conventions.AttributeNetworkASN, 37125),
+				attribute.String(conventions.AttributeNetworkASOrganization, "Layer3"),
+			),
+		},
+		{
+			name:           "unknown values in IP2Location ASN database",
+			config:         Config{DatabasePath: ip2locationASN, Format: "ip2location_asn"},
+			sourceIP:       net.ParseIP("1.0.1.1"),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name: "custom columns",
+			config: Config{DatabasePath: custom, Columns: []string{
+				ColumnRangeStart, ColumnRangeEnd, "", conventions.AttributeNetworkOrganization, "network.internal",
+			}},
+			sourceIP: net.ParseIP("10.1.2.3"),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeNetworkOrganization, "Intranet"),
+				attribute.String("network.internal", "true"),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := newCSVProvider(&tt.config, zap.NewNop())
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, provider.Close(context.Background())) }()
+
+			actualAttributes, err := provider.Location(context.Background(), tt.sourceIP)
+			if tt.expectedErrMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttributes.ToSlice(), actualAttributes.ToSlice())
+		})
+	}
+}
+
+func TestProviderAutoReload(t *testing.T) {
+	previousDelay := reloadDelay
+	reloadDelay = 10 * time.Millisecond
+	defer func() { reloadDelay = previousDelay }()
+
+	dbPath := filepath.Join(t.TempDir(), "db.csv")
+	writeDatabase(t, dbPath, "1.0.0.0,1.0.0.255,AU\n")
+	provider, err := newCSVProvider(&Config{DatabasePath: dbPath, Format: "dbip_country_lite", AutoReload: true}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, provider.Close(context.Background())) }()
+
+	_, err = provider.Location(context.Background(), net.IPv4(1, 0, 1, 1))
+	require.EqualError(t, err, "no geo IP metadata found")
+
+	// the previous database is kept while the file is invalid
+	writeDatabase(t, dbPath, "1.0.0.0,1.0.0.255,AU\n1.0.1.0,invalid,CN\n")
+	time.Sleep(50 * time.Millisecond)
+	attributes, err := provider.Location(context.Background(), net.IPv4(1, 0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, attribute.NewSet(attribute.String(conventions.AttributeGeoCountryIsoCode, "AU")), attributes)
+
+	writeDatabase(t, dbPath, "1.0.0.0,1.0.0.255,AU\n1.0.1.0,1.0.1.255,CN\n")
+	assert.Eventually(t, func() bool {
+		_, err := provider.Location(context.Background(), net.IPv4(1, 0, 1, 1))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -25,6 +25,9 @@ type Config interface {
 type GeoIPProvider interface {
 	// Location returns a set of attributes representing the geographical location for the given IP address. It requires a context for managing request lifetime.
 	Location(context.Context, net.IP) (attribute.Set, error)
+
+	// Close releases the resources of the provider, such as its database file and file watcher.
+	Close(context.Context) error
 }
 
 // GeoIPProviderFactory can create GeoIPProvider instances.
@@ -14,6 +14,9 @@ type Config struct {
 	// DatabasePath section allows specifying a local GeoIP database
 	// file to retrieve the geographical metadata from.
 	DatabasePath string `mapstructure:"database_path"`
+
+	// AutoReload reloads the database when its file changes, without restarting the pipeline.
+	AutoReload bool `mapstructure:"auto_reload"`
 }
 
 var _ provider.Config = (*Config)(nil)
@@ -8,9 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sync"
 
 	"github.com/oschwald/geoip2-golang"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 
 	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
@@ -23,27 +25,72 @@ var (
 	geoLite2CityDBType  = "GeoLite2-City"
 
 	errUnsupportedDB = errors.New("unsupported geo IP database type")
+
+	// reloadDelay is the delay after the last change of the database file before it is reloaded
+	reloadDelay = provider.DefaultReloadDelay
 )
 
 type maxMindProvider struct {
+	// mu protects geoReader, which is replaced when the database is reloaded
+	mu        sync.RWMutex
 	geoReader *geoip2.Reader
+	watcher   *provider.FileWatcher
 	// language code to be used in name retrieval, e.g. "en" or "pt-BR"
 	langCode string
 }
 
 var _ provider.GeoIPProvider = (*maxMindProvider)(nil)
 
-func newMaxMindProvider(cfg *Config) (*maxMindProvider, error) {
+func newMaxMindProvider(cfg *Config, logger *zap.Logger) (*maxMindProvider, error) {
 	geoReader, err := geoip2.Open(cfg.DatabasePath)
 	if err != nil {
 		return nil, fmt.Errorf("could not open geoip database: %w", err)
 	}
 
-	return &maxMindProvider{geoReader: geoReader, langCode: defaultLanguageCode}, nil
+	p := &maxMindProvider{geoReader: geoReader, langCode: defaultLanguageCode}
+	if cfg.AutoReload {
+		p.watcher, err = provider.WatchFile(cfg.DatabasePath, reloadDelay, logger, func() error {
+			return p.reload(cfg.DatabasePath)
+		})
+		if err != nil {
+			_ = geoReader.Close()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// reload replaces the database with the current content of its file.
+func (g *maxMindProvider) reload(path string) error {
+	geoReader, err := geoip2.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open geoip database: %w", err)
+	}
+
+	g.mu.Lock()
+	previous := g.geoReader
+	g.geoReader = geoReader
+	g.mu.Unlock()
+	return previous.Close()
+}
+
+// Close implements provider.GeoIPProvider for MaxMind.
+func (g *maxMindProvider) Close(context.Context) error {
+	var errs []error
+	if g.watcher != nil {
+		errs = append(errs, g.watcher.Close())
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	errs = append(errs, g.geoReader.Close())
+	return errors.Join(errs...)
 }
 
 // Location implements provider.GeoIPProvider for MaxMind. If a non City database type is used or no metadata is found in the database, an error will be returned.
 func (g *maxMindProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	switch g.geoReader.Metadata().DatabaseType {
 	case geoIP2CityDBType, geoLite2CityDBType:
 		attrs, err := g.cityAttributes(ipAddress)
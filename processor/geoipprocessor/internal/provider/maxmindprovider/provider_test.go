@@ -7,26 +7,29 @@ import (
 	"context"
 	"net"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 
 	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider/testdata"
 )
 
 func TestInvalidNewProvider(t *testing.T) {
-	_, err := newMaxMindProvider(&Config{})
+	_, err := newMaxMindProvider(&Config{}, zap.NewNop())
 	expectedErrMsgSuffix := "no such file or directory"
 	if runtime.GOOS == "windows" {
 		expectedErrMsgSuffix = "The system cannot find the file specified."
 	}
 	require.ErrorContains(t, err, "could not open geoip database: open : "+expectedErrMsgSuffix)
 
-	_, err = newMaxMindProvider(&Config{DatabasePath: "no valid path"})
+	_, err = newMaxMindProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
 	require.ErrorContains(t, err, "could not open geoip database: open no valid path: "+expectedErrMsgSuffix)
 }
 
@@ -98,8 +101,9 @@ func TestProviderLocation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// prepare provider
-			provider, err := newMaxMindProvider(&Config{DatabasePath: tmpDBfiles + "/" + tt.testDatabase})
+			provider, err := newMaxMindProvider(&Config{DatabasePath: tmpDBfiles + "/" + tt.testDatabase}, zap.NewNop())
 			assert.NoError(t, err)
+			defer func() { assert.NoError(t, provider.Close(context.Background())) }()
 
 			// assert metrics
 			actualAttributes, err := provider.Location(context.Background(), tt.sourceIP)
@@ -112,3 +116,37 @@ func TestProviderLocation(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderAutoReload(t *testing.T) {
+	previousDelay := reloadDelay
+	reloadDelay = 10 * time.Millisecond
+	defer func() { reloadDelay = previousDelay }()
+
+	tmpDBfiles := testdata.GenerateLocalDB(t, "./testdata")
+	defer os.RemoveAll(tmpDBfiles)
+	dbPath := filepath.Join(t.TempDir(), "GeoIP2-City.mmdb")
+	copyDatabase := func(name string) {
+		content, err := os.ReadFile(filepath.Join(tmpDBfiles, name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(dbPath+".tmp", content, 0o600))
+		require.NoError(t, os.Rename(dbPath+".tmp", dbPath))
+	}
+
+	copyDatabase("GeoLite2-City-Test.mmdb")
+	provider, err := newMaxMindProvider(&Config{DatabasePath: dbPath, AutoReload: true}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, provider.Close(context.Background())) }()
+
+	_, err = provider.Location(context.Background(), net.ParseIP("2001:220::"))
+	require.EqualError(t, err, "no geo IP metadata found")
+
+	copyDatabase("GeoIP2-City-Test.mmdb")
+	assert.Eventually(t, func() bool {
+		attributes, err := provider.Location(context.Background(), net.ParseIP("2001:220::"))
+		if err != nil {
+			return false
+		}
+		country, _ := attributes.Value(conventions.AttributeGeoCountryIsoCode)
+		return country.AsString() == "KR"
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mmdb // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/mmdbprovider"
+
+import (
+	"errors"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// Config defines configuration for the MMDB provider.
+type Config struct {
+	// DatabasePath section allows specifying a local MMDB database
+	// file to retrieve the IP metadata from.
+	DatabasePath string `mapstructure:"database_path"`
+
+	// Attributes maps the fields of the database records to attribute names. Nested fields
+	// are separated by dots, e.g. "traits.isp". By default, the fields of the MaxMind ASN,
+	// ISP, Connection-Type and Anonymous-IP databases are mapped to network attributes.
+	Attributes map[string]string `mapstructure:"attributes"`
+
+	// AutoReload reloads the database when its file changes, without restarting the pipeline.
+	AutoReload bool `mapstructure:"auto_reload"`
+}
+
+var _ provider.Config = (*Config)(nil)
+
+// Validate implements provider.Config.
+func (c *Config) Validate() error {
+	if c.DatabasePath == "" {
+		return errors.New("a local MMDB database path must be provided")
+	}
+	for field, attribute := range c.Attributes {
+		if field == "" || attribute == "" {
+			return errors.New("the database fields and their attribute names must not be empty")
+		}
+	}
+	return nil
+}
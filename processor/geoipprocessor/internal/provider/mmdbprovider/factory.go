@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mmdb // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/mmdbprovider"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+const (
+	// TypeStr the value of "type" key in configuration.
+	TypeStr = "mmdb"
+)
+
+// Factory is the Factory for the MMDB provider.
+type Factory struct{}
+
+var _ provider.GeoIPProviderFactory = (*Factory)(nil)
+
+// CreateDefaultConfig creates the default configuration for the Provider.
+func (f *Factory) CreateDefaultConfig() provider.Config {
+	return &Config{}
+}
+
+// CreateGeoIPProvider creates a provider based on this config.
+func (f *Factory) CreateGeoIPProvider(_ context.Context, settings processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+	mmdbConfig := cfg.(*Config)
+	return newMMDBProvider(mmdbConfig, settings.Logger)
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mmdb // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/mmdbprovider"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/oschwald/maxminddb-golang"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+var (
+	// defaultAttributes maps the fields of the MaxMind ASN, ISP, Connection-Type and Anonymous-IP databases,
+	// which are also used by other MMDB databases such as the DB-IP ASN one, to network attributes.
+	defaultAttributes = map[string]string{
+		"autonomous_system_number":       conventions.AttributeNetworkASN,
+		"autonomous_system_organization": conventions.AttributeNetworkASOrganization,
+		"isp":                            conventions.AttributeNetworkISP,
+		"organization":                   conventions.AttributeNetworkOrganization,
+		"mobile_country_code":            conventions.AttributeNetworkMobileCountryCode,
+		"mobile_network_code":            conventions.AttributeNetworkMobileNetworkCode,
+		"connection_type":                conventions.AttributeNetworkConnectionType,
+		"is_anonymous":                   conventions.AttributeNetworkAnonymous,
+		"is_anonymous_vpn":               conventions.AttributeNetworkAnonymousVPN,
+		"is_hosting_provider":            conventions.AttributeNetworkHostingProvider,
+		"is_public_proxy":                conventions.AttributeNetworkPublicProxy,
+		"is_residential_proxy":           conventions.AttributeNetworkResidentialProxy,
+		"is_tor_exit_node":               conventions.AttributeNetworkTorExitNode,
+	}
+
+	// reloadDelay is the delay after the last change of the database file before it is reloaded
+	reloadDelay = provider.DefaultReloadDelay
+)
+
+type field struct {
+	path      []string
+	attribute string
+}
+
+type mmdbProvider struct {
+	// mu protects reader, which is replaced when the database is reloaded
+	mu      sync.RWMutex
+	reader  *maxminddb.Reader
+	watcher *provider.FileWatcher
+	fields  []field
+}
+
+var _ provider.GeoIPProvider = (*mmdbProvider)(nil)
+
+func newMMDBProvider(cfg *Config, logger *zap.Logger) (*mmdbProvider, error) {
+	reader, err := maxminddb.Open(cfg.DatabasePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open MMDB database: %w", err)
+	}
+
+	attributes := cfg.Attributes
+	if len(attributes) == 0 {
+		attributes = defaultAttributes
+	}
+	fields := make([]field, 0, len(attributes))
+	for path, attr := range attributes {
+		fields = append(fields, field{path: strings.Split(path, "."), attribute: attr})
+	}
+
+	p := &mmdbProvider{reader: reader, fields: fields}
+	if cfg.AutoReload {
+		p.watcher, err = provider.WatchFile(cfg.DatabasePath, reloadDelay, logger, func() error {
+			return p.reload(cfg.DatabasePath)
+		})
+		if err != nil {
+			_ = reader.Close()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// reload replaces the database with the current content of its file.
+func (p *mmdbProvider) reload(path string) error {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open MMDB database: %w", err)
+	}
+
+	p.mu.Lock()
+	previous := p.reader
+	p.reader = reader
+	p.mu.Unlock()
+	return previous.Close()
+}
+
+// Close implements provider.GeoIPProvider for MMDB.
+func (p *mmdbProvider) Close(context.Context) error {
+	var errs []error
+	if p.watcher != nil {
+		errs = append(errs, p.watcher.Close())
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	errs = append(errs, p.reader.Close())
+	return errors.Join(errs...)
+}
+
+// Location implements provider.GeoIPProvider for MMDB. The configured fields of the record
+// of the IP address are returned as attributes.
+func (p *mmdbProvider) Location(_ context.Context, ipAddress net.IP) (attribute.Set, error) {
+	if ipAddress == nil {
+		return attribute.Set{}, errors.New("IP passed to Lookup cannot be nil")
+	}
+
+	var record any
+	p.mu.RLock()
+	_, found, err := p.reader.LookupNetwork(ipAddress, &record)
+	p.mu.RUnlock()
+	if err != nil {
+		return attribute.Set{}, err
+	}
+	if !found {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+
+	attributes := make([]attribute.KeyValue, 0, len(p.fields))
+	for _, f := range p.fields {
+		value, ok := lookupField(record, f.path)
+		if !ok {
+			continue
+		}
+		if kv, ok := toAttribute(f.attribute, value); ok {
+			attributes = append(attributes, kv)
+		}
+	}
+	if len(attributes) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return attribute.NewSet(attributes...), nil
+}
+
+// lookupField returns the value of a possibly nested field of a record.
+func lookupField(record any, path []string) (any, bool) {
+	value := record
+	for _, key := range path {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// toAttribute converts a value decoded from an MMDB database to an attribute. The values which are
+// not scalars and the empty strings are skipped.
+func toAttribute(key string, value any) (attribute.KeyValue, bool) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return attribute.KeyValue{}, false
+		}
+		// some databases encode the autonomous system numbers as strings such as "AS15169"
+		if key == conventions.AttributeNetworkASN {
+			if asn, err := strconv.ParseInt(strings.TrimPrefix(strings.ToUpper(v), "AS"), 10, 64); err == nil {
+				return attribute.Int64(key, asn), true
+			}
+		}
+		return attribute.String(key, v), true
+	case bool:
+		return attribute.Bool(key, v), true
+	case int:
+		return attribute.Int64(key, int64(v)), true
+	case uint64:
+		if v > math.MaxInt64 {
+			return attribute.String(key, strconv.FormatUint(v, 10)), true
+		}
+		return attribute.Int64(key, int64(v)), true
+	case *big.Int:
+		if v.IsInt64() {
+			return attribute.Int64(key, v.Int64()), true
+		}
+		return attribute.String(key, v.String()), true
+	case float32:
+		return attribute.Float64(key, float64(v)), true
+	case float64:
+		return attribute.Float64(key, v), true
+	default:
+		return attribute.KeyValue{}, false
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mmdb
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+)
+
+// writeDatabase writes an MMDB database with the given records at path.
+func writeDatabase(t *testing.T, path string, records map[string]mmdbtype.Map) {
+	tree, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: "Test-Network", RecordSize: 28})
+	require.NoError(t, err)
+	for network, record := range records {
+		_, ipNet, err := net.ParseCIDR(network)
+		require.NoError(t, err)
+		require.NoError(t, tree.Insert(ipNet, record))
+	}
+
+	// the database is written next to its destination and renamed over it, like database updaters do
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	require.NoError(t, err)
+	_, err = tree.WriteTo(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+var testRecords = map[string]mmdbtype.Map{
+	"1.128.0.0/11": {
+		"autonomous_system_number":       mmdbtype.Uint32(1221),
+		"autonomous_system_organization": mmdbtype.String("Telstra Pty Ltd"),
+		"isp":                            mmdbtype.String("Telstra Internet"),
+		"is_anonymous":                   mmdbtype.Bool(true),
+		"is_tor_exit_node":               mmdbtype.Bool(false),
+		"traits": mmdbtype.Map{
+			"connection_type": mmdbtype.String("Cellular"),
+		},
+	},
+	"2.125.160.216/29": {
+		"asn":      mmdbtype.String("AS15169"),
+		"as_name":  mmdbtype.String("Google LLC"),
+		"as_score": mmdbtype.Float64(0.5),
+	},
+	"81.2.69.0/24": {
+		"organization": mmdbtype.String(""),
+	},
+}
+
+func TestInvalidNewProvider(t *testing.T) {
+	_, err := newMMDBProvider(&Config{DatabasePath: "no valid path"}, zap.NewNop())
+	require.ErrorContains(t, err, "could not open MMDB database")
+}
+
+func TestProviderLocation(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "network.mmdb")
+	writeDatabase(t, dbPath, testRecords)
+
+	tests := []struct {
+		name               string
+		attributes         map[string]string
+		sourceIP           net.IP
+		expectedAttributes attribute.Set
+		expectedErrMsg     string
+	}{
+		{
+			name:           "nil IP address",
+			expectedErrMsg: "IP passed to Lookup cannot be nil",
+		},
+		{
+			name:           "IP address not in database",
+			sourceIP:       net.IPv4(89, 160, 20, 112),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:           "no mapped field in record",
+			sourceIP:       net.IPv4(81, 2, 69, 1),
+			expectedErrMsg: "no geo IP metadata found",
+		},
+		{
+			name:     "default attributes",
+			sourceIP: net.IPv4(1, 128, 0, 1),
+			expectedAttributes: attribute.NewSet(
+				attribute.Int64(conventions.AttributeNetworkASN, 1221),
+				attribute.String(conventions.AttributeNetworkASOrganization, "Telstra Pty Ltd"),
+				attribute.String(conventions.AttributeNetworkISP, "Telstra Internet"),
+				attribute.Bool(conventions.AttributeNetworkAnonymous, true),
+				attribute.Bool(conventions.AttributeNetworkTorExitNode, false),
+			),
+		},
+		{
+			name: "custom attributes",
+			attributes: map[string]string{
+				"traits.connection_type": conventions.AttributeNetworkConnectionType,
+				"traits.missing":         "missing",
+				"isp.missing":            "missing",
+			},
+			sourceIP: net.IPv4(1, 128, 0, 1),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeNetworkConnectionType, "Cellular"),
+			),
+		},
+		{
+			name: "autonomous system number as string",
+			attributes: map[string]string{
+				"asn":      conventions.AttributeNetworkASN,
+				"as_name":  conventions.AttributeNetworkASOrganization,
+				"as_score": "network.as_score",
+			},
+			sourceIP: net.IPv4(2, 125, 160, 217),
+			expectedAttributes: attribute.NewSet(
+				attribute.Int64(conventions.AttributeNetworkASN, 15169),
+				attribute.String(conventions.AttributeNetworkASOrganization, "Google LLC"),
+				attribute.Float64("network.as_score", 0.5),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := newMMDBProvider(&Config{DatabasePath: dbPath, Attributes: tt.attributes}, zap.NewNop())
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, provider.Close(context.Background())) }()
+
+			actualAttributes, err := provider.Location(context.Background(), tt.sourceIP)
+			if tt.expectedErrMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttributes.ToSlice(), actualAttributes.ToSlice())
+		})
+	}
+}
+
+func TestProviderAutoReload(t *testing.T) {
+	previousDelay := reloadDelay
+	reloadDelay = 10 * time.Millisecond
+	defer func() { reloadDelay = previousDelay }()
+
+	dbPath := filepath.Join(t.TempDir(), "network.mmdb")
+	writeDatabase(t, dbPath, testRecords)
+	provider, err := newMMDBProvider(&Config{DatabasePath: dbPath, AutoReload: true}, zap.NewNop())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, provider.Close(context.Background())) }()
+
+	_, err = provider.Location(context.Background(), net.IPv4(89, 160, 20, 112))
+	require.EqualError(t, err, "no geo IP metadata found")
+
+	writeDatabase(t, dbPath, map[string]mmdbtype.Map{
+		"89.160.20.0/24": {"autonomous_system_number": mmdbtype.Uint32(64512)},
+	})
+	assert.Eventually(t, func() bool {
+		attributes, err := provider.Location(context.Background(), net.IPv4(89, 160, 20, 112))
+		if err != nil {
+			return false
+		}
+		asn, _ := attributes.Value(conventions.AttributeNetworkASN)
+		return asn.AsInt64() == 64512
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package provider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// DefaultReloadDelay is the delay after the last change of a database file before it is reloaded,
+// so that a database which is being written is only reloaded once it is complete.
+const DefaultReloadDelay = time.Second
+
+// FileWatcher reloads a database when its file changes.
+type FileWatcher struct {
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// WatchFile calls reload every time the file at the given path is written, created or replaced, e.g.
+// by renaming another file over it, until the watcher is closed. The directory of the file is watched
+// rather than the file itself, so that the file keeps being watched after it has been replaced.
+func WatchFile(path string, delay time.Duration, logger *zap.Logger, reload func() error) (*FileWatcher, error) {
+	path = filepath.Clean(path)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("could not create file watcher: %w", err)
+	}
+	if err = watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("could not watch database directory: %w", err)
+	}
+
+	w := &FileWatcher{watcher: watcher, done: make(chan struct{})}
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		timer := time.NewTimer(delay)
+		timer.Stop()
+		defer timer.Stop()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) == path && event.Has(fsnotify.Write|fsnotify.Create) {
+					timer.Reset(delay)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warn("error watching geo IP database", zap.String("path", path), zap.Error(err))
+			case <-timer.C:
+				if err := reload(); err != nil {
+					logger.Error("could not reload geo IP database, keeping the previous one", zap.String("path", path), zap.Error(err))
+					continue
+				}
+				logger.Info("reloaded geo IP database", zap.String("path", path))
+			case <-w.done:
+				return
+			}
+		}
+	}()
+	return w, nil
+}
+
+// Close stops watching the file.
+func (w *FileWatcher) Close() error {
+	close(w.done)
+	w.wg.Wait()
+	return w.watcher.Close()
+}
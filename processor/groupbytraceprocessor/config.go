@@ -4,7 +4,10 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config is the configuration for the processor.
@@ -27,9 +30,30 @@ type Config struct {
 	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
-	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
+	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to the
+	// storage extension referenced by Storage. Traces kept in the storage survive a restart of the collector.
 	// Useful when the duration to wait for traces to complete is high.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// Storage is the ID of the storage extension used when StoreOnDisk is enabled.
+	Storage *component.ID `mapstructure:"storage"`
+
+	// MaxBytes is the maximum size, in bytes of serialized spans, of the traces waiting for the duration.
+	// When set, the oldest traces are evicted once this size is exceeded and NumTraces is not used.
+	// Default: 0, which evicts traces based on NumTraces.
+	MaxBytes int64 `mapstructure:"max_bytes"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.StoreOnDisk && cfg.Storage == nil {
+		return errStorageExtensionRequired
+	}
+	if cfg.MaxBytes < 0 {
+		return errors.New("'max_bytes' must not be negative")
+	}
+	return nil
 }
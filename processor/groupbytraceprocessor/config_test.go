@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestValidateConfig(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	for _, tt := range []struct {
+		name        string
+		config      *Config
+		expectedErr string
+	}{
+		{
+			name:   "default",
+			config: createDefaultConfig().(*Config),
+		},
+		{
+			name:   "store on disk",
+			config: &Config{StoreOnDisk: true, Storage: &storageID},
+		},
+		{
+			name:        "store on disk without storage",
+			config:      &Config{StoreOnDisk: true},
+			expectedErr: errStorageExtensionRequired.Error(),
+		},
+		{
+			name:        "negative max bytes",
+			config:      &Config{MaxBytes: -1},
+			expectedErr: "'max_bytes' must not be negative",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
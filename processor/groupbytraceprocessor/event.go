@@ -32,6 +32,9 @@ const (
 
 	// traceID to be removed
 	traceRemoved
+
+	// trace restored from a persistent storage
+	traceRestored
 )
 
 var (
@@ -78,6 +81,7 @@ type eventMachine struct {
 	onTraceExpired  func(traceID pcommon.TraceID, worker *eventMachineWorker) error
 	onTraceReleased func(rss []ptrace.ResourceSpans) error
 	onTraceRemoved  func(traceID pcommon.TraceID) error
+	onTraceRestored func(trace storedTrace, worker *eventMachineWorker) error
 
 	onError func(event)
 
@@ -208,6 +212,22 @@ func (em *eventMachine) handleEvent(e event, w *eventMachineWorker) {
 		em.handleEventWithObservability("onTraceRemoved", func() error {
 			return em.onTraceRemoved(payload)
 		})
+	case traceRestored:
+		if em.onTraceRestored == nil {
+			em.logger.Debug("onTraceRestored not set, skipping event")
+			em.callOnError(e)
+			return
+		}
+		payload, ok := e.payload.(storedTrace)
+		if !ok {
+			// the payload had an unexpected type!
+			em.callOnError(e)
+			return
+		}
+
+		em.handleEventWithObservability("onTraceRestored", func() error {
+			return em.onTraceRestored(payload, w)
+		})
 	default:
 		em.logger.Info("unknown event type", zap.Any("event", e.typ))
 		em.callOnError(e)
@@ -222,18 +242,29 @@ func (em *eventMachine) consume(td ptrace.Traces) error {
 		return fmt.Errorf("eventmachine consume failed: %w", err)
 	}
 
+	em.workerForTraceID(traceID).fire(event{
+		typ:     traceReceived,
+		payload: tracesWithID{id: traceID, td: td},
+	})
+	return nil
+}
+
+// restore routes a trace restored from a persistent storage to the worker owning its trace ID.
+func (em *eventMachine) restore(trace storedTrace) {
+	em.workerForTraceID(trace.id).fire(event{
+		typ:     traceRestored,
+		payload: trace,
+	})
+}
+
+func (em *eventMachine) workerForTraceID(traceID pcommon.TraceID) *eventMachineWorker {
 	var bucket uint64
 	if len(em.workers) != 1 {
 		bucket = workerIndexForTraceID(traceID, len(em.workers))
 	}
 
 	em.logger.Debug("scheduled trace to worker", zap.Uint64("id", bucket))
-
-	em.workers[bucket].fire(event{
-		typ:     traceReceived,
-		payload: tracesWithID{id: traceID, td: td},
-	})
-	return nil
+	return em.workers[bucket]
 }
 
 func workerIndexForTraceID(traceID pcommon.TraceID, numWorkers int) uint64 {
@@ -306,8 +337,8 @@ func (em *eventMachine) handleEventWithObservability(event string, do func() err
 type eventMachineWorker struct {
 	machine *eventMachine
 
-	// the ring buffer holds the IDs for all the in-flight traces
-	buffer *ringBuffer
+	// the buffer holds the IDs for all the in-flight traces
+	buffer traceBuffer
 
 	events chan event
 }
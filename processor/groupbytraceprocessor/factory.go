@@ -5,6 +5,7 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,7 +25,7 @@ const (
 )
 
 var (
-	errDiskStorageNotSupported    = fmt.Errorf("option 'disk storage' not supported in this release")
+	errStorageExtensionRequired   = errors.New("option 'store_on_disk' requires a 'storage' extension")
 	errDiscardOrphansNotSupported = fmt.Errorf("option 'discard orphans' not supported in this release")
 )
 
@@ -42,10 +43,10 @@ func createDefaultConfig() component.Config {
 		NumTraces:    defaultNumTraces,
 		NumWorkers:   defaultNumWorkers,
 		WaitDuration: defaultWaitDuration,
+		StoreOnDisk:  defaultStoreOnDisk,
 
 		// not supported for now
 		DiscardOrphans: defaultDiscardOrphans,
-		StoreOnDisk:    defaultStoreOnDisk,
 	}
 }
 
@@ -58,17 +59,17 @@ func createTracesProcessor(
 ) (processor.Traces, error) {
 	oCfg := cfg.(*Config)
 
-	var st storage
-	if oCfg.StoreOnDisk {
-		return nil, errDiskStorageNotSupported
+	if oCfg.StoreOnDisk && oCfg.Storage == nil {
+		return nil, errStorageExtensionRequired
 	}
 	if oCfg.DiscardOrphans {
 		return nil, errDiscardOrphansNotSupported
 	}
 
 	processor := newGroupByTraceProcessor(params, nextConsumer, *oCfg)
-	// the only supported storage for now
-	st = newMemoryStorage(processor.telemetryBuilder)
-	processor.st = st
+	if !oCfg.StoreOnDisk {
+		// the persistent storage is created on start, once the storage extension is available
+		processor.st = newMemoryStorage(processor.telemetryBuilder)
+	}
 	return processor, nil
 }
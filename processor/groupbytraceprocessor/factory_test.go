@@ -56,7 +56,7 @@ func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 			&Config{
 				StoreOnDisk: true,
 			},
-			errDiskStorageNotSupported,
+			errStorageExtensionRequired,
 		},
 	} {
 		p, err := f.CreateTraces(context.Background(), processortest.NewNopSettings(metadata.Type), tt.config, consumertest.NewNop())
@@ -33,6 +33,7 @@ import (
 // Each worker in the eventMachine also uses a ring buffer to hold the in-flight trace IDs, so that we don't hold more than the given maximum number
 // of traces in memory/storage. Items that are evicted from the buffer are discarded without warning.
 type groupByTraceProcessor struct {
+	id               component.ID
 	nextConsumer     consumer.Traces
 	config           Config
 	logger           *zap.Logger
@@ -42,6 +43,9 @@ type groupByTraceProcessor struct {
 
 	// the trace storage
 	st storage
+
+	// the sizer used to account the traces against the configured max bytes
+	sizer ptrace.ProtoMarshaler
 }
 
 var _ processor.Traces = (*groupByTraceProcessor)(nil)
@@ -57,8 +61,14 @@ func newGroupByTraceProcessor(set processor.Settings, nextConsumer consumer.Trac
 
 	// the event machine will buffer up to N concurrent events before blocking
 	eventMachine := newEventMachine(set.Logger, 10000, config.NumWorkers, config.NumTraces, telemetryBuilder)
+	if config.MaxBytes > 0 {
+		for _, worker := range eventMachine.workers {
+			worker.buffer = newSizeBuffer(config.MaxBytes / int64(config.NumWorkers))
+		}
+	}
 
 	sp := &groupByTraceProcessor{
+		id:               set.ID,
 		logger:           set.Logger,
 		nextConsumer:     nextConsumer,
 		config:           config,
@@ -71,6 +81,7 @@ func newGroupByTraceProcessor(set processor.Settings, nextConsumer consumer.Trac
 	eventMachine.onTraceExpired = sp.onTraceExpired
 	eventMachine.onTraceReleased = sp.onTraceReleased
 	eventMachine.onTraceRemoved = sp.onTraceRemoved
+	eventMachine.onTraceRestored = sp.onTraceRestored
 
 	return sp
 }
@@ -88,26 +99,71 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
+	if sp.config.StoreOnDisk {
+		client, err := getStorageClient(ctx, host, sp.config.Storage, sp.id)
+		if err != nil {
+			return err
+		}
+		sp.st = newPersistentStorage(client, sp.logger, sp.telemetryBuilder)
+	}
+
 	// start these metrics, as it might take a while for them to receive their first event
 	sp.telemetryBuilder.ProcessorGroupbytraceTracesEvicted.Add(context.Background(), 0)
 	sp.telemetryBuilder.ProcessorGroupbytraceIncompleteReleases.Add(context.Background(), 0)
 	sp.telemetryBuilder.ProcessorGroupbytraceConfNumTraces.Record(context.Background(), (int64(sp.config.NumTraces)))
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	if err := sp.st.start(); err != nil {
+		return err
+	}
+	return sp.recover()
+}
+
+// recover schedules the release of the traces held by a persistent storage when the
+// previous instance of the processor stopped
+func (sp *groupByTraceProcessor) recover() error {
+	rs, ok := sp.st.(recoverableStorage)
+	if !ok {
+		return nil
+	}
+
+	traces, err := rs.recover()
+	if err != nil {
+		return fmt.Errorf("couldn't recover traces from the storage: %w", err)
+	}
+	if len(traces) > 0 {
+		sp.logger.Info("recovered traces from the storage", zap.Int("traces", len(traces)))
+	}
+	for _, trace := range traces {
+		sp.eventMachine.restore(trace)
+	}
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
 func (sp *groupByTraceProcessor) Shutdown(_ context.Context) error {
 	sp.eventMachine.shutdown()
+	// the persistent storage is only set once the processor started successfully
+	if sp.st == nil {
+		return nil
+	}
 	return sp.st.shutdown()
 }
 
 func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eventMachineWorker) error {
 	traceID := trace.id
+
+	var size int
+	if sp.config.MaxBytes > 0 {
+		size = sp.sizer.TracesSize(trace.td)
+	}
+
 	if worker.buffer.contains(traceID) {
 		sp.logger.Debug("trace is already in memory storage")
 
+		// account for the new spans, which might cause other traces to be evicted
+		sp.evict(worker.buffer.add(traceID, size), worker)
+
 		// it exists in memory already, just append the spans to the trace in the storage
 		if err := sp.addSpans(traceID, trace.td); err != nil {
 			return fmt.Errorf("couldn't add spans to existing trace: %w", err)
@@ -120,35 +176,57 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 	// at this point, we determined that we haven't seen the trace yet, so, record the
 	// traceID in the map and the spans to the storage
 
-	// place the trace ID in the buffer, and check if an item had to be evicted
-	evicted := worker.buffer.put(traceID)
-	if !evicted.IsEmpty() {
+	// place the trace ID in the buffer, and check if items had to be evicted
+	sp.evict(worker.buffer.add(traceID, size), worker)
+
+	// we have the traceID in the memory, place the spans in the storage too
+	if err := sp.addSpans(traceID, trace.td); err != nil {
+		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
+	}
+
+	sp.scheduleExpiration(traceID, sp.config.WaitDuration, worker)
+	return nil
+}
+
+func (sp *groupByTraceProcessor) onTraceRestored(trace storedTrace, worker *eventMachineWorker) error {
+	if worker.buffer.contains(trace.id) {
+		// new spans for this trace have been received since the processor started,
+		// and the trace has been scheduled for release already
+		return nil
+	}
+
+	sp.evict(worker.buffer.add(trace.id, trace.size), worker)
+
+	// the trace keeps waiting only for the remainder of the wait duration
+	sp.scheduleExpiration(trace.id, max(0, time.Until(trace.receivedAt.Add(sp.config.WaitDuration))), worker)
+	return nil
+}
+
+// evict removes the given traces from the storage
+func (sp *groupByTraceProcessor) evict(evicted []pcommon.TraceID, worker *eventMachineWorker) {
+	for _, traceID := range evicted {
 		// delete from the storage
 		worker.fire(event{
 			typ:     traceRemoved,
-			payload: evicted,
+			payload: traceID,
 		})
 		sp.telemetryBuilder.ProcessorGroupbytraceTracesEvicted.Add(context.Background(), 1)
 
 		sp.logger.Info("trace evicted: in order to avoid this in the future, adjust the wait duration and/or number of traces to keep in memory",
-			zap.Stringer("traceID", evicted))
-	}
-
-	// we have the traceID in the memory, place the spans in the storage too
-	if err := sp.addSpans(traceID, trace.td); err != nil {
-		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
+			zap.Stringer("traceID", traceID))
 	}
+}
 
-	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", sp.config.WaitDuration))
+func (sp *groupByTraceProcessor) scheduleExpiration(traceID pcommon.TraceID, duration time.Duration, worker *eventMachineWorker) {
+	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", duration))
 
-	time.AfterFunc(sp.config.WaitDuration, func() {
+	time.AfterFunc(duration, func() {
 		// if the event machine has stopped, it will just discard the event
 		worker.fire(event{
 			typ:     traceExpired,
 			payload: traceID,
 		})
 	})
-	return nil
 }
 
 func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker *eventMachineWorker) error {
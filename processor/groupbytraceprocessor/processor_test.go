@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
 )
@@ -137,6 +138,145 @@ func TestInternalCacheLimit(t *testing.T) {
 	assert.NotContains(t, receivedTraceIDs, traceIDs[0])
 }
 
+func TestInternalCacheSizeLimit(t *testing.T) {
+	// prepare
+	wg := &sync.WaitGroup{} // we wait for the next (mock) processor to receive the trace
+
+	traces := make([]ptrace.Traces, 3)
+	for i := range traces {
+		traces[i] = simpleTracesWithID(pcommon.TraceID([16]byte{byte(i + 1)}))
+	}
+	traceSize := (&ptrace.ProtoMarshaler{}).TracesSize(traces[0])
+
+	config := Config{
+		// should be long enough for the test to run without traces being finished, but short enough to not
+		// badly influence the testing experience
+		WaitDuration: 50 * time.Millisecond,
+
+		// only two traces fit in the storage, the first one should be evicted
+		MaxBytes:   int64(2 * traceSize),
+		NumTraces:  10,
+		NumWorkers: 1,
+	}
+
+	wg.Add(2) // 2 traces are expected to be received
+
+	var receivedTraceIDs []pcommon.TraceID
+	mockProcessor := &mockProcessor{}
+	mockProcessor.onTraces = func(_ context.Context, received ptrace.Traces) error {
+		traceID := received.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
+		receivedTraceIDs = append(receivedTraceIDs, traceID)
+		wg.Done()
+		return nil
+	}
+
+	p := newGroupByTraceProcessor(processortest.NewNopSettings(metadata.Type), mockProcessor, config)
+	p.st = newMemoryStorage(p.telemetryBuilder)
+	ctx := context.Background()
+	assert.NoError(t, p.Start(ctx, nil))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// test
+	for _, td := range traces {
+		assert.NoError(t, p.ConsumeTraces(ctx, td))
+	}
+
+	// verify
+	wg.Wait()
+	assert.ElementsMatch(t, []pcommon.TraceID{
+		pcommon.TraceID([16]byte{2}),
+		pcommon.TraceID([16]byte{3}),
+	}, receivedTraceIDs)
+}
+
+func TestTracesAreRecoveredFromPersistentStorage(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("groupbytrace")
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	traces := simpleTracesWithID(traceID)
+
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+		StoreOnDisk:  true,
+		Storage:      &storageID,
+	}
+	ctx := context.Background()
+
+	// the first instance receives the trace but it's shut down before the trace is released
+	first := newGroupByTraceProcessor(processortest.NewNopSettings(metadata.Type), &mockProcessor{}, config)
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("groupbytrace", storageDir)
+	require.NoError(t, first.Start(ctx, host))
+	require.NoError(t, first.ConsumeTraces(ctx, traces))
+	require.Eventually(t, func() bool {
+		return first.st.(*persistentStorage).count() == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, first.Shutdown(ctx))
+
+	// test
+	received := make(chan ptrace.Traces, 1)
+	config.WaitDuration = time.Nanosecond
+	second := newGroupByTraceProcessor(processortest.NewNopSettings(metadata.Type), &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			received <- td
+			return nil
+		},
+	}, config)
+	host = storagetest.NewStorageHost().WithFileBackedStorageExtension("groupbytrace", storageDir)
+	require.NoError(t, second.Start(ctx, host))
+	defer func() {
+		assert.NoError(t, second.Shutdown(ctx))
+	}()
+
+	// verify
+	select {
+	case td := <-received:
+		assert.Equal(t, traces, td)
+	case <-time.After(time.Second):
+		assert.Fail(t, "recovered trace was not released")
+	}
+}
+
+func TestStartFailsWithoutStorageExtension(t *testing.T) {
+	// prepare
+	storageID := storagetest.NewStorageID("missing")
+	config := Config{
+		WaitDuration: time.Second,
+		NumTraces:    10,
+		NumWorkers:   1,
+		StoreOnDisk:  true,
+		Storage:      &storageID,
+	}
+	p := newGroupByTraceProcessor(processortest.NewNopSettings(metadata.Type), &mockProcessor{}, config)
+
+	// test
+	err := p.Start(context.Background(), storagetest.NewStorageHost())
+
+	// verify
+	assert.ErrorContains(t, err, "storage extension 'test_storage/missing' not found")
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestShutdownWithoutStart(t *testing.T) {
+	// prepare
+	storageID := storagetest.NewStorageID("groupbytrace")
+	config := Config{
+		WaitDuration: time.Second,
+		NumTraces:    10,
+		NumWorkers:   1,
+		StoreOnDisk:  true,
+		Storage:      &storageID,
+	}
+	p := newGroupByTraceProcessor(processortest.NewNopSettings(metadata.Type), &mockProcessor{}, config)
+
+	// test and verify
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
 func TestProcessorCapabilities(t *testing.T) {
 	// prepare
 	config := Config{
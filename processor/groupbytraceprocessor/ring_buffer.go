@@ -5,6 +5,18 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import "go.opentelemetry.io/collector/pdata/pcommon"
 
+// traceBuffer keeps track of the in-flight trace IDs of a worker, deciding which
+// traces have to be evicted to keep the amount of data held within its limits.
+type traceBuffer interface {
+	// add records that a batch of the given size was added to the trace, returning the
+	// IDs of the traces that have been evicted to make room for it
+	add(traceID pcommon.TraceID, size int) []pcommon.TraceID
+	contains(traceID pcommon.TraceID) bool
+	delete(traceID pcommon.TraceID) bool
+}
+
+var _ traceBuffer = (*ringBuffer)(nil)
+
 // ringBuffer keeps an in-memory bounded buffer with the in-flight trace IDs
 type ringBuffer struct {
 	index     int
@@ -41,6 +53,18 @@ func (r *ringBuffer) put(traceID pcommon.TraceID) pcommon.TraceID {
 	return evicted
 }
 
+// add places the trace ID in the buffer if it's not there yet, evicting the
+// oldest trace ID when the buffer is full. The size is ignored.
+func (r *ringBuffer) add(traceID pcommon.TraceID, _ int) []pcommon.TraceID {
+	if r.contains(traceID) {
+		return nil
+	}
+	if evicted := r.put(traceID); !evicted.IsEmpty() {
+		return []pcommon.TraceID{evicted}
+	}
+	return nil
+}
+
 func (r *ringBuffer) contains(traceID pcommon.TraceID) bool {
 	_, found := r.idToIndex[traceID]
 	return found
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"container/list"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// sizeBuffer keeps the in-flight trace IDs in arrival order, evicting the oldest
+// traces once the total size of the traces exceeds the maximum size
+type sizeBuffer struct {
+	maxSize   int64
+	size      int64
+	order     *list.List // of *sizeBufferEntry, oldest first
+	idToEntry map[pcommon.TraceID]*list.Element
+}
+
+type sizeBufferEntry struct {
+	traceID pcommon.TraceID
+	size    int64
+}
+
+var _ traceBuffer = (*sizeBuffer)(nil)
+
+func newSizeBuffer(maxSize int64) *sizeBuffer {
+	return &sizeBuffer{
+		maxSize:   maxSize,
+		order:     list.New(),
+		idToEntry: make(map[pcommon.TraceID]*list.Element),
+	}
+}
+
+// add accounts the given size to the trace, evicting the oldest traces other than
+// the given one until the total size fits within the maximum size. A trace bigger
+// than the maximum size on its own is kept, as evicting it wouldn't free any space
+// for other traces.
+func (b *sizeBuffer) add(traceID pcommon.TraceID, size int) []pcommon.TraceID {
+	elem, found := b.idToEntry[traceID]
+	if !found {
+		elem = b.order.PushBack(&sizeBufferEntry{traceID: traceID})
+		b.idToEntry[traceID] = elem
+	}
+	elem.Value.(*sizeBufferEntry).size += int64(size)
+	b.size += int64(size)
+
+	var evicted []pcommon.TraceID
+	for b.size > b.maxSize {
+		oldest := b.order.Front()
+		if oldest == elem {
+			oldest = oldest.Next()
+		}
+		if oldest == nil {
+			break
+		}
+		id := oldest.Value.(*sizeBufferEntry).traceID
+		b.delete(id)
+		evicted = append(evicted, id)
+	}
+	return evicted
+}
+
+func (b *sizeBuffer) contains(traceID pcommon.TraceID) bool {
+	_, found := b.idToEntry[traceID]
+	return found
+}
+
+func (b *sizeBuffer) delete(traceID pcommon.TraceID) bool {
+	elem, found := b.idToEntry[traceID]
+	if !found {
+		return false
+	}
+
+	delete(b.idToEntry, traceID)
+	b.order.Remove(elem)
+	b.size -= elem.Value.(*sizeBufferEntry).size
+	return true
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestSizeBufferEvictsOldestTraces(t *testing.T) {
+	// prepare
+	buffer := newSizeBuffer(100)
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1}),
+		pcommon.TraceID([16]byte{2}),
+		pcommon.TraceID([16]byte{3}),
+	}
+
+	// test
+	assert.Empty(t, buffer.add(traceIDs[0], 40))
+	assert.Empty(t, buffer.add(traceIDs[1], 40))
+	evicted := buffer.add(traceIDs[2], 40)
+
+	// verify
+	assert.Equal(t, []pcommon.TraceID{traceIDs[0]}, evicted)
+	assert.False(t, buffer.contains(traceIDs[0]))
+	assert.True(t, buffer.contains(traceIDs[1]))
+	assert.True(t, buffer.contains(traceIDs[2]))
+	assert.EqualValues(t, 80, buffer.size)
+}
+
+func TestSizeBufferGrowingTraceEvictsOthers(t *testing.T) {
+	// prepare
+	buffer := newSizeBuffer(100)
+	first := pcommon.TraceID([16]byte{1})
+	second := pcommon.TraceID([16]byte{2})
+	assert.Empty(t, buffer.add(first, 30))
+	assert.Empty(t, buffer.add(second, 30))
+
+	// test
+	evicted := buffer.add(first, 50)
+
+	// verify
+	assert.Equal(t, []pcommon.TraceID{second}, evicted)
+	assert.True(t, buffer.contains(first))
+	assert.EqualValues(t, 80, buffer.size)
+}
+
+func TestSizeBufferKeepsTraceBiggerThanMaxSize(t *testing.T) {
+	// prepare
+	buffer := newSizeBuffer(10)
+	traceID := pcommon.TraceID([16]byte{1})
+
+	// test
+	evicted := buffer.add(traceID, 50)
+
+	// verify
+	assert.Empty(t, evicted)
+	assert.True(t, buffer.contains(traceID))
+}
+
+func TestSizeBufferDelete(t *testing.T) {
+	// prepare
+	buffer := newSizeBuffer(100)
+	traceID := pcommon.TraceID([16]byte{1})
+	buffer.add(traceID, 50)
+
+	// test
+	deleted := buffer.delete(traceID)
+
+	// verify
+	assert.True(t, deleted)
+	assert.False(t, buffer.contains(traceID))
+	assert.EqualValues(t, 0, buffer.size)
+	assert.False(t, buffer.delete(traceID))
+}
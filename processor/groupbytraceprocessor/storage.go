@@ -4,6 +4,8 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -30,3 +32,21 @@ type storage interface {
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
 }
+
+// recoverableStorage is implemented by storages able to keep traces across restarts of the processor.
+type recoverableStorage interface {
+	storage
+
+	// recover returns the traces that were in the storage when the previous instance of the
+	// processor stopped. It's called once, after start.
+	recover() ([]storedTrace, error)
+}
+
+// storedTrace describes a trace held by a recoverable storage.
+type storedTrace struct {
+	id pcommon.TraceID
+	// receivedAt is the time when the first spans for the trace were received
+	receivedAt time.Time
+	// size is the size of the serialized spans for the trace
+	size int
+}
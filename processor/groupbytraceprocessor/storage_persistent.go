@@ -0,0 +1,373 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	extensionstorage "go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+const (
+	indexKey         = "index"
+	journalKeyPrefix = "journal_"
+	traceKeyPrefix   = "trace_"
+
+	// the index starts with the sequence number of the first journal entry not included in it
+	indexHeaderLen = 8
+	// each index entry holds the trace ID and the time it was received
+	indexEntryLen = 16 + 8
+	// each journal entry holds the operation, the trace ID and the time it was received
+	journalEntryLen = 1 + indexEntryLen
+)
+
+const (
+	journalOpAdd byte = iota + 1
+	journalOpDelete
+)
+
+var (
+	errInvalidIndex        = errors.New("invalid index")
+	errInvalidJournalEntry = errors.New("invalid journal entry")
+)
+
+// persistentStorage keeps the traces in a storage extension, holding only the trace IDs in memory.
+// Each batch of spans is written to the storage under its own key as soon as it's received. The
+// traces added to and removed from the storage are recorded in a journal, written atomically with
+// the spans, and the journal is periodically compacted into an index, allowing the traces to be
+// recovered after a restart.
+type persistentStorage struct {
+	client    extensionstorage.Client
+	logger    *zap.Logger
+	telemetry *metadata.TelemetryBuilder
+
+	// indexLock guards the index and serializes the writes to the journal, so that its entries have
+	// consecutive sequence numbers
+	indexLock sync.Mutex
+	index     map[pcommon.TraceID]*persistedTrace
+	recovered []storedTrace
+	// indexSeq is the sequence number of the first journal entry not included in the index
+	indexSeq uint64
+	// journalSeq is the sequence number of the next journal entry
+	journalSeq uint64
+
+	stopped            bool
+	stoppedLock        sync.RWMutex
+	checkpointInterval time.Duration
+}
+
+// persistedTrace describes a trace held by the persistent storage.
+type persistedTrace struct {
+	receivedAt time.Time
+	// batches is the number of batches of spans written for the trace
+	batches int
+	// size is the size of the serialized spans for the trace
+	size int
+}
+
+var (
+	_ storage            = (*persistentStorage)(nil)
+	_ recoverableStorage = (*persistentStorage)(nil)
+)
+
+func newPersistentStorage(client extensionstorage.Client, logger *zap.Logger, telemetry *metadata.TelemetryBuilder) *persistentStorage {
+	return &persistentStorage{
+		client:             client,
+		logger:             logger,
+		telemetry:          telemetry,
+		index:              make(map[pcommon.TraceID]*persistedTrace),
+		checkpointInterval: time.Second,
+	}
+}
+
+func (st *persistentStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	marshaler := &ptrace.ProtoMarshaler{}
+	record, err := marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+
+	// the spans for a given trace are always handled by the same worker,
+	// so the trace can't be changed concurrently while it's being written
+	st.indexLock.Lock()
+	trace, ok := st.index[traceID]
+	if !ok {
+		defer st.indexLock.Unlock()
+		trace = &persistedTrace{receivedAt: time.Now()}
+		err = st.writeJournal(journalOpAdd, traceID, trace.receivedAt, extensionstorage.SetOperation(traceKey(traceID, 0), record))
+		if err != nil {
+			return err
+		}
+		trace.batches, trace.size = 1, len(record)
+		st.index[traceID] = trace
+		return nil
+	}
+	batch := trace.batches
+	st.indexLock.Unlock()
+
+	if err = st.client.Set(context.Background(), traceKey(traceID, batch), record); err != nil {
+		return err
+	}
+
+	st.indexLock.Lock()
+	trace.batches++
+	trace.size += len(record)
+	st.indexLock.Unlock()
+	return nil
+}
+
+func (st *persistentStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.indexLock.Lock()
+	trace, ok := st.index[traceID]
+	var batches int
+	if ok {
+		batches = trace.batches
+	}
+	st.indexLock.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	ops := make([]*extensionstorage.Operation, batches)
+	for i := range ops {
+		ops[i] = extensionstorage.GetOperation(traceKey(traceID, i))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+
+	var result []ptrace.ResourceSpans
+	unmarshaler := &ptrace.ProtoUnmarshaler{}
+	for _, op := range ops {
+		if op.Value == nil {
+			return nil, fmt.Errorf("missing spans for trace %s in the persistent storage", traceID)
+		}
+		spans, err := unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < spans.ResourceSpans().Len(); i++ {
+			result = append(result, spans.ResourceSpans().At(i))
+		}
+	}
+	return result, nil
+}
+
+func (st *persistentStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	result, err := st.get(traceID)
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	st.indexLock.Lock()
+	defer st.indexLock.Unlock()
+	trace, ok := st.index[traceID]
+	if !ok {
+		return nil, nil
+	}
+
+	ops := make([]*extensionstorage.Operation, trace.batches)
+	for i := range ops {
+		ops[i] = extensionstorage.DeleteOperation(traceKey(traceID, i))
+	}
+	if err := st.writeJournal(journalOpDelete, traceID, trace.receivedAt, ops...); err != nil {
+		return nil, err
+	}
+	delete(st.index, traceID)
+	return result, nil
+}
+
+func (st *persistentStorage) start() error {
+	if err := st.load(context.Background()); err != nil {
+		return fmt.Errorf("couldn't read the index of the persistent storage: %w", err)
+	}
+
+	go st.periodicCheckpoint()
+	return nil
+}
+
+// load reads the index and the journal entries written after it, and finds the batches of spans written for each trace.
+func (st *persistentStorage) load(ctx context.Context) error {
+	buf, err := st.client.Get(ctx, indexKey)
+	if err != nil {
+		return err
+	}
+
+	st.indexLock.Lock()
+	defer st.indexLock.Unlock()
+
+	receivedAt := make(map[pcommon.TraceID]time.Time)
+	if buf != nil {
+		if len(buf) < indexHeaderLen || (len(buf)-indexHeaderLen)%indexEntryLen != 0 {
+			return fmt.Errorf("%w: length %d", errInvalidIndex, len(buf))
+		}
+		st.indexSeq = binary.BigEndian.Uint64(buf)
+		for i := indexHeaderLen; i < len(buf); i += indexEntryLen {
+			id, at := decodeIndexEntry(buf[i : i+indexEntryLen])
+			receivedAt[id] = at
+		}
+	}
+
+	for st.journalSeq = st.indexSeq; ; st.journalSeq++ {
+		entry, err := st.client.Get(ctx, journalKey(st.journalSeq))
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			break
+		}
+		if len(entry) != journalEntryLen {
+			return fmt.Errorf("%w: length %d", errInvalidJournalEntry, len(entry))
+		}
+		id, at := decodeIndexEntry(entry[1:])
+		switch entry[0] {
+		case journalOpAdd:
+			receivedAt[id] = at
+		case journalOpDelete:
+			delete(receivedAt, id)
+		default:
+			return fmt.Errorf("%w: unknown operation %d", errInvalidJournalEntry, entry[0])
+		}
+	}
+
+	for id, at := range receivedAt {
+		trace := &persistedTrace{receivedAt: at}
+		for ; ; trace.batches++ {
+			record, err := st.client.Get(ctx, traceKey(id, trace.batches))
+			if err != nil {
+				return err
+			}
+			if record == nil {
+				break
+			}
+			trace.size += len(record)
+		}
+		st.index[id] = trace
+		st.recovered = append(st.recovered, storedTrace{id: id, receivedAt: at, size: trace.size})
+	}
+	return nil
+}
+
+func (st *persistentStorage) recover() ([]storedTrace, error) {
+	st.indexLock.Lock()
+	defer st.indexLock.Unlock()
+
+	recovered := st.recovered
+	st.recovered = nil
+	return recovered, nil
+}
+
+func (st *persistentStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	ctx := context.Background()
+	return errors.Join(st.checkpoint(ctx), st.client.Close(ctx))
+}
+
+// writeJournal writes a journal entry for the given trace, along with the given operations. It must be called with indexLock held.
+func (st *persistentStorage) writeJournal(op byte, traceID pcommon.TraceID, receivedAt time.Time, ops ...*extensionstorage.Operation) error {
+	entry := make([]byte, 1, journalEntryLen)
+	entry[0] = op
+	entry = appendIndexEntry(entry, traceID, receivedAt)
+	ops = append(ops, extensionstorage.SetOperation(journalKey(st.journalSeq), entry))
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return err
+	}
+	st.journalSeq++
+	return nil
+}
+
+// checkpoint writes the index of the traces held by the storage and removes the journal entries included in it,
+// if any journal entry was written since the last checkpoint
+func (st *persistentStorage) checkpoint(ctx context.Context) error {
+	st.indexLock.Lock()
+	defer st.indexLock.Unlock()
+	if st.indexSeq == st.journalSeq {
+		return nil
+	}
+
+	buf := make([]byte, indexHeaderLen, indexHeaderLen+len(st.index)*indexEntryLen)
+	binary.BigEndian.PutUint64(buf, st.journalSeq)
+	for id, trace := range st.index {
+		buf = appendIndexEntry(buf, id, trace.receivedAt)
+	}
+	ops := make([]*extensionstorage.Operation, 0, 1+st.journalSeq-st.indexSeq)
+	ops = append(ops, extensionstorage.SetOperation(indexKey, buf))
+	for seq := st.indexSeq; seq < st.journalSeq; seq++ {
+		ops = append(ops, extensionstorage.DeleteOperation(journalKey(seq)))
+	}
+	if err := st.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	st.indexSeq = st.journalSeq
+	return nil
+}
+
+func (st *persistentStorage) periodicCheckpoint() {
+	st.stoppedLock.RLock()
+	defer st.stoppedLock.RUnlock()
+	if st.stopped {
+		return
+	}
+
+	if err := st.checkpoint(context.Background()); err != nil {
+		st.logger.Warn("couldn't write the index of the persistent storage", zap.Error(err))
+	}
+	st.telemetry.ProcessorGroupbytraceNumTracesInMemory.Record(context.Background(), int64(st.count()))
+
+	time.AfterFunc(st.checkpointInterval, func() {
+		st.periodicCheckpoint()
+	})
+}
+
+func (st *persistentStorage) count() int {
+	st.indexLock.Lock()
+	defer st.indexLock.Unlock()
+	return len(st.index)
+}
+
+func traceKey(traceID pcommon.TraceID, batch int) string {
+	return traceKeyPrefix + traceID.String() + "_" + strconv.Itoa(batch)
+}
+
+func journalKey(seq uint64) string {
+	return journalKeyPrefix + strconv.FormatUint(seq, 10)
+}
+
+func appendIndexEntry(buf []byte, traceID pcommon.TraceID, receivedAt time.Time) []byte {
+	buf = append(buf, traceID[:]...)
+	return binary.BigEndian.AppendUint64(buf, uint64(receivedAt.UnixNano()))
+}
+
+func decodeIndexEntry(buf []byte) (pcommon.TraceID, time.Time) {
+	return pcommon.TraceID(buf[:16]), time.Unix(0, int64(binary.BigEndian.Uint64(buf[16:24])))
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (extensionstorage.Client, error) {
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(extensionstorage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+func newTestPersistentStorage(t *testing.T, storageDir string) *persistentStorage {
+	set := processortest.NewNopSettings(metadata.Type)
+	tel, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	require.NoError(t, err)
+	client := storagetest.NewFileBackedClient(component.KindProcessor, set.ID, "", storageDir)
+	return newPersistentStorage(client, zap.NewNop(), tel)
+}
+
+func TestPersistentCreateAppendAndDeleteTrace(t *testing.T) {
+	// prepare
+	st := newTestPersistentStorage(t, t.TempDir())
+	require.NoError(t, st.start())
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+	second := simpleTracesWithID(traceID)
+	second.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("second")
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, first))
+	require.NoError(t, st.createOrAppend(traceID, second))
+
+	// verify
+	expected := []ptrace.ResourceSpans{first.ResourceSpans().At(0), second.ResourceSpans().At(0)}
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, retrieved)
+	assert.Equal(t, 1, st.count())
+
+	deleted, err := st.delete(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, deleted)
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err = st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+
+	deleted, err = st.delete(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+}
+
+func TestPersistentStorageRecoversTraces(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	kept := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	removed := pcommon.TraceID([16]byte{5, 6, 7, 8})
+
+	st := newTestPersistentStorage(t, storageDir)
+	require.NoError(t, st.start())
+	before := time.Now()
+	require.NoError(t, st.createOrAppend(kept, simpleTracesWithID(kept)))
+	require.NoError(t, st.createOrAppend(removed, simpleTracesWithID(removed)))
+	_, err := st.delete(removed)
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newTestPersistentStorage(t, storageDir)
+	require.NoError(t, st.start())
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+	recovered, err := st.recover()
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, recovered, 1)
+	assert.Equal(t, kept, recovered[0].id)
+	assert.False(t, recovered[0].receivedAt.Before(before.Truncate(time.Nanosecond)))
+	assert.Positive(t, recovered[0].size)
+
+	retrieved, err := st.get(kept)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 1)
+
+	// traces are recovered only once
+	recovered, err = st.recover()
+	require.NoError(t, err)
+	assert.Empty(t, recovered)
+}
+
+func TestPersistentStorageRecoversTracesAfterCrash(t *testing.T) {
+	// prepare
+	set := processortest.NewNopSettings(metadata.Type)
+	tel, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	require.NoError(t, err)
+	client := storagetest.NewInMemoryClient(component.KindProcessor, set.ID, "")
+
+	kept := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	removed := pcommon.TraceID([16]byte{5, 6, 7, 8})
+	checkpointed := pcommon.TraceID([16]byte{9, 10, 11, 12})
+
+	st := newPersistentStorage(client, zap.NewNop(), tel)
+	st.checkpointInterval = time.Hour
+	require.NoError(t, st.start())
+	require.NoError(t, st.createOrAppend(checkpointed, simpleTracesWithID(checkpointed)))
+	require.NoError(t, st.checkpoint(context.Background()))
+	require.NoError(t, st.createOrAppend(kept, simpleTracesWithID(kept)))
+	require.NoError(t, st.createOrAppend(kept, simpleTracesWithID(kept)))
+	require.NoError(t, st.createOrAppend(removed, simpleTracesWithID(removed)))
+	_, err = st.delete(removed)
+	require.NoError(t, err)
+
+	// test: the storage isn't shut down, leaving the last changes in the journal only
+	st = newPersistentStorage(client, zap.NewNop(), tel)
+	st.checkpointInterval = time.Hour
+	require.NoError(t, st.start())
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+	recovered, err := st.recover()
+
+	// verify
+	require.NoError(t, err)
+	ids := make([]pcommon.TraceID, 0, len(recovered))
+	for _, trace := range recovered {
+		ids = append(ids, trace.id)
+	}
+	assert.ElementsMatch(t, []pcommon.TraceID{kept, checkpointed}, ids)
+
+	retrieved, err := st.get(kept)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 2)
+
+	// the spans of the removed trace aren't left in the storage
+	record, err := client.Get(context.Background(), traceKey(removed, 0))
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	// the journal entries are removed once they're included in the index
+	require.NoError(t, st.checkpoint(context.Background()))
+	for seq := uint64(0); seq < st.journalSeq; seq++ {
+		entry, err := client.Get(context.Background(), journalKey(seq))
+		require.NoError(t, err)
+		assert.Nil(t, entry)
+	}
+}
+
+func TestPersistentStorageInvalidIndex(t *testing.T) {
+	set := processortest.NewNopSettings(metadata.Type)
+	tel, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	require.NoError(t, err)
+	client := storagetest.NewInMemoryClient(component.KindProcessor, set.ID, "")
+	require.NoError(t, client.Set(context.Background(), indexKey, []byte{1, 2, 3}))
+
+	st := newPersistentStorage(client, zap.NewNop(), tel)
+	assert.ErrorIs(t, st.start(), errInvalidIndex)
+}
@@ -6,6 +6,8 @@ package k8sattributesprocessor // import "github.com/open-telemetry/opentelemetr
 import (
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/featuregate"
@@ -24,6 +26,14 @@ var disallowFieldExtractConfigRegex = featuregate.GlobalRegistry().MustRegister(
 	featuregate.WithRegisterToVersion("v0.122.0"),
 )
 
+// builtinMetadataSources are the kubernetes objects labels and annotations can be extracted from,
+// besides custom resources.
+var builtinMetadataSources = []string{
+	kube.MetadataFromPod, kube.MetadataFromNamespace, kube.MetadataFromNode,
+	kube.MetadataFromReplicaSet, kube.MetadataFromDeployment, kube.MetadataFromStatefulSet,
+	kube.MetadataFromDaemonSet, kube.MetadataFromJob, kube.MetadataFromCronJob,
+}
+
 // Config defines configuration for k8s attributes processor.
 type Config struct {
 	k8sconfig.APIConfig `mapstructure:",squash"`
@@ -68,15 +78,25 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	customResources := map[string]bool{}
+	for _, r := range cfg.Extract.CustomResources {
+		if r.Version == "" || r.Resource == "" || r.Kind == "" {
+			return fmt.Errorf("version, resource and kind are required for custom resources, currently Version:%s, Resource:%s and Kind:%s", r.Version, r.Resource, r.Kind)
+		}
+		name := r.name()
+		if slices.Contains(builtinMetadataSources, name) || customResources[name] {
+			return fmt.Errorf("custom resource kind %s is already defined", r.Kind)
+		}
+		customResources[name] = true
+	}
+
 	for _, f := range append(cfg.Extract.Labels, cfg.Extract.Annotations...) {
 		if f.Key != "" && f.KeyRegex != "" {
 			return fmt.Errorf("Out of Key or KeyRegex only one option is expected to be configured at a time, currently Key:%s and KeyRegex:%s", f.Key, f.KeyRegex)
 		}
 
-		switch f.From {
-		case "", kube.MetadataFromPod, kube.MetadataFromNamespace, kube.MetadataFromNode:
-		default:
-			return fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace, node", f.From)
+		if f.From != "" && !slices.Contains(builtinMetadataSources, f.From) && !customResources[f.From] {
+			return fmt.Errorf("%s is not a valid choice for From. Must be one of: %s or the lowercase kind of a custom resource", f.From, strings.Join(builtinMetadataSources, ", "))
 		}
 
 		if f.KeyRegex != "" {
@@ -166,6 +186,30 @@ type ExtractConfig struct {
 	// It is a list of FieldExtractConfig type. See FieldExtractConfig
 	// documentation for more details.
 	Labels []FieldExtractConfig `mapstructure:"labels"`
+
+	// CustomResources allows extracting labels and annotations from custom resources owning pods,
+	// directly or through other owners. The labels and annotations of a custom resource are extracted
+	// by setting the From field of the extraction rules to the lowercase kind of the custom resource.
+	// It is a list of CustomResourceConfig type. See CustomResourceConfig
+	// documentation for more details.
+	CustomResources []CustomResourceConfig `mapstructure:"custom_resources"`
+}
+
+// CustomResourceConfig identifies a kind of custom resources, which is watched with the dynamic client.
+type CustomResourceConfig struct {
+	// Group is the API group of the custom resource, e.g. argoproj.io.
+	Group string `mapstructure:"group"`
+	// Version is the API version of the custom resource, e.g. v1alpha1.
+	Version string `mapstructure:"version"`
+	// Resource is the plural name of the custom resource, e.g. rollouts.
+	Resource string `mapstructure:"resource"`
+	// Kind is the kind of the custom resource, e.g. Rollout.
+	Kind string `mapstructure:"kind"`
+}
+
+// name returns the name of the custom resource used by the extraction rules.
+func (r CustomResourceConfig) name() string {
+	return strings.ToLower(r.Kind)
 }
 
 // FieldExtractConfig allows specifying an extraction rule to extract a resource attribute from pod (or namespace)
@@ -201,7 +245,9 @@ type FieldExtractConfig struct {
 	KeyRegex string `mapstructure:"key_regex"`
 
 	// From represents the source of the labels/annotations.
-	// Allowed values are "pod", "namespace", and "node". The default is pod.
+	// Allowed values are "pod", "namespace", "node", the kinds of pod owners "replicaset", "deployment",
+	// "statefulset", "daemonset", "job" and "cronjob", and the lowercase kinds of the configured custom
+	// resources. The default is pod.
 	From string `mapstructure:"from"`
 }
 
@@ -132,6 +132,32 @@ func TestLoadConfig(t *testing.T) {
 				WaitForMetadataTimeout: 10 * time.Second,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "owners"),
+			expected: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Extract: ExtractConfig{
+					Metadata: enabledAttributes(),
+					Labels: []FieldExtractConfig{
+						{Key: "app.kubernetes.io/version", From: kube.MetadataFromDeployment},
+						{TagName: "team", Key: "team", From: "rollout"},
+					},
+					Annotations: []FieldExtractConfig{
+						{TagName: "$$1", KeyRegex: "example.com/(.*)", From: kube.MetadataFromCronJob},
+					},
+					CustomResources: []CustomResourceConfig{
+						{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts", Kind: "Rollout"},
+					},
+				},
+				Exclude: ExcludeConfig{
+					Pods: []ExcludePodConfig{
+						{Name: "jaeger-agent"},
+						{Name: "jaeger-collector"},
+					},
+				},
+				WaitForMetadataTimeout: 10 * time.Second,
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "too_many_sources"),
 		},
@@ -147,6 +173,15 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_from_annotations"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_from_custom_resource"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_custom_resource"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "duplicate_custom_resource"),
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_keyregex_labels"),
 		},
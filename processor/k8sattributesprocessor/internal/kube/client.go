@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,13 +17,16 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	apps_v1 "k8s.io/api/apps/v1"
 	api_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
@@ -44,10 +48,15 @@ type WatchClient struct {
 	deleteMut              sync.Mutex
 	logger                 *zap.Logger
 	kc                     kubernetes.Interface
+	dc                     dynamic.Interface
 	informer               cache.SharedInformer
 	namespaceInformer      cache.SharedInformer
 	nodeInformer           cache.SharedInformer
 	replicasetInformer     cache.SharedInformer
+	ownerInformers         map[string]cache.SharedInformer
+	sharedInformers        []*sharedInformer
+	registrations          []informerRegistration
+	watchOwners            bool
 	replicasetRegex        *regexp.Regexp
 	cronJobRegex           *regexp.Regexp
 	deleteQueue            []deleteRequest
@@ -75,9 +84,19 @@ type WatchClient struct {
 	// Key is replicaset uid
 	ReplicaSets map[string]*ReplicaSet
 
+	// A map containing the owners of pods whose labels or annotations are extracted,
+	// along with the owners in between them and the pods. Key is owner uid
+	Owners map[string]*Owner
+
 	telemetryBuilder *metadata.TelemetryBuilder
 }
 
+// informerRegistration is an event handler added to an informer.
+type informerRegistration struct {
+	informer     cache.SharedInformer
+	registration cache.ResourceEventHandlerRegistration
+}
+
 // Extract replicaset name from the pod name. Pod name is created using
 // format: [deployment-name]-[Random-String-For-ReplicaSet]
 var rRegex = regexp.MustCompile(`^(.*)-[0-9a-zA-Z]+$`)
@@ -95,12 +114,13 @@ func New(
 	associations []Association,
 	exclude Excludes,
 	newClientSet APIClientsetProvider,
+	newDynamicClient APIDynamicClientProvider,
 	newInformer InformerProvider,
 	newNamespaceInformer InformerProviderNamespace,
 	newReplicaSetInformer InformerProviderReplicaSet,
 	waitForMetadata bool,
 	waitForMetadataTimeout time.Duration,
-) (Client, error) {
+) (_ Client, err error) {
 	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
 	if err != nil {
 		return nil, err
@@ -119,13 +139,22 @@ func New(
 		waitForMetadataTimeout: waitForMetadataTimeout,
 	}
 	go c.deleteLoop(time.Second*30, defaultPodDeleteGracePeriod)
+	// release the shared informers acquired so far and unregister the telemetry callbacks if the
+	// watch client can't be created
+	defer func() {
+		if err != nil {
+			c.Stop()
+		}
+	}()
 
 	c.Pods = map[PodIdentifier]*Pod{}
 	c.Namespaces = map[string]*Namespace{}
 	c.Nodes = map[string]*Node{}
 	c.ReplicaSets = map[string]*ReplicaSet{}
+	c.Owners = map[string]*Owner{}
 	if newClientSet == nil {
-		newClientSet = k8sconfig.MakeClient
+		// the informers can only be shared by the watch clients using the same kubernetes client
+		newClientSet = informerCache.client
 	}
 
 	kc, err := newClientSet(apiCfg)
@@ -143,10 +172,14 @@ func New(
 		zap.String("labelSelector", labelSelector.String()),
 		zap.String("fieldSelector", fieldSelector.String()),
 	)
+	// the informers created by the default providers are shared with the other watch clients
+	sharePodInformer := newInformer == nil
 	if newInformer == nil {
 		newInformer = newSharedInformer
 	}
 
+	shareNamespaceInformer := newNamespaceInformer == nil
+	namespaceResource := "namespaces"
 	if newNamespaceInformer == nil {
 		switch {
 		case c.extractNamespaceLabelsAnnotations():
@@ -157,41 +190,75 @@ func New(
 			// use kube-system shared informer to only watch kube-system namespace
 			// reducing overhead of watching all the namespaces
 			newNamespaceInformer = newKubeSystemSharedInformer
+			namespaceResource = "namespaces/" + kubeSystemNamespace
 		default:
 			newNamespaceInformer = NewNoOpInformer
+			shareNamespaceInformer = false
 		}
 	}
 
-	c.informer = newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
-	err = c.informer.SetTransform(
+	c.informer, err = c.newInformer(
+		sharePodInformer,
+		informerKey{
+			client:        c.kc,
+			resource:      "pods",
+			namespace:     c.Filters.Namespace,
+			labelSelector: labelSelector.String(),
+			fieldSelector: fieldSelector.String(),
+			transform:     podDataToKeep(rules),
+		},
+		func() cache.SharedInformer {
+			return newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
+		},
 		func(object any) (any, error) {
 			originalPod, success := object.(*api_v1.Pod)
 			if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
 				return object, nil
 			}
 
-			return removeUnnecessaryPodData(originalPod, c.Rules), nil
+			return removeUnnecessaryPodData(originalPod, rules), nil
 		},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.namespaceInformer = newNamespaceInformer(c.kc)
+	c.namespaceInformer, err = c.newInformer(
+		shareNamespaceInformer,
+		informerKey{client: c.kc, resource: namespaceResource},
+		func() cache.SharedInformer {
+			return newNamespaceInformer(c.kc)
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	if rules.DeploymentName || rules.DeploymentUID {
+	ownersToWatch := rules.ownersToWatch()
+	if rules.DeploymentName || rules.DeploymentUID || len(ownersToWatch) > 0 {
+		shareReplicaSetInformer := newReplicaSetInformer == nil
 		if newReplicaSetInformer == nil {
 			newReplicaSetInformer = newReplicaSetSharedInformer
 		}
-		c.replicasetInformer = newReplicaSetInformer(c.kc, c.Filters.Namespace)
-		err = c.replicasetInformer.SetTransform(
+		c.replicasetInformer, err = c.newInformer(
+			shareReplicaSetInformer,
+			informerKey{
+				client:    c.kc,
+				resource:  "replicasets",
+				namespace: c.Filters.Namespace,
+				transform: rules.extractsFrom(MetadataFromReplicaSet),
+			},
+			func() cache.SharedInformer {
+				return newReplicaSetInformer(c.kc, c.Filters.Namespace)
+			},
 			func(object any) (any, error) {
 				originalReplicaset, success := object.(*apps_v1.ReplicaSet)
 				if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
 					return object, nil
 				}
 
-				return removeUnnecessaryReplicaSetData(originalReplicaset), nil
+				return removeUnnecessaryReplicaSetData(originalReplicaset, rules), nil
 			},
 		)
 		if err != nil {
@@ -199,11 +266,91 @@ func New(
 		}
 	}
 
+	if err = c.createOwnerInformers(apiCfg, ownersToWatch, newDynamicClient); err != nil {
+		return nil, err
+	}
+
 	if c.extractNodeLabelsAnnotations() || c.extractNodeUID() {
-		c.nodeInformer = k8sconfig.NewNodeSharedInformer(c.kc, c.Filters.Node, 5*time.Minute)
+		c.nodeInformer, err = c.newInformer(
+			true,
+			informerKey{client: c.kc, resource: "nodes", fieldSelector: c.Filters.Node},
+			func() cache.SharedInformer {
+				return k8sconfig.NewNodeSharedInformer(c.kc, c.Filters.Node, 5*time.Minute)
+			},
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = telemetryBuilder.RegisterOtelsvcK8sInformerCacheSizeCallback(func(_ context.Context, o metric.Int64Observer) error {
+		var size int
+		for _, informer := range c.sharedInformers {
+			size += informer.cacheSize()
+		}
+		o.Observe(int64(size))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = telemetryBuilder.RegisterOtelsvcK8sInformerWatchRestartsCallback(func(_ context.Context, o metric.Int64Observer) error {
+		var restarts int64
+		for _, informer := range c.sharedInformers {
+			restarts += informer.watchRestarts.Load()
+		}
+		o.Observe(restarts)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// newInformer returns the informer shared with the other watch clients under the given key if share is true,
+// otherwise a new informer. The informers are created with the given function, and the transform is set on them
+// when not nil. The shared informers are released when the watch client is stopped.
+func (c *WatchClient) newInformer(
+	share bool,
+	key informerKey,
+	create func() cache.SharedInformer,
+	transform cache.TransformFunc,
+) (cache.SharedInformer, error) {
+	newInformer := func() (cache.SharedInformer, error) {
+		informer := create()
+		if transform != nil {
+			if err := informer.SetTransform(transform); err != nil {
+				return nil, err
+			}
+		}
+		return informer, nil
+	}
+	if !share {
+		return newInformer()
 	}
 
-	return c, err
+	informer, err := informerCache.acquire(key, newInformer)
+	if err != nil {
+		return nil, err
+	}
+	c.sharedInformers = append(c.sharedInformers, informer)
+	return informer, nil
+}
+
+// addEventHandler adds the handler to the informer, and keeps track of the handlers added to shared informers
+// so that they are removed when the watch client is stopped.
+func (c *WatchClient) addEventHandler(informer cache.SharedInformer, handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	reg, err := informer.AddEventHandler(handler)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := informer.(*sharedInformer); ok {
+		c.registrations = append(c.registrations, informerRegistration{informer: informer, registration: reg})
+	}
+	return reg, nil
 }
 
 // Start registers pod event handlers and starts watching the kubernetes cluster for pod changes.
@@ -211,8 +358,8 @@ func (c *WatchClient) Start() error {
 	synced := make([]cache.InformerSynced, 0)
 	// start the replicaSet informer first, as the replica sets need to be
 	// present at the time the pods are handled, to correctly establish the connection between pods and deployments
-	if c.Rules.DeploymentName || c.Rules.DeploymentUID {
-		reg, err := c.replicasetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+	if c.replicasetInformer != nil {
+		reg, err := c.addEventHandler(c.replicasetInformer, cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.handleReplicaSetAdd,
 			UpdateFunc: c.handleReplicaSetUpdate,
 			DeleteFunc: c.handleReplicaSetDelete,
@@ -224,7 +371,17 @@ func (c *WatchClient) Start() error {
 		go c.replicasetInformer.Run(c.stopCh)
 	}
 
-	reg, err := c.namespaceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+	// the owners also need to be present at the time the pods are handled
+	for owner, informer := range c.ownerInformers {
+		reg, err := c.addEventHandler(informer, c.ownerEventHandler(owner))
+		if err != nil {
+			return err
+		}
+		synced = append(synced, reg.HasSynced)
+		go informer.Run(c.stopCh)
+	}
+
+	reg, err := c.addEventHandler(c.namespaceInformer, cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handleNamespaceAdd,
 		UpdateFunc: c.handleNamespaceUpdate,
 		DeleteFunc: c.handleNamespaceDelete,
@@ -236,7 +393,7 @@ func (c *WatchClient) Start() error {
 	go c.namespaceInformer.Run(c.stopCh)
 
 	if c.nodeInformer != nil {
-		reg, err = c.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		reg, err = c.addEventHandler(c.nodeInformer, cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.handleNodeAdd,
 			UpdateFunc: c.handleNodeUpdate,
 			DeleteFunc: c.handleNodeDelete,
@@ -248,7 +405,12 @@ func (c *WatchClient) Start() error {
 		go c.nodeInformer.Run(c.stopCh)
 	}
 
-	reg, err = c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+	// a shared pod informer which is already running hands the pods over to the handler as soon as it is added,
+	// so the other informers need to be synced first
+	if informer, ok := c.informer.(*sharedInformer); ok && informer.running.Load() {
+		c.waitForDependencies(synced)
+	}
+	reg, err = c.addEventHandler(c.informer, cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handlePodAdd,
 		UpdateFunc: c.handlePodUpdate,
 		DeleteFunc: c.handlePodDelete,
@@ -277,8 +439,18 @@ func (c *WatchClient) Start() error {
 }
 
 // Stop signals the k8s watcher/informer to stop watching for new events.
+// The shared informers keep watching until they are no longer used by other watch clients.
 func (c *WatchClient) Stop() {
 	close(c.stopCh)
+	for _, r := range c.registrations {
+		if err := r.informer.RemoveEventHandler(r.registration); err != nil {
+			c.logger.Warn("failed to remove informer event handler", zap.Error(err))
+		}
+	}
+	for _, informer := range c.sharedInformers {
+		informerCache.release(informer)
+	}
+	c.telemetryBuilder.Shutdown()
 }
 
 func (c *WatchClient) handlePodAdd(obj any) {
@@ -574,9 +746,46 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 	for _, r := range c.Rules.Annotations {
 		r.extractFromPodMetadata(pod.Annotations, tags, "k8s.pod.annotations.%s")
 	}
+
+	if c.watchOwners {
+		c.extractOwnersAttributes(pod.OwnerReferences, tags)
+	}
 	return tags
 }
 
+// extractOwnersAttributes adds the attributes of the owners in the chain of owner references to the tags.
+// The chain is walked from the pod, so that the attributes of the nearest owner are kept when
+// several owners have the same attribute.
+func (c *WatchClient) extractOwnersAttributes(ownerReferences []meta_v1.OwnerReference, tags map[string]string) {
+	uids := make([]string, 0, len(ownerReferences))
+	for _, ref := range ownerReferences {
+		uids = append(uids, string(ref.UID))
+	}
+
+	c.m.RLock()
+	defer c.m.RUnlock()
+	visited := map[string]bool{}
+	for len(uids) > 0 {
+		uid := uids[0]
+		uids = uids[1:]
+		if visited[uid] {
+			continue
+		}
+		visited[uid] = true
+
+		owner, ok := c.Owners[uid]
+		if !ok {
+			continue
+		}
+		for k, v := range owner.Attributes {
+			if _, ok := tags[k]; !ok {
+				tags[k] = v
+			}
+		}
+		uids = append(uids, owner.OwnerUIDs...)
+	}
+}
+
 // This function removes all data from the Pod except what is required by extraction rules and pod association
 func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Pod {
 	// name, namespace, uid, start time and ip are needed for identifying Pods
@@ -672,6 +881,35 @@ func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Po
 	return &transformedPod
 }
 
+// podData describes the data of the pods kept by removeUnnecessaryPodData.
+type podData struct {
+	startTime         bool
+	nodeName          bool
+	hostName          bool
+	containers        bool
+	containerImages   bool
+	containerImageIDs bool
+	labels            bool
+	annotations       bool
+	ownerReferences   bool
+}
+
+// podDataToKeep returns the data of the pods kept by removeUnnecessaryPodData for the given rules.
+// The pod informers can only be shared by watch clients keeping the same data.
+func podDataToKeep(rules ExtractionRules) podData {
+	return podData{
+		startTime:         rules.StartTime,
+		nodeName:          rules.Node,
+		hostName:          rules.PodHostName,
+		containers:        needContainerAttributes(rules),
+		containerImages:   rules.ContainerImageName || rules.ContainerImageTag,
+		containerImageIDs: rules.ContainerImageRepoDigests,
+		labels:            len(rules.Labels) > 0,
+		annotations:       len(rules.Annotations) > 0,
+		ownerReferences:   rules.IncludesOwnerMetadata(),
+	}
+}
+
 // parseNameAndTagFromImage parses the image name and tag for differently-formatted image names.
 // returns "latest" as the default if tag not present. also checks if the image contains a digest.
 // if it does, no latest tag is assumed.
@@ -1090,6 +1328,7 @@ func (c *WatchClient) handleReplicaSetDelete(obj any) {
 		c.m.Lock()
 		key := string(replicaset.UID)
 		delete(c.ReplicaSets, key)
+		delete(c.Owners, key)
 		c.m.Unlock()
 	} else {
 		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", obj))
@@ -1118,10 +1357,14 @@ func (c *WatchClient) addOrUpdateReplicaSet(replicaset *apps_v1.ReplicaSet) {
 		c.ReplicaSets[string(replicaset.UID)] = newReplicaSet
 	}
 	c.m.Unlock()
+
+	if c.watchOwners {
+		c.addOrUpdateOwner(MetadataFromReplicaSet, replicaset)
+	}
 }
 
 // This function removes all data from the ReplicaSet except what is required by extraction rules
-func removeUnnecessaryReplicaSetData(replicaset *apps_v1.ReplicaSet) *apps_v1.ReplicaSet {
+func removeUnnecessaryReplicaSetData(replicaset *apps_v1.ReplicaSet, rules ExtractionRules) *apps_v1.ReplicaSet {
 	transformedReplicaset := apps_v1.ReplicaSet{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:      replicaset.GetName(),
@@ -1130,9 +1373,159 @@ func removeUnnecessaryReplicaSetData(replicaset *apps_v1.ReplicaSet) *apps_v1.Re
 		},
 	}
 	transformedReplicaset.SetOwnerReferences(replicaset.GetOwnerReferences())
+	if rules.extractsFrom(MetadataFromReplicaSet) {
+		transformedReplicaset.SetLabels(replicaset.GetLabels())
+		transformedReplicaset.SetAnnotations(replicaset.GetAnnotations())
+	}
 	return &transformedReplicaset
 }
 
+// createOwnerInformers creates the informers watching the given kinds of pod owners, except replicasets
+// which are watched by the replicaset informer.
+func (c *WatchClient) createOwnerInformers(apiCfg k8sconfig.APIConfig, owners []string, newDynamicClient APIDynamicClientProvider) error {
+	if len(owners) == 0 {
+		return nil
+	}
+	c.watchOwners = true
+	c.ownerInformers = map[string]cache.SharedInformer{}
+	for _, owner := range owners {
+		if owner == MetadataFromReplicaSet {
+			continue
+		}
+
+		keepMetadata := c.Rules.extractsFrom(owner)
+		key := informerKey{client: c.kc, resource: owner, namespace: c.Filters.Namespace, transform: keepMetadata}
+		create := func() cache.SharedInformer {
+			return newOwnerSharedInformer(c.kc, owner, c.Filters.Namespace)
+		}
+		if resource, ok := c.customResource(owner); ok {
+			dc, err := c.dynamicClient(apiCfg, newDynamicClient)
+			if err != nil {
+				return err
+			}
+			key.client = dc
+			key.resource = resource.Resource.String()
+			create = func() cache.SharedInformer {
+				return newCustomResourceSharedInformer(dc, resource.Resource, c.Filters.Namespace)
+			}
+		} else if !slices.Contains(builtinOwners, owner) {
+			return fmt.Errorf("unknown kind of pod owner %q", owner)
+		}
+
+		informer, err := c.newInformer(true, key, create, func(object any) (any, error) {
+			return removeUnnecessaryOwnerData(object, keepMetadata), nil
+		})
+		if err != nil {
+			return err
+		}
+		c.ownerInformers[owner] = informer
+	}
+	return nil
+}
+
+func (c *WatchClient) customResource(name string) (CustomResource, bool) {
+	for _, resource := range c.Rules.CustomResources {
+		if resource.Name == name {
+			return resource, true
+		}
+	}
+	return CustomResource{}, false
+}
+
+// dynamicClient returns the dynamic client, which is created the first time custom resources are watched.
+func (c *WatchClient) dynamicClient(apiCfg k8sconfig.APIConfig, newDynamicClient APIDynamicClientProvider) (dynamic.Interface, error) {
+	if c.dc != nil {
+		return c.dc, nil
+	}
+	if newDynamicClient == nil {
+		newDynamicClient = informerCache.dynamicClient
+	}
+	dc, err := newDynamicClient(apiCfg)
+	if err != nil {
+		return nil, err
+	}
+	c.dc = dc
+	return dc, nil
+}
+
+// This function removes all data from the owner except what is required by extraction rules and
+// to follow the chain of owner references.
+func removeUnnecessaryOwnerData(object any, keepMetadata bool) any {
+	owner, err := meta.Accessor(object)
+	if err != nil { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+		return object
+	}
+	transformedOwner := meta_v1.PartialObjectMetadata{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      owner.GetName(),
+			Namespace: owner.GetNamespace(),
+			UID:       owner.GetUID(),
+		},
+	}
+	transformedOwner.SetOwnerReferences(owner.GetOwnerReferences())
+	if keepMetadata {
+		transformedOwner.SetLabels(owner.GetLabels())
+		transformedOwner.SetAnnotations(owner.GetAnnotations())
+	}
+	return &transformedOwner
+}
+
+func (c *WatchClient) ownerEventHandler(owner string) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			c.addOrUpdateOwner(owner, obj)
+		},
+		UpdateFunc: func(_, newObj any) {
+			c.addOrUpdateOwner(owner, newObj)
+		},
+		DeleteFunc: func(obj any) {
+			if object, err := meta.Accessor(ignoreDeletedFinalStateUnknown(obj)); err == nil {
+				c.m.Lock()
+				delete(c.Owners, string(object.GetUID()))
+				c.m.Unlock()
+			} else {
+				c.logger.Error("object received was not a kubernetes object", zap.String("owner", owner), zap.Any("received", obj))
+			}
+		},
+	}
+}
+
+func (c *WatchClient) addOrUpdateOwner(owner string, obj any) {
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		c.logger.Error("object received was not a kubernetes object", zap.String("owner", owner), zap.Any("received", obj))
+		return
+	}
+	newOwner := &Owner{
+		Name:       object.GetName(),
+		UID:        string(object.GetUID()),
+		Attributes: c.extractOwnerAttributes(owner, object),
+	}
+	for _, ref := range object.GetOwnerReferences() {
+		newOwner.OwnerUIDs = append(newOwner.OwnerUIDs, string(ref.UID))
+	}
+
+	c.m.Lock()
+	if newOwner.UID != "" {
+		c.Owners[newOwner.UID] = newOwner
+	}
+	c.m.Unlock()
+}
+
+func (c *WatchClient) extractOwnerAttributes(owner string, object meta_v1.Object) map[string]string {
+	tags := map[string]string{}
+
+	for _, r := range c.Rules.Labels {
+		r.extractFromOwnerMetadata(owner, object.GetLabels(), tags, "k8s."+owner+".labels.%s")
+	}
+
+	for _, r := range c.Rules.Annotations {
+		r.extractFromOwnerMetadata(owner, object.GetAnnotations(), tags, "k8s."+owner+".annotations.%s")
+	}
+
+	return tags
+}
+
 func (c *WatchClient) getReplicaSet(uid string) (*ReplicaSet, bool) {
 	c.m.RLock()
 	replicaset, ok := c.ReplicaSets[uid]
@@ -1147,6 +1540,12 @@ func (c *WatchClient) getReplicaSet(uid string) (*ReplicaSet, bool) {
 // before the informer is started. This is necessary e.g. for the pod informer which requires the replica set informer
 // to be finished to correctly establish the connection to the replicaset/deployment it belongs to.
 func (c *WatchClient) runInformerWithDependencies(informer cache.SharedInformer, dependencies []cache.InformerSynced) {
+	c.waitForDependencies(dependencies)
+	informer.Run(c.stopCh)
+}
+
+// waitForDependencies waits for the given informers to be synced, or for a timeout.
+func (c *WatchClient) waitForDependencies(dependencies []cache.InformerSynced) {
 	if len(dependencies) > 0 {
 		timeoutCh := make(chan struct{})
 		// TODO hard coding the timeout for now, check if we should make this configurable
@@ -1156,7 +1555,6 @@ func (c *WatchClient) runInformerWithDependencies(informer cache.SharedInformer,
 		defer t.Stop()
 		cache.WaitForCacheSync(timeoutCh, dependencies...)
 	}
-	informer.Run(c.stopCh)
 }
 
 // ignoreDeletedFinalStateUnknown returns the object wrapped in
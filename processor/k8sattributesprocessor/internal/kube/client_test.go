@@ -5,7 +5,9 @@ package kube
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"testing"
 	"time"
 
@@ -16,11 +18,18 @@ import (
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
@@ -145,17 +154,17 @@ func nodeAddAndUpdateTest(t *testing.T, c *WatchClient, handler func(obj any)) {
 }
 
 func TestDefaultClientset(t *testing.T) {
-	c, err := New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil, nil, false, 10*time.Second)
+	c, err := New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil, nil, nil, false, 10*time.Second)
 	require.EqualError(t, err, "invalid authType for kubernetes: ")
 	assert.Nil(t, c)
 
-	c, err = New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil, nil, false, 10*time.Second)
+	c, err = New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil, nil, nil, false, 10*time.Second)
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
 }
 
 func TestBadFilters(t *testing.T) {
-	c, err := New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{Fields: []FieldFilter{{Op: selection.Exists}}}, []Association{}, Excludes{}, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, false, 10*time.Second)
+	c, err := New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{Fields: []FieldFilter{{Op: selection.Exists}}}, []Association{}, Excludes{}, newFakeAPIClientset, nil, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, false, 10*time.Second)
 	assert.Error(t, err)
 	assert.Nil(t, c)
 }
@@ -191,13 +200,37 @@ func TestConstructorErrors(t *testing.T) {
 			gotAPIConfig = c
 			return nil, fmt.Errorf("error creating k8s client")
 		}
-		c, err := New(componenttest.NewNopTelemetrySettings(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer, nil, false, 10*time.Second)
+		c, err := New(componenttest.NewNopTelemetrySettings(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, nil, NewFakeInformer, NewFakeNamespaceInformer, nil, false, 10*time.Second)
 		assert.Nil(t, c)
 		require.EqualError(t, err, "error creating k8s client")
 		assert.Equal(t, apiCfg, gotAPIConfig)
 	})
 }
 
+func TestConstructorErrorReleasesSharedInformers(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	rules := ExtractionRules{
+		Labels:          []FieldExtractionRule{{Name: "team", Key: "team", From: "rollout"}},
+		CustomResources: []CustomResource{{Name: "rollout", Resource: schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}}},
+	}
+	c, err := New(
+		componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, rules, Filters{}, []Association{}, Excludes{},
+		func(k8sconfig.APIConfig) (kubernetes.Interface, error) { return clientset, nil },
+		func(k8sconfig.APIConfig) (dynamic.Interface, error) {
+			return nil, fmt.Errorf("error creating dynamic client")
+		},
+		nil, nil, nil, false, 10*time.Second,
+	)
+	assert.Nil(t, c)
+	require.EqualError(t, err, "error creating dynamic client")
+
+	informerCache.mu.Lock()
+	defer informerCache.mu.Unlock()
+	for key := range informerCache.informers {
+		assert.NotEqual(t, clientset, key.client, "informer %q was not released", key.resource)
+	}
+}
+
 func TestPodAdd(t *testing.T) {
 	c, _ := newTestClient(t)
 	podAddAndUpdateTest(t, c, c.handlePodAdd)
@@ -970,7 +1003,7 @@ func TestExtractionRules(t *testing.T) {
 			// manually call the data removal functions here
 			// normally the informer does this, but fully emulating the informer in this test is annoying
 			transformedPod := removeUnnecessaryPodData(pod, c.Rules)
-			transformedReplicaset := removeUnnecessaryReplicaSetData(replicaset)
+			transformedReplicaset := removeUnnecessaryReplicaSetData(replicaset, ExtractionRules{})
 			c.handleReplicaSetAdd(transformedReplicaset)
 			c.handlePodAdd(transformedPod)
 			p, ok := c.GetPod(newPodIdentifier("connection", "", pod.Status.PodIP))
@@ -1126,7 +1159,7 @@ func TestReplicaSetExtractionRules(t *testing.T) {
 			// manually call the data removal functions here
 			// normally the informer does this, but fully emulating the informer in this test is annoying
 			transformedPod := removeUnnecessaryPodData(pod, c.Rules)
-			transformedReplicaset := removeUnnecessaryReplicaSetData(replicaset)
+			transformedReplicaset := removeUnnecessaryReplicaSetData(replicaset, ExtractionRules{})
 			c.handleReplicaSetAdd(transformedReplicaset)
 			c.handlePodAdd(transformedPod)
 			p, ok := c.GetPod(newPodIdentifier("connection", "", pod.Status.PodIP))
@@ -1999,7 +2032,7 @@ func newTestClientWithRulesAndFilters(t *testing.T, f Filters) (*WatchClient, *o
 			},
 		},
 	}
-	c, err := New(set, k8sconfig.APIConfig{}, ExtractionRules{}, f, associations, exclude, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, false, 10*time.Second)
+	c, err := New(set, k8sconfig.APIConfig{}, ExtractionRules{}, f, associations, exclude, newFakeAPIClientset, nil, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, false, 10*time.Second)
 	require.NoError(t, err)
 	return c.(*WatchClient), logs
 }
@@ -2047,7 +2080,7 @@ func TestWaitForMetadata(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			c, err := New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, tc.informerProvider, nil, nil, true, 1*time.Second)
+			c, err := New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, tc.informerProvider, nil, nil, true, 1*time.Second)
 			require.NoError(t, err)
 
 			err = c.Start()
@@ -2059,3 +2092,201 @@ func TestWaitForMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractOwnerAttributes(t *testing.T) {
+	ownerReference := func(kind string, name string, uid string) []meta_v1.OwnerReference {
+		return []meta_v1.OwnerReference{{Kind: kind, Name: name, UID: types.UID(uid)}}
+	}
+	clientset := fake.NewSimpleClientset(
+		&apps_v1.Deployment{ObjectMeta: meta_v1.ObjectMeta{
+			Name: "frontend", Namespace: "ns", UID: "deployment-uid",
+			Labels:      map[string]string{"app.kubernetes.io/version": "1.2.3", "team": "web"},
+			Annotations: map[string]string{"example.com/owner": "deployment"},
+		}},
+		&apps_v1.ReplicaSet{ObjectMeta: meta_v1.ObjectMeta{
+			Name: "frontend-b48cd", Namespace: "ns", UID: "frontend-replicaset-uid",
+			Labels:          map[string]string{"pod-template-hash": "b48cd"},
+			OwnerReferences: ownerReference("Deployment", "frontend", "deployment-uid"),
+		}},
+		&apps_v1.ReplicaSet{ObjectMeta: meta_v1.ObjectMeta{
+			Name: "backend-5f7d9", Namespace: "ns", UID: "backend-replicaset-uid",
+			Labels:          map[string]string{"pod-template-hash": "5f7d9"},
+			OwnerReferences: ownerReference("Rollout", "backend", "rollout-uid"),
+		}},
+		&batch_v1.CronJob{ObjectMeta: meta_v1.ObjectMeta{
+			Name: "report", Namespace: "ns", UID: "cronjob-uid",
+			Annotations: map[string]string{"example.com/owner": "cronjob", "example.com/schedule": "daily"},
+		}},
+		&batch_v1.Job{ObjectMeta: meta_v1.ObjectMeta{
+			Name: "report-28391", Namespace: "ns", UID: "job-uid",
+			Annotations:     map[string]string{"example.com/owner": "job"},
+			OwnerReferences: ownerReference("CronJob", "report", "cronjob-uid"),
+		}},
+	)
+
+	rollouts := schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+	rollout := &unstructured.Unstructured{}
+	rollout.SetAPIVersion("argoproj.io/v1alpha1")
+	rollout.SetKind("Rollout")
+	rollout.SetName("backend")
+	rollout.SetNamespace("ns")
+	rollout.SetUID("rollout-uid")
+	rollout.SetLabels(map[string]string{"team": "api"})
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		rollouts: "RolloutList",
+	}, rollout)
+
+	rules := ExtractionRules{
+		Labels: []FieldExtractionRule{
+			{Name: "k8s.deployment.labels.app.kubernetes.io/version", Key: "app.kubernetes.io/version", From: MetadataFromDeployment},
+			{Name: "pod-template-hash", Key: "pod-template-hash", From: MetadataFromReplicaSet},
+			{Name: "team", Key: "team", From: MetadataFromDeployment},
+			{Name: "team", Key: "team", From: "rollout"},
+		},
+		Annotations: []FieldExtractionRule{
+			{Name: "$1", KeyRegex: regexp.MustCompile("^(?:example.com/(.*))$"), HasKeyRegexReference: true, From: MetadataFromJob},
+			{Name: "$1", KeyRegex: regexp.MustCompile("^(?:example.com/(.*))$"), HasKeyRegexReference: true, From: MetadataFromCronJob},
+		},
+		CustomResources: []CustomResource{{Name: "rollout", Resource: rollouts}},
+	}
+	c, err := New(
+		componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, rules, Filters{}, []Association{}, Excludes{},
+		func(k8sconfig.APIConfig) (kubernetes.Interface, error) { return clientset, nil },
+		func(k8sconfig.APIConfig) (dynamic.Interface, error) { return dynamicClient, nil },
+		NewFakeInformer, NewFakeNamespaceInformer, nil, true, 10*time.Second,
+	)
+	require.NoError(t, err)
+	wc := c.(*WatchClient)
+	assert.ElementsMatch(t, []string{MetadataFromDeployment, MetadataFromJob, MetadataFromCronJob, "rollout"}, slices.Collect(maps.Keys(wc.ownerInformers)))
+	require.NoError(t, c.Start())
+	defer c.Stop()
+	// the fake pod informer does not wait for the owner informers
+	require.Eventually(t, func() bool {
+		wc.m.RLock()
+		defer wc.m.RUnlock()
+		return len(wc.Owners) == 6
+	}, 5*time.Second, 10*time.Millisecond)
+
+	testCases := []struct {
+		name     string
+		owners   []meta_v1.OwnerReference
+		expected map[string]string
+	}{
+		{
+			name:   "deployment",
+			owners: ownerReference("ReplicaSet", "frontend-b48cd", "frontend-replicaset-uid"),
+			expected: map[string]string{
+				"k8s.deployment.labels.app.kubernetes.io/version": "1.2.3",
+				"pod-template-hash": "b48cd",
+				"team":              "web",
+			},
+		},
+		{
+			name:   "custom resource",
+			owners: ownerReference("ReplicaSet", "backend-5f7d9", "backend-replicaset-uid"),
+			expected: map[string]string{
+				"pod-template-hash": "5f7d9",
+				"team":              "api",
+			},
+		},
+		{
+			name:   "nearest owner first",
+			owners: ownerReference("Job", "report-28391", "job-uid"),
+			expected: map[string]string{
+				"owner":    "job",
+				"schedule": "daily",
+			},
+		},
+		{
+			name:     "unknown owner",
+			owners:   ownerReference("StatefulSet", "db", "statefulset-uid"),
+			expected: map[string]string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &api_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{
+				Name: "pod", Namespace: "ns", UID: "pod-uid", OwnerReferences: tc.owners,
+			}}
+			assert.Equal(t, tc.expected, wc.extractPodAttributes(pod))
+		})
+	}
+
+	// the labels and annotations of the owners are only kept when they are extracted
+	wc.m.RLock()
+	defer wc.m.RUnlock()
+	assert.Equal(t, &Owner{Name: "report-28391", UID: "job-uid", Attributes: map[string]string{"owner": "job"}, OwnerUIDs: []string{"cronjob-uid"}}, wc.Owners["job-uid"])
+	assert.Equal(t, &Owner{Name: "frontend", UID: "deployment-uid", Attributes: map[string]string{
+		"k8s.deployment.labels.app.kubernetes.io/version": "1.2.3",
+		"team": "web",
+	}}, wc.Owners["deployment-uid"])
+}
+
+func TestRemoveUnnecessaryOwnerData(t *testing.T) {
+	deployment := &apps_v1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            "frontend",
+			Namespace:       "ns",
+			UID:             "deployment-uid",
+			Labels:          map[string]string{"app": "frontend"},
+			Annotations:     map[string]string{"example.com/owner": "web"},
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "Rollout", Name: "frontend", UID: "rollout-uid"}},
+		},
+		Spec: apps_v1.DeploymentSpec{Replicas: new(int32)},
+	}
+	expected := &meta_v1.PartialObjectMetadata{ObjectMeta: meta_v1.ObjectMeta{
+		Name:            "frontend",
+		Namespace:       "ns",
+		UID:             "deployment-uid",
+		OwnerReferences: []meta_v1.OwnerReference{{Kind: "Rollout", Name: "frontend", UID: "rollout-uid"}},
+	}}
+	assert.Equal(t, expected, removeUnnecessaryOwnerData(deployment, false))
+
+	expected.Labels = deployment.Labels
+	expected.Annotations = deployment.Annotations
+	assert.Equal(t, expected, removeUnnecessaryOwnerData(deployment, true))
+
+	deleted := cache.DeletedFinalStateUnknown{Key: "ns/frontend", Obj: deployment}
+	assert.Equal(t, deleted, removeUnnecessaryOwnerData(deleted, true))
+}
+
+func TestOwnersToWatch(t *testing.T) {
+	rollouts := []CustomResource{{Name: "rollout"}}
+	testCases := []struct {
+		name     string
+		rules    ExtractionRules
+		expected []string
+	}{
+		{
+			name: "no owner",
+			rules: ExtractionRules{
+				Labels:          []FieldExtractionRule{{Key: "app", From: MetadataFromPod}},
+				CustomResources: rollouts,
+			},
+		},
+		{
+			name:     "replicaset",
+			rules:    ExtractionRules{Labels: []FieldExtractionRule{{Key: "app", From: MetadataFromReplicaSet}}},
+			expected: []string{MetadataFromReplicaSet},
+		},
+		{
+			name:     "cronjob",
+			rules:    ExtractionRules{Annotations: []FieldExtractionRule{{Key: "app", From: MetadataFromCronJob}}},
+			expected: []string{MetadataFromReplicaSet, MetadataFromJob, MetadataFromCronJob},
+		},
+		{
+			name: "custom resource",
+			rules: ExtractionRules{
+				Labels:          []FieldExtractionRule{{Key: "app", From: "rollout"}},
+				CustomResources: rollouts,
+			},
+			expected: []string{MetadataFromReplicaSet, MetadataFromDeployment, "rollout"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.rules.ownersToWatch())
+			assert.Equal(t, tc.expected != nil, tc.rules.IncludesOwnerMetadata())
+		})
+	}
+}
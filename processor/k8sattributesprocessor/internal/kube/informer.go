@@ -7,12 +7,16 @@ import (
 	"context"
 
 	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 )
@@ -153,3 +157,90 @@ func replicasetWatchFuncWithSelectors(client kubernetes.Interface, namespace str
 		return client.AppsV1().ReplicaSets(namespace).Watch(context.Background(), opts)
 	}
 }
+
+// newOwnerSharedInformer returns a SharedInformer watching the built-in kind of pod owners,
+// e.g. deployment, with the given name.
+func newOwnerSharedInformer(
+	client kubernetes.Interface,
+	owner string,
+	namespace string,
+) cache.SharedInformer {
+	var lw *cache.ListWatch
+	var objType runtime.Object
+	switch owner {
+	case MetadataFromDeployment:
+		lw = &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.AppsV1().Deployments(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.AppsV1().Deployments(namespace).Watch(context.Background(), opts)
+			},
+		}
+		objType = &apps_v1.Deployment{}
+	case MetadataFromStatefulSet:
+		lw = &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.AppsV1().StatefulSets(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.AppsV1().StatefulSets(namespace).Watch(context.Background(), opts)
+			},
+		}
+		objType = &apps_v1.StatefulSet{}
+	case MetadataFromDaemonSet:
+		lw = &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.AppsV1().DaemonSets(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.AppsV1().DaemonSets(namespace).Watch(context.Background(), opts)
+			},
+		}
+		objType = &apps_v1.DaemonSet{}
+	case MetadataFromJob:
+		lw = &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.BatchV1().Jobs(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.BatchV1().Jobs(namespace).Watch(context.Background(), opts)
+			},
+		}
+		objType = &batch_v1.Job{}
+	case MetadataFromCronJob:
+		lw = &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.BatchV1().CronJobs(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.BatchV1().CronJobs(namespace).Watch(context.Background(), opts)
+			},
+		}
+		objType = &batch_v1.CronJob{}
+	default:
+		return nil
+	}
+	return cache.NewSharedInformer(lw, objType, watchSyncPeriod)
+}
+
+// newCustomResourceSharedInformer returns a SharedInformer watching custom resources with the dynamic client.
+func newCustomResourceSharedInformer(
+	client dynamic.Interface,
+	resource schema.GroupVersionResource,
+	namespace string,
+) cache.SharedInformer {
+	informer := cache.NewSharedInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.Resource(resource).Namespace(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.Resource(resource).Namespace(namespace).Watch(context.Background(), opts)
+			},
+		},
+		&unstructured.Unstructured{},
+		watchSyncPeriod,
+	)
+	return informer
+}
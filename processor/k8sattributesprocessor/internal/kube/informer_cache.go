@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+// informerCache holds the informers shared by the watch clients of all the processor instances,
+// so that the instances watching the same objects keep a single copy of them and open a single watch.
+var informerCache = newSharedInformers()
+
+// informerKey identifies the objects watched by an informer, and the data of the objects kept in its store.
+type informerKey struct {
+	// client is the kubernetes.Interface or dynamic.Interface used to watch the objects.
+	client        any
+	resource      string
+	namespace     string
+	labelSelector string
+	fieldSelector string
+	// transform describes the data kept by the transform of the informer. It must be comparable.
+	transform any
+}
+
+// sharedInformers is a reference-counted map of informers, similar to sharedcomponent.SharedComponents.
+type sharedInformers struct {
+	mu        sync.Mutex
+	informers map[informerKey]*sharedInformer
+
+	// clients and dynamicClients are never evicted: a client is kept for every distinct API config
+	// found in the processor configurations, of which there are only a few over the lifetime of the
+	// collector, and the informers shared by the watch clients are keyed on the client they use.
+	clientsMu      sync.Mutex
+	clients        map[k8sconfig.APIConfig]kubernetes.Interface
+	dynamicClients map[k8sconfig.APIConfig]dynamic.Interface
+}
+
+func newSharedInformers() *sharedInformers {
+	return &sharedInformers{
+		informers:      map[informerKey]*sharedInformer{},
+		clients:        map[k8sconfig.APIConfig]kubernetes.Interface{},
+		dynamicClients: map[k8sconfig.APIConfig]dynamic.Interface{},
+	}
+}
+
+// client returns the kubernetes client shared by the watch clients using the same API config,
+// which allows them to share informers.
+func (s *sharedInformers) client(apiCfg k8sconfig.APIConfig) (kubernetes.Interface, error) {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	if client, ok := s.clients[apiCfg]; ok {
+		return client, nil
+	}
+	client, err := k8sconfig.MakeClient(apiCfg)
+	if err != nil {
+		return nil, err
+	}
+	s.clients[apiCfg] = client
+	return client, nil
+}
+
+// dynamicClient returns the dynamic client shared by the watch clients using the same API config.
+func (s *sharedInformers) dynamicClient(apiCfg k8sconfig.APIConfig) (dynamic.Interface, error) {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	if client, ok := s.dynamicClients[apiCfg]; ok {
+		return client, nil
+	}
+	client, err := k8sconfig.MakeDynamicClient(apiCfg)
+	if err != nil {
+		return nil, err
+	}
+	s.dynamicClients[apiCfg] = client
+	return client, nil
+}
+
+// acquire returns the informer stored with the given key, after creating it with the given function if
+// there is none. Each call must be followed by a call to release once the informer is no longer used.
+func (s *sharedInformers) acquire(key informerKey, create func() (cache.SharedInformer, error)) (*sharedInformer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if informer, ok := s.informers[key]; ok {
+		informer.refs++
+		return informer, nil
+	}
+
+	created, err := create()
+	if err != nil {
+		return nil, err
+	}
+	informer := &sharedInformer{
+		SharedInformer: created,
+		key:            key,
+		refs:           1,
+		stopCh:         make(chan struct{}),
+	}
+	err = created.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		informer.watchRestarts.Add(1)
+		cache.DefaultWatchErrorHandler(r, err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.informers[key] = informer
+	return informer, nil
+}
+
+// release stops the informer once it is released by all the watch clients which acquired it.
+func (s *sharedInformers) release(informer *sharedInformer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	informer.refs--
+	if informer.refs == 0 {
+		delete(s.informers, informer.key)
+		close(informer.stopCh)
+	}
+}
+
+// sharedInformer is an informer shared by several watch clients. It is run once, until all the watch clients release it.
+type sharedInformer struct {
+	cache.SharedInformer
+
+	key           informerKey
+	refs          int
+	stopCh        chan struct{}
+	runOnce       sync.Once
+	running       atomic.Bool
+	watchRestarts atomic.Int64
+}
+
+// Run starts the informer if it is not running yet, and blocks until stopCh is closed.
+func (i *sharedInformer) Run(stopCh <-chan struct{}) {
+	i.runOnce.Do(func() {
+		i.running.Store(true)
+		go i.SharedInformer.Run(i.stopCh)
+	})
+	<-stopCh
+}
+
+// cacheSize returns the number of objects in the store of the informer.
+func (i *sharedInformer) cacheSize() int {
+	return len(i.GetStore().ListKeys())
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/metadatatest"
+)
+
+func TestSharedInformers(t *testing.T) {
+	s := newSharedInformers()
+	client := fake.NewSimpleClientset()
+	var created int
+	create := func() (cache.SharedInformer, error) {
+		created++
+		return newReplicaSetSharedInformer(client, ""), nil
+	}
+
+	key := informerKey{client: client, resource: "replicasets"}
+	first, err := s.acquire(key, create)
+	require.NoError(t, err)
+	second, err := s.acquire(key, create)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	// informers keeping other data are not shared
+	key.transform = true
+	other, err := s.acquire(key, create)
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+	assert.Equal(t, 2, created)
+
+	s.release(first)
+	assert.Len(t, s.informers, 2)
+	select {
+	case <-first.stopCh:
+		t.Fatal("the informer was stopped while still in use")
+	default:
+	}
+
+	s.release(second)
+	s.release(other)
+	assert.Empty(t, s.informers)
+	<-first.stopCh
+	<-other.stopCh
+}
+
+func TestWatchClientsShareInformers(t *testing.T) {
+	newPod := func(name string, uid string) *api_v1.Pod {
+		return &api_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "ns", UID: types.UID(uid)}}
+	}
+	clientset := fake.NewSimpleClientset(newPod("pod-a", "uid-a"))
+	newClient := func(t *testing.T) (*WatchClient, *componenttest.Telemetry) {
+		tel := componenttest.NewTelemetry()
+		t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+		c, err := New(
+			tel.NewTelemetrySettings(), k8sconfig.APIConfig{}, ExtractionRules{PodName: true}, Filters{}, []Association{}, Excludes{},
+			func(k8sconfig.APIConfig) (kubernetes.Interface, error) { return clientset, nil },
+			nil, nil, nil, nil, true, 10*time.Second,
+		)
+		require.NoError(t, err)
+		require.NoError(t, c.Start())
+		return c.(*WatchClient), tel
+	}
+	hasPod := func(c *WatchClient, uid string) bool {
+		_, ok := c.GetPod(newPodIdentifier(ResourceSource, "k8s.pod.uid", uid))
+		return ok
+	}
+
+	first, _ := newClient(t)
+	second, tel := newClient(t)
+	assert.Same(t, first.informer, second.informer)
+	assert.True(t, hasPod(first, "uid-a"))
+	assert.True(t, hasPod(second, "uid-a"))
+
+	// the informer keeps running for the remaining client
+	first.Stop()
+	_, err := clientset.CoreV1().Pods("ns").Create(context.Background(), newPod("pod-b", "uid-b"), meta_v1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return hasPod(second, "uid-b")
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, hasPod(first, "uid-b"))
+
+	metadatatest.AssertEqualOtelsvcK8sInformerCacheSize(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOtelsvcK8sInformerWatchRestarts(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 0}},
+		metricdatatest.IgnoreTimestamp())
+
+	second.Stop()
+	informerCache.mu.Lock()
+	defer informerCache.mu.Unlock()
+	assert.NotContains(t, informerCache.informers, second.informer.(*sharedInformer).key)
+}
@@ -10,7 +10,9 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -28,7 +30,19 @@ const (
 	// MetadataFromNamespace is used to specify to extract metadata/labels/annotations from namespace
 	MetadataFromNamespace = "namespace"
 	// MetadataFromNode is used to specify to extract metadata/labels/annotations from node
-	MetadataFromNode       = "node"
+	MetadataFromNode = "node"
+	// MetadataFromReplicaSet is used to specify to extract labels/annotations from the replicaset owning the pod
+	MetadataFromReplicaSet = "replicaset"
+	// MetadataFromDeployment is used to specify to extract labels/annotations from the deployment owning the pod
+	MetadataFromDeployment = "deployment"
+	// MetadataFromStatefulSet is used to specify to extract labels/annotations from the statefulset owning the pod
+	MetadataFromStatefulSet = "statefulset"
+	// MetadataFromDaemonSet is used to specify to extract labels/annotations from the daemonset owning the pod
+	MetadataFromDaemonSet = "daemonset"
+	// MetadataFromJob is used to specify to extract labels/annotations from the job owning the pod
+	MetadataFromJob = "job"
+	// MetadataFromCronJob is used to specify to extract labels/annotations from the cronjob owning the pod
+	MetadataFromCronJob    = "cronjob"
 	PodIdentifierMaxLength = 4
 
 	ResourceSource   = "resource_attribute"
@@ -96,12 +110,16 @@ type Client interface {
 }
 
 // ClientProvider defines a func type that returns a new Client.
-type ClientProvider func(component.TelemetrySettings, k8sconfig.APIConfig, ExtractionRules, Filters, []Association, Excludes, APIClientsetProvider, InformerProvider, InformerProviderNamespace, InformerProviderReplicaSet, bool, time.Duration) (Client, error)
+type ClientProvider func(component.TelemetrySettings, k8sconfig.APIConfig, ExtractionRules, Filters, []Association, Excludes, APIClientsetProvider, APIDynamicClientProvider, InformerProvider, InformerProviderNamespace, InformerProviderReplicaSet, bool, time.Duration) (Client, error)
 
 // APIClientsetProvider defines a func type that initializes and return a new kubernetes
 // Clientset object.
 type APIClientsetProvider func(config k8sconfig.APIConfig) (kubernetes.Interface, error)
 
+// APIDynamicClientProvider defines a func type that initializes and return a new kubernetes
+// dynamic client, used to watch custom resources.
+type APIDynamicClientProvider func(config k8sconfig.APIConfig) (dynamic.Interface, error)
+
 // Pod represents a kubernetes pod.
 type Pod struct {
 	Name        string
@@ -153,6 +171,17 @@ type Namespace struct {
 	DeletedAt    time.Time
 }
 
+// Owner represents a kubernetes object owning pods, directly or through other owners,
+// such as a deployment or a custom resource.
+type Owner struct {
+	Name string
+	UID  string
+	// Attributes are the labels and annotations extracted from the owner.
+	Attributes map[string]string
+	// OwnerUIDs are the UIDs of the owners of the owner.
+	OwnerUIDs []string
+}
+
 // Node represents a kubernetes node.
 type Node struct {
 	Name       string
@@ -241,6 +270,17 @@ type ExtractionRules struct {
 
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
+
+	// CustomResources are the custom resources which can own pods, directly or through other owners.
+	CustomResources []CustomResource
+}
+
+// CustomResource is a kind of custom resources owning pods, such as Argo Rollouts.
+type CustomResource struct {
+	// Name is used by the extraction rules to refer to the custom resources, e.g. rollout.
+	Name string
+	// Resource is the group, version and resource of the custom resources, watched with the dynamic client.
+	Resource schema.GroupVersionResource
 }
 
 // IncludesOwnerMetadata determines whether the ExtractionRules include metadata about Pod Owners
@@ -263,9 +303,64 @@ func (rules *ExtractionRules) IncludesOwnerMetadata() bool {
 			return true
 		}
 	}
+	return len(rules.ownersToWatch()) > 0
+}
+
+// extractsFrom determines whether labels or annotations are extracted from the given kubernetes object kind.
+func (rules *ExtractionRules) extractsFrom(from string) bool {
+	for _, r := range rules.Labels {
+		if r.From == from {
+			return true
+		}
+	}
+	for _, r := range rules.Annotations {
+		if r.From == from {
+			return true
+		}
+	}
 	return false
 }
 
+// builtinOwners are the built-in kinds of pod owners which are watched by owner informers,
+// replicasets being watched by the replicaset informer.
+var builtinOwners = []string{MetadataFromDeployment, MetadataFromStatefulSet, MetadataFromDaemonSet, MetadataFromJob, MetadataFromCronJob}
+
+// ownersToWatch returns the kinds of owners whose labels or annotations are extracted, along with the
+// kinds which may be in between them and the pods in the chain of owner references.
+func (rules *ExtractionRules) ownersToWatch() []string {
+	var targeted []string
+	for _, owner := range builtinOwners {
+		if rules.extractsFrom(owner) {
+			targeted = append(targeted, owner)
+		}
+	}
+	var customResourcesTargeted bool
+	for _, resource := range rules.CustomResources {
+		customResourcesTargeted = customResourcesTargeted || rules.extractsFrom(resource.Name)
+	}
+	if len(targeted) == 0 && !customResourcesTargeted && !rules.extractsFrom(MetadataFromReplicaSet) {
+		return nil
+	}
+
+	// most workloads own their pods through replicasets, and cronjobs through jobs
+	owners := []string{MetadataFromReplicaSet}
+	if rules.extractsFrom(MetadataFromCronJob) && !rules.extractsFrom(MetadataFromJob) {
+		owners = append(owners, MetadataFromJob)
+	}
+	// custom resources usually own their pods through deployments or other custom resources, e.g. Knative
+	// services own configurations, which own revisions, which own deployments
+	if customResourcesTargeted && !rules.extractsFrom(MetadataFromDeployment) {
+		owners = append(owners, MetadataFromDeployment)
+	}
+	owners = append(owners, targeted...)
+	if customResourcesTargeted {
+		for _, resource := range rules.CustomResources {
+			owners = append(owners, resource.Name)
+		}
+	}
+	return owners
+}
+
 // FieldExtractionRule is used to specify which fields to extract from pod fields
 // and inject into spans as attributes.
 type FieldExtractionRule struct {
@@ -280,10 +375,12 @@ type FieldExtractionRule struct {
 	// Full value is extracted when no regexp is provided.
 	Regex *regexp.Regexp
 	// From determines the kubernetes object the field should be retrieved from.
-	// Currently only three values are supported,
+	// Currently the following values are supported,
 	//  - pod
 	//  - namespace
 	//  - node
+	//  - replicaset, deployment, statefulset, daemonset, job or cronjob
+	//  - the name of a custom resource
 	From string
 }
 
@@ -306,6 +403,12 @@ func (r *FieldExtractionRule) extractFromNodeMetadata(metadata map[string]string
 	}
 }
 
+func (r *FieldExtractionRule) extractFromOwnerMetadata(owner string, metadata map[string]string, tags map[string]string, formatter string) {
+	if r.From == owner {
+		r.extractFromMetadata(metadata, tags, formatter)
+	}
+}
+
 func (r *FieldExtractionRule) extractFromMetadata(metadata map[string]string, tags map[string]string, formatter string) {
 	if r.KeyRegex != nil {
 		for k, v := range metadata {
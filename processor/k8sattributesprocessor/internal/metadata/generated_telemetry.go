@@ -3,10 +3,12 @@
 package metadata
 
 import (
+	"context"
 	"errors"
 	"sync"
 
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
@@ -23,23 +25,25 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                       metric.Meter
-	mu                          sync.Mutex
-	registrations               []metric.Registration
-	OtelsvcK8sIPLookupMiss      metric.Int64Counter
-	OtelsvcK8sNamespaceAdded    metric.Int64Counter
-	OtelsvcK8sNamespaceDeleted  metric.Int64Counter
-	OtelsvcK8sNamespaceUpdated  metric.Int64Counter
-	OtelsvcK8sNodeAdded         metric.Int64Counter
-	OtelsvcK8sNodeDeleted       metric.Int64Counter
-	OtelsvcK8sNodeUpdated       metric.Int64Counter
-	OtelsvcK8sPodAdded          metric.Int64Counter
-	OtelsvcK8sPodDeleted        metric.Int64Counter
-	OtelsvcK8sPodTableSize      metric.Int64Gauge
-	OtelsvcK8sPodUpdated        metric.Int64Counter
-	OtelsvcK8sReplicasetAdded   metric.Int64Counter
-	OtelsvcK8sReplicasetDeleted metric.Int64Counter
-	OtelsvcK8sReplicasetUpdated metric.Int64Counter
+	meter                           metric.Meter
+	mu                              sync.Mutex
+	registrations                   []metric.Registration
+	OtelsvcK8sInformerCacheSize     metric.Int64ObservableGauge
+	OtelsvcK8sInformerWatchRestarts metric.Int64ObservableCounter
+	OtelsvcK8sIPLookupMiss          metric.Int64Counter
+	OtelsvcK8sNamespaceAdded        metric.Int64Counter
+	OtelsvcK8sNamespaceDeleted      metric.Int64Counter
+	OtelsvcK8sNamespaceUpdated      metric.Int64Counter
+	OtelsvcK8sNodeAdded             metric.Int64Counter
+	OtelsvcK8sNodeDeleted           metric.Int64Counter
+	OtelsvcK8sNodeUpdated           metric.Int64Counter
+	OtelsvcK8sPodAdded              metric.Int64Counter
+	OtelsvcK8sPodDeleted            metric.Int64Counter
+	OtelsvcK8sPodTableSize          metric.Int64Gauge
+	OtelsvcK8sPodUpdated            metric.Int64Counter
+	OtelsvcK8sReplicasetAdded       metric.Int64Counter
+	OtelsvcK8sReplicasetDeleted     metric.Int64Counter
+	OtelsvcK8sReplicasetUpdated     metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -53,6 +57,46 @@ func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
 	tbof(mb)
 }
 
+// RegisterOtelsvcK8sInformerCacheSizeCallback sets callback for observable OtelsvcK8sInformerCacheSize metric.
+func (builder *TelemetryBuilder) RegisterOtelsvcK8sInformerCacheSizeCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.OtelsvcK8sInformerCacheSize, obs: o})
+		return nil
+	}, builder.OtelsvcK8sInformerCacheSize)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
+// RegisterOtelsvcK8sInformerWatchRestartsCallback sets callback for observable OtelsvcK8sInformerWatchRestarts metric.
+func (builder *TelemetryBuilder) RegisterOtelsvcK8sInformerWatchRestartsCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.OtelsvcK8sInformerWatchRestarts, obs: o})
+		return nil
+	}, builder.OtelsvcK8sInformerWatchRestarts)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
+type observerInt64 struct {
+	embedded.Int64Observer
+	inst metric.Int64Observable
+	obs  metric.Observer
+}
+
+func (oi *observerInt64) Observe(value int64, opts ...metric.ObserveOption) {
+	oi.obs.ObserveInt64(oi.inst, value, opts...)
+}
+
 // Shutdown unregister all registered callbacks for async instruments.
 func (builder *TelemetryBuilder) Shutdown() {
 	builder.mu.Lock()
@@ -71,6 +115,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 	}
 	builder.meter = Meter(settings)
 	var err, errs error
+	builder.OtelsvcK8sInformerCacheSize, err = builder.meter.Int64ObservableGauge(
+		"otelcol_otelsvc_k8s_informer_cache_size",
+		metric.WithDescription("Number of objects in the informer caches used by the processor, which are shared with the other processors watching the same objects"),
+		metric.WithUnit("{object}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.OtelsvcK8sInformerWatchRestarts, err = builder.meter.Int64ObservableCounter(
+		"otelcol_otelsvc_k8s_informer_watch_restarts",
+		metric.WithDescription("Number of times the watches of the informers used by the processor were restarted after an error"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.OtelsvcK8sIPLookupMiss, err = builder.meter.Int64Counter(
 		"otelcol_otelsvc_k8s_ip_lookup_miss",
 		metric.WithDescription("Number of times pod by IP lookup failed."),
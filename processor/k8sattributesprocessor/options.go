@@ -10,6 +10,7 @@ import (
 	"time"
 
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -222,6 +223,24 @@ func withExtractAnnotations(annotations ...FieldExtractConfig) option {
 	}
 }
 
+// withExtractCustomResources allows specifying the custom resources labels and annotations can be extracted from.
+func withExtractCustomResources(resources ...CustomResourceConfig) option {
+	return func(p *kubernetesprocessor) error {
+		p.rules.CustomResources = nil
+		for _, r := range resources {
+			p.rules.CustomResources = append(p.rules.CustomResources, kube.CustomResource{
+				Name: r.name(),
+				Resource: schema.GroupVersionResource{
+					Group:    r.Group,
+					Version:  r.Version,
+					Resource: r.Resource,
+				},
+			})
+		}
+		return nil
+	}
+}
+
 func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.FieldExtractionRule, error) {
 	var rules []kube.FieldExtractionRule
 	for _, a := range fields {
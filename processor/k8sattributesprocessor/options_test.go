@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -606,6 +607,22 @@ func Test_extractFieldRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "owner",
+			args: args{"annotations", []FieldExtractConfig{
+				{
+					Key:  "key",
+					From: kube.MetadataFromDeployment,
+				},
+			}},
+			want: []kube.FieldExtractionRule{
+				{
+					Name: "k8s.deployment.annotations.key",
+					Key:  "key",
+					From: kube.MetadataFromDeployment,
+				},
+			},
+		},
 		{
 			name: "keyregex-capture-group",
 			args: args{"labels", []FieldExtractConfig{
@@ -638,6 +655,18 @@ func Test_extractFieldRules(t *testing.T) {
 	}
 }
 
+func TestWithExtractCustomResources(t *testing.T) {
+	p := &kubernetesprocessor{}
+	assert.NoError(t, withExtractCustomResources(
+		CustomResourceConfig{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts", Kind: "Rollout"},
+		CustomResourceConfig{Version: "v1", Resource: "widgets", Kind: "Widget"},
+	)(p))
+	assert.Equal(t, []kube.CustomResource{
+		{Name: "rollout", Resource: schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}},
+		{Name: "widget", Resource: schema.GroupVersionResource{Version: "v1", Resource: "widgets"}},
+	}, p.rules.CustomResources)
+}
+
 func TestWithExtractPodAssociation(t *testing.T) {
 	tests := []struct {
 		name string
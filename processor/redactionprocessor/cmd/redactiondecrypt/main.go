@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// redactiondecrypt decrypts the values encrypted by the redaction processor. It reads text from
+// its standard input, such as exported attribute values or log lines, and writes it to its
+// standard output with the encrypted values replaced with their decryption.
+//
+// Usage:
+//
+//	redactiondecrypt -keys-file keys.json < redacted.log
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/encryption"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, in io.Reader, out io.Writer, errOut io.Writer) error {
+	flags := flag.NewFlagSet("redactiondecrypt", flag.ContinueOnError)
+	flags.SetOutput(errOut)
+	keysFile := flags.String("keys-file", "", "path of the JSON keyring file used by the redaction processor")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *keysFile == "" {
+		return errors.New("-keys-file is required")
+	}
+
+	keyring, err := encryption.LoadKeyringFile(*keysFile)
+	if err != nil {
+		return err
+	}
+
+	var failed bool
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	w := bufio.NewWriter(out)
+	for line := 1; scanner.Scan(); line++ {
+		decrypted, err := keyring.DecryptAll(scanner.Text())
+		if err != nil {
+			// the values which cannot be decrypted are written as is
+			failed = true
+			fmt.Fprintf(errOut, "line %d: %v\n", line, err)
+		}
+		if _, err = fmt.Fprintln(w, decrypted); err != nil {
+			return err
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	if failed {
+		return errors.New("some values could not be decrypted")
+	}
+	return nil
+}
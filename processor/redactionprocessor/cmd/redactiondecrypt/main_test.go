@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/encryption"
+)
+
+func TestRun(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(keysFile, []byte(`{"active_key": "k1", "keys": {"k1": "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="}}`), 0o600))
+	keyring, err := encryption.LoadKeyringFile(keysFile)
+	require.NoError(t, err)
+	card, err := keyring.Encrypt("4111 1111 1111 1111")
+	require.NoError(t, err)
+
+	var out, errOut bytes.Buffer
+	in := strings.NewReader("payment with " + card + "\nno secret\n")
+	require.NoError(t, run([]string{"-keys-file", keysFile}, in, &out, &errOut))
+	assert.Equal(t, "payment with 4111 1111 1111 1111\nno secret\n", out.String())
+	assert.Empty(t, errOut.String())
+
+	out.Reset()
+	in = strings.NewReader("first\nsecond enc:k2:AAAA\n")
+	require.EqualError(t, run([]string{"-keys-file", keysFile}, in, &out, &errOut), "some values could not be decrypted")
+	assert.Equal(t, "first\nsecond enc:k2:AAAA\n", out.String())
+	assert.Equal(t, "line 2: unknown key \"k2\"\n", errOut.String())
+
+	require.EqualError(t, run(nil, in, &out, &errOut), "-keys-file is required")
+}
@@ -8,6 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 )
 
 var _ encoding.TextUnmarshaler = (*HashFunction)(nil)
@@ -54,6 +58,19 @@ type Config struct {
 	// blocked span attributes. Values that match are not masked.
 	AllowedValues []string `mapstructure:"allowed_values"`
 
+	// Detectors is a list of built-in detectors of sensitive values, such as
+	// credit card numbers, which are applied to the values of the allowed
+	// attributes and to the log bodies, with the action of each detector.
+	Detectors []DetectorConfig `mapstructure:"detectors"`
+
+	// TokenizationKey is the secret key of the HMAC which derives the tokens
+	// of the values found by the detectors with the tokenize action.
+	TokenizationKey configopaque.String `mapstructure:"tokenization_key"`
+
+	// Encryption configures the keys which encrypt the blocked values instead
+	// of masking them, so that they can be recovered with the same keys.
+	Encryption EncryptionConfig `mapstructure:"encryption"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
 	// the processor adds to the spans when it redacts or masks other
 	// attributes. In some contexts a list of redacted attributes leaks
@@ -62,6 +79,54 @@ type Config struct {
 	Summary string `mapstructure:"summary"`
 }
 
+// EncryptionConfig configures the encryption of the blocked values with AES-GCM.
+// The encryption is enabled when either KeysFile or KeyProvider is set.
+type EncryptionConfig struct {
+	// KeysFile is the path of a JSON keyring file, with the keys by ID and
+	// the ID of the active key which encrypts the values.
+	KeysFile string `mapstructure:"keys_file"`
+
+	// KeyProvider is the ID of an extension which provides the keyring.
+	KeyProvider *component.ID `mapstructure:"key_provider"`
+
+	// ReloadInterval is the interval at which the keyring is reloaded, so
+	// that the keys can be rotated without restarting the collector.
+	// The keyring is never reloaded when it is 0.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+func (c EncryptionConfig) enabled() bool {
+	return c.KeysFile != "" || c.KeyProvider != nil
+}
+
+func (c *Config) Validate() error {
+	var tokenize, encrypt bool
+	for _, d := range c.Detectors {
+		if err := d.validate(); err != nil {
+			return err
+		}
+		tokenize = tokenize || d.Action == ActionTokenize
+		encrypt = encrypt || d.Action == ActionEncrypt
+	}
+	if tokenize && c.TokenizationKey == "" {
+		return errors.New("tokenization_key must be set when a detector uses the tokenize action")
+	}
+
+	if c.Encryption.KeysFile != "" && c.Encryption.KeyProvider != nil {
+		return errors.New("only one of encryption::keys_file and encryption::key_provider can be set")
+	}
+	if c.Encryption.ReloadInterval < 0 {
+		return errors.New("encryption::reload_interval must not be negative")
+	}
+	if c.Encryption.enabled() && c.HashFunction != None {
+		return errors.New("hash_function cannot be set when the encryption is enabled")
+	}
+	if encrypt && !c.Encryption.enabled() {
+		return errors.New("encryption::keys_file or encryption::key_provider must be set when a detector uses the encrypt action")
+	}
+	return nil
+}
+
 func (u HashFunction) String() string {
 	return string(u)
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -41,6 +42,30 @@ func TestLoadConfig(t *testing.T) {
 			id:       component.NewIDWithName(metadata.Type, "empty"),
 			expected: createDefaultConfig(),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "detectors"),
+			expected: &Config{
+				AllowAllKeys: true,
+				Detectors: []DetectorConfig{
+					{Name: DetectorCreditCard, Action: ActionTokenize},
+					{Name: DetectorIBAN, Action: ActionDrop},
+					{Name: DetectorEmail},
+				},
+				TokenizationKey: "secret",
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "encryption"),
+			expected: &Config{
+				AllowAllKeys:  true,
+				BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+				Detectors:     []DetectorConfig{{Name: DetectorEmail, Action: ActionEncrypt}},
+				Encryption: EncryptionConfig{
+					KeysFile:       "/etc/otelcol/redaction-keys.json",
+					ReloadInterval: time.Minute,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -95,3 +120,91 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDetectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *Config
+		expected string
+	}{
+		{
+			name:   "valid",
+			config: &Config{Detectors: []DetectorConfig{{Name: DetectorPhone, Action: ActionHash}, {Name: DetectorJWT}}},
+		},
+		{
+			name:     "unknown detector",
+			config:   &Config{Detectors: []DetectorConfig{{Name: "ssn"}}},
+			expected: `unknown detector "ssn", allowed detectors are credit_card, iban, email, jwt and phone`,
+		},
+		{
+			name:     "unknown action",
+			config:   &Config{Detectors: []DetectorConfig{{Name: DetectorEmail, Action: "encode"}}},
+			expected: `unknown action "encode" for detector "email", allowed actions are mask, hash, drop, tokenize and encrypt`,
+		},
+		{
+			name:     "missing tokenization key",
+			config:   &Config{Detectors: []DetectorConfig{{Name: DetectorEmail, Action: ActionTokenize}}},
+			expected: "tokenization_key must be set when a detector uses the tokenize action",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateEncryption(t *testing.T) {
+	keyProvider := component.MustNewID("keys")
+	tests := []struct {
+		name     string
+		config   *Config
+		expected string
+	}{
+		{
+			name:   "keys file",
+			config: &Config{Encryption: EncryptionConfig{KeysFile: "keys.json", ReloadInterval: time.Minute}},
+		},
+		{
+			name:   "key provider",
+			config: &Config{Encryption: EncryptionConfig{KeyProvider: &keyProvider}},
+		},
+		{
+			name:     "keys file and key provider",
+			config:   &Config{Encryption: EncryptionConfig{KeysFile: "keys.json", KeyProvider: &keyProvider}},
+			expected: "only one of encryption::keys_file and encryption::key_provider can be set",
+		},
+		{
+			name:     "negative reload interval",
+			config:   &Config{Encryption: EncryptionConfig{KeysFile: "keys.json", ReloadInterval: -time.Second}},
+			expected: "encryption::reload_interval must not be negative",
+		},
+		{
+			name:     "hash function",
+			config:   &Config{HashFunction: SHA3, Encryption: EncryptionConfig{KeysFile: "keys.json"}},
+			expected: "hash_function cannot be set when the encryption is enabled",
+		},
+		{
+			name:     "encrypt action without keys",
+			config:   &Config{Detectors: []DetectorConfig{{Name: DetectorEmail, Action: ActionEncrypt}}},
+			expected: "encryption::keys_file or encryption::key_provider must be set when a detector uses the encrypt action",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
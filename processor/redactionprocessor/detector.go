@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// DetectorAction is the action applied to the values found by a detector.
+type DetectorAction string
+
+const (
+	// ActionMask replaces the detected values with a fixed string.
+	ActionMask DetectorAction = "mask"
+	// ActionHash replaces the detected values with their hash.
+	ActionHash DetectorAction = "hash"
+	// ActionDrop removes the attribute or the log body containing a detected value.
+	ActionDrop DetectorAction = "drop"
+	// ActionEncrypt replaces the detected values with their encryption.
+	ActionEncrypt DetectorAction = "encrypt"
+	// ActionTokenize replaces the detected values with a token of the same format,
+	// derived from the values with a keyed HMAC.
+	ActionTokenize DetectorAction = "tokenize"
+)
+
+// The names of the built-in detectors.
+const (
+	DetectorCreditCard = "credit_card"
+	DetectorIBAN       = "iban"
+	DetectorEmail      = "email"
+	DetectorJWT        = "jwt"
+	DetectorPhone      = "phone"
+)
+
+// DetectorConfig configures a built-in detector of sensitive values.
+type DetectorConfig struct {
+	// Name is the name of the detector, one of credit_card, iban, email, jwt and phone.
+	Name string `mapstructure:"name"`
+
+	// Action is the action applied to the detected values, one of mask, hash,
+	// drop, tokenize and encrypt. Defaults to mask.
+	Action DetectorAction `mapstructure:"action"`
+}
+
+type builtinDetector struct {
+	pattern *regexp.Regexp
+	// validate filters out the matches of the pattern which are not valid values, if set
+	validate func(string) bool
+}
+
+var builtinDetectors = map[string]builtinDetector{
+	DetectorCreditCard: {
+		pattern:  regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		validate: isValidCreditCard,
+	},
+	DetectorIBAN: {
+		pattern:  regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`),
+		validate: isValidIBAN,
+	},
+	DetectorEmail: {
+		pattern: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}\b`),
+	},
+	DetectorJWT: {
+		pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	},
+	DetectorPhone: {
+		pattern: regexp.MustCompile(
+			`\+\d{1,3}(?:[ .-]?\(?\d{1,4}\)?){1,2}(?:[ .-]?\d{2,4}){1,4}\b` +
+				`|\(\d{3}\) ?\d{3}[ .-]\d{4}\b` +
+				`|\b\d{3}[.-]\d{3}[.-]\d{4}\b`),
+		validate: isValidPhone,
+	},
+}
+
+func (c DetectorConfig) validate() error {
+	if _, ok := builtinDetectors[c.Name]; !ok {
+		return fmt.Errorf("unknown detector %q, allowed detectors are %s, %s, %s, %s and %s",
+			c.Name, DetectorCreditCard, DetectorIBAN, DetectorEmail, DetectorJWT, DetectorPhone)
+	}
+	switch c.Action {
+	case "", ActionMask, ActionHash, ActionDrop, ActionTokenize, ActionEncrypt:
+		return nil
+	}
+	return fmt.Errorf("unknown action %q for detector %q, allowed actions are %s, %s, %s, %s and %s",
+		c.Action, c.Name, ActionMask, ActionHash, ActionDrop, ActionTokenize, ActionEncrypt)
+}
+
+type detector struct {
+	builtinDetector
+	action DetectorAction
+}
+
+func makeDetectors(c *Config) []detector {
+	detectors := make([]detector, 0, len(c.Detectors))
+	for _, dc := range c.Detectors {
+		action := dc.Action
+		if action == "" {
+			action = ActionMask
+		}
+		detectors = append(detectors, detector{builtinDetector: builtinDetectors[dc.Name], action: action})
+	}
+	return detectors
+}
+
+// detect applies the detectors to a value. It returns the redacted value, and reports whether
+// the value must be dropped and whether any sensitive value was found.
+func (s *redaction) detect(val string) (redacted string, drop bool, found bool) {
+	for _, d := range s.detectors {
+		val = d.pattern.ReplaceAllStringFunc(val, func(match string) string {
+			if drop || (d.validate != nil && !d.validate(match)) {
+				return match
+			}
+			found = true
+			switch d.action {
+			case ActionDrop:
+				drop = true
+				return match
+			case ActionHash:
+				hashFunction := s.hashFunction
+				if hashFunction == None {
+					hashFunction = SHA3
+				}
+				return hashValue(match, hashFunction)
+			case ActionTokenize:
+				return tokenize(s.tokenizationKey, match)
+			case ActionEncrypt:
+				return s.encryptValue(match)
+			default:
+				return "****"
+			}
+		})
+		if drop {
+			return "", true, true
+		}
+	}
+	return val, false, found
+}
+
+// tokenize replaces the digits and the ASCII letters of a value with other digits and letters,
+// derived from the value with a HMAC-SHA256 keyed with the given key. The other characters are kept,
+// so that the token has the same format as the value, and the same value always has the same token.
+func tokenize(key []byte, val string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(val))
+	stream := mac.Sum(nil)
+
+	var b strings.Builder
+	b.Grow(len(val))
+	var i int
+	for _, r := range val {
+		var base rune
+		var size byte
+		switch {
+		case r >= '0' && r <= '9':
+			base, size = '0', 10
+		case r >= 'a' && r <= 'z':
+			base, size = 'a', 26
+		case r >= 'A' && r <= 'Z':
+			base, size = 'A', 26
+		default:
+			b.WriteRune(r)
+			continue
+		}
+		if i == len(stream) {
+			// extend the stream for values longer than the digest
+			mac.Reset()
+			mac.Write(stream[len(stream)-sha256.Size:])
+			stream = mac.Sum(stream)
+		}
+		b.WriteRune(base + rune(stream[i]%size))
+		i++
+	}
+	return b.String()
+}
+
+func digits(val string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, val)
+}
+
+// isValidCreditCard checks the length and the Luhn checksum of a card number.
+func isValidCreditCard(val string) bool {
+	number := digits(val)
+	if len(number) < 13 || len(number) > 19 {
+		return false
+	}
+	var sum int
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// isValidIBAN checks the length and the ISO 7064 MOD 97-10 checksum of an IBAN.
+func isValidIBAN(val string) bool {
+	iban := strings.ReplaceAll(val, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	// the first four characters are moved to the end, and the letters are replaced with numbers
+	var numeric strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			fmt.Fprintf(&numeric, "%d", r-'A'+10)
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	return ok && n.Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// isValidPhone checks that a phone number has as many digits as an E.164 number.
+func isValidPhone(val string) bool {
+	n := len(digits(val))
+	return n >= 7 && n <= 15
+}
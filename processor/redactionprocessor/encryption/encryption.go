@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package encryption implements the reversible encryption of the values redacted by the
+// redaction processor, and their decryption by the processes allowed to recover them.
+package encryption // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/encryption"
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Prefix is the prefix of the encrypted values, which are formatted as
+// "enc:<key ID>:<base64url encoded nonce and ciphertext>".
+const Prefix = "enc:"
+
+var (
+	keyIDRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	tokenRegex = regexp.MustCompile(`enc:[A-Za-z0-9._-]+:[A-Za-z0-9_-]+`)
+)
+
+// KeyProvider is implemented by the extensions which provide the encryption keys to the redaction processor.
+// Keyring is called when the processor starts and every reload interval, so that the keys can be rotated.
+type KeyProvider interface {
+	Keyring(ctx context.Context) (*Keyring, error)
+}
+
+// Keyring is a set of AES keys identified by their ID. Values are encrypted with the active key,
+// and decrypted with the key whose ID is embedded in the encrypted value, so that the values
+// encrypted before a rotation can still be decrypted as long as their key is in the keyring.
+type Keyring struct {
+	activeKeyID string
+	aeads       map[string]cipher.AEAD
+}
+
+// KeyringFile is the content of a keyring file, a JSON document such as:
+//
+//	{"active_key": "2024-06", "keys": {"2024-01": "<base64 key>", "2024-06": "<base64 key>"}}
+type KeyringFile struct {
+	// ActiveKey is the ID of the key used to encrypt values.
+	ActiveKey string `json:"active_key"`
+	// Keys are the base64 encoded keys of 16, 24 or 32 bytes, by ID.
+	Keys map[string]string `json:"keys"`
+}
+
+// NewKeyring creates a keyring from AES keys of 16, 24 or 32 bytes, by ID.
+// The active key is used to encrypt values, and can be empty for a keyring which only decrypts values.
+func NewKeyring(activeKeyID string, keys map[string][]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no keys")
+	}
+	k := &Keyring{
+		activeKeyID: activeKeyID,
+		aeads:       make(map[string]cipher.AEAD, len(keys)),
+	}
+	for id, key := range keys {
+		if !keyIDRegex.MatchString(id) {
+			return nil, fmt.Errorf("invalid key ID %q, must only contain letters, digits, '.', '_' and '-'", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", id, err)
+		}
+		k.aeads[id] = aead
+	}
+	if _, ok := k.aeads[activeKeyID]; activeKeyID != "" && !ok {
+		return nil, fmt.Errorf("active key %q not found", activeKeyID)
+	}
+	return k, nil
+}
+
+// ParseKeyring parses the JSON content of a keyring file.
+func ParseKeyring(data []byte) (*Keyring, error) {
+	var f KeyringFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse keyring: %w", err)
+	}
+	keys := make(map[string][]byte, len(f.Keys))
+	for id, encoded := range f.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode key %q: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return NewKeyring(f.ActiveKey, keys)
+}
+
+// LoadKeyringFile loads a keyring from a JSON file.
+func LoadKeyringFile(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring file: %w", err)
+	}
+	return ParseKeyring(data)
+}
+
+// ActiveKeyID returns the ID of the key used to encrypt values.
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeKeyID
+}
+
+// Encrypt encrypts a value with AES-GCM and the active key. The key ID is authenticated
+// along with the value, and embedded in the encrypted value.
+func (k *Keyring) Encrypt(value string) (string, error) {
+	aead, ok := k.aeads[k.activeKeyID]
+	if !ok {
+		return "", errors.New("no active key")
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(k.activeKeyID))
+	return Prefix + k.activeKeyID + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value encrypted by Encrypt, with the key whose ID is embedded in the value.
+func (k *Keyring) Decrypt(encrypted string) (string, error) {
+	keyID, payload, ok := strings.Cut(strings.TrimPrefix(encrypted, Prefix), ":")
+	if !ok || !strings.HasPrefix(encrypted, Prefix) {
+		return "", errors.New("not an encrypted value")
+	}
+	aead, ok := k.aeads[keyID]
+	if !ok {
+		return "", fmt.Errorf("unknown key %q", keyID)
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode encrypted value: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("encrypted value is too short")
+	}
+	value, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(keyID))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value with key %q: %w", keyID, err)
+	}
+	return string(value), nil
+}
+
+// DecryptAll decrypts all the encrypted values found in a text, such as an attribute value
+// of which only some parts were encrypted.
+func (k *Keyring) DecryptAll(text string) (string, error) {
+	var errs []error
+	decrypted := tokenRegex.ReplaceAllStringFunc(text, func(encrypted string) string {
+		value, err := k.Decrypt(encrypted)
+		if err != nil {
+			errs = append(errs, err)
+			return encrypted
+		}
+		return value
+	})
+	return decrypted, errors.Join(errs...)
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	key1 = bytes.Repeat([]byte{1}, 32)
+	key2 = bytes.Repeat([]byte{2}, 16)
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	keyring, err := NewKeyring("k1", map[string][]byte{"k1": key1})
+	require.NoError(t, err)
+	assert.Equal(t, "k1", keyring.ActiveKeyID())
+
+	encrypted, err := keyring.Encrypt("4111 1111 1111 1111")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "enc:k1:"))
+	assert.NotContains(t, encrypted, "4111")
+
+	// the same value is encrypted differently every time
+	other, err := keyring.Encrypt("4111 1111 1111 1111")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, other)
+
+	decrypted, err := keyring.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "4111 1111 1111 1111", decrypted)
+}
+
+func TestRotation(t *testing.T) {
+	before, err := NewKeyring("k1", map[string][]byte{"k1": key1})
+	require.NoError(t, err)
+	encrypted, err := before.Encrypt("secret")
+	require.NoError(t, err)
+
+	after, err := NewKeyring("k2", map[string][]byte{"k1": key1, "k2": key2})
+	require.NoError(t, err)
+	rotated, err := after.Encrypt("secret")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rotated, "enc:k2:"))
+
+	for _, e := range []string{encrypted, rotated} {
+		decrypted, err := after.Decrypt(e)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", decrypted)
+	}
+
+	_, err = before.Decrypt(rotated)
+	assert.EqualError(t, err, `unknown key "k2"`)
+}
+
+func TestDecryptErrors(t *testing.T) {
+	keyring, err := NewKeyring("k1", map[string][]byte{"k1": key1, "k2": key2})
+	require.NoError(t, err)
+	encrypted, err := keyring.Encrypt("secret")
+	require.NoError(t, err)
+
+	_, err = keyring.Decrypt("secret")
+	assert.EqualError(t, err, "not an encrypted value")
+	_, err = keyring.Decrypt("enc:k1:!!")
+	assert.ErrorContains(t, err, "failed to decode encrypted value")
+	_, err = keyring.Decrypt("enc:k1:AAAA")
+	assert.EqualError(t, err, "encrypted value is too short")
+
+	// the key ID is authenticated
+	_, err = keyring.Decrypt(strings.Replace(encrypted, "enc:k1:", "enc:k2:", 1))
+	assert.ErrorContains(t, err, `failed to decrypt value with key "k2"`)
+}
+
+func TestDecryptAll(t *testing.T) {
+	keyring, err := NewKeyring("k1", map[string][]byte{"k1": key1})
+	require.NoError(t, err)
+	card, err := keyring.Encrypt("4111 1111 1111 1111")
+	require.NoError(t, err)
+	email, err := keyring.Encrypt("jane@example.com")
+	require.NoError(t, err)
+
+	decrypted, err := keyring.DecryptAll("card " + card + " of " + email + ".")
+	require.NoError(t, err)
+	assert.Equal(t, "card 4111 1111 1111 1111 of jane@example.com.", decrypted)
+
+	decrypted, err = keyring.DecryptAll("card enc:k3:AAAA of " + email)
+	assert.EqualError(t, err, `unknown key "k3"`)
+	assert.Equal(t, "card enc:k3:AAAA of jane@example.com", decrypted)
+}
+
+func TestNewKeyringErrors(t *testing.T) {
+	_, err := NewKeyring("k1", nil)
+	assert.EqualError(t, err, "no keys")
+	_, err = NewKeyring("k:1", map[string][]byte{"k:1": key1})
+	assert.ErrorContains(t, err, `invalid key ID "k:1"`)
+	_, err = NewKeyring("k1", map[string][]byte{"k1": []byte("short")})
+	assert.ErrorContains(t, err, `invalid key "k1"`)
+	_, err = NewKeyring("k2", map[string][]byte{"k1": key1})
+	assert.EqualError(t, err, `active key "k2" not found`)
+
+	// a keyring without an active key only decrypts values
+	keyring, err := NewKeyring("", map[string][]byte{"k1": key1})
+	require.NoError(t, err)
+	_, err = keyring.Encrypt("secret")
+	assert.EqualError(t, err, "no active key")
+}
+
+func TestLoadKeyringFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+  "active_key": "k2",
+  "keys": {
+    "k1": "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=",
+    "k2": "AgICAgICAgICAgICAgICAg=="
+  }
+}`), 0o600))
+
+	keyring, err := LoadKeyringFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", keyring.ActiveKeyID())
+
+	old, err := NewKeyring("k1", map[string][]byte{"k1": key1})
+	require.NoError(t, err)
+	encrypted, err := old.Encrypt("secret")
+	require.NoError(t, err)
+	decrypted, err := keyring.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	_, err = LoadKeyringFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read keyring file")
+	_, err = ParseKeyring([]byte(`{`))
+	assert.ErrorContains(t, err, "failed to parse keyring")
+	_, err = ParseKeyring([]byte(`{"active_key": "k1", "keys": {"k1": "!"}}`))
+	assert.ErrorContains(t, err, `failed to decode key "k1"`)
+}
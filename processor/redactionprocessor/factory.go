@@ -53,7 +53,9 @@ func createTracesProcessor(
 		cfg,
 		next,
 		redaction.processTraces,
-		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+		processorhelper.WithStart(redaction.start),
+		processorhelper.WithShutdown(redaction.shutdown))
 }
 
 // createLogsProcessor creates an instance of redaction for processing logs
@@ -76,7 +78,9 @@ func createLogsProcessor(
 		cfg,
 		next,
 		red.processLogs,
-		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+		processorhelper.WithStart(red.start),
+		processorhelper.WithShutdown(red.shutdown))
 }
 
 // createMetricsProcessor creates an instance of redaction for processing metrics
@@ -99,5 +103,7 @@ func createMetricsProcessor(
 		cfg,
 		next,
 		red.processMetrics,
-		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+		processorhelper.WithStart(red.start),
+		processorhelper.WithShutdown(red.shutdown))
 }
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/encryption"
+)
+
+// start loads the encryption keyring, and starts reloading it when a reload interval is set.
+func (s *redaction) start(ctx context.Context, host component.Host) error {
+	cfg := s.config.Encryption
+	switch {
+	case cfg.KeysFile != "":
+		s.loadKeyring = func(context.Context) (*encryption.Keyring, error) {
+			return encryption.LoadKeyringFile(cfg.KeysFile)
+		}
+	case cfg.KeyProvider != nil:
+		ext, ok := host.GetExtensions()[*cfg.KeyProvider]
+		if !ok {
+			return fmt.Errorf("key provider extension %q not found", cfg.KeyProvider)
+		}
+		provider, ok := ext.(encryption.KeyProvider)
+		if !ok {
+			return fmt.Errorf("extension %q is not a key provider", cfg.KeyProvider)
+		}
+		s.loadKeyring = provider.Keyring
+	default:
+		return nil
+	}
+
+	keyring, err := s.loadEncryptionKeyring(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load the encryption keys: %w", err)
+	}
+	s.keyring.Store(keyring)
+
+	if cfg.ReloadInterval > 0 {
+		s.done = make(chan struct{})
+		s.wg.Add(1)
+		go s.reloadKeyring(cfg.ReloadInterval)
+	}
+	return nil
+}
+
+func (s *redaction) shutdown(context.Context) error {
+	if s.done != nil {
+		close(s.done)
+		s.wg.Wait()
+	}
+	return nil
+}
+
+// loadEncryptionKeyring loads the keyring and checks that it has an active key to encrypt the values.
+func (s *redaction) loadEncryptionKeyring(ctx context.Context) (*encryption.Keyring, error) {
+	keyring, err := s.loadKeyring(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if keyring.ActiveKeyID() == "" {
+		return nil, errors.New("the keyring has no active key")
+	}
+	return keyring, nil
+}
+
+// reloadKeyring reloads the keyring periodically. The previous keyring is kept when it cannot be reloaded.
+func (s *redaction) reloadKeyring(interval time.Duration) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			keyring, err := s.loadEncryptionKeyring(context.Background())
+			if err != nil {
+				s.logger.Error("failed to reload the encryption keys, keeping the previous ones", zap.Error(err))
+				continue
+			}
+			if previous := s.keyring.Swap(keyring); previous.ActiveKeyID() != keyring.ActiveKeyID() {
+				s.logger.Info("rotated the encryption key", zap.String("key_id", keyring.ActiveKeyID()))
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// encryptValue encrypts a value with the active key. The value is masked when it cannot be encrypted.
+func (s *redaction) encryptValue(val string) string {
+	keyring := s.keyring.Load()
+	if keyring == nil {
+		s.logger.Error("no encryption keys loaded, masking the value")
+		return "****"
+	}
+	encrypted, err := keyring.Encrypt(val)
+	if err != nil {
+		s.logger.Error("failed to encrypt the value, masking it", zap.Error(err))
+		return "****"
+	}
+	return encrypted
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/encryption"
+)
+
+const (
+	testKeyring        = `{"active_key": "k1", "keys": {"k1": "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="}}`
+	testRotatedKeyring = `{"active_key": "k2", "keys": {"k1": "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=", "k2": "AgICAgICAgICAgICAgICAg=="}}`
+	testDecryptKeyring = `{"keys": {"k1": "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="}}`
+)
+
+func writeKeyring(t *testing.T, path string, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestEncryptBlockedValues(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys.json")
+	writeKeyring(t, keysFile, testKeyring)
+
+	config := &Config{
+		AllowAllKeys:       true,
+		BlockedValues:      []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		BlockedKeyPatterns: []string{".*token.*"},
+		Detectors:          []DetectorConfig{{Name: DetectorEmail, Action: ActionEncrypt}},
+		Encryption:         EncryptionConfig{KeysFile: keysFile},
+	}
+	require.NoError(t, config.Validate())
+	processor, err := newRedaction(context.TODO(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, processor.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, processor.shutdown(context.Background())) }()
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr("card", "card 4111111111111111")
+	attrs.PutStr("api_token", "abc")
+	attrs.PutStr("user", "jane@example.com")
+	processor.processAttrs(context.TODO(), attrs)
+
+	keyring, err := encryption.LoadKeyringFile(keysFile)
+	require.NoError(t, err)
+	for key, expected := range map[string]string{
+		"card":      "card 4111111111111111",
+		"api_token": "abc",
+		"user":      "jane@example.com",
+	} {
+		val, ok := attrs.Get(key)
+		require.True(t, ok)
+		assert.Contains(t, val.Str(), "enc:k1:")
+		decrypted, err := keyring.DecryptAll(val.Str())
+		require.NoError(t, err)
+		assert.Equal(t, expected, decrypted)
+	}
+}
+
+func TestEncryptWithoutKeyring(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"secret"},
+		Encryption:    EncryptionConfig{KeysFile: "keys.json"},
+	}
+	processor, err := newRedaction(context.TODO(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	// values are masked when they cannot be encrypted
+	attrs := pcommon.NewMap()
+	attrs.PutStr("password", "secret")
+	processor.processAttrs(context.TODO(), attrs)
+	val, _ := attrs.Get("password")
+	assert.Equal(t, "****", val.Str())
+
+	err = processor.start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "failed to load the encryption keys")
+}
+
+func TestKeyringWithoutActiveKey(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys.json")
+	writeKeyring(t, keysFile, testDecryptKeyring)
+
+	config := &Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"secret"},
+		Encryption:    EncryptionConfig{KeysFile: keysFile},
+	}
+	processor, err := newRedaction(context.TODO(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	err = processor.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "failed to load the encryption keys: the keyring has no active key")
+}
+
+func TestReloadKeyring(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "keys.json")
+	writeKeyring(t, keysFile, testKeyring)
+
+	config := &Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"secret"},
+		Encryption:    EncryptionConfig{KeysFile: keysFile, ReloadInterval: 10 * time.Millisecond},
+	}
+	processor, err := newRedaction(context.TODO(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, processor.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, processor.shutdown(context.Background())) }()
+	assert.True(t, strings.HasPrefix(processor.encryptValue("secret"), "enc:k1:"))
+
+	// the previous keyring is kept while the file is invalid
+	writeKeyring(t, keysFile, "{")
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, strings.HasPrefix(processor.encryptValue("secret"), "enc:k1:"))
+
+	// and while it has no active key
+	writeKeyring(t, keysFile, testDecryptKeyring)
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, strings.HasPrefix(processor.encryptValue("secret"), "enc:k1:"))
+
+	writeKeyring(t, keysFile, testRotatedKeyring)
+	assert.Eventually(t, func() bool {
+		return strings.HasPrefix(processor.encryptValue("secret"), "enc:k2:")
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+type fakeKeyProvider struct {
+	component.StartFunc
+	component.ShutdownFunc
+	keyring *encryption.Keyring
+}
+
+func (p *fakeKeyProvider) Keyring(context.Context) (*encryption.Keyring, error) {
+	return p.keyring, nil
+}
+
+type otherExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type fakeHost struct {
+	extensions map[component.ID]component.Component
+}
+
+func (h *fakeHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestKeyProvider(t *testing.T) {
+	keyring, err := encryption.ParseKeyring([]byte(testKeyring))
+	require.NoError(t, err)
+	providerID := component.MustNewID("keys")
+	otherID := component.MustNewID("other")
+	host := &fakeHost{extensions: map[component.ID]component.Component{
+		providerID: &fakeKeyProvider{keyring: keyring},
+		otherID:    &otherExtension{},
+	}}
+
+	processor, err := newRedaction(context.TODO(), &Config{Encryption: EncryptionConfig{KeyProvider: &providerID}}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, processor.start(context.Background(), host))
+	encrypted := processor.encryptValue("secret")
+	decrypted, err := keyring.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+	require.NoError(t, processor.shutdown(context.Background()))
+
+	processor, err = newRedaction(context.TODO(), &Config{Encryption: EncryptionConfig{KeyProvider: &otherID}}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.EqualError(t, processor.start(context.Background(), host), `extension "other" is not a key provider`)
+
+	missingID := component.MustNewID("missing")
+	processor, err = newRedaction(context.TODO(), &Config{Encryption: EncryptionConfig{KeyProvider: &missingID}}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.EqualError(t, processor.start(context.Background(), host), `key provider extension "missing" not found`)
+}
@@ -14,6 +14,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -21,6 +23,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/sha3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/encryption"
 )
 
 const attrValuesSeparator = ","
@@ -38,6 +42,18 @@ type redaction struct {
 	blockKeyRegexList map[string]*regexp.Regexp
 	// Hash function to hash blocked values
 	hashFunction HashFunction
+	// Built-in detectors of sensitive values
+	detectors []detector
+	// Key of the HMAC deriving the tokens of detected values
+	tokenizationKey []byte
+	// Whether to encrypt blocked values instead of masking them
+	encrypt bool
+	// Keyring encrypting the blocked values, and the function loading it
+	keyring     atomic.Pointer[encryption.Keyring]
+	loadKeyring func(context.Context) (*encryption.Keyring, error)
+	// Stops reloading the keyring
+	done chan struct{}
+	wg   sync.WaitGroup
 	// Redaction processor configuration
 	config *Config
 	// Logger
@@ -72,6 +88,9 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*red
 		allowRegexList:    allowRegexList,
 		blockKeyRegexList: blockKeysRegexList,
 		hashFunction:      config.HashFunction,
+		detectors:         makeDetectors(config),
+		tokenizationKey:   []byte(config.TokenizationKey),
+		encrypt:           config.Encryption.enabled(),
 		config:            config,
 		logger:            logger,
 	}, nil
@@ -144,6 +163,7 @@ func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs
 		for k := 0; k < ils.LogRecords().Len(); k++ {
 			log := ils.LogRecords().At(k)
 			s.processAttrs(ctx, log.Attributes())
+			s.processLogBody(ctx, log)
 		}
 	}
 }
@@ -241,8 +261,23 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 			}
 		}
 
-		// Mask any blocked values for the other attributes
+		// Apply the detectors of sensitive values
 		var matched bool
+		if len(s.detectors) > 0 {
+			redacted, drop, found := s.detect(strVal)
+			if drop {
+				toDelete = append(toDelete, k)
+				return true
+			}
+			if found {
+				matched = true
+				toBlock = append(toBlock, k)
+				value.SetStr(redacted)
+				strVal = redacted
+			}
+		}
+
+		// Mask any blocked values for the other attributes
 		for _, compiledRE := range s.blockRegexList {
 			if compiledRE.MatchString(strVal) {
 				if !matched {
@@ -269,23 +304,73 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	s.addMetaAttrs(ignoring, attributes, "", ignoredKeyCount)
 }
 
-//nolint:gosec
+// processLogBody applies the detectors of sensitive values to the string values of a log body
+func (s *redaction) processLogBody(_ context.Context, log plog.LogRecord) {
+	if len(s.detectors) == 0 {
+		return
+	}
+
+	drop, found := s.detectInValue(log.Body())
+	switch {
+	case drop:
+		log.Body().SetStr("")
+		s.addMetaAttrs([]string{bodyKey}, log.Attributes(), redactedKeys, redactedKeyCount)
+	case found:
+		s.addMetaAttrs([]string{bodyKey}, log.Attributes(), maskedValues, maskedValueCount)
+	}
+}
+
+// detectInValue applies the detectors to a string value, or to the string values nested in a map or
+// a slice. The entries of maps and slices are removed when they must be dropped.
+func (s *redaction) detectInValue(value pcommon.Value) (drop bool, found bool) {
+	switch value.Type() {
+	case pcommon.ValueTypeStr:
+		redacted, drop, found := s.detect(value.Str())
+		if found && !drop {
+			value.SetStr(redacted)
+		}
+		return drop, found
+	case pcommon.ValueTypeMap:
+		value.Map().RemoveIf(func(_ string, v pcommon.Value) bool {
+			drop, foundInValue := s.detectInValue(v)
+			found = found || foundInValue
+			return drop
+		})
+	case pcommon.ValueTypeSlice:
+		value.Slice().RemoveIf(func(v pcommon.Value) bool {
+			drop, foundInValue := s.detectInValue(v)
+			found = found || foundInValue
+			return drop
+		})
+	}
+	return false, found
+}
+
 func (s *redaction) maskValue(val string, regex *regexp.Regexp) string {
 	hashFunc := func(match string) string {
-		switch s.hashFunction {
-		case SHA1:
-			return hashString(match, sha1.New())
-		case SHA3:
-			return hashString(match, sha3.New256())
-		case MD5:
-			return hashString(match, md5.New())
-		default:
+		if s.encrypt {
+			return s.encryptValue(match)
+		}
+		if s.hashFunction == None {
 			return "****"
 		}
+		return hashValue(match, s.hashFunction)
 	}
 	return regex.ReplaceAllStringFunc(val, hashFunc)
 }
 
+//nolint:gosec
+func hashValue(val string, hashFunction HashFunction) string {
+	switch hashFunction {
+	case SHA1:
+		return hashString(val, sha1.New())
+	case MD5:
+		return hashString(val, md5.New())
+	default:
+		return hashString(val, sha3.New256())
+	}
+}
+
 func hashString(input string, hasher hash.Hash) string {
 	hasher.Write([]byte(input))
 	return hex.EncodeToString(hasher.Sum(nil))
@@ -324,6 +409,8 @@ const (
 	allowedValues     = "redaction.allowed.keys"
 	allowedValueCount = "redaction.allowed.count"
 	ignoredKeyCount   = "redaction.ignored.count"
+	// bodyKey is the key listed in the summary when a log body is redacted or masked
+	bodyKey = "body"
 )
 
 // makeAllowList sets up a lookup table of allowed span attribute keys
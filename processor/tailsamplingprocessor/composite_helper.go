@@ -13,6 +13,7 @@ import (
 func getNewCompositePolicy(settings component.TelemetrySettings, config *CompositeCfg) (sampling.PolicyEvaluator, error) {
 	subPolicyEvalParams := make([]sampling.SubPolicyEvalParams, len(config.SubPolicyCfg))
 	rateAllocationsMap := getRateAllocationMap(config)
+	byteRateAllocationsMap := getByteRateAllocationMap(config)
 	for i := range config.SubPolicyCfg {
 		policyCfg := &config.SubPolicyCfg[i]
 		policy, err := getCompositeSubPolicyEvaluator(settings, policyCfg)
@@ -24,23 +25,32 @@ func getNewCompositePolicy(settings component.TelemetrySettings, config *Composi
 			Evaluator:         policy,
 			MaxSpansPerSecond: int64(rateAllocationsMap[policyCfg.Name]),
 			Name:              policyCfg.Name,
+			MaxBytesPerSecond: int64(byteRateAllocationsMap[policyCfg.Name]),
 		}
 		subPolicyEvalParams[i] = evalParams
 	}
-	return sampling.NewComposite(settings.Logger, config.MaxTotalSpansPerSecond, subPolicyEvalParams, sampling.MonotonicClock{}, telemetry.IsRecordPolicyEnabled()), nil
+	return sampling.NewComposite(settings.Logger, config.MaxTotalSpansPerSecond, config.MaxTotalBytesPerSecond, subPolicyEvalParams, sampling.MonotonicClock{}, telemetry.IsRecordPolicyEnabled()), nil
 }
 
 // Apply rate allocations to the sub-policies
 func getRateAllocationMap(config *CompositeCfg) map[string]float64 {
+	return allocateRate(config, float64(config.MaxTotalSpansPerSecond))
+}
+
+// Apply rate allocations to the byte budget of the sub-policies
+func getByteRateAllocationMap(config *CompositeCfg) map[string]float64 {
+	return allocateRate(config, float64(config.MaxTotalBytesPerSecond))
+}
+
+func allocateRate(config *CompositeCfg, maxTotal float64) map[string]float64 {
 	rateAllocationsMap := make(map[string]float64)
-	maxTotalSPS := float64(config.MaxTotalSpansPerSecond)
-	// Default SPS determined by equally diving number of sub policies
-	defaultSPS := maxTotalSPS / float64(len(config.SubPolicyCfg))
+	// Default rate determined by equally diving number of sub policies
+	defaultRate := maxTotal / float64(len(config.SubPolicyCfg))
 	for _, rAlloc := range config.RateAllocation {
 		if rAlloc.Percent > 0 {
-			rateAllocationsMap[rAlloc.Policy] = (float64(rAlloc.Percent) / 100) * maxTotalSPS
+			rateAllocationsMap[rAlloc.Policy] = (float64(rAlloc.Percent) / 100) * maxTotal
 		} else {
-			rateAllocationsMap[rAlloc.Policy] = defaultSPS
+			rateAllocationsMap[rAlloc.Policy] = defaultRate
 		}
 	}
 	return rateAllocationsMap
@@ -48,7 +48,7 @@ func TestCompositeHelper(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		expected := sampling.NewComposite(zap.NewNop(), 1000, []sampling.SubPolicyEvalParams{
+		expected := sampling.NewComposite(zap.NewNop(), 1000, 0, []sampling.SubPolicyEvalParams{
 			{
 				Evaluator:         sampling.NewLatency(componenttest.NewNopTelemetrySettings(), 100, 0),
 				MaxSpansPerSecond: 250,
@@ -63,6 +63,29 @@ func TestCompositeHelper(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("bytes per second allocation", func(t *testing.T) {
+		config := &CompositeCfg{
+			MaxTotalSpansPerSecond: 1000,
+			MaxTotalBytesPerSecond: 4000,
+			SubPolicyCfg: []CompositeSubPolicyCfg{
+				{sharedPolicyCfg: sharedPolicyCfg{Name: "test-composite-policy-1", Type: AlwaysSample}},
+				{sharedPolicyCfg: sharedPolicyCfg{Name: "test-composite-policy-2", Type: AlwaysSample}},
+			},
+			RateAllocation: []RateAllocationCfg{
+				{Policy: "test-composite-policy-1", Percent: 25},
+				{Policy: "test-composite-policy-2"},
+			},
+		}
+		assert.Equal(t, map[string]float64{
+			"test-composite-policy-1": 250,
+			"test-composite-policy-2": 500,
+		}, getRateAllocationMap(config))
+		assert.Equal(t, map[string]float64{
+			"test-composite-policy-1": 1000,
+			"test-composite-policy-2": 2000,
+		}, getByteRateAllocationMap(config))
+	})
+
 	t.Run("unsupported sampling policy type", func(t *testing.T) {
 		_, err := getNewCompositePolicy(componenttest.NewNopTelemetrySettings(), &CompositeCfg{
 			SubPolicyCfg: []CompositeSubPolicyCfg{
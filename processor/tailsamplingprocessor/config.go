@@ -4,8 +4,15 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/confmap"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -43,6 +50,16 @@ const (
 	// OTTLCondition sample traces which match user provided OpenTelemetry Transformation Language
 	// conditions.
 	OTTLCondition PolicyType = "ottl_condition"
+	// Adaptive samples the traces of each service with the probability needed to keep
+	// its throughput close to a target rate.
+	Adaptive PolicyType = "adaptive"
+)
+
+const (
+	// defaultAdaptiveKey is the resource attribute used by the adaptive policy when none is configured.
+	defaultAdaptiveKey = "service.name"
+	// defaultAdaptiveWindow is the sliding window used by the adaptive policy when none is configured.
+	defaultAdaptiveWindow = 30 * time.Second
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -72,6 +89,8 @@ type sharedPolicyCfg struct {
 	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
 	// Configs for OTTL condition filter sampling policy evaluator
 	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
+	// Configs for adaptive sampling policy evaluator.
+	AdaptiveCfg AdaptiveCfg `mapstructure:"adaptive"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -103,7 +122,11 @@ type AndCfg struct {
 // CompositeCfg holds the configurable settings to create a composite
 // sampling policy evaluator.
 type CompositeCfg struct {
-	MaxTotalSpansPerSecond int64                   `mapstructure:"max_total_spans_per_second"`
+	MaxTotalSpansPerSecond int64 `mapstructure:"max_total_spans_per_second"`
+	// MaxTotalBytesPerSecond limits the size of the traces sampled each second, using their
+	// protobuf encoded size. The budget is allocated to the sub-policies with the same
+	// rate_allocation as the spans per second. Disabled when zero.
+	MaxTotalBytesPerSecond int64                   `mapstructure:"max_total_bytes_per_second"`
 	PolicyOrder            []string                `mapstructure:"policy_order"`
 	SubPolicyCfg           []CompositeSubPolicyCfg `mapstructure:"composite_sub_policy"`
 	RateAllocation         []RateAllocationCfg     `mapstructure:"rate_allocation"`
@@ -192,6 +215,23 @@ type StringAttributeCfg struct {
 type RateLimitingCfg struct {
 	// SpansPerSecond sets the limit on the maximum number of spans that can be processed each second.
 	SpansPerSecond int64 `mapstructure:"spans_per_second"`
+	// BytesPerSecond sets the limit on the maximum size, in bytes, of the traces that can be processed
+	// each second, using their protobuf encoded size. Disabled when zero.
+	BytesPerSecond int64 `mapstructure:"bytes_per_second"`
+}
+
+// AdaptiveCfg holds the configurable settings to create an adaptive sampling
+// policy evaluator.
+type AdaptiveCfg struct {
+	// Key is the resource attribute used to group traces, each of its values being sampled
+	// independently. Defaults to "service.name".
+	Key string `mapstructure:"key"`
+	// TargetTracesPerSecond is the number of traces per second to sample for each value of Key.
+	// Values whose throughput is below the target are fully sampled.
+	TargetTracesPerSecond float64 `mapstructure:"target_traces_per_second"`
+	// Window is the duration of the sliding window over which the throughput of each value of
+	// Key is measured. It is rounded down to whole seconds. Defaults to 30s.
+	Window time.Duration `mapstructure:"window"`
 }
 
 // SpanCountCfg holds the configurable settings to create a Span Count filter sampling
@@ -224,6 +264,57 @@ type OTTLConditionCfg struct {
 	SpanEventConditions []string       `mapstructure:"spanevent"`
 }
 
+// DecisionSharingCfg holds the configurable settings to share the sampling decisions with
+// other tail sampling processors.
+type DecisionSharingCfg struct {
+	// Group shares the decisions with the processors of the same collector configured with
+	// the same group. When set, Server and Peers are ignored.
+	Group string `mapstructure:"group"`
+	// Server configures the gRPC server receiving the decisions of the peers.
+	Server *configgrpc.ServerConfig `mapstructure:"server"`
+	// Peers configures the gRPC clients sending the decisions to the other collectors.
+	Peers []configgrpc.ClientConfig `mapstructure:"peers"`
+}
+
+// Unmarshal applies the defaults of the gRPC server, listening on TCP, when the server is configured.
+func (cfg *DecisionSharingCfg) Unmarshal(conf *confmap.Conf) error {
+	if conf.IsSet("server") {
+		cfg.Server = configgrpc.NewDefaultServerConfig()
+		cfg.Server.NetAddr.Transport = confignet.TransportTypeTCP
+	}
+	return conf.Unmarshal(cfg)
+}
+
+// Validate checks that the decisions are shared with a group or with peers over gRPC.
+func (cfg *DecisionSharingCfg) Validate() error {
+	if cfg.Group != "" {
+		return nil
+	}
+	if cfg.Server == nil && len(cfg.Peers) == 0 {
+		return errors.New("'decision_sharing' requires a 'group', a 'server' or 'peers'")
+	}
+	if cfg.Server != nil && cfg.Server.NetAddr.Endpoint == "" {
+		return errors.New("'decision_sharing' server requires an 'endpoint'")
+	}
+	for i, peer := range cfg.Peers {
+		if peer.Endpoint == "" {
+			return fmt.Errorf("'decision_sharing' peer %d requires an 'endpoint'", i)
+		}
+	}
+	return nil
+}
+
+// ShadowCfg holds the configurable settings of the shadow policies, whose decisions are
+// only reported in the internal telemetry and, optionally, in the attributes of sampled traces.
+type ShadowCfg struct {
+	// PolicyCfgs sets the candidate policies, combined the same way as the live policies.
+	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// RecordDecision adds the decision that the shadow policies would have taken to the traces
+	// sampled by the live policies, in the tailsampling.shadow.decision and
+	// tailsampling.shadow.policy attributes.
+	RecordDecision bool `mapstructure:"record_decision"`
+}
+
 type DecisionCacheConfig struct {
 	// SampledCacheSize specifies the size of the cache that holds the sampled trace IDs.
 	// This value will be the maximum amount of trace IDs that the cache can hold before overwriting previous IDs.
@@ -253,6 +344,25 @@ type Config struct {
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
 	// DecisionCache holds configuration for the decision cache(s)
 	DecisionCache DecisionCacheConfig `mapstructure:"decision_cache"`
+	// Storage is the ID of the storage extension used to persist the traces waiting for a
+	// sampling decision and the contents of the decision caches, so that they survive a
+	// collector restart. If not set, the state of the processor is only kept in memory.
+	Storage *component.ID `mapstructure:"storage"`
+	// DecisionSharing configures sharing the sampling decisions with other tail sampling processors,
+	// so that late spans of a trace decided by a peer get the same decision.
+	DecisionSharing *DecisionSharingCfg `mapstructure:"decision_sharing"`
+	// Shadow holds a set of candidate policies evaluated alongside the ones in PolicyCfgs,
+	// without affecting the sampling decisions.
+	Shadow ShadowCfg `mapstructure:"shadow"`
 	// Options allows for additional configuration of the tail-based sampling processor in code.
 	Options []Option `mapstructure:"-"`
 }
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	// the decisions of the peers are kept in the decision caches, which drop everything when their size is 0
+	if cfg.DecisionSharing != nil && (cfg.DecisionCache.SampledCacheSize <= 0 || cfg.DecisionCache.NonSampledCacheSize <= 0) {
+		return errors.New("'decision_sharing' requires 'decision_cache' with 'sampled_cache_size' and 'non_sampled_cache_size' greater than 0")
+	}
+	return nil
+}
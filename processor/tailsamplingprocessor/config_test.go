@@ -11,7 +11,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
@@ -36,6 +40,28 @@ func TestLoadConfig(t *testing.T) {
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
 			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 1_000, NonSampledCacheSize: 10_000},
+			DecisionSharing: &DecisionSharingCfg{
+				Server: &configgrpc.ServerConfig{
+					NetAddr:   confignet.AddrConfig{Endpoint: "0.0.0.0:4320", Transport: confignet.TransportTypeTCP},
+					Keepalive: configgrpc.NewDefaultKeepaliveServerConfig(),
+				},
+				Peers: []configgrpc.ClientConfig{
+					{Endpoint: "collector-1:4320", TLSSetting: configtls.ClientConfig{Insecure: true}},
+					{Endpoint: "collector-2:4320", TLSSetting: configtls.ClientConfig{Insecure: true}},
+				},
+			},
+			Shadow: ShadowCfg{
+				RecordDecision: true,
+				PolicyCfgs: []PolicyCfg{
+					{
+						sharedPolicyCfg: sharedPolicyCfg{
+							Name:       "shadow-policy-1",
+							Type:       Latency,
+							LatencyCfg: LatencyCfg{ThresholdMs: 500},
+						},
+					},
+				},
+			},
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -82,7 +108,7 @@ func TestLoadConfig(t *testing.T) {
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name:            "test-policy-7",
 						Type:            RateLimiting,
-						RateLimitingCfg: RateLimitingCfg{SpansPerSecond: 35},
+						RateLimitingCfg: RateLimitingCfg{SpansPerSecond: 35, BytesPerSecond: 1048576},
 					},
 				},
 				{
@@ -117,6 +143,17 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-12",
+						Type: Adaptive,
+						AdaptiveCfg: AdaptiveCfg{
+							Key:                   "service.name",
+							TargetTracesPerSecond: 10,
+							Window:                time.Minute,
+						},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -148,6 +185,7 @@ func TestLoadConfig(t *testing.T) {
 					},
 					CompositeCfg: CompositeCfg{
 						MaxTotalSpansPerSecond: 1000,
+						MaxTotalBytesPerSecond: 1048576,
 						PolicyOrder:            []string{"test-composite-policy-1", "test-composite-policy-2", "test-composite-policy-3"},
 						SubPolicyCfg: []CompositeSubPolicyCfg{
 							{
@@ -186,3 +224,61 @@ func TestLoadConfig(t *testing.T) {
 			},
 		}, cfg)
 }
+
+func TestDecisionSharingConfigValidate(t *testing.T) {
+	caches := DecisionCacheConfig{SampledCacheSize: 100, NonSampledCacheSize: 100}
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr string
+	}{
+		{
+			name: "group",
+			cfg:  Config{DecisionCache: caches, DecisionSharing: &DecisionSharingCfg{Group: "group"}},
+		},
+		{
+			name: "server and peers",
+			cfg: Config{DecisionCache: caches, DecisionSharing: &DecisionSharingCfg{
+				Server: &configgrpc.ServerConfig{NetAddr: confignet.AddrConfig{Endpoint: "0.0.0.0:4320", Transport: confignet.TransportTypeTCP}},
+				Peers:  []configgrpc.ClientConfig{{Endpoint: "collector-1:4320"}},
+			}},
+		},
+		{
+			name:        "empty",
+			cfg:         Config{DecisionCache: caches, DecisionSharing: &DecisionSharingCfg{}},
+			expectedErr: "'decision_sharing' requires a 'group', a 'server' or 'peers'",
+		},
+		{
+			name: "server without endpoint",
+			cfg: Config{DecisionCache: caches, DecisionSharing: &DecisionSharingCfg{
+				Server: &configgrpc.ServerConfig{NetAddr: confignet.AddrConfig{Transport: confignet.TransportTypeTCP}},
+			}},
+			expectedErr: "'decision_sharing' server requires an 'endpoint'",
+		},
+		{
+			name: "peer without endpoint",
+			cfg: Config{DecisionCache: caches, DecisionSharing: &DecisionSharingCfg{
+				Peers: []configgrpc.ClientConfig{{Endpoint: "collector-1:4320"}, {}},
+			}},
+			expectedErr: "'decision_sharing' peer 1 requires an 'endpoint'",
+		},
+		{
+			name: "no decision caches",
+			cfg: Config{
+				DecisionCache:   DecisionCacheConfig{SampledCacheSize: 100},
+				DecisionSharing: &DecisionSharingCfg{Group: "group"},
+			},
+			expectedErr: "'decision_sharing' requires 'decision_cache' with 'sampled_cache_size' and 'non_sampled_cache_size' greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := xconfmap.Validate(&tt.cfg)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
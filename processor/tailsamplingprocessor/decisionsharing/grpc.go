@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decisionsharing // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionsharing"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	serviceName   = "tailsampling.DecisionSharing"
+	publishMethod = "/" + serviceName + "/Publish"
+
+	// each encoded decision starts with the trace ID, the sampled flag and the length of the policy name
+	decisionHeaderLen = 16 + 1 + 2
+)
+
+var errInvalidMessage = errors.New("invalid decision sharing message")
+
+type grpcExchange struct {
+	settings component.TelemetrySettings
+	server   *configgrpc.ServerConfig
+	peers    []configgrpc.ClientConfig
+
+	grpcServer *grpc.Server
+	listener   net.Listener
+	clients    []*grpc.ClientConn
+	handler    func([]Decision)
+	wg         sync.WaitGroup
+}
+
+var _ Exchange = (*grpcExchange)(nil)
+
+// NewGRPCExchange returns an Exchange receiving the decisions of its peers with the given
+// server and sending its own decisions to the given peers over gRPC. The decisions are sent
+// as a protobuf BytesValue holding the encoded decisions. A nil server disables receiving
+// decisions.
+func NewGRPCExchange(settings component.TelemetrySettings, server *configgrpc.ServerConfig, peers []configgrpc.ClientConfig) Exchange {
+	return &grpcExchange{
+		settings: settings,
+		server:   server,
+		peers:    peers,
+	}
+}
+
+func (e *grpcExchange) Start(ctx context.Context, host component.Host, handler func([]Decision)) error {
+	e.handler = handler
+
+	for i := range e.peers {
+		client, err := e.peers[i].ToClientConn(ctx, host, e.settings)
+		if err != nil {
+			return fmt.Errorf("failed to create client for peer %q: %w", e.peers[i].Endpoint, err)
+		}
+		e.clients = append(e.clients, client)
+	}
+
+	if e.server == nil {
+		return nil
+	}
+	var err error
+	if e.listener, err = e.server.NetAddr.Listen(ctx); err != nil {
+		return fmt.Errorf("failed to listen on %q: %w", e.server.NetAddr.Endpoint, err)
+	}
+	if e.grpcServer, err = e.server.ToServer(ctx, host, e.settings); err != nil {
+		return errors.Join(fmt.Errorf("failed to create the decision sharing server: %w", err), e.listener.Close())
+	}
+	e.grpcServer.RegisterService(&serviceDesc, e)
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		if err := e.grpcServer.Serve(e.listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			e.settings.Logger.Error("Decision sharing server stopped", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (e *grpcExchange) Publish(ctx context.Context, decisions []Decision) error {
+	buf, err := encodeDecisions(decisions)
+	if err != nil {
+		return err
+	}
+	request := wrapperspb.Bytes(buf)
+
+	var errs error
+	for _, client := range e.clients {
+		if err := client.Invoke(ctx, publishMethod, request, &emptypb.Empty{}); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to publish decisions to %q: %w", client.Target(), err))
+		}
+	}
+	return errs
+}
+
+func (e *grpcExchange) Shutdown(context.Context) error {
+	if e.grpcServer != nil {
+		e.grpcServer.Stop()
+		e.wg.Wait()
+	}
+	var errs error
+	for _, client := range e.clients {
+		errs = errors.Join(errs, client.Close())
+	}
+	e.clients = nil
+	return errs
+}
+
+func (e *grpcExchange) receive(request *wrapperspb.BytesValue) error {
+	decisions, err := decodeDecisions(request.GetValue())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(decisions) > 0 {
+		e.handler(decisions)
+	}
+	return nil
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Publish",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				request := &wrapperspb.BytesValue{}
+				if err := dec(request); err != nil {
+					return nil, err
+				}
+				handler := func(_ context.Context, req any) (any, error) {
+					return &emptypb.Empty{}, srv.(*grpcExchange).receive(req.(*wrapperspb.BytesValue))
+				}
+				if interceptor == nil {
+					return handler(ctx, request)
+				}
+				return interceptor(ctx, request, &grpc.UnaryServerInfo{Server: srv, FullMethod: publishMethod}, handler)
+			},
+		},
+	},
+}
+
+func encodeDecisions(decisions []Decision) ([]byte, error) {
+	size := 0
+	for _, d := range decisions {
+		if len(d.Policy) > math.MaxUint16 {
+			return nil, fmt.Errorf("policy name of length %d is too long", len(d.Policy))
+		}
+		size += decisionHeaderLen + len(d.Policy)
+	}
+
+	buf := make([]byte, 0, size)
+	for _, d := range decisions {
+		buf = append(buf, d.TraceID[:]...)
+		sampled := byte(0)
+		if d.Sampled {
+			sampled = 1
+		}
+		buf = append(buf, sampled)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(d.Policy)))
+		buf = append(buf, d.Policy...)
+	}
+	return buf, nil
+}
+
+func decodeDecisions(buf []byte) ([]Decision, error) {
+	var decisions []Decision
+	for len(buf) > 0 {
+		if len(buf) < decisionHeaderLen {
+			return nil, errInvalidMessage
+		}
+		policyLen := int(binary.BigEndian.Uint16(buf[17:19]))
+		if len(buf) < decisionHeaderLen+policyLen {
+			return nil, errInvalidMessage
+		}
+		decisions = append(decisions, Decision{
+			TraceID: pcommon.TraceID(buf[0:16]),
+			Sampled: buf[16] == 1,
+			Policy:  string(buf[decisionHeaderLen : decisionHeaderLen+policyLen]),
+		})
+		buf = buf[decisionHeaderLen+policyLen:]
+	}
+	return decisions, nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decisionsharing
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newPeerConfig(endpoint string) configgrpc.ClientConfig {
+	cfg := configgrpc.NewDefaultClientConfig()
+	cfg.Endpoint = endpoint
+	cfg.TLSSetting.Insecure = true
+	return *cfg
+}
+
+func TestGRPCExchange(t *testing.T) {
+	var mu sync.Mutex
+	var received []Decision
+	serverCfg := configgrpc.NewDefaultServerConfig()
+	serverCfg.NetAddr = confignet.AddrConfig{Endpoint: "127.0.0.1:0", Transport: confignet.TransportTypeTCP}
+	server := NewGRPCExchange(componenttest.NewNopTelemetrySettings(), serverCfg, nil)
+	require.NoError(t, server.Start(context.Background(), componenttest.NewNopHost(), func(d []Decision) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, d...)
+	}))
+	defer func() { require.NoError(t, server.Shutdown(context.Background())) }()
+
+	endpoint := server.(*grpcExchange).listener.Addr().String()
+	client := NewGRPCExchange(componenttest.NewNopTelemetrySettings(), nil, []configgrpc.ClientConfig{newPeerConfig(endpoint)})
+	require.NoError(t, client.Start(context.Background(), componenttest.NewNopHost(), func([]Decision) {}))
+	defer func() { require.NoError(t, client.Shutdown(context.Background())) }()
+
+	decisions := []Decision{
+		{TraceID: pcommon.TraceID{1}, Sampled: true, Policy: "policy-1"},
+		{TraceID: pcommon.TraceID{2}, Sampled: false},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, client.Publish(ctx, decisions))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, decisions, received)
+}
+
+func TestGRPCExchangePeerUnavailable(t *testing.T) {
+	client := NewGRPCExchange(componenttest.NewNopTelemetrySettings(), nil, []configgrpc.ClientConfig{newPeerConfig("127.0.0.1:1")})
+	require.NoError(t, client.Start(context.Background(), componenttest.NewNopHost(), func([]Decision) {}))
+	defer func() { require.NoError(t, client.Shutdown(context.Background())) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := client.Publish(ctx, []Decision{{TraceID: pcommon.TraceID{1}}})
+	assert.ErrorContains(t, err, "failed to publish decisions to \"127.0.0.1:1\"")
+}
+
+func TestDecisionsEncoding(t *testing.T) {
+	decisions := []Decision{
+		{TraceID: pcommon.TraceID{1, 2, 3}, Sampled: true, Policy: "policy-1"},
+		{TraceID: pcommon.TraceID{4, 5, 6}},
+	}
+	buf, err := encodeDecisions(decisions)
+	require.NoError(t, err)
+
+	decoded, err := decodeDecisions(buf)
+	require.NoError(t, err)
+	assert.Equal(t, decisions, decoded)
+
+	_, err = decodeDecisions(buf[:len(buf)-1])
+	assert.ErrorIs(t, err, errInvalidMessage)
+	_, err = decodeDecisions(buf[:10])
+	assert.ErrorIs(t, err, errInvalidMessage)
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decisionsharing // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionsharing"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	groupsMu sync.Mutex
+	groups   = make(map[string]map[*inProcessExchange]struct{})
+)
+
+type inProcessExchange struct {
+	group   string
+	handler func([]Decision)
+}
+
+var _ Exchange = (*inProcessExchange)(nil)
+
+// NewInProcessExchange returns an Exchange sharing decisions with the other exchanges of
+// the same group created in the same process.
+func NewInProcessExchange(group string) Exchange {
+	return &inProcessExchange{group: group}
+}
+
+func (e *inProcessExchange) Start(_ context.Context, _ component.Host, handler func([]Decision)) error {
+	groupsMu.Lock()
+	defer groupsMu.Unlock()
+
+	e.handler = handler
+	members, ok := groups[e.group]
+	if !ok {
+		members = make(map[*inProcessExchange]struct{})
+		groups[e.group] = members
+	}
+	members[e] = struct{}{}
+	return nil
+}
+
+func (e *inProcessExchange) Publish(_ context.Context, decisions []Decision) error {
+	// the handlers are called without holding the lock, so that they can't block the other
+	// members of the group from starting, publishing or shutting down
+	groupsMu.Lock()
+	handlers := make([]func([]Decision), 0, len(groups[e.group]))
+	for member := range groups[e.group] {
+		if member != e {
+			handlers = append(handlers, member.handler)
+		}
+	}
+	groupsMu.Unlock()
+
+	for _, handler := range handlers {
+		handler(decisions)
+	}
+	return nil
+}
+
+func (e *inProcessExchange) Shutdown(context.Context) error {
+	groupsMu.Lock()
+	defer groupsMu.Unlock()
+
+	members := groups[e.group]
+	delete(members, e)
+	if len(members) == 0 {
+		delete(groups, e.group)
+	}
+	return nil
+}
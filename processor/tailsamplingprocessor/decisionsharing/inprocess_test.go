@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package decisionsharing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestInProcessExchange(t *testing.T) {
+	var receivedA, receivedB, receivedOther []Decision
+	a := NewInProcessExchange("group")
+	b := NewInProcessExchange("group")
+	other := NewInProcessExchange("other")
+
+	host := componenttest.NewNopHost()
+	require.NoError(t, a.Start(context.Background(), host, func(d []Decision) { receivedA = append(receivedA, d...) }))
+	require.NoError(t, b.Start(context.Background(), host, func(d []Decision) { receivedB = append(receivedB, d...) }))
+	require.NoError(t, other.Start(context.Background(), host, func(d []Decision) { receivedOther = append(receivedOther, d...) }))
+
+	decisions := []Decision{{TraceID: pcommon.TraceID{1}, Sampled: true, Policy: "policy"}}
+	require.NoError(t, a.Publish(context.Background(), decisions))
+	assert.Empty(t, receivedA)
+	assert.Equal(t, decisions, receivedB)
+	assert.Empty(t, receivedOther)
+
+	// members that were shut down don't receive decisions anymore
+	require.NoError(t, b.Shutdown(context.Background()))
+	require.NoError(t, a.Publish(context.Background(), decisions))
+	assert.Len(t, receivedB, 1)
+
+	require.NoError(t, a.Shutdown(context.Background()))
+	require.NoError(t, other.Shutdown(context.Background()))
+	assert.Empty(t, groups)
+}
+
+func TestInProcessExchangeHandlerCanPublish(t *testing.T) {
+	a := NewInProcessExchange("group")
+	b := NewInProcessExchange("group")
+
+	var receivedA []Decision
+	host := componenttest.NewNopHost()
+	require.NoError(t, a.Start(context.Background(), host, func(d []Decision) { receivedA = append(receivedA, d...) }))
+	// b forwards the decisions it receives, which would deadlock if the handlers were
+	// called while holding the lock of the groups
+	require.NoError(t, b.Start(context.Background(), host, func(d []Decision) {
+		assert.NoError(t, b.Publish(context.Background(), []Decision{{TraceID: d[0].TraceID, Sampled: !d[0].Sampled}}))
+	}))
+
+	require.NoError(t, a.Publish(context.Background(), []Decision{{TraceID: pcommon.TraceID{1}, Sampled: true}}))
+	assert.Equal(t, []Decision{{TraceID: pcommon.TraceID{1}, Sampled: false}}, receivedA)
+
+	require.NoError(t, a.Shutdown(context.Background()))
+	require.NoError(t, b.Shutdown(context.Background()))
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package decisionsharing allows tail sampling processors running in different
+// pipelines or collectors to share the sampling decisions they take, so that spans
+// arriving late for a trace decided elsewhere get the same decision.
+package decisionsharing // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionsharing"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Decision is a final sampling decision taken for a trace.
+type Decision struct {
+	// TraceID is the ID of the decided trace.
+	TraceID pcommon.TraceID
+	// Sampled tells whether the trace was sampled.
+	Sampled bool
+	// Policy is the name of the policy that took the decision, if any.
+	Policy string
+}
+
+// Exchange publishes the decisions taken by a processor and delivers the decisions
+// taken by its peers.
+type Exchange interface {
+	// Start starts the exchange. The decisions published by peers are passed to the
+	// handler, which must not block.
+	Start(ctx context.Context, host component.Host, handler func([]Decision)) error
+	// Publish sends the given decisions to the peers.
+	Publish(ctx context.Context, decisions []Decision) error
+	// Shutdown stops the exchange.
+	Shutdown(ctx context.Context) error
+}
@@ -38,6 +38,9 @@ type TelemetryBuilder struct {
 	ProcessorTailSamplingSamplingTraceDroppedTooEarly   metric.Int64Counter
 	ProcessorTailSamplingSamplingTraceRemovalAge        metric.Int64Histogram
 	ProcessorTailSamplingSamplingTracesOnMemory         metric.Int64Gauge
+	ProcessorTailSamplingShadowCountTracesSampled       metric.Int64Counter
+	ProcessorTailSamplingShadowGlobalCountBytesSampled  metric.Int64Counter
+	ProcessorTailSamplingShadowGlobalCountTracesSampled metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -143,5 +146,23 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{traces}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingShadowCountTracesSampled, err = builder.meter.Int64Counter(
+		"otelcol_processor_tail_sampling_shadow_count_traces_sampled",
+		metric.WithDescription("Count of traces that would have been sampled or not per shadow sampling policy"),
+		metric.WithUnit("{traces}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingShadowGlobalCountBytesSampled, err = builder.meter.Int64Counter(
+		"otelcol_processor_tail_sampling_shadow_global_count_bytes_sampled",
+		metric.WithDescription("Size of the traces that would have been sampled or not by at least one shadow policy"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingShadowGlobalCountTracesSampled, err = builder.meter.Int64Counter(
+		"otelcol_processor_tail_sampling_shadow_global_count_traces_sampled",
+		metric.WithDescription("Global count of traces that would have been sampled or not by at least one shadow policy"),
+		metric.WithUnit("{traces}"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+var errInvalidTargetRate = errors.New("the target traces per second must be greater than zero")
+
+// adaptiveThresholdPrecision is the number of hexadecimal digits used to encode
+// the sampling thresholds written to the tracestate.
+const adaptiveThresholdPrecision = 4
+
+// keyRate tracks the number of traces seen for a single key, with one bucket
+// per second of the sliding window.
+type keyRate struct {
+	counts    []int64
+	seconds   []int64
+	firstSeen int64
+	lastSeen  int64
+}
+
+// rate returns the number of traces per second seen for the key. Once a full second
+// was observed the rate is measured over the complete seconds of the window ending at
+// now, otherwise it is the number of traces seen during the current second.
+func (k *keyRate) rate(now int64) float64 {
+	window := int64(len(k.counts))
+	if now == k.firstSeen {
+		return float64(k.counts[now%window])
+	}
+	var total int64
+	for i, second := range k.seconds {
+		if second < now && now-second <= window {
+			total += k.counts[i]
+		}
+	}
+	elapsed := min(now-k.firstSeen, window)
+	return float64(total) / float64(elapsed)
+}
+
+func (k *keyRate) add(now int64) {
+	i := now % int64(len(k.counts))
+	if k.seconds[i] != now {
+		k.seconds[i] = now
+		k.counts[i] = 0
+	}
+	k.counts[i]++
+	k.lastSeen = now
+}
+
+type adaptive struct {
+	logger       *zap.Logger
+	key          string
+	target       float64
+	window       int64
+	timeProvider TimeProvider
+
+	mu            sync.Mutex
+	rates         map[string]*keyRate
+	currentSecond int64
+}
+
+var (
+	_ PolicyEvaluator   = (*adaptive)(nil)
+	_ ThresholdRecorder = (*adaptive)(nil)
+)
+
+// NewAdaptive creates a policy evaluator that samples the traces of each value of the
+// given resource attribute with the probability needed to keep its throughput close to
+// targetTracesPerSecond. The throughput is measured over a sliding window of the given
+// number of seconds, and keys below the target are fully sampled. When the policy takes the
+// final decision, sampled traces have their OpenTelemetry tracestate threshold updated, so
+// that their adjusted count can be computed downstream.
+func NewAdaptive(settings component.TelemetrySettings, key string, targetTracesPerSecond float64, windowSeconds int64, timeProvider TimeProvider) (PolicyEvaluator, error) {
+	if targetTracesPerSecond <= 0 {
+		return nil, errInvalidTargetRate
+	}
+	return &adaptive{
+		logger:       settings.Logger,
+		key:          key,
+		target:       targetTracesPerSecond,
+		window:       max(windowSeconds, 1),
+		timeProvider: timeProvider,
+		rates:        make(map[string]*keyRate),
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (a *adaptive) Evaluate(_ context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	a.logger.Debug("Evaluating spans in adaptive filter")
+
+	trace.Lock()
+	defer trace.Unlock()
+	batches := trace.ReceivedBatches
+
+	threshold, err := sampling.ProbabilityToThresholdWithPrecision(a.probability(keyValue(batches, a.key)), adaptiveThresholdPrecision)
+	if err != nil {
+		return Error, err
+	}
+	if !threshold.ShouldSample(traceRandomness(batches, traceID)) {
+		return NotSampled, nil
+	}
+
+	if trace.thresholds == nil {
+		trace.thresholds = make(map[PolicyEvaluator]sampling.Threshold)
+	}
+	trace.thresholds[a] = threshold
+	return Sampled, nil
+}
+
+// RecordThreshold writes the threshold the trace was sampled with to its tracestate.
+func (a *adaptive) RecordThreshold(trace *TraceData) {
+	trace.Lock()
+	defer trace.Unlock()
+	if threshold, ok := trace.thresholds[a]; ok {
+		updateThreshold(trace.ReceivedBatches, threshold)
+	}
+}
+
+// probability records a trace for the given key value and returns the sampling probability
+// that keeps the throughput of that key at the target.
+func (a *adaptive) probability(value string) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.timeProvider.getCurSecond()
+	if now != a.currentSecond {
+		a.currentSecond = now
+		// Forget the keys that were not seen during the whole window.
+		for k, r := range a.rates {
+			if now-r.lastSeen >= a.window {
+				delete(a.rates, k)
+			}
+		}
+	}
+
+	r, ok := a.rates[value]
+	if !ok {
+		r = &keyRate{
+			counts:    make([]int64, a.window),
+			seconds:   make([]int64, a.window),
+			firstSeen: now,
+		}
+		a.rates[value] = r
+	}
+	r.add(now)
+
+	rate := r.rate(now)
+	if rate <= a.target {
+		return 1
+	}
+	return a.target / rate
+}
+
+// keyValue returns the value of the given resource attribute, or an empty string when
+// the trace doesn't have it.
+func keyValue(batches ptrace.Traces, key string) string {
+	rs := batches.ResourceSpans()
+	for i := 0; i < rs.Len(); i++ {
+		if v, ok := rs.At(i).Resource().Attributes().Get(key); ok {
+			return v.AsString()
+		}
+	}
+	return ""
+}
+
+// traceRandomness returns the randomness of the trace, taken from the explicit
+// randomness value of the tracestate when present, and from the trace ID otherwise.
+func traceRandomness(batches ptrace.Traces, traceID pcommon.TraceID) sampling.Randomness {
+	var rnd sampling.Randomness
+	found := false
+	forEachSpan(batches, func(span ptrace.Span) bool {
+		ts, err := sampling.NewW3CTraceState(span.TraceState().AsRaw())
+		if err != nil {
+			return true
+		}
+		rnd, found = ts.OTelValue().RValueRandomness()
+		return !found
+	})
+	if found {
+		return rnd
+	}
+	return sampling.TraceIDToRandomness(traceID)
+}
+
+// updateThreshold records the sampling threshold in the tracestate of all spans. The
+// threshold of spans that were already sampled with a lower probability is left untouched.
+func updateThreshold(batches ptrace.Traces, threshold sampling.Threshold) {
+	forEachSpan(batches, func(span ptrace.Span) bool {
+		ts, err := sampling.NewW3CTraceState(span.TraceState().AsRaw())
+		if err != nil {
+			// Leave malformed tracestates as they are.
+			return true
+		}
+		otts := ts.OTelValue()
+		if existing, ok := otts.TValueThreshold(); ok && sampling.ThresholdGreater(existing, threshold) {
+			return true
+		}
+		if otts.UpdateTValueWithSampling(threshold) != nil {
+			return true
+		}
+		var w strings.Builder
+		if ts.Serialize(&w) == nil {
+			span.TraceState().FromRaw(w.String())
+		}
+		return true
+	})
+}
+
+// forEachSpan calls fn for every span of the batches until it returns false.
+func forEachSpan(batches ptrace.Traces, fn func(span ptrace.Span) bool) {
+	rs := batches.ResourceSpans()
+	for i := 0; i < rs.Len(); i++ {
+		ss := rs.At(i).ScopeSpans()
+		for j := 0; j < ss.Len(); j++ {
+			spans := ss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if !fn(spans.At(k)) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+func newAdaptiveTrace(service string, traceState string) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", service)
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.TraceState().FromRaw(traceState)
+	return &TraceData{ReceivedBatches: traces}
+}
+
+func adaptiveTraceID(i uint64) pcommon.TraceID {
+	var id pcommon.TraceID
+	// spread the IDs over the whole randomness range
+	binary.BigEndian.PutUint64(id[8:], i*0x9e3779b97f4a7c15)
+	return id
+}
+
+func spanTraceState(trace *TraceData) string {
+	return trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceState().AsRaw()
+}
+
+func TestAdaptiveInvalidTarget(t *testing.T) {
+	_, err := NewAdaptive(componenttest.NewNopTelemetrySettings(), "service.name", 0, 10, MonotonicClock{})
+	assert.ErrorIs(t, err, errInvalidTargetRate)
+}
+
+func TestAdaptiveSamplesRareKeys(t *testing.T) {
+	clock := &FakeTimeProvider{second: 100}
+	adaptive, err := NewAdaptive(componenttest.NewNopTelemetrySettings(), "service.name", 10, 10, clock)
+	require.NoError(t, err)
+
+	for i := uint64(0); i < 10; i++ {
+		trace := newAdaptiveTrace("rare", "")
+		decision, err := adaptive.Evaluate(context.Background(), adaptiveTraceID(i), trace)
+		require.NoError(t, err)
+		assert.Equal(t, Sampled, decision)
+		// the threshold is only recorded once the policy is known to take the final decision
+		assert.Empty(t, spanTraceState(trace))
+		adaptive.(ThresholdRecorder).RecordThreshold(trace)
+		assert.Equal(t, "ot=th:0", spanTraceState(trace))
+	}
+}
+
+func TestAdaptiveLimitsBusyKeys(t *testing.T) {
+	clock := &FakeTimeProvider{second: 100}
+	adaptive, err := NewAdaptive(componenttest.NewNopTelemetrySettings(), "service.name", 10, 10, clock)
+	require.NoError(t, err)
+
+	sampled := 0
+	var id uint64
+	for second := int64(100); second < 110; second++ {
+		clock.second = second
+		for i := 0; i < 1000; i++ {
+			id++
+			decision, err := adaptive.Evaluate(context.Background(), adaptiveTraceID(id), newAdaptiveTrace("busy", ""))
+			require.NoError(t, err)
+			// the first second is used to measure the throughput
+			if decision == Sampled && second > 100 {
+				sampled++
+			}
+		}
+
+		// a rare service is still fully sampled while the busy one is throttled
+		decision, err := adaptive.Evaluate(context.Background(), adaptiveTraceID(id), newAdaptiveTrace("rare", ""))
+		require.NoError(t, err)
+		assert.Equal(t, Sampled, decision)
+	}
+
+	// the busy service is sampled at about 10 traces per second
+	assert.InDelta(t, 90, sampled, 30)
+
+	// sampled traces carry the probability they were sampled with
+	trace := newAdaptiveTrace("busy", "")
+	for decision := NotSampled; decision != Sampled; {
+		id++
+		trace = newAdaptiveTrace("busy", "")
+		decision, err = adaptive.Evaluate(context.Background(), adaptiveTraceID(id), trace)
+		require.NoError(t, err)
+	}
+	adaptive.(ThresholdRecorder).RecordThreshold(trace)
+	ts, err := sampling.NewW3CTraceState(spanTraceState(trace))
+	require.NoError(t, err)
+	threshold, ok := ts.OTelValue().TValueThreshold()
+	require.True(t, ok)
+	assert.InDelta(t, 0.01, threshold.Probability(), 0.002)
+}
+
+func TestAdaptiveForgetsIdleKeys(t *testing.T) {
+	clock := &FakeTimeProvider{second: 100}
+	evaluator, err := NewAdaptive(componenttest.NewNopTelemetrySettings(), "service.name", 1, 5, clock)
+	require.NoError(t, err)
+	a := evaluator.(*adaptive)
+
+	for i := uint64(0); i < 100; i++ {
+		_, err = a.Evaluate(context.Background(), adaptiveTraceID(i), newAdaptiveTrace("busy", ""))
+		require.NoError(t, err)
+	}
+	assert.InDelta(t, 100, a.rates["busy"].rate(100), 0)
+
+	// once the window elapsed without traces, the key starts from scratch
+	clock.second = 105
+	trace := newAdaptiveTrace("other", "")
+	_, err = a.Evaluate(context.Background(), adaptiveTraceID(1), trace)
+	require.NoError(t, err)
+	assert.NotContains(t, a.rates, "busy")
+	assert.Contains(t, a.rates, "other")
+}
+
+func TestAdaptiveTraceState(t *testing.T) {
+	tests := []struct {
+		name       string
+		traceState string
+		decision   Decision
+		expected   string
+	}{
+		{
+			name:       "threshold is added to existing tracestate",
+			traceState: "vendor=value",
+			decision:   Sampled,
+			expected:   "ot=th:8,vendor=value",
+		},
+		{
+			name:       "more restrictive threshold is kept",
+			traceState: "ot=th:c;rv:ffffffffffffff",
+			decision:   Sampled,
+			expected:   "ot=th:c;rv:ffffffffffffff",
+		},
+		{
+			name:       "explicit randomness is used",
+			traceState: "ot=rv:00000000000000",
+			decision:   NotSampled,
+			expected:   "ot=rv:00000000000000",
+		},
+		{
+			name:       "malformed tracestate is left untouched",
+			traceState: "ot=th:zz",
+			decision:   Sampled,
+			expected:   "ot=th:zz",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &FakeTimeProvider{second: 100}
+			a, err := NewAdaptive(componenttest.NewNopTelemetrySettings(), "service.name", 1, 1, clock)
+			require.NoError(t, err)
+			// the first trace of the second is always sampled, the second one at 50%
+			_, err = a.Evaluate(context.Background(), adaptiveTraceID(1), newAdaptiveTrace("svc", ""))
+			require.NoError(t, err)
+
+			trace := newAdaptiveTrace("svc", tt.traceState)
+			// the trace ID randomness is above the 50% threshold
+			traceID := pcommon.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+			decision, err := a.Evaluate(context.Background(), traceID, trace)
+			require.NoError(t, err)
+			assert.Equal(t, tt.decision, decision)
+			assert.Equal(t, tt.traceState, spanTraceState(trace))
+
+			a.(ThresholdRecorder).RecordThreshold(trace)
+			assert.Equal(t, tt.expected, spanTraceState(trace))
+		})
+	}
+}
+
+func TestAdaptiveInAndPolicy(t *testing.T) {
+	clock := &FakeTimeProvider{second: 100}
+	adaptive, err := NewAdaptive(componenttest.NewNopTelemetrySettings(), "service.name", 10, 10, clock)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		other    PolicyEvaluator
+		decision Decision
+		expected string
+	}{
+		{
+			name:     "threshold is recorded when the and policy samples the trace",
+			other:    NewAlwaysSample(componenttest.NewNopTelemetrySettings()),
+			decision: Sampled,
+			expected: "ot=th:0",
+		},
+		{
+			name:     "threshold is not recorded when another subpolicy doesn't sample the trace",
+			other:    NewStringAttributeFilter(componenttest.NewNopTelemetrySettings(), "missing", []string{"value"}, false, 0, false),
+			decision: NotSampled,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			and := NewAnd(zap.NewNop(), []PolicyEvaluator{adaptive, tt.other})
+			trace := newAdaptiveTrace("svc", "")
+			decision, err := and.Evaluate(context.Background(), adaptiveTraceID(1), trace)
+			require.NoError(t, err)
+			assert.Equal(t, tt.decision, decision)
+			assert.Empty(t, spanTraceState(trace))
+
+			if decision == Sampled {
+				and.(ThresholdRecorder).RecordThreshold(trace)
+			}
+			assert.Equal(t, tt.expected, spanTraceState(trace))
+		})
+	}
+}
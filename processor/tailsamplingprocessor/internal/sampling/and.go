@@ -42,6 +42,16 @@ func (c *And) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *Trac
 	return Sampled, nil
 }
 
+// RecordThreshold records the thresholds of the subpolicies sampling with a known probability,
+// which all sampled the trace when the policy did.
+func (c *And) RecordThreshold(trace *TraceData) {
+	for _, sub := range c.subpolicies {
+		if recorder, ok := sub.(ThresholdRecorder); ok {
+			recorder.RecordThreshold(trace)
+		}
+	}
+}
+
 // OnDroppedSpans is called when the trace needs to be dropped, due to memory
 // pressure, before the decision_wait time has been reached.
 func (c *And) OnDroppedSpans(pcommon.TraceID, *TraceData) (Decision, error) {
@@ -20,6 +20,12 @@ type subpolicy struct {
 	// spans per second that each subpolicy sampled in this period
 	sampledSPS int64
 
+	// bytes per second allocated to each subpolicy
+	allocatedBPS int64
+
+	// bytes per second that each subpolicy sampled in this period
+	sampledBPS int64
+
 	name string
 }
 
@@ -31,6 +37,9 @@ type Composite struct {
 	// maximum total spans per second that must be sampled
 	maxTotalSPS int64
 
+	// maximum total bytes per second that must be sampled, if greater than zero
+	maxTotalBPS int64
+
 	// current unix timestamp second
 	currentSecond int64
 
@@ -41,19 +50,26 @@ type Composite struct {
 	recordSubPolicy bool
 }
 
-var _ PolicyEvaluator = (*Composite)(nil)
+var (
+	_ PolicyEvaluator = (*Composite)(nil)
+	_ TraceMutator    = (*Composite)(nil)
+)
 
-// SubPolicyEvalParams defines the evaluator and max rate for a sub-policy
+// SubPolicyEvalParams defines the evaluator and max rates for a sub-policy
 type SubPolicyEvalParams struct {
 	Evaluator         PolicyEvaluator
 	MaxSpansPerSecond int64
 	Name              string
+	MaxBytesPerSecond int64
 }
 
 // NewComposite creates a policy evaluator that samples all subpolicies.
+// The size of the sampled traces is only limited when maxTotalBytesPerSecond is greater than
+// zero, in which case the number of spans is only limited if maxTotalSpansPerSecond is set too.
 func NewComposite(
 	logger *zap.Logger,
 	maxTotalSpansPerSecond int64,
+	maxTotalBytesPerSecond int64,
 	subPolicyParams []SubPolicyEvalParams,
 	timeProvider TimeProvider,
 	recordSubPolicy bool,
@@ -64,6 +80,7 @@ func NewComposite(
 		sub := &subpolicy{}
 		sub.evaluator = subPolicyParams[i].Evaluator
 		sub.allocatedSPS = subPolicyParams[i].MaxSpansPerSecond
+		sub.allocatedBPS = subPolicyParams[i].MaxBytesPerSecond
 		sub.name = subPolicyParams[i].Name
 		// We are just starting, so there is no previous input, set it to 0
 		sub.sampledSPS = 0
@@ -73,6 +90,7 @@ func NewComposite(
 
 	return &Composite{
 		maxTotalSPS:     maxTotalSpansPerSecond,
+		maxTotalBPS:     maxTotalBytesPerSecond,
 		subpolicies:     subpolicies,
 		timeProvider:    timeProvider,
 		logger:          logger,
@@ -96,9 +114,13 @@ func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace
 		// Reset counters
 		for i := range c.subpolicies {
 			c.subpolicies[i].sampledSPS = 0
+			c.subpolicies[i].sampledBPS = 0
 		}
 	}
 
+	// The size of the trace is only computed if needed, and at most once.
+	size := int64(-1)
+
 	for _, sub := range c.subpolicies {
 		decision, err := sub.evaluator.Evaluate(ctx, traceID, trace)
 		if err != nil {
@@ -108,12 +130,25 @@ func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace
 		if decision == Sampled || decision == InvertSampled {
 			// The subpolicy made a decision to Sample. Now we need to make our decision.
 
-			// Calculate resulting SPS counter if we decide to sample this trace
+			// Calculate resulting SPS and BPS counters if we decide to sample this trace
 			spansInSecondIfSampled := sub.sampledSPS + trace.SpanCount.Load()
+			withinSPS := !limitSpans(c.maxTotalSPS, c.maxTotalBPS) ||
+				(spansInSecondIfSampled <= sub.allocatedSPS && spansInSecondIfSampled <= c.maxTotalSPS)
+
+			bytesInSecondIfSampled := sub.sampledBPS
+			withinBPS := true
+			if withinSPS && c.maxTotalBPS > 0 {
+				if size < 0 {
+					size = traceSize(trace)
+				}
+				bytesInSecondIfSampled += size
+				withinBPS = bytesInSecondIfSampled <= sub.allocatedBPS && bytesInSecondIfSampled <= c.maxTotalBPS
+			}
 
-			// Check if the rate will be within the allocated bandwidth.
-			if spansInSecondIfSampled <= sub.allocatedSPS && spansInSecondIfSampled <= c.maxTotalSPS {
+			// Check if the rates will be within the allocated bandwidth.
+			if withinSPS && withinBPS {
 				sub.sampledSPS = spansInSecondIfSampled
+				sub.sampledBPS = bytesInSecondIfSampled
 
 				// Let the sampling happen
 				if c.recordSubPolicy {
@@ -124,7 +159,7 @@ func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace
 
 			// We exceeded the rate limit. Don't sample this trace.
 			// Note that we will continue evaluating new incoming traces against
-			// allocated SPS, we do not update sub.sampledSPS here in order to give
+			// allocated SPS and BPS, we do not update the counters here in order to give
 			// chance to another smaller trace to be accepted later.
 			return NotSampled, nil
 		}
@@ -133,6 +168,11 @@ func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace
 	return NotSampled, nil
 }
 
+// MutatesTrace tells whether the policy records the sub-policy that sampled a trace in its spans.
+func (c *Composite) MutatesTrace() bool {
+	return c.recordSubPolicy
+}
+
 // OnDroppedSpans is called when the trace needs to be dropped, due to memory
 // pressure, before the decision_wait time has been reached.
 func (c *Composite) OnDroppedSpans(pcommon.TraceID, *TraceData) (Decision, error) {
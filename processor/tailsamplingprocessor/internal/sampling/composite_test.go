@@ -62,7 +62,7 @@ func TestCompositeEvaluatorNotSampled(t *testing.T) {
 	max100 := int64(100)
 	n1 := NewNumericAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", &min0, &max100, false)
 	n2 := NewNumericAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", &min0, &max100, false)
-	c := NewComposite(zap.NewNop(), 1000, []SubPolicyEvalParams{{n1, 100, "eval-1"}, {n2, 100, "eval-2"}}, FakeTimeProvider{}, false)
+	c := NewComposite(zap.NewNop(), 1000, 0, []SubPolicyEvalParams{{n1, 100, "eval-1", 0}, {n2, 100, "eval-2", 0}}, FakeTimeProvider{}, false)
 
 	trace := createTrace()
 
@@ -81,7 +81,7 @@ func TestCompositeEvaluatorSampled(t *testing.T) {
 	max100 := int64(100)
 	n1 := NewNumericAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", &min0, &max100, false)
 	n2 := NewAlwaysSample(componenttest.NewNopTelemetrySettings())
-	c := NewComposite(zap.NewNop(), 1000, []SubPolicyEvalParams{{n1, 100, "eval-1"}, {n2, 100, "eval-2"}}, FakeTimeProvider{}, false)
+	c := NewComposite(zap.NewNop(), 1000, 0, []SubPolicyEvalParams{{n1, 100, "eval-1", 0}, {n2, 100, "eval-2", 0}}, FakeTimeProvider{}, false)
 
 	trace := createTrace()
 
@@ -99,7 +99,7 @@ func TestCompositeEvaluatorSampled_RecordSubPolicy(t *testing.T) {
 	max100 := int64(100)
 	n1 := NewNumericAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", &min0, &max100, false)
 	n2 := NewAlwaysSample(componenttest.NewNopTelemetrySettings())
-	c := NewComposite(zap.NewNop(), 1000, []SubPolicyEvalParams{{n1, 100, "eval-1"}, {n2, 100, "eval-2"}}, FakeTimeProvider{}, true)
+	c := NewComposite(zap.NewNop(), 1000, 0, []SubPolicyEvalParams{{n1, 100, "eval-1", 0}, {n2, 100, "eval-2", 0}}, FakeTimeProvider{}, true)
 
 	trace := newTraceWithKV(traceID, "test-key", 0)
 
@@ -122,7 +122,7 @@ func TestCompositeEvaluator_OverflowAlwaysSampled(t *testing.T) {
 	max100 := int64(100)
 	n1 := NewNumericAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", &min0, &max100, false)
 	n2 := NewAlwaysSample(componenttest.NewNopTelemetrySettings())
-	c := NewComposite(zap.NewNop(), 3, []SubPolicyEvalParams{{n1, 1, "eval-1"}, {n2, 1, "eval-2"}}, timeProvider, false)
+	c := NewComposite(zap.NewNop(), 3, 0, []SubPolicyEvalParams{{n1, 1, "eval-1", 0}, {n2, 1, "eval-2", 0}}, timeProvider, false)
 
 	trace := newTraceWithKV(traceID, "tag", int64(10))
 
@@ -157,7 +157,7 @@ func TestCompositeEvaluatorSampled_AlwaysSampled(t *testing.T) {
 	max100 := int64(100)
 	n1 := NewNumericAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", &min0, &max100, false)
 	n2 := NewAlwaysSample(componenttest.NewNopTelemetrySettings())
-	c := NewComposite(zap.NewNop(), 10, []SubPolicyEvalParams{{n1, 20, "eval-1"}, {n2, 20, "eval-2"}}, FakeTimeProvider{}, false)
+	c := NewComposite(zap.NewNop(), 10, 0, []SubPolicyEvalParams{{n1, 20, "eval-1", 0}, {n2, 20, "eval-2", 0}}, FakeTimeProvider{}, false)
 
 	for i := 1; i <= 10; i++ {
 		trace := createTrace()
@@ -175,7 +175,7 @@ func TestCompositeEvaluatorInverseSampled_AlwaysSampled(t *testing.T) {
 	// The first policy does not match, the second matches through invert
 	n1 := NewStringAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", []string{"foo"}, false, 0, false)
 	n2 := NewStringAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", []string{"foo"}, false, 0, true)
-	c := NewComposite(zap.NewNop(), 10, []SubPolicyEvalParams{{n1, 20, "eval-1"}, {n2, 20, "eval-2"}}, FakeTimeProvider{}, false)
+	c := NewComposite(zap.NewNop(), 10, 0, []SubPolicyEvalParams{{n1, 20, "eval-1", 0}, {n2, 20, "eval-2", 0}}, FakeTimeProvider{}, false)
 
 	for i := 1; i <= 10; i++ {
 		trace := createTrace()
@@ -193,7 +193,7 @@ func TestCompositeEvaluatorInverseSampled_AlwaysSampled_RecordSubPolicy(t *testi
 	// The first policy does not match, the second matches through invert
 	n1 := NewStringAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", []string{"foo"}, false, 0, false)
 	n2 := NewStringAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", []string{"foo"}, false, 0, true)
-	c := NewComposite(zap.NewNop(), 10, []SubPolicyEvalParams{{n1, 20, "eval-1"}, {n2, 20, "eval-2"}}, FakeTimeProvider{}, true)
+	c := NewComposite(zap.NewNop(), 10, 0, []SubPolicyEvalParams{{n1, 20, "eval-1", 0}, {n2, 20, "eval-2", 0}}, FakeTimeProvider{}, true)
 
 	for i := 1; i <= 10; i++ {
 		trace := newTraceWithKV(traceID, "test-key", 0)
@@ -215,7 +215,7 @@ func TestCompositeEvaluatorThrottling(t *testing.T) {
 	n1 := NewAlwaysSample(componenttest.NewNopTelemetrySettings())
 	timeProvider := &FakeTimeProvider{second: 0}
 	const totalSPS = 10
-	c := NewComposite(zap.NewNop(), totalSPS, []SubPolicyEvalParams{{n1, totalSPS, "eval-1"}}, timeProvider, false)
+	c := NewComposite(zap.NewNop(), totalSPS, 0, []SubPolicyEvalParams{{n1, totalSPS, "eval-1", 0}}, timeProvider, false)
 
 	trace := createTrace()
 
@@ -250,6 +250,59 @@ func TestCompositeEvaluatorThrottling(t *testing.T) {
 	}
 }
 
+func TestCompositeEvaluatorBytesThrottling(t *testing.T) {
+	// Create only one subpolicy, with 100% Sampled policy and only a bytes budget.
+	n1 := NewAlwaysSample(componenttest.NewNopTelemetrySettings())
+	timeProvider := &FakeTimeProvider{second: 0}
+	trace := newTraceWithKV(traceID, "tag", 1)
+	trace.SpanCount.Store(1000)
+	const tracesPerSecond = 3
+	totalBPS := tracesPerSecond * traceSize(trace)
+	c := NewComposite(zap.NewNop(), 0, totalBPS, []SubPolicyEvalParams{{n1, 0, "eval-1", totalBPS}}, timeProvider, false)
+
+	// First traces fitting in the budget should be Sampled, regardless of their number of spans
+	for i := 0; i < tracesPerSecond; i++ {
+		decision, err := c.Evaluate(context.Background(), traceID, trace)
+		require.NoError(t, err)
+		assert.Equal(t, Sampled, decision)
+	}
+
+	// Now we hit the bytes limit
+	decision, err := c.Evaluate(context.Background(), traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+
+	// Let the time advance by one second.
+	timeProvider.second++
+
+	decision, err = c.Evaluate(context.Background(), traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestCompositeEvaluatorSpansAndBytesThrottling(t *testing.T) {
+	n1 := NewAlwaysSample(componenttest.NewNopTelemetrySettings())
+	timeProvider := &FakeTimeProvider{second: 0}
+	trace := newTraceWithKV(traceID, "tag", 1)
+	size := traceSize(trace)
+
+	// The spans budget is reached first
+	c := NewComposite(zap.NewNop(), 2, 10*size, []SubPolicyEvalParams{{n1, 2, "eval-1", 10 * size}}, timeProvider, false)
+	for _, expected := range []Decision{Sampled, Sampled, NotSampled} {
+		decision, err := c.Evaluate(context.Background(), traceID, trace)
+		require.NoError(t, err)
+		assert.Equal(t, expected, decision)
+	}
+
+	// The bytes budget of the sub-policy is reached first
+	c = NewComposite(zap.NewNop(), 10, 10*size, []SubPolicyEvalParams{{n1, 10, "eval-1", size}}, timeProvider, false)
+	for _, expected := range []Decision{Sampled, NotSampled} {
+		decision, err := c.Evaluate(context.Background(), traceID, trace)
+		require.NoError(t, err)
+		assert.Equal(t, expected, decision)
+	}
+}
+
 func TestCompositeEvaluator2SubpolicyThrottling(t *testing.T) {
 	min0 := int64(0)
 	max100 := int64(100)
@@ -257,7 +310,7 @@ func TestCompositeEvaluator2SubpolicyThrottling(t *testing.T) {
 	n2 := NewAlwaysSample(componenttest.NewNopTelemetrySettings())
 	timeProvider := &FakeTimeProvider{second: 0}
 	const totalSPS = 10
-	c := NewComposite(zap.NewNop(), totalSPS, []SubPolicyEvalParams{{n1, totalSPS / 2, "eval-1"}, {n2, totalSPS / 2, "eval-2"}}, timeProvider, false)
+	c := NewComposite(zap.NewNop(), totalSPS, 0, []SubPolicyEvalParams{{n1, totalSPS / 2, "eval-1", 0}, {n2, totalSPS / 2, "eval-2", 0}}, timeProvider, false)
 
 	trace := createTrace()
 
@@ -11,6 +11,8 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 // TraceData stores the sampling related trace data.
@@ -26,6 +28,9 @@ type TraceData struct {
 	ReceivedBatches ptrace.Traces
 	// FinalDecision.
 	FinalDecision Decision
+	// thresholds holds the sampling thresholds of the policies that sampled the trace with a
+	// known probability, to be recorded in the tracestate by the policy taking the final decision.
+	thresholds map[PolicyEvaluator]sampling.Threshold
 }
 
 // Decision gives the status of sampling decision.
@@ -55,6 +60,21 @@ const (
 	InvertNotSampled
 )
 
+// ThresholdRecorder is implemented by the policy evaluators that sample traces with a known
+// probability. RecordThreshold writes that probability to the tracestate of the spans of the
+// trace. It is only called for the policy taking the final sampling decision, just before the
+// trace is released, so that the tracestate isn't modified by policies whose decision is
+// overridden.
+type ThresholdRecorder interface {
+	RecordThreshold(trace *TraceData)
+}
+
+// TraceMutator is implemented by the policy evaluators that can modify the spans of the traces
+// they evaluate, e.g. to record the sub-policy that sampled them.
+type TraceMutator interface {
+	MutatesTrace() bool
+}
+
 // PolicyEvaluator implements a tail-based sampling policy evaluator,
 // which makes a sampling decision for a given trace when requested.
 type PolicyEvaluator interface {
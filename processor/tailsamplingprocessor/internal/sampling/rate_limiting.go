@@ -15,16 +15,22 @@ import (
 type rateLimiting struct {
 	currentSecond        int64
 	spansInCurrentSecond int64
+	bytesInCurrentSecond int64
 	spansPerSecond       int64
+	bytesPerSecond       int64
 	logger               *zap.Logger
 }
 
 var _ PolicyEvaluator = (*rateLimiting)(nil)
 
 // NewRateLimiting creates a policy evaluator the samples all traces.
-func NewRateLimiting(settings component.TelemetrySettings, spansPerSecond int64) PolicyEvaluator {
+// The number of spans sampled each second is limited by spansPerSecond, and the size of the
+// traces sampled each second, in bytes, is limited by bytesPerSecond when it is greater than
+// zero. When only a bytes limit is set, the number of spans is not limited.
+func NewRateLimiting(settings component.TelemetrySettings, spansPerSecond int64, bytesPerSecond int64) PolicyEvaluator {
 	return &rateLimiting{
 		spansPerSecond: spansPerSecond,
+		bytesPerSecond: bytesPerSecond,
 		logger:         settings.Logger,
 	}
 }
@@ -36,13 +42,29 @@ func (r *rateLimiting) Evaluate(_ context.Context, _ pcommon.TraceID, trace *Tra
 	if r.currentSecond != currSecond {
 		r.currentSecond = currSecond
 		r.spansInCurrentSecond = 0
+		r.bytesInCurrentSecond = 0
 	}
 
 	spansInSecondIfSampled := r.spansInCurrentSecond + trace.SpanCount.Load()
-	if spansInSecondIfSampled < r.spansPerSecond {
-		r.spansInCurrentSecond = spansInSecondIfSampled
-		return Sampled, nil
+	if limitSpans(r.spansPerSecond, r.bytesPerSecond) && spansInSecondIfSampled >= r.spansPerSecond {
+		return NotSampled, nil
 	}
 
-	return NotSampled, nil
+	var bytesInSecondIfSampled int64
+	if r.bytesPerSecond > 0 {
+		bytesInSecondIfSampled = r.bytesInCurrentSecond + traceSize(trace)
+		if bytesInSecondIfSampled > r.bytesPerSecond {
+			return NotSampled, nil
+		}
+	}
+
+	r.spansInCurrentSecond = spansInSecondIfSampled
+	r.bytesInCurrentSecond = bytesInSecondIfSampled
+	return Sampled, nil
+}
+
+// limitSpans tells whether the number of spans is limited, which is always the case
+// unless only a limit on the number of bytes is configured.
+func limitSpans(spansPerSecond, bytesPerSecond int64) bool {
+	return spansPerSecond > 0 || bytesPerSecond <= 0
 }
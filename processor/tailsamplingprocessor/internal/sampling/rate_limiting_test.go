@@ -16,7 +16,7 @@ import (
 func TestRateLimiter(t *testing.T) {
 	trace := newTraceStringAttrs(nil, "example", "value")
 	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
-	rateLimiter := NewRateLimiting(componenttest.NewNopTelemetrySettings(), 3)
+	rateLimiter := NewRateLimiting(componenttest.NewNopTelemetrySettings(), 3, 0)
 
 	// Trace span count greater than spans per second
 	traceSpanCount := &atomic.Int64{}
@@ -49,3 +49,35 @@ func TestRateLimiter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, Sampled, decision)
 }
+
+func TestRateLimiterBytes(t *testing.T) {
+	trace := newTraceStringAttrs(nil, "example", "value")
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	size := traceSize(trace)
+
+	// Only the bytes are limited, the number of spans is not
+	rateLimiter := NewRateLimiting(componenttest.NewNopTelemetrySettings(), 0, 2*size)
+	traceSpanCount := &atomic.Int64{}
+	traceSpanCount.Store(1000)
+	trace.SpanCount = traceSpanCount
+
+	decision, err := rateLimiter.Evaluate(context.Background(), traceID, trace)
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	// Trace size equal to the remaining budget
+	decision, err = rateLimiter.Evaluate(context.Background(), traceID, trace)
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	// Budget exhausted
+	decision, err = rateLimiter.Evaluate(context.Background(), traceID, trace)
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+
+	// Both limits apply when set
+	rateLimiter = NewRateLimiting(componenttest.NewNopTelemetrySettings(), 3, 10*size)
+	decision, err = rateLimiter.Evaluate(context.Background(), traceID, trace)
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
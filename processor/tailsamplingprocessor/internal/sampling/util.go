@@ -107,3 +107,12 @@ func SetAttrOnScopeSpans(data *TraceData, attrName string, attrKey string) {
 		}
 	}
 }
+
+// traceSize returns the size of the trace once encoded in protobuf.
+func traceSize(data *TraceData) int64 {
+	data.Mutex.Lock()
+	defer data.Mutex.Unlock()
+
+	sizer := ptrace.ProtoMarshaler{}
+	return int64(sizer.TracesSize(data.ReceivedBatches))
+}
@@ -0,0 +1,332 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const (
+	pendingIndexKey        = "pending_traces"
+	sampledDecisionsKey    = "sampled_decisions"
+	nonSampledDecisionsKey = "non_sampled_decisions"
+	traceKeyPrefix         = "trace_"
+
+	// traceRecordHeaderLen is the size of the fixed header of a persisted trace:
+	// the arrival time in unix nanoseconds followed by the span count.
+	traceRecordHeaderLen = 16
+)
+
+var errInvalidTraceRecord = errors.New("invalid persisted trace record")
+
+// persistedTrace is a pending trace restored from storage.
+type persistedTrace struct {
+	id   pcommon.TraceID
+	data *sampling.TraceData
+}
+
+// traceStore checkpoints the traces waiting for a sampling decision and the
+// most recent sampling decisions into a storage.Client, so that they can be
+// restored after the collector restarts.
+//
+// Writes are not performed on the hot path: traces are only marked as dirty
+// when spans arrive and are written out by checkpoint, which the processor
+// calls after every run of the decision ticker and on shutdown.
+type traceStore struct {
+	client storage.Client
+
+	mu         sync.Mutex
+	pending    map[pcommon.TraceID]struct{}
+	dirty      map[pcommon.TraceID]struct{}
+	removed    map[pcommon.TraceID]struct{}
+	indexDirty bool
+
+	sampled    *decisionJournal
+	notSampled *decisionJournal
+}
+
+func newTraceStore(client storage.Client, sampledSize, nonSampledSize int) *traceStore {
+	return &traceStore{
+		client:     client,
+		pending:    make(map[pcommon.TraceID]struct{}),
+		dirty:      make(map[pcommon.TraceID]struct{}),
+		removed:    make(map[pcommon.TraceID]struct{}),
+		sampled:    newDecisionJournal(sampledSize),
+		notSampled: newDecisionJournal(nonSampledSize),
+	}
+}
+
+// markDirty records that new spans were added to the given pending trace.
+func (s *traceStore) markDirty(id pcommon.TraceID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pending[id]; !ok {
+		s.pending[id] = struct{}{}
+		s.indexDirty = true
+	}
+	delete(s.removed, id)
+	s.dirty[id] = struct{}{}
+}
+
+// markRemoved records that the given trace is no longer held by the processor.
+func (s *traceStore) markRemoved(id pcommon.TraceID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pending[id]; !ok {
+		return
+	}
+	delete(s.pending, id)
+	delete(s.dirty, id)
+	s.removed[id] = struct{}{}
+	s.indexDirty = true
+}
+
+// recordDecision records the final sampling decision taken for the given trace.
+func (s *traceStore) recordDecision(id pcommon.TraceID, decision sampling.Decision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch decision {
+	case sampling.Sampled:
+		s.sampled.add(id)
+	case sampling.NotSampled:
+		s.notSampled.add(id)
+	}
+}
+
+// checkpoint writes all the changes recorded since the previous checkpoint.
+// The lookup function is used to retrieve the current data of dirty traces.
+func (s *traceStore) checkpoint(ctx context.Context, lookup func(pcommon.TraceID) (*sampling.TraceData, bool)) error {
+	s.mu.Lock()
+	ops := make([]*storage.Operation, 0, len(s.dirty)+len(s.removed)+3)
+	for id := range s.removed {
+		ops = append(ops, storage.DeleteOperation(traceKey(id)))
+	}
+	dirty := s.dirty
+	s.dirty = make(map[pcommon.TraceID]struct{})
+	s.removed = make(map[pcommon.TraceID]struct{})
+	if s.indexDirty {
+		ops = append(ops, storage.SetOperation(pendingIndexKey, encodeTraceIDs(keys(s.pending))))
+		s.indexDirty = false
+	}
+	if s.sampled.dirty {
+		ops = append(ops, storage.SetOperation(sampledDecisionsKey, encodeTraceIDs(s.sampled.ids())))
+		s.sampled.dirty = false
+	}
+	if s.notSampled.dirty {
+		ops = append(ops, storage.SetOperation(nonSampledDecisionsKey, encodeTraceIDs(s.notSampled.ids())))
+		s.notSampled.dirty = false
+	}
+	s.mu.Unlock()
+
+	// Traces are marshaled outside the store lock to avoid blocking the ingestion path.
+	for id := range dirty {
+		trace, ok := lookup(id)
+		if !ok {
+			continue
+		}
+		record, err := encodeTraceRecord(trace)
+		if err != nil {
+			return fmt.Errorf("failed to encode trace %s: %w", id, err)
+		}
+		ops = append(ops, storage.SetOperation(traceKey(id), record))
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+	return s.client.Batch(ctx, ops...)
+}
+
+// load restores the pending traces and sampling decisions from the storage.
+func (s *traceStore) load(ctx context.Context) (traces []persistedTrace, sampled, notSampled []pcommon.TraceID, err error) {
+	index, err := s.client.Get(ctx, pendingIndexKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ids, err := decodeTraceIDs(index)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		record, err := s.client.Get(ctx, traceKey(id))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if record == nil {
+			// The index was written but the trace itself was not, nothing to restore.
+			s.indexDirty = true
+			continue
+		}
+		trace, err := decodeTraceRecord(record)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to decode trace %s: %w", id, err)
+		}
+		s.pending[id] = struct{}{}
+		traces = append(traces, persistedTrace{id: id, data: trace})
+	}
+
+	if sampled, err = s.loadDecisions(ctx, sampledDecisionsKey, s.sampled); err != nil {
+		return nil, nil, nil, err
+	}
+	if notSampled, err = s.loadDecisions(ctx, nonSampledDecisionsKey, s.notSampled); err != nil {
+		return nil, nil, nil, err
+	}
+	return traces, sampled, notSampled, nil
+}
+
+func (s *traceStore) loadDecisions(ctx context.Context, key string, journal *decisionJournal) ([]pcommon.TraceID, error) {
+	buf, err := s.client.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := decodeTraceIDs(buf)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		journal.add(id)
+	}
+	journal.dirty = false
+	return journal.ids(), nil
+}
+
+// decisionJournal keeps the most recent trace IDs for a given decision, bounded
+// by the size of the matching decision cache.
+type decisionJournal struct {
+	buf   []pcommon.TraceID
+	next  int
+	full  bool
+	dirty bool
+}
+
+func newDecisionJournal(size int) *decisionJournal {
+	return &decisionJournal{buf: make([]pcommon.TraceID, size)}
+}
+
+func (j *decisionJournal) add(id pcommon.TraceID) {
+	if len(j.buf) == 0 {
+		return
+	}
+	j.buf[j.next] = id
+	j.next++
+	if j.next == len(j.buf) {
+		j.next = 0
+		j.full = true
+	}
+	j.dirty = true
+}
+
+// ids returns the journaled IDs from the oldest to the most recent.
+func (j *decisionJournal) ids() []pcommon.TraceID {
+	if !j.full {
+		return append([]pcommon.TraceID(nil), j.buf[:j.next]...)
+	}
+	ids := make([]pcommon.TraceID, 0, len(j.buf))
+	ids = append(ids, j.buf[j.next:]...)
+	return append(ids, j.buf[:j.next]...)
+}
+
+func traceKey(id pcommon.TraceID) string {
+	return traceKeyPrefix + id.String()
+}
+
+func keys(m map[pcommon.TraceID]struct{}) []pcommon.TraceID {
+	ids := make([]pcommon.TraceID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func encodeTraceIDs(ids []pcommon.TraceID) []byte {
+	buf := make([]byte, 0, len(ids)*len(pcommon.TraceID{}))
+	for _, id := range ids {
+		buf = append(buf, id[:]...)
+	}
+	return buf
+}
+
+func decodeTraceIDs(buf []byte) ([]pcommon.TraceID, error) {
+	idLen := len(pcommon.TraceID{})
+	if len(buf)%idLen != 0 {
+		return nil, fmt.Errorf("invalid persisted trace ID list of length %d", len(buf))
+	}
+	ids := make([]pcommon.TraceID, 0, len(buf)/idLen)
+	for i := 0; i < len(buf); i += idLen {
+		ids = append(ids, pcommon.TraceID(buf[i:i+idLen]))
+	}
+	return ids, nil
+}
+
+func encodeTraceRecord(trace *sampling.TraceData) ([]byte, error) {
+	marshaler := &ptrace.ProtoMarshaler{}
+
+	trace.Lock()
+	defer trace.Unlock()
+
+	spans, err := marshaler.MarshalTraces(trace.ReceivedBatches)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, traceRecordHeaderLen, traceRecordHeaderLen+len(spans))
+	binary.BigEndian.PutUint64(buf[0:8], uint64(trace.ArrivalTime.UnixNano()))
+	binary.BigEndian.PutUint64(buf[8:16], uint64(trace.SpanCount.Load()))
+	return append(buf, spans...), nil
+}
+
+func decodeTraceRecord(buf []byte) (*sampling.TraceData, error) {
+	if len(buf) < traceRecordHeaderLen {
+		return nil, errInvalidTraceRecord
+	}
+	unmarshaler := &ptrace.ProtoUnmarshaler{}
+	spans, err := unmarshaler.UnmarshalTraces(buf[traceRecordHeaderLen:])
+	if err != nil {
+		return nil, err
+	}
+
+	spanCount := &atomic.Int64{}
+	spanCount.Store(int64(binary.BigEndian.Uint64(buf[8:16])))
+
+	trace := &sampling.TraceData{
+		ArrivalTime:     time.Unix(0, int64(binary.BigEndian.Uint64(buf[0:8]))),
+		SpanCount:       spanCount,
+		ReceivedBatches: spans,
+	}
+	return trace, nil
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func newPersistentTestProcessor(t *testing.T, storageID component.ID, decisionWait time.Duration, mpe *mockPolicyEvaluator, nextConsumer *consumertest.TracesSink) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait:  decisionWait,
+		NumTraces:     defaultNumTraces,
+		DecisionCache: DecisionCacheConfig{SampledCacheSize: 10, NonSampledCacheSize: 10},
+		Storage:       &storageID,
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+			withPolicies([]*policy{
+				{name: "mock-policy", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy"))},
+			}),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+	require.NoError(t, err)
+	return p.(*tailSamplingSpanProcessor)
+}
+
+func TestPendingTracesSurviveRestart(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("test")
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	// The first instance receives the trace but is stopped before taking a decision.
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	sink := new(consumertest.TracesSink)
+	tsp := newPersistentTestProcessor(t, storageID, defaultTestDecisionWait, mpe, sink)
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", storageDir)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	tsp.policyTicker.OnTick()
+	require.NoError(t, tsp.Shutdown(context.Background()))
+	assert.Equal(t, 0, mpe.EvaluationCount)
+	assert.Equal(t, 0, sink.SpanCount())
+
+	// The second instance restores the trace, keeping its original arrival time.
+	mpe = &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	sink = new(consumertest.TracesSink)
+	tsp = newPersistentTestProcessor(t, storageID, time.Nanosecond, mpe, sink)
+	host = storagetest.NewStorageHost().WithFileBackedStorageExtension("test", storageDir)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	assert.EqualValues(t, 1, tsp.numTracesOnMap.Load())
+
+	d, ok := tsp.idToTrace.Load(traceID)
+	require.True(t, ok)
+	assert.EqualValues(t, 2, d.(*sampling.TraceData).SpanCount.Load())
+
+	// The decision wait has already elapsed, so the trace is evaluated on the first tick.
+	tsp.policyTicker.OnTick()
+	assert.Equal(t, 1, mpe.EvaluationCount)
+	assert.Equal(t, 2, sink.SpanCount())
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	// Once decided, the trace is not restored anymore.
+	tsp = newPersistentTestProcessor(t, storageID, time.Nanosecond, &mockPolicyEvaluator{}, new(consumertest.TracesSink))
+	host = storagetest.NewStorageHost().WithFileBackedStorageExtension("test", storageDir)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	assert.EqualValues(t, 0, tsp.numTracesOnMap.Load())
+	require.NoError(t, tsp.Shutdown(context.Background()))
+}
+
+func TestDecisionsSurviveRestart(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("test")
+	sampledID := uInt64ToTraceID(1)
+	notSampledID := uInt64ToTraceID(2)
+
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp := newPersistentTestProcessor(t, storageID, defaultTestDecisionWait, mpe, new(consumertest.TracesSink))
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", storageDir)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	mpe.NextDecision = sampling.NotSampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(notSampledID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Equal(t, 2, mpe.EvaluationCount)
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	// Late spans received by the next instance are released according to the persisted decisions.
+	mpe = &mockPolicyEvaluator{}
+	sink := new(consumertest.TracesSink)
+	tsp = newPersistentTestProcessor(t, storageID, defaultTestDecisionWait, mpe, sink)
+	host = storagetest.NewStorageHost().WithFileBackedStorageExtension("test", storageDir)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(notSampledID)))
+	assert.Equal(t, 1, sink.SpanCount())
+	assert.EqualValues(t, 0, tsp.numTracesOnMap.Load())
+	require.NoError(t, tsp.Shutdown(context.Background()))
+}
+
+func TestStorageExtensionNotFound(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	tsp := newPersistentTestProcessor(t, storageID, defaultTestDecisionWait, &mockPolicyEvaluator{}, new(consumertest.TracesSink))
+	err := tsp.Start(context.Background(), storagetest.NewStorageHost())
+	require.ErrorContains(t, err, "storage extension 'test_storage/missing' not found")
+	require.NoError(t, tsp.Shutdown(context.Background()))
+}
+
+func TestDecisionJournal(t *testing.T) {
+	ids := []pcommon.TraceID{{1}, {2}, {3}, {4}}
+
+	j := newDecisionJournal(3)
+	j.add(ids[0])
+	j.add(ids[1])
+	assert.Equal(t, ids[:2], j.ids())
+
+	j.add(ids[2])
+	j.add(ids[3])
+	assert.Equal(t, ids[1:], j.ids())
+
+	disabled := newDecisionJournal(0)
+	disabled.add(ids[0])
+	assert.Empty(t, disabled.ids())
+	assert.False(t, disabled.dirty)
+}
+
+func TestTraceRecordRoundTrip(t *testing.T) {
+	spanCount := &atomic.Int64{}
+	spanCount.Store(1)
+	trace := &sampling.TraceData{
+		ArrivalTime:     time.Unix(0, 1_700_000_000_123_456_789),
+		SpanCount:       spanCount,
+		ReceivedBatches: simpleTraces(),
+	}
+
+	buf, err := encodeTraceRecord(trace)
+	require.NoError(t, err)
+	decoded, err := decodeTraceRecord(buf)
+	require.NoError(t, err)
+	assert.True(t, trace.ArrivalTime.Equal(decoded.ArrivalTime))
+	assert.Equal(t, trace.SpanCount.Load(), decoded.SpanCount.Load())
+	assert.Equal(t, trace.ReceivedBatches, decoded.ReceivedBatches)
+
+	_, err = decodeTraceRecord(buf[:traceRecordHeaderLen-1])
+	assert.ErrorIs(t, err, errInvalidTraceRecord)
+}
@@ -5,9 +5,11 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -23,6 +25,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionsharing"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
@@ -63,6 +66,26 @@ type tailSamplingSpanProcessor struct {
 	recordPolicy      bool
 	setPolicyMux      sync.Mutex
 	pendingPolicy     []PolicyCfg
+
+	decisionWait   time.Duration
+	storageID      *component.ID
+	store          *traceStore
+	resumedTraces  []persistedTrace
+	sampledSize    int
+	nonSampledSize int
+
+	exchange       decisionsharing.Exchange
+	publishQueue   chan []decisionsharing.Decision
+	publishTimeout time.Duration
+	stopPublishing context.CancelFunc
+	publishWG      sync.WaitGroup
+
+	shadowPolicies       []*policy
+	shadowMutates        bool
+	pendingShadowPolicy  []PolicyCfg
+	shadowPolicyPending  bool
+	recordShadowDecision bool
+	sizer                ptrace.ProtoMarshaler
 }
 
 // spanAndScope a structure for holding information about span and its instrumentation scope.
@@ -73,6 +96,13 @@ type spanAndScope struct {
 	instrumentationScope *pcommon.InstrumentationScope
 }
 
+const (
+	// publishQueueSize is the number of ticks whose decisions can wait to be shared with the peers.
+	publishQueueSize = 16
+	// publishTimeout bounds the time spent sharing the decisions of a tick with the peers.
+	publishTimeout = 5 * time.Second
+)
+
 var (
 	attrSampledTrue     = metric.WithAttributes(attribute.String("sampled", "true"))
 	attrSampledFalse    = metric.WithAttributes(attribute.String("sampled", "false"))
@@ -121,6 +151,10 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		logger:            telemetrySettings.Logger,
 		numTracesOnMap:    &atomic.Uint64{},
 		deleteChan:        make(chan pcommon.TraceID, cfg.NumTraces),
+		decisionWait:      cfg.DecisionWait,
+		storageID:         cfg.Storage,
+		sampledSize:       cfg.DecisionCache.SampledCacheSize,
+		nonSampledSize:    cfg.DecisionCache.NonSampledCacheSize,
 	}
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
 
@@ -128,6 +162,18 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		opt(tsp)
 	}
 
+	if tsp.exchange == nil && cfg.DecisionSharing != nil {
+		if cfg.DecisionSharing.Group != "" {
+			tsp.exchange = decisionsharing.NewInProcessExchange(cfg.DecisionSharing.Group)
+		} else {
+			tsp.exchange = decisionsharing.NewGRPCExchange(telemetrySettings, cfg.DecisionSharing.Server, cfg.DecisionSharing.Peers)
+		}
+	}
+	if tsp.exchange != nil {
+		tsp.publishQueue = make(chan []decisionsharing.Decision, publishQueueSize)
+		tsp.publishTimeout = publishTimeout
+	}
+
 	if tsp.tickerFrequency == 0 {
 		tsp.tickerFrequency = time.Second
 	}
@@ -139,6 +185,13 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		}
 	}
 
+	if tsp.shadowPolicies == nil && len(cfg.Shadow.PolicyCfgs) > 0 {
+		if err := tsp.loadShadowSamplingPolicy(cfg.Shadow.PolicyCfgs); err != nil {
+			return nil, fmt.Errorf("failed to load shadow policies: %w", err)
+		}
+	}
+	tsp.recordShadowDecision = tsp.recordShadowDecision || cfg.Shadow.RecordDecision
+
 	if tsp.decisionBatcher == nil {
 		// this will start a goroutine in the background, so we run it only if everything went
 		// well in creating the policies
@@ -188,6 +241,13 @@ func WithNonSampledDecisionCache(c cache.Cache[bool]) Option {
 	}
 }
 
+// WithDecisionExchange sets the exchange used to share sampling decisions with other processors.
+func WithDecisionExchange(exchange decisionsharing.Exchange) Option {
+	return func(tsp *tailSamplingSpanProcessor) {
+		tsp.exchange = exchange
+	}
+}
+
 func withRecordPolicy() Option {
 	return func(tsp *tailSamplingSpanProcessor) {
 		tsp.recordPolicy = true
@@ -230,7 +290,7 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 		return sampling.NewStatusCodeFilter(settings, scfCfg.StatusCodes)
 	case RateLimiting:
 		rlfCfg := cfg.RateLimitingCfg
-		return sampling.NewRateLimiting(settings, rlfCfg.SpansPerSecond), nil
+		return sampling.NewRateLimiting(settings, rlfCfg.SpansPerSecond, rlfCfg.BytesPerSecond), nil
 	case SpanCount:
 		spCfg := cfg.SpanCountCfg
 		return sampling.NewSpanCount(settings, spCfg.MinSpans, spCfg.MaxSpans), nil
@@ -243,6 +303,17 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 	case OTTLCondition:
 		ottlfCfg := cfg.OTTLConditionCfg
 		return sampling.NewOTTLConditionFilter(settings, ottlfCfg.SpanConditions, ottlfCfg.SpanEventConditions, ottlfCfg.ErrorMode)
+	case Adaptive:
+		aCfg := cfg.AdaptiveCfg
+		key := aCfg.Key
+		if key == "" {
+			key = defaultAdaptiveKey
+		}
+		window := aCfg.Window
+		if window <= 0 {
+			window = defaultAdaptiveWindow
+		}
+		return sampling.NewAdaptive(settings, key, aCfg.TargetTracesPerSecond, int64(window/time.Second), sampling.MonotonicClock{})
 
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
@@ -250,10 +321,43 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 }
 
 type policyMetrics struct {
-	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled int64
+	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled, decisionFromPeer int64
 }
 
 func (tsp *tailSamplingSpanProcessor) loadSamplingPolicy(cfgs []PolicyCfg) error {
+	policies, err := tsp.newPolicies(cfgs)
+	if err != nil {
+		return err
+	}
+
+	tsp.policies = policies
+
+	tsp.logger.Debug("Loaded sampling policy", zap.Int("policies.len", len(policies)))
+
+	return nil
+}
+
+func (tsp *tailSamplingSpanProcessor) loadShadowSamplingPolicy(cfgs []PolicyCfg) error {
+	policies, err := tsp.newPolicies(cfgs)
+	if err != nil {
+		return err
+	}
+
+	tsp.shadowPolicies = policies
+	tsp.shadowMutates = false
+	for _, p := range policies {
+		if mutator, ok := p.evaluator.(sampling.TraceMutator); ok && mutator.MutatesTrace() {
+			tsp.shadowMutates = true
+		}
+	}
+
+	tsp.logger.Debug("Loaded shadow sampling policy", zap.Int("policies.len", len(policies)))
+
+	return nil
+}
+
+// newPolicies creates the policy evaluators for the given configurations.
+func (tsp *tailSamplingSpanProcessor) newPolicies(cfgs []PolicyCfg) ([]*policy, error) {
 	telemetrySettings := tsp.set.TelemetrySettings
 	componentID := tsp.set.ID.Name()
 
@@ -263,17 +367,17 @@ func (tsp *tailSamplingSpanProcessor) loadSamplingPolicy(cfgs []PolicyCfg) error
 
 	for _, cfg := range cfgs {
 		if cfg.Name == "" {
-			return fmt.Errorf("policy name cannot be empty")
+			return nil, fmt.Errorf("policy name cannot be empty")
 		}
 
 		if _, exists := policyNames[cfg.Name]; exists {
-			return fmt.Errorf("duplicate policy name %q", cfg.Name)
+			return nil, fmt.Errorf("duplicate policy name %q", cfg.Name)
 		}
 		policyNames[cfg.Name] = struct{}{}
 
 		eval, err := getPolicyEvaluator(telemetrySettings, &cfg)
 		if err != nil {
-			return fmt.Errorf("failed to create policy evaluator for %q: %w", cfg.Name, err)
+			return nil, fmt.Errorf("failed to create policy evaluator for %q: %w", cfg.Name, err)
 		}
 
 		uniquePolicyName := cfg.Name
@@ -288,11 +392,7 @@ func (tsp *tailSamplingSpanProcessor) loadSamplingPolicy(cfgs []PolicyCfg) error
 		})
 	}
 
-	tsp.policies = policies
-
-	tsp.logger.Debug("Loaded sampling policy", zap.Int("policies.len", len(policies)))
-
-	return nil
+	return policies, nil
 }
 
 func (tsp *tailSamplingSpanProcessor) SetSamplingPolicy(cfgs []PolicyCfg) {
@@ -304,10 +404,32 @@ func (tsp *tailSamplingSpanProcessor) SetSamplingPolicy(cfgs []PolicyCfg) {
 	tsp.pendingPolicy = cfgs
 }
 
+// SetShadowSamplingPolicy sets the shadow policies to evaluate from the next sampling decisions
+// on, replacing the current ones. No shadow policies are evaluated if cfgs is empty.
+func (tsp *tailSamplingSpanProcessor) SetShadowSamplingPolicy(cfgs []PolicyCfg) {
+	tsp.logger.Debug("Setting pending shadow sampling policy", zap.Int("pending.len", len(cfgs)))
+
+	tsp.setPolicyMux.Lock()
+	defer tsp.setPolicyMux.Unlock()
+
+	tsp.pendingShadowPolicy = cfgs
+	tsp.shadowPolicyPending = true
+}
+
 func (tsp *tailSamplingSpanProcessor) loadPendingSamplingPolicy() {
 	tsp.setPolicyMux.Lock()
 	defer tsp.setPolicyMux.Unlock()
 
+	if tsp.shadowPolicyPending {
+		tsp.logger.Debug("Loading pending shadow sampling policy", zap.Int("pending.len", len(tsp.pendingShadowPolicy)))
+		if err := tsp.loadShadowSamplingPolicy(tsp.pendingShadowPolicy); err != nil {
+			tsp.logger.Error("Failed to load pending shadow sampling policy", zap.Error(err))
+			tsp.logger.Debug("Continuing to use the previously loaded shadow sampling policy")
+		}
+		tsp.pendingShadowPolicy = nil
+		tsp.shadowPolicyPending = false
+	}
+
 	// Nothing pending, do nothing.
 	pLen := len(tsp.pendingPolicy)
 	if pLen == 0 {
@@ -338,7 +460,9 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 	startTime := time.Now()
 
 	batch, _ := tsp.decisionBatcher.CloseCurrentAndTakeFirstBatch()
+	batch = append(batch, tsp.takeResumedTraces(startTime)...)
 	batchLen := len(batch)
+	var decided []decisionsharing.Decision
 
 	for _, id := range batch {
 		d, ok := tsp.idToTrace.Load(id)
@@ -349,7 +473,34 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace := d.(*sampling.TraceData)
 		trace.DecisionTime = time.Now()
 
-		decision := tsp.makeDecision(id, trace, &metrics)
+		decision, ok := tsp.peerDecision(id)
+		if ok {
+			metrics.decisionFromPeer++
+		} else {
+			var shadowDecision sampling.Decision
+			var shadowPolicy string
+			if len(tsp.shadowPolicies) > 0 {
+				shadowDecision, shadowPolicy = tsp.makeShadowDecision(id, trace)
+			}
+
+			var decidingPolicy *policy
+			decision, decidingPolicy = tsp.makeDecision(id, trace, &metrics)
+			if tsp.recordShadowDecision && decision == sampling.Sampled && len(tsp.shadowPolicies) > 0 {
+				recordShadowDecision(trace, shadowDecision, shadowPolicy)
+			}
+			var policyName string
+			if decidingPolicy != nil {
+				policyName = decidingPolicy.name
+				// only the deciding policy records its sampling threshold, as the traces are
+				// released with its decision
+				if recorder, ok := decidingPolicy.evaluator.(sampling.ThresholdRecorder); ok && decision == sampling.Sampled {
+					recorder.RecordThreshold(trace)
+				}
+			}
+			if tsp.exchange != nil {
+				decided = append(decided, decisionsharing.Decision{TraceID: id, Sampled: decision == sampling.Sampled, Policy: policyName})
+			}
+		}
 
 		tsp.telemetry.ProcessorTailSamplingSamplingDecisionTimerLatency.Record(tsp.ctx, int64(time.Since(startTime)/time.Millisecond))
 		tsp.telemetry.ProcessorTailSamplingGlobalCountTracesSampled.Add(tsp.ctx, 1, decisionToAttribute[decision])
@@ -361,6 +512,11 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
 
+		if tsp.store != nil {
+			tsp.store.recordDecision(id, decision)
+			tsp.store.markRemoved(id)
+		}
+
 		switch decision {
 		case sampling.Sampled:
 			tsp.releaseSampledTrace(ctx, id, allSpans)
@@ -369,6 +525,9 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		}
 	}
 
+	tsp.checkpoint(ctx)
+	tsp.publishDecisions(decided)
+
 	tsp.telemetry.ProcessorTailSamplingSamplingTracesOnMemory.Record(tsp.ctx, int64(tsp.numTracesOnMap.Load()))
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceDroppedTooEarly.Add(tsp.ctx, metrics.idNotFoundOnMapCount)
 	tsp.telemetry.ProcessorTailSamplingSamplingPolicyEvaluationError.Add(tsp.ctx, metrics.evaluateErrorCount)
@@ -377,13 +536,15 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		zap.Int("batch.len", batchLen),
 		zap.Int64("sampled", metrics.decisionSampled),
 		zap.Int64("notSampled", metrics.decisionNotSampled),
+		zap.Int64("decidedByPeers", metrics.decisionFromPeer),
 		zap.Int64("droppedPriorToEvaluation", metrics.idNotFoundOnMapCount),
 		zap.Int64("policyEvaluationErrors", metrics.evaluateErrorCount),
 	)
 }
 
-func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sampling.TraceData, metrics *policyMetrics) sampling.Decision {
-	finalDecision := sampling.NotSampled
+// makeDecision evaluates the policies for the given trace and returns the final decision
+// along with the policy that took it, if any.
+func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sampling.TraceData, metrics *policyMetrics) (sampling.Decision, *policy) {
 	samplingDecisions := map[sampling.Decision]*policy{
 		sampling.Error:            nil,
 		sampling.Sampled:          nil,
@@ -422,32 +583,103 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		}
 	}
 
-	var sampledPolicy *policy
+	finalDecision, sampledPolicy, decidingPolicy := combineDecisions(samplingDecisions)
+
+	if tsp.recordPolicy && sampledPolicy != nil {
+		sampling.SetAttrOnScopeSpans(trace, "tailsampling.policy", sampledPolicy.name)
+	}
+
+	switch finalDecision {
+	case sampling.Sampled:
+		metrics.decisionSampled++
+	case sampling.NotSampled:
+		metrics.decisionNotSampled++
+	}
+
+	return finalDecision, decidingPolicy
+}
+
+// combineDecisions returns the final decision given the first policy that returned each
+// decision, along with the policy that sampled the trace and the policy that took the
+// final decision, if any.
+func combineDecisions(samplingDecisions map[sampling.Decision]*policy) (finalDecision sampling.Decision, sampledPolicy, decidingPolicy *policy) {
+	finalDecision = sampling.NotSampled
 
 	// InvertNotSampled takes precedence over any other decision
 	switch {
 	case samplingDecisions[sampling.InvertNotSampled] != nil:
-		finalDecision = sampling.NotSampled
+		decidingPolicy = samplingDecisions[sampling.InvertNotSampled]
 	case samplingDecisions[sampling.Sampled] != nil:
 		finalDecision = sampling.Sampled
 		sampledPolicy = samplingDecisions[sampling.Sampled]
 	case samplingDecisions[sampling.InvertSampled] != nil && samplingDecisions[sampling.NotSampled] == nil:
 		finalDecision = sampling.Sampled
 		sampledPolicy = samplingDecisions[sampling.InvertSampled]
+	default:
+		decidingPolicy = samplingDecisions[sampling.NotSampled]
 	}
+	if sampledPolicy != nil {
+		decidingPolicy = sampledPolicy
+	}
+	return finalDecision, sampledPolicy, decidingPolicy
+}
 
-	if tsp.recordPolicy && sampledPolicy != nil {
-		sampling.SetAttrOnScopeSpans(trace, "tailsampling.policy", sampledPolicy.name)
+// makeShadowDecision evaluates the shadow policies for the given trace and records what
+// they would have decided. If a shadow policy can modify the trace, the policies are
+// evaluated on a copy of the trace, so that they don't affect the data sent to the next
+// consumer.
+func (tsp *tailSamplingSpanProcessor) makeShadowDecision(id pcommon.TraceID, trace *sampling.TraceData) (sampling.Decision, string) {
+	shadowTrace := trace
+	trace.Lock()
+	size := tsp.sizer.TracesSize(trace.ReceivedBatches)
+	if tsp.shadowMutates {
+		batches := ptrace.NewTraces()
+		trace.ReceivedBatches.CopyTo(batches)
+		spanCount := &atomic.Int64{}
+		spanCount.Store(trace.SpanCount.Load())
+		shadowTrace = &sampling.TraceData{
+			ArrivalTime:     trace.ArrivalTime,
+			DecisionTime:    trace.DecisionTime,
+			SpanCount:       spanCount,
+			ReceivedBatches: batches,
+		}
 	}
+	trace.Unlock()
 
-	switch finalDecision {
-	case sampling.Sampled:
-		metrics.decisionSampled++
-	case sampling.NotSampled:
-		metrics.decisionNotSampled++
+	samplingDecisions := make(map[sampling.Decision]*policy)
+	ctx := context.Background()
+	for _, p := range tsp.shadowPolicies {
+		decision, err := p.evaluator.Evaluate(ctx, id, shadowTrace)
+		if err != nil {
+			tsp.logger.Debug("Shadow sampling policy error", zap.Error(err))
+			continue
+		}
+		tsp.telemetry.ProcessorTailSamplingShadowCountTracesSampled.Add(ctx, 1, p.attribute, decisionToAttribute[decision])
+		if samplingDecisions[decision] == nil {
+			samplingDecisions[decision] = p
+		}
 	}
 
-	return finalDecision
+	finalDecision, _, decidingPolicy := combineDecisions(samplingDecisions)
+	tsp.telemetry.ProcessorTailSamplingShadowGlobalCountTracesSampled.Add(ctx, 1, decisionToAttribute[finalDecision])
+	tsp.telemetry.ProcessorTailSamplingShadowGlobalCountBytesSampled.Add(ctx, int64(size), decisionToAttribute[finalDecision])
+
+	if decidingPolicy == nil {
+		return finalDecision, ""
+	}
+	return finalDecision, decidingPolicy.name
+}
+
+// recordShadowDecision adds the decision of the shadow policies to the attributes of the trace.
+func recordShadowDecision(trace *sampling.TraceData, decision sampling.Decision, policyName string) {
+	value := "not_sampled"
+	if decision == sampling.Sampled {
+		value = "sampled"
+	}
+	sampling.SetAttrOnScopeSpans(trace, "tailsampling.shadow.decision", value)
+	if policyName != "" {
+		sampling.SetAttrOnScopeSpans(trace, "tailsampling.shadow.policy", policyName)
+	}
 }
 
 // ConsumeTraces is required by the processor.Traces interface.
@@ -522,16 +754,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 				newTraceIDs++
 				tsp.decisionBatcher.AddToCurrentBatch(id)
 				tsp.numTracesOnMap.Add(1)
-				postDeletion := false
-				for !postDeletion {
-					select {
-					case tsp.deleteChan <- id:
-						postDeletion = true
-					default:
-						traceKeyToDrop := <-tsp.deleteChan
-						tsp.dropTrace(traceKeyToDrop, currTime)
-					}
-				}
+				tsp.trackForDeletion(id, currTime)
 			}
 		}
 
@@ -547,6 +770,9 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			// If the final decision hasn't been made, add the new spans under the lock.
 			appendToTraces(actualData.ReceivedBatches, resourceSpans, spans)
 			actualData.Unlock()
+			if tsp.store != nil {
+				tsp.store.markDirty(id)
+			}
 			continue
 		}
 
@@ -576,18 +802,193 @@ func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		client, err := getStorageClient(ctx, host, tsp.storageID, tsp.set.ID)
+		if err != nil {
+			return err
+		}
+		tsp.store = newTraceStore(client, tsp.sampledSize, tsp.nonSampledSize)
+		if err := tsp.restore(ctx); err != nil {
+			return fmt.Errorf("failed to restore persisted traces: %w", err)
+		}
+	}
+	if tsp.exchange != nil {
+		if err := tsp.exchange.Start(ctx, host, tsp.onPeerDecisions); err != nil {
+			return fmt.Errorf("failed to start decision sharing: %w", err)
+		}
+		var publishCtx context.Context
+		publishCtx, tsp.stopPublishing = context.WithCancel(context.Background())
+		tsp.publishWG.Add(1)
+		go tsp.publishLoop(publishCtx)
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+	if tsp.stopPublishing != nil {
+		tsp.stopPublishing()
+		tsp.publishWG.Wait()
+	}
+	var errs error
+	if tsp.exchange != nil {
+		errs = tsp.exchange.Shutdown(ctx)
+	}
+	if tsp.store == nil {
+		return errs
+	}
+	tsp.checkpoint(ctx)
+	return errors.Join(errs, tsp.store.client.Close(ctx))
+}
+
+// onPeerDecisions records the decisions taken by peers in the decision caches, so that
+// the spans of these traces get the same decision when they arrive here.
+func (tsp *tailSamplingSpanProcessor) onPeerDecisions(decisions []decisionsharing.Decision) {
+	for _, d := range decisions {
+		decision := sampling.NotSampled
+		if d.Sampled {
+			decision = sampling.Sampled
+			tsp.sampledIDCache.Put(d.TraceID, true)
+		} else {
+			tsp.nonSampledIDCache.Put(d.TraceID, true)
+		}
+		if tsp.store != nil {
+			tsp.store.recordDecision(d.TraceID, decision)
+		}
+	}
+	tsp.logger.Debug("Received sampling decisions from peers", zap.Int("decisions", len(decisions)))
+}
+
+// peerDecision returns the decision taken by a peer for a trace that is still waiting
+// for a decision locally.
+func (tsp *tailSamplingSpanProcessor) peerDecision(id pcommon.TraceID) (sampling.Decision, bool) {
+	if tsp.exchange == nil {
+		return sampling.Unspecified, false
+	}
+	if _, ok := tsp.sampledIDCache.Get(id); ok {
+		return sampling.Sampled, true
+	}
+	if _, ok := tsp.nonSampledIDCache.Get(id); ok {
+		return sampling.NotSampled, true
+	}
+	return sampling.Unspecified, false
+}
+
+// publishDecisions queues the decisions taken in the last tick to be sent to the peers, so that
+// slow or unreachable peers don't delay the sampling decisions. The decisions are dropped if the
+// queue is full.
+func (tsp *tailSamplingSpanProcessor) publishDecisions(decisions []decisionsharing.Decision) {
+	if len(decisions) == 0 {
+		return
+	}
+	select {
+	case tsp.publishQueue <- decisions:
+	default:
+		tsp.logger.Warn("Dropping sampling decisions, too many decisions are waiting to be shared", zap.Int("decisions", len(decisions)))
+	}
+}
+
+// publishLoop sends the queued decisions to the peers until the given context is canceled.
+func (tsp *tailSamplingSpanProcessor) publishLoop(ctx context.Context) {
+	defer tsp.publishWG.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case decisions := <-tsp.publishQueue:
+			publishCtx, cancel := context.WithTimeout(ctx, tsp.publishTimeout)
+			if err := tsp.exchange.Publish(publishCtx, decisions); err != nil {
+				tsp.logger.Warn("Failed to share sampling decisions", zap.Error(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// restore loads the traces and decisions persisted by a previous run of the
+// processor. Pending traces keep their original arrival time, so they are
+// evaluated once the remainder of their decision wait has elapsed.
+func (tsp *tailSamplingSpanProcessor) restore(ctx context.Context) error {
+	traces, sampled, notSampled, err := tsp.store.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range sampled {
+		tsp.sampledIDCache.Put(id, true)
+	}
+	for _, id := range notSampled {
+		tsp.nonSampledIDCache.Put(id, true)
+	}
+
+	sort.Slice(traces, func(i, j int) bool {
+		return traces[i].data.ArrivalTime.Before(traces[j].data.ArrivalTime)
+	})
+	now := time.Now()
+	for _, t := range traces {
+		if _, loaded := tsp.idToTrace.LoadOrStore(t.id, t.data); loaded {
+			continue
+		}
+		tsp.numTracesOnMap.Add(1)
+		tsp.trackForDeletion(t.id, now)
+		tsp.resumedTraces = append(tsp.resumedTraces, t)
+	}
+
+	tsp.logger.Info("Restored persisted tail sampling state",
+		zap.Int("pendingTraces", len(tsp.resumedTraces)),
+		zap.Int("sampledDecisions", len(sampled)),
+		zap.Int("notSampledDecisions", len(notSampled)),
+	)
 	return nil
 }
 
+// takeResumedTraces returns the IDs of the restored traces whose decision wait
+// has elapsed by the given time.
+func (tsp *tailSamplingSpanProcessor) takeResumedTraces(now time.Time) []pcommon.TraceID {
+	var ids []pcommon.TraceID
+	for len(tsp.resumedTraces) > 0 && !tsp.resumedTraces[0].data.ArrivalTime.Add(tsp.decisionWait).After(now) {
+		ids = append(ids, tsp.resumedTraces[0].id)
+		tsp.resumedTraces = tsp.resumedTraces[1:]
+	}
+	return ids
+}
+
+// checkpoint persists the changes to the pending traces and decisions, if a
+// storage is configured.
+func (tsp *tailSamplingSpanProcessor) checkpoint(ctx context.Context) {
+	if tsp.store == nil {
+		return
+	}
+	err := tsp.store.checkpoint(ctx, func(id pcommon.TraceID) (*sampling.TraceData, bool) {
+		d, ok := tsp.idToTrace.Load(id)
+		if !ok {
+			return nil, false
+		}
+		return d.(*sampling.TraceData), true
+	})
+	if err != nil {
+		tsp.logger.Warn("Failed to persist tail sampling state", zap.Error(err))
+	}
+}
+
+// trackForDeletion enqueues the trace ID in the circular buffer bounding the
+// number of traces in memory, dropping the oldest trace if it is full.
+func (tsp *tailSamplingSpanProcessor) trackForDeletion(id pcommon.TraceID, currTime time.Time) {
+	for {
+		select {
+		case tsp.deleteChan <- id:
+			return
+		default:
+			traceKeyToDrop := <-tsp.deleteChan
+			tsp.dropTrace(traceKeyToDrop, currTime)
+		}
+	}
+}
+
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
 	var trace *sampling.TraceData
 	if d, ok := tsp.idToTrace.Load(traceID); ok {
@@ -595,6 +996,9 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 		tsp.idToTrace.Delete(traceID)
 		// Subtract one from numTracesOnMap per https://godoc.org/sync/atomic#AddUint64
 		tsp.numTracesOnMap.Add(^uint64(0))
+		if tsp.store != nil {
+			tsp.store.markRemoved(traceID)
+		}
 	}
 	if trace == nil {
 		tsp.logger.Debug("Attempt to delete trace ID not on table", zap.Stringer("id", traceID))
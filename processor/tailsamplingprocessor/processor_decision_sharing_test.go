@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/decisionsharing"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+type recordingExchange struct {
+	decisionsharing.Exchange
+	mu        sync.Mutex
+	published []decisionsharing.Decision
+}
+
+func (e *recordingExchange) Publish(ctx context.Context, decisions []decisionsharing.Decision) error {
+	e.mu.Lock()
+	e.published = append(e.published, decisions...)
+	e.mu.Unlock()
+	return e.Exchange.Publish(ctx, decisions)
+}
+
+func (e *recordingExchange) getPublished() []decisionsharing.Decision {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return slices.Clone(e.published)
+}
+
+// blockingExchange blocks publishing until the context of the call is done.
+type blockingExchange struct {
+	decisionsharing.Exchange
+	errs chan error
+}
+
+func (e *blockingExchange) Publish(ctx context.Context, _ []decisionsharing.Decision) error {
+	<-ctx.Done()
+	select {
+	case e.errs <- ctx.Err():
+	default:
+	}
+	return ctx.Err()
+}
+
+func newSharingTestProcessor(t *testing.T, exchange decisionsharing.Exchange, mpe *mockPolicyEvaluator, nextConsumer *consumertest.TracesSink) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait:  defaultTestDecisionWait,
+		NumTraces:     defaultNumTraces,
+		DecisionCache: DecisionCacheConfig{SampledCacheSize: 10, NonSampledCacheSize: 10},
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+			withPolicies([]*policy{
+				{name: "mock-policy", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy"))},
+			}),
+			WithDecisionExchange(exchange),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+	require.NoError(t, err)
+	tsp := p.(*tailSamplingSpanProcessor)
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, tsp.Shutdown(context.Background())) })
+	return tsp
+}
+
+func TestDecisionsAreSharedWithPeers(t *testing.T) {
+	sampledID := uInt64ToTraceID(1)
+	notSampledID := uInt64ToTraceID(2)
+	pendingID := uInt64ToTraceID(3)
+
+	exchange := &recordingExchange{Exchange: decisionsharing.NewInProcessExchange(t.Name())}
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp := newSharingTestProcessor(t, exchange, mpe, new(consumertest.TracesSink))
+
+	peerMpe := &mockPolicyEvaluator{NextDecision: sampling.NotSampled}
+	peerSink := new(consumertest.TracesSink)
+	peer := newSharingTestProcessor(t, decisionsharing.NewInProcessExchange(t.Name()), peerMpe, peerSink)
+
+	// the peer receives spans of a trace before it is decided elsewhere
+	require.NoError(t, peer.ConsumeTraces(context.Background(), simpleTracesWithID(pendingID)))
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(pendingID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	mpe.NextDecision = sampling.NotSampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(notSampledID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	expected := []decisionsharing.Decision{
+		{TraceID: sampledID, Sampled: true, Policy: "mock-policy"},
+		{TraceID: pendingID, Sampled: true, Policy: "mock-policy"},
+		{TraceID: notSampledID, Sampled: false, Policy: "mock-policy"},
+	}
+	require.Eventually(t, func() bool {
+		return slices.Equal(expected, exchange.getPublished())
+	}, time.Second, time.Millisecond)
+
+	// late spans received by the peer follow the shared decisions
+	require.NoError(t, peer.ConsumeTraces(context.Background(), simpleTracesWithID(sampledID)))
+	require.NoError(t, peer.ConsumeTraces(context.Background(), simpleTracesWithID(notSampledID)))
+	assert.Equal(t, 1, peerSink.SpanCount())
+
+	// the trace pending on the peer takes the shared decision instead of being evaluated again
+	peer.policyTicker.OnTick()
+	peer.policyTicker.OnTick()
+	assert.Equal(t, 0, peerMpe.EvaluationCount)
+	assert.Equal(t, 2, peerSink.SpanCount())
+}
+
+func TestPublishingDecisionsDoesNotBlockTicks(t *testing.T) {
+	exchange := &blockingExchange{
+		Exchange: decisionsharing.NewInProcessExchange(t.Name()),
+		errs:     make(chan error, 1),
+	}
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	sink := new(consumertest.TracesSink)
+	tsp := newSharingTestProcessor(t, exchange, mpe, sink)
+	tsp.publishTimeout = 10 * time.Millisecond
+
+	// the ticks go on while the peers don't receive the decisions, which are dropped once
+	// the queue is full
+	for i := range 2 * publishQueueSize {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(uInt64ToTraceID(uint64(i+1)))))
+		tsp.policyTicker.OnTick()
+		tsp.policyTicker.OnTick()
+	}
+	assert.Equal(t, 2*publishQueueSize, sink.SpanCount())
+
+	// every call to the exchange is bounded by the publish timeout
+	select {
+	case err := <-exchange.errs:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		assert.Fail(t, "publishing the decisions didn't time out")
+	}
+}
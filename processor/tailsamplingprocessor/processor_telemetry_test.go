@@ -5,6 +5,7 @@ package tailsamplingprocessor
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -722,3 +723,199 @@ func (tt *testTelemetry) len(got metricdata.ResourceMetrics) int {
 func (tt *testTelemetry) Shutdown(ctx context.Context) error {
 	return tt.meterProvider.Shutdown(ctx)
 }
+
+func TestShadowPolicies(t *testing.T) {
+	// prepare
+	s := setupTestTelemetry()
+	b := newSyncIDBatcher()
+	syncBatcher := b.(*syncIDBatcher)
+
+	cfg := Config{
+		DecisionWait: 1,
+		NumTraces:    100,
+		PolicyCfgs: []PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name: "always",
+					Type: AlwaysSample,
+				},
+			},
+		},
+		Shadow: ShadowCfg{
+			PolicyCfgs: []PolicyCfg{
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name:          "errors",
+						Type:          StatusCode,
+						StatusCodeCfg: StatusCodeCfg{StatusCodes: []string{"ERROR"}},
+					},
+				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name:        "adaptive",
+						Type:        Adaptive,
+						AdaptiveCfg: AdaptiveCfg{TargetTracesPerSecond: 1},
+					},
+				},
+			},
+			RecordDecision: true,
+		},
+		Options: []Option{
+			withDecisionBatcher(syncBatcher),
+		},
+	}
+	cs := &consumertest.TracesSink{}
+	ct := s.newSettings()
+	proc, err := newTracesProcessor(context.Background(), ct, cs, cfg)
+	require.NoError(t, err)
+	defer func() {
+		err = proc.Shutdown(context.Background())
+		require.NoError(t, err)
+	}()
+
+	err = proc.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	// test
+	err = proc.ConsumeTraces(context.Background(), simpleTraces())
+	require.NoError(t, err)
+
+	tsp := proc.(*tailSamplingSpanProcessor)
+	tsp.policyTicker.OnTick() // the first tick always gets an empty batch
+	tsp.policyTicker.OnTick()
+
+	// verify
+	require.Len(t, cs.AllTraces(), 1)
+	scopeSpans := cs.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0)
+	decision, ok := scopeSpans.Scope().Attributes().Get("tailsampling.shadow.decision")
+	require.True(t, ok)
+	assert.Equal(t, "sampled", decision.Str())
+	shadowPolicy, ok := scopeSpans.Scope().Attributes().Get("tailsampling.shadow.policy")
+	require.True(t, ok)
+	assert.Equal(t, "adaptive", shadowPolicy.Str())
+	// the shadow policies don't modify the data sent to the next consumer
+	assert.Empty(t, scopeSpans.Spans().At(0).TraceState().AsRaw())
+
+	// the size of the trace as evaluated by the shadow policies
+	evaluated := ptrace.NewTraces()
+	cs.AllTraces()[0].CopyTo(evaluated)
+	evaluated.ResourceSpans().At(0).ScopeSpans().At(0).Scope().Attributes().Clear()
+	size := (&ptrace.ProtoMarshaler{}).TracesSize(evaluated)
+
+	var md metricdata.ResourceMetrics
+	require.NoError(t, s.reader.Collect(context.Background(), &md))
+
+	for _, tt := range []metricdata.Metrics{
+		{
+			Name:        "otelcol_processor_tail_sampling_shadow_count_traces_sampled",
+			Description: "Count of traces that would have been sampled or not per shadow sampling policy",
+			Unit:        "{traces}",
+			Data: metricdata.Sum[int64]{
+				IsMonotonic: true,
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(
+							attribute.String("policy", "errors"),
+							attribute.String("sampled", "false"),
+						),
+						Value: 1,
+					},
+					{
+						Attributes: attribute.NewSet(
+							attribute.String("policy", "adaptive"),
+							attribute.String("sampled", "true"),
+						),
+						Value: 1,
+					},
+				},
+			},
+		},
+		{
+			Name:        "otelcol_processor_tail_sampling_shadow_global_count_traces_sampled",
+			Description: "Global count of traces that would have been sampled or not by at least one shadow policy",
+			Unit:        "{traces}",
+			Data: metricdata.Sum[int64]{
+				IsMonotonic: true,
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(attribute.String("sampled", "true")),
+						Value:      1,
+					},
+				},
+			},
+		},
+		{
+			Name:        "otelcol_processor_tail_sampling_shadow_global_count_bytes_sampled",
+			Description: "Size of the traces that would have been sampled or not by at least one shadow policy",
+			Unit:        "By",
+			Data: metricdata.Sum[int64]{
+				IsMonotonic: true,
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(attribute.String("sampled", "true")),
+						Value:      int64(size),
+					},
+				},
+			},
+		},
+	} {
+		got := s.getMetric(tt.Name, md)
+		metricdatatest.AssertEqual(t, tt, got, metricdatatest.IgnoreTimestamp())
+	}
+
+	// the live policies are not affected
+	got := s.getMetric("otelcol_processor_tail_sampling_count_traces_sampled", md)
+	require.IsType(t, metricdata.Sum[int64]{}, got.Data)
+	assert.Len(t, got.Data.(metricdata.Sum[int64]).DataPoints, 1)
+}
+
+func TestShadowPoliciesCopyMutatedTraces(t *testing.T) {
+	composite := PolicyCfg{
+		sharedPolicyCfg: sharedPolicyCfg{Name: "composite", Type: Composite},
+		CompositeCfg: CompositeCfg{
+			MaxTotalSpansPerSecond: 1000,
+			PolicyOrder:            []string{"always"},
+			SubPolicyCfg:           []CompositeSubPolicyCfg{{sharedPolicyCfg: sharedPolicyCfg{Name: "always", Type: AlwaysSample}}},
+			RateAllocation:         []RateAllocationCfg{{Policy: "always", Percent: 100}},
+		},
+	}
+
+	for _, recordPolicy := range []bool{false, true} {
+		t.Run(fmt.Sprintf("recordpolicy=%t", recordPolicy), func(t *testing.T) {
+			require.NoError(t, featuregate.GlobalRegistry().Set("processor.tailsamplingprocessor.recordpolicy", recordPolicy))
+			defer func() {
+				require.NoError(t, featuregate.GlobalRegistry().Set("processor.tailsamplingprocessor.recordpolicy", false))
+			}()
+
+			cfg := Config{
+				DecisionWait: 1,
+				NumTraces:    100,
+				PolicyCfgs:   []PolicyCfg{{sharedPolicyCfg: sharedPolicyCfg{Name: "always", Type: AlwaysSample}}},
+				Shadow:       ShadowCfg{PolicyCfgs: []PolicyCfg{composite}},
+				Options:      []Option{withDecisionBatcher(newSyncIDBatcher())},
+			}
+			cs := &consumertest.TracesSink{}
+			proc, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), cs, cfg)
+			require.NoError(t, err)
+			require.NoError(t, proc.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, proc.Shutdown(context.Background()))
+			}()
+
+			tsp := proc.(*tailSamplingSpanProcessor)
+			// the traces are only copied when the composite policy records its sub-policy in them
+			assert.Equal(t, recordPolicy, tsp.shadowMutates)
+
+			require.NoError(t, proc.ConsumeTraces(context.Background(), simpleTraces()))
+			tsp.policyTicker.OnTick()
+			tsp.policyTicker.OnTick()
+
+			require.Len(t, cs.AllTraces(), 1)
+			_, ok := cs.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Scope().Attributes().Get("tailsampling.composite_policy")
+			assert.False(t, ok)
+		})
+	}
+}
@@ -556,6 +556,50 @@ func TestSetSamplingPolicy(t *testing.T) {
 	assert.Len(t, tsp.policies, 2)
 }
 
+func TestSetShadowSamplingPolicy(t *testing.T) {
+	always := PolicyCfg{sharedPolicyCfg: sharedPolicyCfg{Name: "always", Type: AlwaysSample}}
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		PolicyCfgs:   []PolicyCfg{always},
+		Shadow:       ShadowCfg{PolicyCfgs: []PolicyCfg{always}},
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), new(consumertest.TracesSink), cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	tsp := p.(*tailSamplingSpanProcessor)
+	assert.Len(t, tsp.shadowPolicies, 1)
+
+	// the shadow policies are loaded with the live ones
+	everything := PolicyCfg{sharedPolicyCfg: sharedPolicyCfg{Name: "everything", Type: AlwaysSample}}
+	tsp.SetSamplingPolicy([]PolicyCfg{always, everything})
+	tsp.SetShadowSamplingPolicy([]PolicyCfg{always, everything})
+	assert.Len(t, tsp.shadowPolicies, 1)
+
+	tsp.policyTicker.OnTick()
+	assert.Len(t, tsp.policies, 2)
+	assert.Len(t, tsp.shadowPolicies, 2)
+
+	// Duplicate policy name.
+	tsp.SetShadowSamplingPolicy([]PolicyCfg{always, always})
+	tsp.policyTicker.OnTick()
+	assert.Len(t, tsp.shadowPolicies, 2)
+
+	// the shadow policies can be removed
+	tsp.SetShadowSamplingPolicy(nil)
+	tsp.policyTicker.OnTick()
+	assert.Empty(t, tsp.shadowPolicies)
+	assert.Len(t, tsp.policies, 2)
+}
+
 func TestSubSecondDecisionTime(t *testing.T) {
 	// prepare
 	msp := new(consumertest.TracesSink)
@@ -660,7 +704,7 @@ func TestDecisionPolicyMetrics(t *testing.T) {
 			ReceivedBatches: batches[i],
 		}
 
-		_ = tsp.makeDecision(id, sb, metrics)
+		_, _ = tsp.makeDecision(id, sb, metrics)
 	}
 
 	assert.EqualValues(t, 5, metrics.decisionSampled)
@@ -781,6 +825,73 @@ func (s *syncIDBatcher) CloseCurrentAndTakeFirstBatch() (idbatcher.Batch, bool)
 func (s *syncIDBatcher) Stop() {
 }
 
+func TestAdaptiveThresholdRecordedByDecidingPolicy(t *testing.T) {
+	adaptive := sharedPolicyCfg{Name: "adaptive", Type: Adaptive, AdaptiveCfg: AdaptiveCfg{TargetTracesPerSecond: 100}}
+	always := sharedPolicyCfg{Name: "always", Type: AlwaysSample}
+	unmatched := sharedPolicyCfg{Name: "unmatched", Type: StringAttribute, StringAttributeCfg: StringAttributeCfg{Key: "missing", Values: []string{"value"}}}
+
+	tests := []struct {
+		name       string
+		policies   []PolicyCfg
+		traceState string
+	}{
+		{
+			name:       "adaptive policy deciding",
+			policies:   []PolicyCfg{{sharedPolicyCfg: adaptive}},
+			traceState: "ot=th:0",
+		},
+		{
+			name: "and policy deciding",
+			policies: []PolicyCfg{{
+				sharedPolicyCfg: sharedPolicyCfg{Name: "and", Type: And},
+				AndCfg:          AndCfg{SubPolicyCfg: []AndSubPolicyCfg{{sharedPolicyCfg: adaptive}, {sharedPolicyCfg: always}}},
+			}},
+			traceState: "ot=th:0",
+		},
+		{
+			name: "and policy not sampling the trace",
+			policies: []PolicyCfg{
+				{
+					sharedPolicyCfg: sharedPolicyCfg{Name: "and", Type: And},
+					AndCfg:          AndCfg{SubPolicyCfg: []AndSubPolicyCfg{{sharedPolicyCfg: adaptive}, {sharedPolicyCfg: unmatched}}},
+				},
+				{sharedPolicyCfg: always},
+			},
+		},
+		{
+			name:     "another policy deciding",
+			policies: []PolicyCfg{{sharedPolicyCfg: always}, {sharedPolicyCfg: adaptive}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			cfg := Config{
+				DecisionWait: defaultTestDecisionWait,
+				NumTraces:    defaultNumTraces,
+				PolicyCfgs:   tt.policies,
+				Options:      []Option{withDecisionBatcher(newSyncIDBatcher())},
+			}
+			p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+			require.NoError(t, err)
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			require.NoError(t, p.ConsumeTraces(context.Background(), simpleTraces()))
+			tsp := p.(*tailSamplingSpanProcessor)
+			tsp.policyTicker.OnTick()
+			tsp.policyTicker.OnTick()
+
+			require.Equal(t, 1, sink.SpanCount())
+			span := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.traceState, span.TraceState().AsRaw())
+		})
+	}
+}
+
 func simpleTraces() ptrace.Traces {
 	return simpleTracesWithID(pcommon.TraceID([16]byte{1, 2, 3, 4}))
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/profiles"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/traces"
 )
 
@@ -39,12 +40,22 @@ type Config struct {
 	// The default value is `propagate`.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
 
-	TraceStatements  []common.ContextStatements `mapstructure:"trace_statements"`
-	MetricStatements []common.ContextStatements `mapstructure:"metric_statements"`
-	LogStatements    []common.ContextStatements `mapstructure:"log_statements"`
+	TraceStatements   []common.ContextStatements `mapstructure:"trace_statements"`
+	MetricStatements  []common.ContextStatements `mapstructure:"metric_statements"`
+	LogStatements     []common.ContextStatements `mapstructure:"log_statements"`
+	ProfileStatements []common.ContextStatements `mapstructure:"profile_statements"`
+
+	// Functions declares user functions, composed of the other OTTL functions, that can be called by the
+	// statements of all contexts like built-in functions.
+	Functions []ottl.UserFunctionConfig `mapstructure:"functions"`
 
 	FlattenData bool `mapstructure:"flatten_data"`
-	logger      *zap.Logger
+
+	// Profiling enables the execution telemetry of the statements, reporting for each statement the number
+	// of executions, matches and errors, and the cumulative execution time.
+	Profiling bool `mapstructure:"profiling"`
+
+	logger *zap.Logger
 }
 
 // Unmarshal is used internally by mapstructure to parse the transformprocessor configuration (Config),
@@ -73,9 +84,10 @@ func (c *Config) Unmarshal(conf *confmap.Conf) error {
 	}
 
 	contextStatementsFields := map[string]*[]common.ContextStatements{
-		"trace_statements":  &c.TraceStatements,
-		"metric_statements": &c.MetricStatements,
-		"log_statements":    &c.LogStatements,
+		"trace_statements":   &c.TraceStatements,
+		"metric_statements":  &c.MetricStatements,
+		"log_statements":     &c.LogStatements,
+		"profile_statements": &c.ProfileStatements,
 	}
 
 	flatContextStatements := map[string][]int{}
@@ -132,8 +144,13 @@ var _ component.Config = (*Config)(nil)
 func (c *Config) Validate() error {
 	var errors error
 
+	userFunctions, err := c.userFunctions()
+	if err != nil {
+		return err
+	}
+
 	if len(c.TraceStatements) > 0 {
-		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(traces.SpanFunctions()), common.WithSpanEventParser(traces.SpanEventFunctions()))
+		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, userFunctions, common.WithSpanParser(traces.SpanFunctions(), userFunctions), common.WithSpanEventParser(traces.SpanEventFunctions(), userFunctions))
 		if err != nil {
 			return err
 		}
@@ -146,7 +163,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.MetricStatements) > 0 {
-		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(metrics.MetricFunctions()), common.WithDataPointParser(metrics.DataPointFunctions()))
+		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, userFunctions, common.WithMetricParser(metrics.MetricFunctions(), userFunctions), common.WithDataPointParser(metrics.DataPointFunctions(), userFunctions))
 		if err != nil {
 			return err
 		}
@@ -159,7 +176,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.LogStatements) > 0 {
-		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(logs.LogFunctions()))
+		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, userFunctions, common.WithLogParser(logs.LogFunctions(), userFunctions))
 		if err != nil {
 			return err
 		}
@@ -171,9 +188,30 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if len(c.ProfileStatements) > 0 {
+		pc, err := common.NewProfileParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, userFunctions, common.WithProfileParser(profiles.ProfileFunctions(), userFunctions), common.WithSampleParser(profiles.SampleFunctions(), userFunctions))
+		if err != nil {
+			return err
+		}
+		for _, cs := range c.ProfileStatements {
+			_, err = pc.ParseContextStatements(cs)
+			if err != nil {
+				errors = multierr.Append(errors, err)
+			}
+		}
+	}
+
 	if c.FlattenData && !flatLogsFeatureGate.IsEnabled() {
 		errors = multierr.Append(errors, errFlatLogsGateDisabled)
 	}
 
 	return errors
 }
+
+// userFunctions returns the user functions declared by the configuration, or nil if there are none.
+func (c *Config) userFunctions() (*ottl.UserFunctions, error) {
+	if len(c.Functions) == 0 {
+		return nil, nil
+	}
+	return ottl.NewUserFunctions(c.Functions)
+}
@@ -75,6 +75,7 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -108,6 +109,7 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -122,8 +124,9 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
-				MetricStatements: []common.ContextStatements{},
-				LogStatements:    []common.ContextStatements{},
+				MetricStatements:  []common.ContextStatements{},
+				LogStatements:     []common.ContextStatements{},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -144,6 +147,44 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "unknown_function_log"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "profile_statements"),
+			expected: &Config{
+				ErrorMode:        ottl.PropagateError,
+				TraceStatements:  []common.ContextStatements{},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements:    []common.ContextStatements{},
+				ProfileStatements: []common.ContextStatements{
+					{
+						Context:    "profile",
+						Conditions: []string{`period_type.type == "cpu"`},
+						Statements: []string{`set(attributes["profiler"], "pprof")`},
+					},
+					{
+						Statements:  []string{`set(sample.attributes["depth"], Len(sample.function_names))`},
+						SharedCache: true,
+					},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_profile"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "unknown_function_profile"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_user_function"),
+			errors: []error{
+				errors.New("converters must declare an expression and no statements"),
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "built_in_user_function"),
+			errors: []error{
+				errors.New(`user function "Concat" conflicts with the built-in function of the same name`),
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_multi_signal"),
 			errors: []error{
@@ -152,6 +193,38 @@ func TestLoadConfig(t *testing.T) {
 				errors.New("unexpected token \"none\""),
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "user_functions"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Functions: []ottl.UserFunctionConfig{
+					{
+						Name:       "set_upper",
+						Params:     []string{"target"},
+						Statements: []string{`set(target, ToUpperCase(target))`},
+					},
+					{
+						Name:       "Shout",
+						Params:     []string{"value"},
+						Expression: `Concat([ToUpperCase(value), "!"], "")`,
+					},
+				},
+				TraceStatements: []common.ContextStatements{
+					{
+						SharedCache: true,
+						Statements:  []string{`set_upper(span.name)`},
+					},
+				},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						SharedCache: true,
+						Statements:  []string{`set(log.body, Shout(log.body))`},
+					},
+				},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "structured_configuration_with_path_context"),
 			expected: &Config{
@@ -174,6 +247,7 @@ func TestLoadConfig(t *testing.T) {
 						Statements: []string{`set(log.body, "bear") where log.attributes["http.path"] == "/animal"`},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -204,6 +278,7 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -240,6 +315,7 @@ func TestLoadConfig(t *testing.T) {
 						Statements:  []string{`set(resource.attributes["name"], "bear")`},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -306,6 +382,7 @@ func TestLoadConfig(t *testing.T) {
 						Statements:  []string{`set(log.body, "lion") where log.attributes["http.path"] == "/animal"`},
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 		{
@@ -342,6 +419,7 @@ func TestLoadConfig(t *testing.T) {
 						ErrorMode:  "",
 					},
 				},
+				ProfileStatements: []common.ContextStatements{},
 			},
 		},
 	}
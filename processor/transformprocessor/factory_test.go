@@ -13,8 +13,10 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/processor/xprocessor"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
@@ -30,10 +32,11 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
 	assert.Equal(t, &Config{
-		ErrorMode:        ottl.PropagateError,
-		TraceStatements:  []common.ContextStatements{},
-		MetricStatements: []common.ContextStatements{},
-		LogStatements:    []common.ContextStatements{},
+		ErrorMode:         ottl.PropagateError,
+		TraceStatements:   []common.ContextStatements{},
+		MetricStatements:  []common.ContextStatements{},
+		LogStatements:     []common.ContextStatements{},
+		ProfileStatements: []common.ContextStatements{},
 	}, cfg)
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
 }
@@ -190,6 +193,52 @@ func TestFactoryCreateLogs_InvalidActions(t *testing.T) {
 	assert.Nil(t, ap)
 }
 
+func TestFactoryCreateProfiles(t *testing.T) {
+	factory := NewFactory().(xprocessor.Factory)
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.ErrorMode = ottl.IgnoreError
+	oCfg.ProfileStatements = []common.ContextStatements{
+		{
+			Context: "profile",
+			Statements: []string{
+				`set(attributes["test"], "pass") where period == 10`,
+				`set(attributes["test error mode"], ParseJSON(1)) where period == 10`,
+			},
+		},
+	}
+	pp, err := factory.CreateProfiles(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	assert.NotNil(t, pp)
+	assert.NoError(t, err)
+
+	pd := pprofile.NewProfiles()
+	profile := pd.ResourceProfiles().AppendEmpty().ScopeProfiles().AppendEmpty().Profiles().AppendEmpty()
+	profile.SetPeriod(10)
+
+	err = pp.ConsumeProfiles(context.Background(), pd)
+	assert.NoError(t, err)
+
+	attrs := pprofile.FromAttributeIndices(profile.AttributeTable(), profile)
+	val, ok := attrs.Get("test")
+	assert.True(t, ok)
+	assert.Equal(t, "pass", val.Str())
+}
+
+func TestFactoryCreateProfiles_InvalidActions(t *testing.T) {
+	factory := NewFactory().(xprocessor.Factory)
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.ProfileStatements = []common.ContextStatements{
+		{
+			Context:    "sample",
+			Statements: []string{`set(123`},
+		},
+	}
+	ap, err := factory.CreateProfiles(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, ap)
+}
+
 func TestFactoryCreateLogProcessor(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
@@ -58,9 +59,9 @@ type LogParserCollection ottl.ParserCollection[LogsConsumer]
 
 type LogParserCollectionOption ottl.ParserCollectionOption[LogsConsumer]
 
-func WithLogParser(functions map[string]ottl.Factory[ottllog.TransformContext]) LogParserCollectionOption {
+func WithLogParser(functions map[string]ottl.Factory[ottllog.TransformContext], userFunctions *ottl.UserFunctions) LogParserCollectionOption {
 	return func(pc *ottl.ParserCollection[LogsConsumer]) error {
-		logParser, err := ottllog.NewParser(functions, pc.Settings, ottllog.EnablePathContextNames())
+		logParser, err := ottllog.NewParser(functions, pc.Settings, parserOptions(userFunctions, ottllog.EnablePathContextNames())...)
 		if err != nil {
 			return err
 		}
@@ -72,9 +73,13 @@ func WithLogErrorMode(errorMode ottl.ErrorMode) LogParserCollectionOption {
 	return LogParserCollectionOption(ottl.WithParserCollectionErrorMode[LogsConsumer](errorMode))
 }
 
-func NewLogParserCollection(settings component.TelemetrySettings, options ...LogParserCollectionOption) (*LogParserCollection, error) {
+func WithLogProfiling(enabled bool) LogParserCollectionOption {
+	return LogParserCollectionOption(ottl.WithParserCollectionProfiling[LogsConsumer](enabled))
+}
+
+func NewLogParserCollection(settings component.TelemetrySettings, userFunctions *ottl.UserFunctions, options ...LogParserCollectionOption) (*LogParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[LogsConsumer]{
-		withCommonContextParsers[LogsConsumer](),
+		withCommonContextParsers[LogsConsumer](userFunctions),
 		ottl.EnableParserCollectionModifiedStatementLogging[LogsConsumer](true),
 	}
 
@@ -108,7 +113,11 @@ func convertLogStatements(pc *ottl.ParserCollection[LogsConsumer], _ *ottl.Parse
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	lStatements := ottllog.NewStatementSequence(parsedStatements, pc.Settings, ottllog.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottllog.StatementSequenceOption{ottllog.WithStatementSequenceErrorMode(errorMode)}
+	if pc.Profiling {
+		sequenceOptions = append(sequenceOptions, ottllog.WithStatementSequenceProfiling(attribute.String(ottl.AttributeContext, ottllog.ContextName)))
+	}
+	lStatements := ottllog.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return logStatements{lStatements, globalExpr}, nil
 }
 
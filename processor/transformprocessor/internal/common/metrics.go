@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
@@ -168,9 +169,9 @@ type MetricParserCollection ottl.ParserCollection[MetricsConsumer]
 
 type MetricParserCollectionOption ottl.ParserCollectionOption[MetricsConsumer]
 
-func WithMetricParser(functions map[string]ottl.Factory[ottlmetric.TransformContext]) MetricParserCollectionOption {
+func WithMetricParser(functions map[string]ottl.Factory[ottlmetric.TransformContext], userFunctions *ottl.UserFunctions) MetricParserCollectionOption {
 	return func(pc *ottl.ParserCollection[MetricsConsumer]) error {
-		metricParser, err := ottlmetric.NewParser(functions, pc.Settings, ottlmetric.EnablePathContextNames())
+		metricParser, err := ottlmetric.NewParser(functions, pc.Settings, parserOptions(userFunctions, ottlmetric.EnablePathContextNames())...)
 		if err != nil {
 			return err
 		}
@@ -178,9 +179,9 @@ func WithMetricParser(functions map[string]ottl.Factory[ottlmetric.TransformCont
 	}
 }
 
-func WithDataPointParser(functions map[string]ottl.Factory[ottldatapoint.TransformContext]) MetricParserCollectionOption {
+func WithDataPointParser(functions map[string]ottl.Factory[ottldatapoint.TransformContext], userFunctions *ottl.UserFunctions) MetricParserCollectionOption {
 	return func(pc *ottl.ParserCollection[MetricsConsumer]) error {
-		dataPointParser, err := ottldatapoint.NewParser(functions, pc.Settings, ottldatapoint.EnablePathContextNames())
+		dataPointParser, err := ottldatapoint.NewParser(functions, pc.Settings, parserOptions(userFunctions, ottldatapoint.EnablePathContextNames())...)
 		if err != nil {
 			return err
 		}
@@ -192,9 +193,13 @@ func WithMetricErrorMode(errorMode ottl.ErrorMode) MetricParserCollectionOption
 	return MetricParserCollectionOption(ottl.WithParserCollectionErrorMode[MetricsConsumer](errorMode))
 }
 
-func NewMetricParserCollection(settings component.TelemetrySettings, options ...MetricParserCollectionOption) (*MetricParserCollection, error) {
+func WithMetricProfiling(enabled bool) MetricParserCollectionOption {
+	return MetricParserCollectionOption(ottl.WithParserCollectionProfiling[MetricsConsumer](enabled))
+}
+
+func NewMetricParserCollection(settings component.TelemetrySettings, userFunctions *ottl.UserFunctions, options ...MetricParserCollectionOption) (*MetricParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[MetricsConsumer]{
-		withCommonContextParsers[MetricsConsumer](),
+		withCommonContextParsers[MetricsConsumer](userFunctions),
 		ottl.EnableParserCollectionModifiedStatementLogging[MetricsConsumer](true),
 	}
 
@@ -228,7 +233,11 @@ func convertMetricStatements(pc *ottl.ParserCollection[MetricsConsumer], _ *ottl
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	mStatements := ottlmetric.NewStatementSequence(parsedStatements, pc.Settings, ottlmetric.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlmetric.StatementSequenceOption{ottlmetric.WithStatementSequenceErrorMode(errorMode)}
+	if pc.Profiling {
+		sequenceOptions = append(sequenceOptions, ottlmetric.WithStatementSequenceProfiling(attribute.String(ottl.AttributeContext, ottlmetric.ContextName)))
+	}
+	mStatements := ottlmetric.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return metricStatements{mStatements, globalExpr}, nil
 }
 
@@ -249,7 +258,11 @@ func convertDataPointStatements(pc *ottl.ParserCollection[MetricsConsumer], _ *o
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	dpStatements := ottldatapoint.NewStatementSequence(parsedStatements, pc.Settings, ottldatapoint.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottldatapoint.StatementSequenceOption{ottldatapoint.WithStatementSequenceErrorMode(errorMode)}
+	if pc.Profiling {
+		sequenceOptions = append(sequenceOptions, ottldatapoint.WithStatementSequenceProfiling(attribute.String(ottl.AttributeContext, ottldatapoint.ContextName)))
+	}
+	dpStatements := ottldatapoint.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return dataPointStatements{dpStatements, globalExpr}, nil
 }
 
@@ -10,7 +10,9 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
@@ -84,6 +86,24 @@ func (r resourceStatements) ConsumeLogs(ctx context.Context, ld plog.Logs, cache
 	return nil
 }
 
+func (r resourceStatements) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles, cache *pcommon.Map) error {
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		rprofiles := pd.ResourceProfiles().At(i)
+		tCtx := ottlresource.NewTransformContext(rprofiles.Resource(), rprofiles, ottlresource.WithCache(cache))
+		condition, err := r.BoolExpr.Eval(ctx, tCtx)
+		if err != nil {
+			return err
+		}
+		if condition {
+			err := r.Execute(ctx, tCtx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 var _ baseContext = &scopeStatements{}
 
 type scopeStatements struct {
@@ -158,19 +178,41 @@ func (s scopeStatements) ConsumeLogs(ctx context.Context, ld plog.Logs, cache *p
 	return nil
 }
 
+func (s scopeStatements) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles, cache *pcommon.Map) error {
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		rprofiles := pd.ResourceProfiles().At(i)
+		for j := 0; j < rprofiles.ScopeProfiles().Len(); j++ {
+			sprofiles := rprofiles.ScopeProfiles().At(j)
+			tCtx := ottlscope.NewTransformContext(sprofiles.Scope(), rprofiles.Resource(), sprofiles, ottlscope.WithCache(cache))
+			condition, err := s.BoolExpr.Eval(ctx, tCtx)
+			if err != nil {
+				return err
+			}
+			if condition {
+				err := s.Execute(ctx, tCtx)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 type baseContext interface {
 	TracesConsumer
 	MetricsConsumer
 	LogsConsumer
+	ProfilesConsumer
 }
 
-func withCommonContextParsers[R any]() ottl.ParserCollectionOption[R] {
+func withCommonContextParsers[R any](userFunctions *ottl.UserFunctions) ottl.ParserCollectionOption[R] {
 	return func(pc *ottl.ParserCollection[R]) error {
-		rp, err := ottlresource.NewParser(ResourceFunctions(), pc.Settings, ottlresource.EnablePathContextNames())
+		rp, err := ottlresource.NewParser(ResourceFunctions(), pc.Settings, parserOptions(userFunctions, ottlresource.EnablePathContextNames())...)
 		if err != nil {
 			return err
 		}
-		sp, err := ottlscope.NewParser(ScopeFunctions(), pc.Settings, ottlscope.EnablePathContextNames())
+		sp, err := ottlscope.NewParser(ScopeFunctions(), pc.Settings, parserOptions(userFunctions, ottlscope.EnablePathContextNames())...)
 		if err != nil {
 			return err
 		}
@@ -189,6 +231,15 @@ func withCommonContextParsers[R any]() ottl.ParserCollectionOption[R] {
 	}
 }
 
+// parserOptions appends the option making the user functions available to the given parser options,
+// if there are any.
+func parserOptions[K any](userFunctions *ottl.UserFunctions, options ...ottl.Option[K]) []ottl.Option[K] {
+	if userFunctions == nil {
+		return options
+	}
+	return append(options, ottl.WithUserFunctions[K](userFunctions))
+}
+
 func parseResourceContextStatements[R any](
 	pc *ottl.ParserCollection[R],
 	_ *ottl.Parser[ottlresource.TransformContext],
@@ -212,7 +263,11 @@ func parseResourceContextStatements[R any](
 	if errGlobalBoolExpr != nil {
 		return *new(R), errGlobalBoolExpr
 	}
-	rStatements := ottlresource.NewStatementSequence(parsedStatements, pc.Settings, ottlresource.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlresource.StatementSequenceOption{ottlresource.WithStatementSequenceErrorMode(errorMode)}
+	if pc.Profiling {
+		sequenceOptions = append(sequenceOptions, ottlresource.WithStatementSequenceProfiling(attribute.String(ottl.AttributeContext, ottlresource.ContextName)))
+	}
+	rStatements := ottlresource.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	result := (baseContext)(resourceStatements{rStatements, globalExpr})
 	return result.(R), nil
 }
@@ -240,7 +295,11 @@ func parseScopeContextStatements[R any](
 	if errGlobalBoolExpr != nil {
 		return *new(R), errGlobalBoolExpr
 	}
-	sStatements := ottlscope.NewStatementSequence(parsedStatements, pc.Settings, ottlscope.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlscope.StatementSequenceOption{ottlscope.WithStatementSequenceErrorMode(errorMode)}
+	if pc.Profiling {
+		sequenceOptions = append(sequenceOptions, ottlscope.WithStatementSequenceProfiling(attribute.String(ottl.AttributeContext, ottlscope.ContextName)))
+	}
+	sStatements := ottlscope.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	result := (baseContext)(scopeStatements{sStatements, globalExpr})
 	return result.(R), nil
 }
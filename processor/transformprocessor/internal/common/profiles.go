@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlsample"
+)
+
+type ProfilesConsumer interface {
+	Context() ContextID
+	ConsumeProfiles(ctx context.Context, pd pprofile.Profiles, cache *pcommon.Map) error
+}
+
+type profileStatements struct {
+	ottl.StatementSequence[ottlprofile.TransformContext]
+	expr.BoolExpr[ottlprofile.TransformContext]
+}
+
+func (p profileStatements) Context() ContextID {
+	return Profile
+}
+
+func (p profileStatements) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles, cache *pcommon.Map) error {
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		rprofiles := pd.ResourceProfiles().At(i)
+		for j := 0; j < rprofiles.ScopeProfiles().Len(); j++ {
+			sprofiles := rprofiles.ScopeProfiles().At(j)
+			profiles := sprofiles.Profiles()
+			for k := 0; k < profiles.Len(); k++ {
+				tCtx := ottlprofile.NewTransformContext(profiles.At(k), sprofiles.Scope(), rprofiles.Resource(), sprofiles, rprofiles, ottlprofile.WithCache(cache))
+				condition, err := p.BoolExpr.Eval(ctx, tCtx)
+				if err != nil {
+					return err
+				}
+				if condition {
+					err := p.Execute(ctx, tCtx)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type sampleStatements struct {
+	ottl.StatementSequence[ottlsample.TransformContext]
+	expr.BoolExpr[ottlsample.TransformContext]
+}
+
+func (s sampleStatements) Context() ContextID {
+	return Sample
+}
+
+func (s sampleStatements) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles, cache *pcommon.Map) error {
+	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
+		rprofiles := pd.ResourceProfiles().At(i)
+		for j := 0; j < rprofiles.ScopeProfiles().Len(); j++ {
+			sprofiles := rprofiles.ScopeProfiles().At(j)
+			profiles := sprofiles.Profiles()
+			for k := 0; k < profiles.Len(); k++ {
+				profile := profiles.At(k)
+				samples := profile.Sample()
+				attributeIndex := ottlsample.NewAttributeIndex(profile)
+				for n := 0; n < samples.Len(); n++ {
+					tCtx := ottlsample.NewTransformContext(samples.At(n), profile, sprofiles.Scope(), rprofiles.Resource(), sprofiles, rprofiles, ottlsample.WithCache(cache), ottlsample.WithAttributeIndex(attributeIndex))
+					condition, err := s.BoolExpr.Eval(ctx, tCtx)
+					if err != nil {
+						return err
+					}
+					if condition {
+						err := s.Execute(ctx, tCtx)
+						if err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type ProfileParserCollection ottl.ParserCollection[ProfilesConsumer]
+
+type ProfileParserCollectionOption ottl.ParserCollectionOption[ProfilesConsumer]
+
+func WithProfileParser(functions map[string]ottl.Factory[ottlprofile.TransformContext], userFunctions *ottl.UserFunctions) ProfileParserCollectionOption {
+	return func(pc *ottl.ParserCollection[ProfilesConsumer]) error {
+		parser, err := ottlprofile.NewParser(functions, pc.Settings, parserOptions(userFunctions, ottlprofile.EnablePathContextNames())...)
+		if err != nil {
+			return err
+		}
+		return ottl.WithParserCollectionContext(ottlprofile.ContextName, &parser, convertProfileStatements)(pc)
+	}
+}
+
+func WithSampleParser(functions map[string]ottl.Factory[ottlsample.TransformContext], userFunctions *ottl.UserFunctions) ProfileParserCollectionOption {
+	return func(pc *ottl.ParserCollection[ProfilesConsumer]) error {
+		parser, err := ottlsample.NewParser(functions, pc.Settings, parserOptions(userFunctions, ottlsample.EnablePathContextNames())...)
+		if err != nil {
+			return err
+		}
+		return ottl.WithParserCollectionContext(ottlsample.ContextName, &parser, convertSampleStatements)(pc)
+	}
+}
+
+func WithProfileErrorMode(errorMode ottl.ErrorMode) ProfileParserCollectionOption {
+	return ProfileParserCollectionOption(ottl.WithParserCollectionErrorMode[ProfilesConsumer](errorMode))
+}
+
+func WithProfileProfiling(enabled bool) ProfileParserCollectionOption {
+	return ProfileParserCollectionOption(ottl.WithParserCollectionProfiling[ProfilesConsumer](enabled))
+}
+
+func NewProfileParserCollection(settings component.TelemetrySettings, userFunctions *ottl.UserFunctions, options ...ProfileParserCollectionOption) (*ProfileParserCollection, error) {
+	pcOptions := []ottl.ParserCollectionOption[ProfilesConsumer]{
+		withCommonContextParsers[ProfilesConsumer](userFunctions),
+		ottl.EnableParserCollectionModifiedStatementLogging[ProfilesConsumer](true),
+	}
+
+	for _, option := range options {
+		pcOptions = append(pcOptions, ottl.ParserCollectionOption[ProfilesConsumer](option))
+	}
+
+	pc, err := ottl.NewParserCollection(settings, pcOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	ppc := ProfileParserCollection(*pc)
+	return &ppc, nil
+}
+
+func convertProfileStatements(pc *ottl.ParserCollection[ProfilesConsumer], _ *ottl.Parser[ottlprofile.TransformContext], _ string, statements ottl.StatementsGetter, parsedStatements []*ottl.Statement[ottlprofile.TransformContext]) (ProfilesConsumer, error) {
+	contextStatements, err := toContextStatements(statements)
+	if err != nil {
+		return nil, err
+	}
+	errorMode := pc.ErrorMode
+	if contextStatements.ErrorMode != "" {
+		errorMode = contextStatements.ErrorMode
+	}
+	var parserOptions []ottl.Option[ottlprofile.TransformContext]
+	if contextStatements.Context == "" {
+		parserOptions = append(parserOptions, ottlprofile.EnablePathContextNames())
+	}
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForProfileWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardProfileFuncs(), parserOptions)
+	if errGlobalBoolExpr != nil {
+		return nil, errGlobalBoolExpr
+	}
+	sequenceOptions := []ottlprofile.StatementSequenceOption{ottlprofile.WithStatementSequenceErrorMode(errorMode)}
+	if pc.Profiling {
+		sequenceOptions = append(sequenceOptions, ottlprofile.WithStatementSequenceProfiling(attribute.String(ottl.AttributeContext, ottlprofile.ContextName)))
+	}
+	pStatements := ottlprofile.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
+	return profileStatements{pStatements, globalExpr}, nil
+}
+
+func convertSampleStatements(pc *ottl.ParserCollection[ProfilesConsumer], _ *ottl.Parser[ottlsample.TransformContext], _ string, statements ottl.StatementsGetter, parsedStatements []*ottl.Statement[ottlsample.TransformContext]) (ProfilesConsumer, error) {
+	contextStatements, err := toContextStatements(statements)
+	if err != nil {
+		return nil, err
+	}
+	errorMode := pc.ErrorMode
+	if contextStatements.ErrorMode != "" {
+		errorMode = contextStatements.ErrorMode
+	}
+	var parserOptions []ottl.Option[ottlsample.TransformContext]
+	if contextStatements.Context == "" {
+		parserOptions = append(parserOptions, ottlsample.EnablePathContextNames())
+	}
+	globalExpr, errGlobalBoolExpr := parseGlobalExpr(filterottl.NewBoolExprForSampleWithOptions, contextStatements.Conditions, errorMode, pc.Settings, filterottl.StandardSampleFuncs(), parserOptions)
+	if errGlobalBoolExpr != nil {
+		return nil, errGlobalBoolExpr
+	}
+	sequenceOptions := []ottlsample.StatementSequenceOption{ottlsample.WithStatementSequenceErrorMode(errorMode)}
+	if pc.Profiling {
+		sequenceOptions = append(sequenceOptions, ottlsample.WithStatementSequenceProfiling(attribute.String(ottl.AttributeContext, ottlsample.ContextName)))
+	}
+	sStatements := ottlsample.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
+	return sampleStatements{sStatements, globalExpr}, nil
+}
+
+func (ppc *ProfileParserCollection) ParseContextStatements(contextStatements ContextStatements) (ProfilesConsumer, error) {
+	pc := ottl.ParserCollection[ProfilesConsumer](*ppc)
+	if contextStatements.Context != "" {
+		return pc.ParseStatementsWithContext(string(contextStatements.Context), contextStatements, true)
+	}
+	return pc.ParseStatements(contextStatements)
+}
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
@@ -96,9 +97,9 @@ type TraceParserCollection ottl.ParserCollection[TracesConsumer]
 
 type TraceParserCollectionOption ottl.ParserCollectionOption[TracesConsumer]
 
-func WithSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext]) TraceParserCollectionOption {
+func WithSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext], userFunctions *ottl.UserFunctions) TraceParserCollectionOption {
 	return func(pc *ottl.ParserCollection[TracesConsumer]) error {
-		parser, err := ottlspan.NewParser(functions, pc.Settings, ottlspan.EnablePathContextNames())
+		parser, err := ottlspan.NewParser(functions, pc.Settings, parserOptions(userFunctions, ottlspan.EnablePathContextNames())...)
 		if err != nil {
 			return err
 		}
@@ -106,9 +107,9 @@ func WithSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext]
 	}
 }
 
-func WithSpanEventParser(functions map[string]ottl.Factory[ottlspanevent.TransformContext]) TraceParserCollectionOption {
+func WithSpanEventParser(functions map[string]ottl.Factory[ottlspanevent.TransformContext], userFunctions *ottl.UserFunctions) TraceParserCollectionOption {
 	return func(pc *ottl.ParserCollection[TracesConsumer]) error {
-		parser, err := ottlspanevent.NewParser(functions, pc.Settings, ottlspanevent.EnablePathContextNames())
+		parser, err := ottlspanevent.NewParser(functions, pc.Settings, parserOptions(userFunctions, ottlspanevent.EnablePathContextNames())...)
 		if err != nil {
 			return err
 		}
@@ -120,9 +121,13 @@ func WithTraceErrorMode(errorMode ottl.ErrorMode) TraceParserCollectionOption {
 	return TraceParserCollectionOption(ottl.WithParserCollectionErrorMode[TracesConsumer](errorMode))
 }
 
-func NewTraceParserCollection(settings component.TelemetrySettings, options ...TraceParserCollectionOption) (*TraceParserCollection, error) {
+func WithTraceProfiling(enabled bool) TraceParserCollectionOption {
+	return TraceParserCollectionOption(ottl.WithParserCollectionProfiling[TracesConsumer](enabled))
+}
+
+func NewTraceParserCollection(settings component.TelemetrySettings, userFunctions *ottl.UserFunctions, options ...TraceParserCollectionOption) (*TraceParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[TracesConsumer]{
-		withCommonContextParsers[TracesConsumer](),
+		withCommonContextParsers[TracesConsumer](userFunctions),
 		ottl.EnableParserCollectionModifiedStatementLogging[TracesConsumer](true),
 	}
 
@@ -156,7 +161,11 @@ func convertSpanStatements(pc *ottl.ParserCollection[TracesConsumer], _ *ottl.Pa
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	sStatements := ottlspan.NewStatementSequence(parsedStatements, pc.Settings, ottlspan.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlspan.StatementSequenceOption{ottlspan.WithStatementSequenceErrorMode(errorMode)}
+	if pc.Profiling {
+		sequenceOptions = append(sequenceOptions, ottlspan.WithStatementSequenceProfiling(attribute.String(ottl.AttributeContext, ottlspan.ContextName)))
+	}
+	sStatements := ottlspan.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return traceStatements{sStatements, globalExpr}, nil
 }
 
@@ -177,7 +186,11 @@ func convertSpanEventStatements(pc *ottl.ParserCollection[TracesConsumer], _ *ot
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	seStatements := ottlspanevent.NewStatementSequence(parsedStatements, pc.Settings, ottlspanevent.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlspanevent.StatementSequenceOption{ottlspanevent.WithStatementSequenceErrorMode(errorMode)}
+	if pc.Profiling {
+		sequenceOptions = append(sequenceOptions, ottlspanevent.WithStatementSequenceProfiling(attribute.String(ottl.AttributeContext, ottlspanevent.ContextName)))
+	}
+	seStatements := ottlspanevent.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return spanEventStatements{seStatements, globalExpr}, nil
 }
 
@@ -28,8 +28,8 @@ type Processor struct {
 	flatMode bool
 }
 
-func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, flatMode bool, settings component.TelemetrySettings) (*Processor, error) {
-	pc, err := common.NewLogParserCollection(settings, common.WithLogParser(LogFunctions()), common.WithLogErrorMode(errorMode))
+func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, flatMode bool, profiling bool, userFunctions *ottl.UserFunctions, settings component.TelemetrySettings) (*Processor, error) {
+	pc, err := common.NewLogParserCollection(settings, userFunctions, common.WithLogParser(LogFunctions(), userFunctions), common.WithLogErrorMode(errorMode), common.WithLogProfiling(profiling))
 	if err != nil {
 		return nil, err
 	}
@@ -26,8 +26,8 @@ type Processor struct {
 	logger   *zap.Logger
 }
 
-func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, settings component.TelemetrySettings) (*Processor, error) {
-	pc, err := common.NewMetricParserCollection(settings, common.WithMetricParser(MetricFunctions()), common.WithDataPointParser(DataPointFunctions()), common.WithMetricErrorMode(errorMode))
+func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, profiling bool, userFunctions *ottl.UserFunctions, settings component.TelemetrySettings) (*Processor, error) {
+	pc, err := common.NewMetricParserCollection(settings, userFunctions, common.WithMetricParser(MetricFunctions(), userFunctions), common.WithDataPointParser(DataPointFunctions(), userFunctions), common.WithMetricErrorMode(errorMode), common.WithMetricProfiling(profiling))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,333 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+)
+
+func Test_ProcessProfiles_ResourceContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(td pprofile.Profiles)
+	}{
+		{
+			statement: `set(attributes["test"], "pass")`,
+			want: func(td pprofile.Profiles) {
+				td.ResourceProfiles().At(0).Resource().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where attributes["host.name"] == "wrong"`,
+			want: func(_ pprofile.Profiles) {
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, nil, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructProfiles()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_ProcessProfiles_ScopeContext(t *testing.T) {
+	td := constructProfiles()
+	processor, err := NewProcessor([]common.ContextStatements{{Statements: []string{`set(scope.attributes["test"], "pass") where scope.name == "scope"`}}}, ottl.IgnoreError, false, nil, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	_, err = processor.ProcessProfiles(context.Background(), td)
+	require.NoError(t, err)
+
+	exTd := constructProfiles()
+	exTd.ResourceProfiles().At(0).ScopeProfiles().At(0).Scope().Attributes().PutStr("test", "pass")
+
+	assert.Equal(t, exTd, td)
+}
+
+func Test_ProcessProfiles_ProfileContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		context   common.ContextID
+		want      func(td pprofile.Profiles)
+	}{
+		{
+			statement: `set(attributes["test"], "pass")`,
+			context:   common.Profile,
+			want: func(td pprofile.Profiles) {
+				putAttribute(profileAt(td, 0), profileAt(td, 0), "test", "pass")
+				putAttribute(profileAt(td, 1), profileAt(td, 1), "test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where period == 10`,
+			context:   common.Profile,
+			want: func(td pprofile.Profiles) {
+				putAttribute(profileAt(td, 0), profileAt(td, 0), "test", "pass")
+			},
+		},
+		{
+			statement: `set(profile.attributes["test"], resource.attributes["host.name"]) where profile.sample_count == 0`,
+			want: func(td pprofile.Profiles) {
+				putAttribute(profileAt(td, 1), profileAt(td, 1), "test", "localhost")
+			},
+		},
+		{
+			statement: `set(profile.period_type.unit, "milliseconds") where profile.period_type.type == "cpu"`,
+			want: func(td pprofile.Profiles) {
+				profile := profileAt(td, 0)
+				profile.StringTable().Append("milliseconds")
+				profile.PeriodType().SetUnitStrindex(int32(profile.StringTable().Len() - 1))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{tt.statement}}}, ottl.IgnoreError, false, nil, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructProfiles()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_ProcessProfiles_SampleContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		context   common.ContextID
+		want      func(td pprofile.Profiles)
+	}{
+		{
+			statement: `set(attributes["test"], "pass") where Len(function_names) == 2`,
+			context:   common.Sample,
+			want: func(td pprofile.Profiles) {
+				profile := profileAt(td, 0)
+				putAttribute(profile, profile.Sample().At(0), "test", "pass")
+			},
+		},
+		{
+			statement: `set(sample.attributes["depth"], Len(sample.function_names))`,
+			want: func(td pprofile.Profiles) {
+				profile := profileAt(td, 0)
+				putAttributeValue(profile, profile.Sample().At(0), "depth", pcommon.NewValueInt(2))
+				putAttributeValue(profile, profile.Sample().At(1), "depth", pcommon.NewValueInt(1))
+			},
+		},
+		{
+			statement: `set(sample.attributes["deep"], true) where profile.period == 10 and Len(sample.locations) == 2`,
+			want: func(td pprofile.Profiles) {
+				profile := profileAt(td, 0)
+				putAttributeValue(profile, profile.Sample().At(0), "deep", pcommon.NewValueBool(true))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{tt.statement}}}, ottl.IgnoreError, false, nil, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructProfiles()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_ProcessProfiles_MixContext(t *testing.T) {
+	td := constructProfiles()
+	processor, err := NewProcessor([]common.ContextStatements{
+		{
+			Statements: []string{`set(resource.attributes["test"], "pass")`},
+		},
+		{
+			Statements: []string{`set(profile.cache["period"], profile.period)`, `set(profile.attributes["period"], profile.cache["period"])`},
+			Conditions: []string{`profile.period == 20`},
+		},
+		{
+			Statements: []string{`set(sample.attributes["host"], resource.attributes["host.name"]) where resource.attributes["test"] == "pass"`},
+		},
+	}, ottl.PropagateError, false, nil, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	_, err = processor.ProcessProfiles(context.Background(), td)
+	require.NoError(t, err)
+
+	exTd := constructProfiles()
+	exTd.ResourceProfiles().At(0).Resource().Attributes().PutStr("test", "pass")
+	putAttributeValue(profileAt(exTd, 1), profileAt(exTd, 1), "period", pcommon.NewValueInt(20))
+	profile := profileAt(exTd, 0)
+	putAttribute(profile, profile.Sample().At(0), "host", "localhost")
+	putAttribute(profile, profile.Sample().At(1), "host", "localhost")
+
+	assert.Equal(t, exTd, td)
+}
+
+func Test_ProcessProfiles_ErrorMode(t *testing.T) {
+	for _, ctx := range []common.ContextID{common.Resource, common.Scope, common.Profile, common.Sample} {
+		t.Run(string(ctx), func(t *testing.T) {
+			td := constructProfiles()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: ctx, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, false, nil, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			_, err = processor.ProcessProfiles(context.Background(), td)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_ProcessProfiles_ReadOnlyPath(t *testing.T) {
+	td := constructProfiles()
+	processor, err := NewProcessor([]common.ContextStatements{{Statements: []string{`set(sample.function_names, ["main"])`}}}, ottl.PropagateError, false, nil, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	_, err = processor.ProcessProfiles(context.Background(), td)
+	assert.ErrorContains(t, err, "the 'function_names' path cannot be modified")
+}
+
+func Test_ProcessProfiles_InPlaceEditor(t *testing.T) {
+	for _, statement := range []string{
+		`delete_key(profile.attributes, "service.version")`,
+		`keep_keys(profile.attributes, ["service.version"])`,
+		`delete_key(sample.attributes, "thread.name")`,
+		`keep_keys(sample.attributes, ["thread.name"])`,
+	} {
+		t.Run(statement, func(t *testing.T) {
+			_, err := NewProcessor([]common.ContextStatements{{Statements: []string{statement}}}, ottl.PropagateError, false, nil, componenttest.NewNopTelemetrySettings())
+			assert.ErrorContains(t, err, "use set instead")
+		})
+	}
+}
+
+func Test_ProcessProfiles_ClearAttributesWithSet(t *testing.T) {
+	td := constructProfiles()
+	processor, err := NewProcessor([]common.ContextStatements{{Statements: []string{
+		`delete_key(resource.attributes, "host.name")`,
+		`set(profile.attributes, {})`,
+	}}}, ottl.PropagateError, false, nil, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	_, err = processor.ProcessProfiles(context.Background(), td)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, td.ResourceProfiles().At(0).Resource().Attributes().Len())
+	assert.Equal(t, 0, profileAt(td, 0).AttributeIndices().Len())
+	assert.Equal(t, 0, profileAt(td, 1).AttributeIndices().Len())
+}
+
+func profileAt(td pprofile.Profiles, i int) pprofile.Profile {
+	return td.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(i)
+}
+
+type attributable interface {
+	AttributeIndices() pcommon.Int32Slice
+}
+
+func putAttribute(profile pprofile.Profile, record attributable, key, value string) {
+	putAttributeValue(profile, record, key, pcommon.NewValueStr(value))
+}
+
+// putAttributeValue mirrors how the OTTL contexts write attributes back to the profile's
+// attribute table, so the expected data can be compared as a whole.
+func putAttributeValue(profile pprofile.Profile, record attributable, key string, value pcommon.Value) {
+	attrs := pprofile.FromAttributeIndices(profile.AttributeTable(), record)
+	value.CopyTo(attrs.PutEmpty(key))
+	var indices []int32
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		table := profile.AttributeTable()
+		for i := 0; i < table.Len(); i++ {
+			if table.At(i).Key() == k && reflect.DeepEqual(table.At(i).Value().AsRaw(), v.AsRaw()) {
+				indices = append(indices, int32(i))
+				return true
+			}
+		}
+		attr := table.AppendEmpty()
+		attr.SetKey(k)
+		v.CopyTo(attr.Value())
+		indices = append(indices, int32(table.Len()-1))
+		return true
+	})
+	record.AttributeIndices().FromRaw(indices)
+}
+
+func constructProfiles() pprofile.Profiles {
+	td := pprofile.NewProfiles()
+	rp0 := td.ResourceProfiles().AppendEmpty()
+	rp0.Resource().Attributes().PutStr("host.name", "localhost")
+	rp0sp0 := rp0.ScopeProfiles().AppendEmpty()
+	rp0sp0.Scope().SetName("scope")
+	fillProfileOne(rp0sp0.Profiles().AppendEmpty())
+	fillProfileTwo(rp0sp0.Profiles().AppendEmpty())
+	return td
+}
+
+func fillProfileOne(profile pprofile.Profile) {
+	profile.StringTable().FromRaw([]string{"", "cpu", "nanoseconds", "main", "compute"})
+	profile.SetPeriod(10)
+	profile.PeriodType().SetTypeStrindex(1)
+	profile.PeriodType().SetUnitStrindex(2)
+
+	attr := profile.AttributeTable().AppendEmpty()
+	attr.SetKey("service.version")
+	attr.Value().SetStr("1.0")
+	profile.AttributeIndices().Append(0)
+
+	mainFn := profile.FunctionTable().AppendEmpty()
+	mainFn.SetNameStrindex(3)
+	computeFn := profile.FunctionTable().AppendEmpty()
+	computeFn.SetNameStrindex(4)
+
+	profile.LocationTable().AppendEmpty().Line().AppendEmpty().SetFunctionIndex(0)
+	profile.LocationTable().AppendEmpty().Line().AppendEmpty().SetFunctionIndex(1)
+	profile.LocationIndices().FromRaw([]int32{1, 0})
+
+	sample0 := profile.Sample().AppendEmpty()
+	sample0.SetLocationsStartIndex(0)
+	sample0.SetLocationsLength(2)
+	sample0.Value().FromRaw([]int64{100})
+
+	sample1 := profile.Sample().AppendEmpty()
+	sample1.SetLocationsStartIndex(1)
+	sample1.SetLocationsLength(1)
+	sample1.Value().FromRaw([]int64{5})
+}
+
+func fillProfileTwo(profile pprofile.Profile) {
+	profile.StringTable().Append("")
+	profile.SetPeriod(20)
+}
@@ -26,8 +26,8 @@ type Processor struct {
 	logger   *zap.Logger
 }
 
-func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, settings component.TelemetrySettings) (*Processor, error) {
-	pc, err := common.NewTraceParserCollection(settings, common.WithSpanParser(SpanFunctions()), common.WithSpanEventParser(SpanEventFunctions()), common.WithTraceErrorMode(errorMode))
+func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, profiling bool, userFunctions *ottl.UserFunctions, settings component.TelemetrySettings) (*Processor, error) {
+	pc, err := common.NewTraceParserCollection(settings, userFunctions, common.WithSpanParser(SpanFunctions(), userFunctions), common.WithSpanEventParser(SpanEventFunctions(), userFunctions), common.WithTraceErrorMode(errorMode), common.WithTraceProfiling(profiling))
 	if err != nil {
 		return nil, err
 	}